@@ -8,3 +8,71 @@ type TaskAdded struct {
 }
 
 type AddTaskTrigger bool
+
+// AddSubtaskTrigger opens the add screen for a subtask of Parent.
+type AddSubtaskTrigger struct {
+	Parent domain.Item
+}
+
+// EditTaskTrigger opens the edit screen for Item.
+type EditTaskTrigger struct {
+	Item domain.Item
+}
+
+// TaskEdited gives the item with the given ID a new title and tags. Title is
+// empty when editing was cancelled.
+type TaskEdited struct {
+	ID    string
+	Title string
+	Tags  []string
+}
+
+// DetailTrigger opens the detail screen for Item.
+type DetailTrigger struct {
+	Item domain.Item
+}
+
+// WorkspaceTrigger opens the workspace picker.
+type WorkspaceTrigger struct{}
+
+// CycleWorkspaceTrigger switches to the workspace Step places after the
+// active one in the picker, or before it for a negative Step.
+type CycleWorkspaceTrigger struct {
+	Step int
+}
+
+// NewListTrigger asks for the name of a new list and switches to it.
+type NewListTrigger struct{}
+
+// MoveToListTrigger asks which list to move Item, with its subtasks, to.
+type MoveToListTrigger struct {
+	Item domain.Item
+}
+
+// ImportTrigger asks the list to import the items from the file at Path.
+type ImportTrigger struct {
+	Path string
+}
+
+// StatsTrigger opens the stats screen.
+type StatsTrigger struct{}
+
+// VersionTrigger shows the version and what changed in each release.
+type VersionTrigger struct{}
+
+// ActivityTrigger opens the activity screen.
+type ActivityTrigger struct{}
+
+// DedupeTrigger goes through the tasks that look like duplicates.
+type DedupeTrigger struct{}
+
+// BoardTrigger opens the board.
+type BoardTrigger struct{}
+
+// CycleThemeTrigger switches to the next built-in theme.
+type CycleThemeTrigger struct{}
+
+// WaitTrigger opens the waiting prompt for Item.
+type WaitTrigger struct {
+	Item domain.Item
+}
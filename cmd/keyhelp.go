@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// HelpCategory is a column of the list's full help. The short help lists
+// its bindings in the same order.
+type HelpCategory int
+
+// The categories, in the order of the full help's columns: moving around,
+// what's shown, changing tasks, the list as a whole, and help and quitting.
+const (
+	HelpNavigation HelpCategory = iota
+	HelpView
+	HelpTasks
+	HelpList
+	HelpApp
+
+	numHelpCategories
+)
+
+// HelpEntry places a binding of the list in its help.
+type HelpEntry struct {
+	// The action of the binding, as it's remapped in the keys file, such
+	// as "cursor_up".
+	Action string
+
+	Category HelpCategory
+
+	// Orders the entries of a category, lightest first. Entries are spaced
+	// out by tens so new ones fit in between.
+	Weight int
+
+	// Whether the binding is shown in the short help, the full help, or
+	// both.
+	Short, Full bool
+
+	// Whether the status bar points it out while a task is selected, see
+	// status_hints.
+	Hint bool
+}
+
+// ListHelp registers every binding of the list that's shown in its help or
+// its status bar hints. The help is built from it in the order of
+// HelpEntries, whatever the order here. The list also switches all of them
+// off at once while a prompt or overlay has the keyboard, see ListBindings.
+var ListHelp = []HelpEntry{
+	{Action: "cursor_up", Category: HelpNavigation, Weight: 10, Short: true, Full: true},
+	{Action: "cursor_down", Category: HelpNavigation, Weight: 20, Short: true, Full: true},
+	{Action: "next_page", Category: HelpNavigation, Weight: 30, Full: true},
+	{Action: "prev_page", Category: HelpNavigation, Weight: 40, Full: true},
+	{Action: "go_to_start", Category: HelpNavigation, Weight: 50, Full: true},
+	{Action: "go_to_end", Category: HelpNavigation, Weight: 60, Full: true},
+	{Action: "page_summary", Category: HelpNavigation, Weight: 70, Full: true},
+
+	{Action: "open_detail", Category: HelpView, Weight: 10, Full: true},
+	{Action: "toggle_detail", Category: HelpView, Weight: 20, Full: true},
+	{Action: "zen", Category: HelpView, Weight: 30, Full: true},
+	{Action: "toggle_notes", Category: HelpView, Weight: 40, Full: true},
+	{Action: "detail_up", Category: HelpView, Weight: 50, Full: true},
+	{Action: "detail_down", Category: HelpView, Weight: 60, Full: true},
+
+	{Action: "toggle_done", Category: HelpTasks, Weight: 10, Full: true, Hint: true},
+	{Action: "delete_item", Category: HelpTasks, Weight: 20, Full: true, Hint: true},
+	{Action: "clear_done", Category: HelpTasks, Weight: 30, Full: true},
+	{Action: "sort_mode", Category: HelpTasks, Weight: 40, Full: true},
+	{Action: "apply_sort", Category: HelpTasks, Weight: 50, Full: true},
+	{Action: "someday", Category: HelpTasks, Weight: 60, Full: true},
+	{Action: "show_someday", Category: HelpTasks, Weight: 70, Full: true},
+	{Action: "undo", Category: HelpTasks, Weight: 80, Full: true},
+	{Action: "redo", Category: HelpTasks, Weight: 90, Full: true},
+	{Action: "copy_item", Category: HelpTasks, Weight: 100, Full: true},
+	{Action: "copy_list", Category: HelpTasks, Weight: 110, Full: true},
+	{Action: "copy_link", Category: HelpTasks, Weight: 115, Full: true},
+	{Action: "next_overdue", Category: HelpTasks, Weight: 120, Full: true},
+	{Action: "agenda", Category: HelpTasks, Weight: 130, Full: true},
+	{Action: "hide_done", Category: HelpTasks, Weight: 140, Full: true},
+	{Action: "reload", Category: HelpTasks, Weight: 150, Full: true},
+	{Action: "raise_prio", Category: HelpTasks, Weight: 160, Full: true},
+	{Action: "lower_prio", Category: HelpTasks, Weight: 170, Full: true},
+	{Action: "move_item_up", Category: HelpTasks, Weight: 180, Full: true},
+	{Action: "move_item_down", Category: HelpTasks, Weight: 190, Full: true},
+	{Action: "waiting", Category: HelpTasks, Weight: 200, Full: true},
+	{Action: "add_subtask", Category: HelpTasks, Weight: 210, Full: true},
+	{Action: "edit_item", Category: HelpTasks, Weight: 220, Full: true},
+	{Action: "workspace", Category: HelpTasks, Weight: 230, Full: true},
+	{Action: "prev_list", Category: HelpTasks, Weight: 240, Full: true},
+	{Action: "next_list", Category: HelpTasks, Weight: 250, Full: true},
+	{Action: "new_list", Category: HelpTasks, Weight: 260, Full: true},
+	{Action: "move_to_list", Category: HelpTasks, Weight: 270, Full: true},
+
+	{Action: "filter", Category: HelpList, Weight: 10, Short: true, Full: true},
+	{Action: "clear_filter", Category: HelpList, Weight: 20, Short: true, Full: true},
+	{Action: "jump", Category: HelpList, Weight: 30, Short: true, Full: true},
+	{Action: "go_to_number", Category: HelpList, Weight: 40, Full: true},
+	{Action: "pick_tags", Category: HelpList, Weight: 50, Short: true, Full: true},
+	{Action: "palette", Category: HelpList, Weight: 60, Short: true, Full: true},
+	{Action: "stats", Category: HelpList, Weight: 70, Full: true},
+	{Action: "activity", Category: HelpList, Weight: 80, Full: true},
+	{Action: "dedupe", Category: HelpList, Weight: 90, Full: true},
+	{Action: "board", Category: HelpList, Weight: 100, Full: true},
+	{Action: "cycle_theme", Category: HelpList, Weight: 110, Full: true},
+	{Action: "accept_while_filtering", Category: HelpList, Weight: 120, Short: true, Full: true},
+	{Action: "cancel_while_filtering", Category: HelpList, Weight: 130, Short: true, Full: true},
+	{Action: "toggle_regex", Category: HelpList, Weight: 140, Short: true, Full: true},
+	{Action: "cancel_while_importing", Category: HelpList, Weight: 150, Short: true, Full: true},
+
+	{Action: "quit", Category: HelpApp, Weight: 10, Short: true, Full: true},
+	{Action: "show_full_help", Category: HelpApp, Weight: 20, Short: true},
+	{Action: "close_full_help", Category: HelpApp, Weight: 20, Full: true},
+}
+
+// HelpEntries returns the entries of ListHelp in the order the help shows
+// them: by category, and by weight within one.
+func HelpEntries() []HelpEntry {
+	entries := slices.Clone(ListHelp)
+	slices.SortStableFunc(entries, func(a, b HelpEntry) int {
+		return cmp.Or(cmp.Compare(a.Category, b.Category), cmp.Compare(a.Weight, b.Weight))
+	})
+	return entries
+}
+
+// actionFields maps the names of actions to their fields of KeyMap.
+var actionFields = sync.OnceValue(func() map[string]int {
+	fields := make(map[string]int)
+	for i, name := range ActionNames() {
+		fields[name] = i
+	}
+	return fields
+})
+
+// Binding returns the binding of the named action, such as "cursor_up".
+func (k KeyMap) Binding(action string) (key.Binding, bool) {
+	i, ok := actionFields()[action]
+	if !ok {
+		return key.Binding{}, false
+	}
+	return reflect.ValueOf(k).Field(i).Interface().(key.Binding), true
+}
+
+// ListBindings returns the bindings of every entry of ListHelp, for switching
+// them on or off together.
+func (k *KeyMap) ListBindings() []*key.Binding {
+	v := reflect.ValueOf(k).Elem()
+	bindings := make([]*key.Binding, 0, len(ListHelp))
+	for _, e := range HelpEntries() {
+		if i, ok := actionFields()[e.Action]; ok {
+			bindings = append(bindings, v.Field(i).Addr().Interface().(*key.Binding))
+		}
+	}
+	return bindings
+}
+
+// ShortHelpBindings returns the bindings of the short help by category.
+func (k KeyMap) ShortHelpBindings() [numHelpCategories][]key.Binding {
+	return k.helpBindings(func(e HelpEntry) bool { return e.Short })
+}
+
+// FullHelpBindings returns the bindings of the full help by category.
+func (k KeyMap) FullHelpBindings() [numHelpCategories][]key.Binding {
+	return k.helpBindings(func(e HelpEntry) bool { return e.Full })
+}
+
+// HintBindings returns the bindings the status bar points out while a task
+// is selected.
+func (k KeyMap) HintBindings() []key.Binding {
+	var bindings []key.Binding
+	for _, e := range HelpEntries() {
+		if b, ok := k.Binding(e.Action); ok && e.Hint {
+			bindings = append(bindings, b)
+		}
+	}
+	return bindings
+}
+
+func (k KeyMap) helpBindings(in func(HelpEntry) bool) (groups [numHelpCategories][]key.Binding) {
+	// Looked up on one reflect.Value rather than with Binding, which copies
+	// the whole KeyMap for every entry.
+	v := reflect.ValueOf(&k).Elem()
+	for _, e := range HelpEntries() {
+		if i, ok := actionFields()[e.Action]; ok && in(e) {
+			groups[e.Category] = append(groups[e.Category], v.Field(i).Interface().(key.Binding))
+		}
+	}
+	return groups
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestListHelpRegistersEveryListBinding(t *testing.T) {
+	actions := make(map[string]bool)
+	for _, e := range ListHelp {
+		if _, ok := (KeyMap{}).Binding(e.Action); !ok {
+			t.Errorf("ListHelp has %q, which isn't a binding", e.Action)
+		}
+		if actions[e.Action] {
+			t.Errorf("ListHelp has %q twice", e.Action)
+		}
+		actions[e.Action] = true
+	}
+
+	// Every binding of browsing the list and filtering it, from ToggleDone
+	// to ToggleRegex in KeyMap, and the ones kept with the others.
+	names := ActionNames()
+	first, last := slices.Index(names, "toggle_done"), slices.Index(names, "toggle_regex")
+	list := append(names[first:last+1:last+1], "quit", "show_full_help", "close_full_help", "cancel_while_importing")
+	for _, action := range list {
+		if !actions[action] {
+			t.Errorf("%s is a binding of the list missing from ListHelp", action)
+		}
+	}
+}
+
+func TestListBindings(t *testing.T) {
+	k := DefaultKeyMap()
+	bindings := k.ListBindings()
+	if len(bindings) != len(ListHelp) {
+		t.Fatalf("ListBindings() gave %d bindings for %d entries", len(bindings), len(ListHelp))
+	}
+	for _, b := range bindings {
+		b.SetEnabled(false)
+	}
+	for _, e := range ListHelp {
+		if b, _ := k.Binding(e.Action); b.Enabled() {
+			t.Errorf("%s is still enabled after switching off every list binding", e.Action)
+		}
+	}
+	if !k.AddTask.Enabled() || !k.CancelPalette.Enabled() {
+		t.Error("switching off the list's bindings switched off others too")
+	}
+}
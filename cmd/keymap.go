@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+// keyPreset returns the keymap DefaultKeyMap starts from.
+var keyPreset = builtinKeyMap
+
+// keyOverrides are the keys from the keys file that DefaultKeyMap applies.
+var keyOverrides map[string][]string
+
+// SetKeyPreset makes DefaultKeyMap start from the named keymap: "default" or
+// "vim", see VimKeyMap. Call it once at startup, before SetKeyOverrides.
+func SetKeyPreset(name string) error {
+	switch name {
+	case "", "default":
+		keyPreset = builtinKeyMap
+	case "vim":
+		keyPreset = VimKeyMap
+	default:
+		return fmt.Errorf("keymap must be default or vim, got %q", name)
+	}
+	return nil
+}
+
+// SetKeyOverrides makes DefaultKeyMap bind the actions named in keys, such as
+// "cursor_up", to the given keys instead of their built-in ones. It returns
+// an error, and changes nothing, if an action is unknown or the keys would
+// clash. Call it once at startup, before any view is created.
+func SetKeyOverrides(keys map[string][]string) error {
+	k := keyPreset()
+	if err := k.Remap(keys); err != nil {
+		return err
+	}
+	keyOverrides = keys
+	return nil
+}
+
+// ActionNames returns the names actions are remapped by, in KeyMap order.
+func ActionNames() []string {
+	t := reflect.TypeOf(KeyMap{})
+	names := make([]string, t.NumField())
+	for i := range names {
+		names[i] = actionName(t.Field(i).Name)
+	}
+	return names
+}
+
+// actionName turns a KeyMap field name into the name its action is remapped
+// by: CursorUp is cursor_up.
+func actionName(field string) string {
+	var b strings.Builder
+	for i, r := range field {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// keyScopes names the groups of bindings that are active at the same time,
+// by the first field of each group in KeyMap order; a group runs up to the
+// next one. The bindings of a group must not share keys. Bindings in the
+// unnamed group are active everywhere.
+var keyScopes = map[string]string{
+	"AddTask":              "add",
+	"ToggleDone":           "list",
+	"CancelWhileFiltering": "filter",
+	"CancelWhileJumping":   "jump",
+	"CancelGoTo":           "go to",
+	"CancelPalette":        "palette",
+	"ConfirmWIP":           "wip",
+	"PrevChip":             "chips",
+	"DismissTip":           "list",
+	"NagComplete":          "nag",
+	"LegacyMigrate":        "legacy",
+	"DedupeMerge":          "dedupe",
+	"PrevColumn":           "board",
+	"CloseStats":           "stats",
+	"CloseDetail":          "detail",
+	"GoToActivity":         "activity",
+	"AcceptWorkspace":      "workspace",
+	"AcceptListName":       "list name",
+	"AcceptEdit":           "edit",
+	"AcceptWait":           "wait",
+	"CancelWhileImporting": "list",
+	"ToggleImportRow":      "import",
+	"ShowFullHelp":         "list",
+	"ForceQuit":            "",
+}
+
+// Remap binds the actions named in keys to the given keys and shows those in
+// the help. Keys separated by a space, like "g g", are a sequence pressed one
+// after the other. It fails for unknown actions, actions without keys, and
+// keys that end up bound to two actions active at the same time, unless the
+// preset binds them that way too. A key that starts a sequence can't also be
+// bound on its own.
+func (k *KeyMap) Remap(keys map[string][]string) error {
+	v := reflect.ValueOf(k).Elem()
+	names := ActionNames()
+	remapped := make([]bool, len(names))
+
+	actions := make([]string, 0, len(keys))
+	for action := range keys {
+		actions = append(actions, action)
+	}
+	slices.Sort(actions)
+	for _, action := range actions {
+		i := slices.Index(names, action)
+		if i < 0 {
+			return fmt.Errorf("unknown action %q", action)
+		}
+		if len(keys[action]) == 0 {
+			return fmt.Errorf("%s: no keys given", action)
+		}
+		b := v.Field(i).Addr().Interface().(*key.Binding)
+		b.SetKeys(keys[action]...)
+		b.SetHelp(helpKeys(keys[action]), b.Help().Desc)
+		remapped[i] = true
+	}
+	return k.checkClashes(remapped)
+}
+
+// checkClashes returns an error if a remapped binding clashes with another
+// binding of its scope in a way it doesn't in the preset.
+func (k *KeyMap) checkClashes(remapped []bool) error {
+	preset := reflect.ValueOf(keyPreset())
+	v := reflect.ValueOf(*k)
+	t := v.Type()
+
+	scopes := make([]string, t.NumField())
+	scope := ""
+	for i := range scopes {
+		if s, ok := keyScopes[t.Field(i).Name]; ok {
+			scope = s
+		}
+		scopes[i] = scope
+	}
+
+	binding := func(v reflect.Value, i int) key.Binding { return v.Field(i).Interface().(key.Binding) }
+	for i := range scopes {
+		for j := i + 1; j < len(scopes); j++ {
+			if !remapped[i] && !remapped[j] || scopes[i] != scopes[j] && scopes[i] != "" && scopes[j] != "" {
+				continue
+			}
+			for _, a := range binding(v, i).Keys() {
+				for _, b := range binding(v, j).Keys() {
+					if !keysClash(a, b) || slices.Contains(binding(preset, i).Keys(), a) && slices.Contains(binding(preset, j).Keys(), b) {
+						continue
+					}
+					if a == b {
+						return fmt.Errorf("%q is bound to both %s and %s", a, actionName(t.Field(i).Name), actionName(t.Field(j).Name))
+					}
+					return fmt.Errorf("%q of %s and %q of %s start alike", a, actionName(t.Field(i).Name), b, actionName(t.Field(j).Name))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// keysClash reports whether keys a and b can't both be bound in one scope:
+// they're the same, or one is a sequence the other starts.
+func keysClash(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if IsSequence(a) == IsSequence(b) {
+		return false
+	}
+	if IsSequence(b) {
+		a, b = b, a
+	}
+	return strings.Fields(a)[0] == b
+}
+
+// IsSequence reports whether k is a sequence of keys, like "g g".
+func IsSequence(k string) bool {
+	return len(strings.Fields(k)) > 1
+}
+
+// Sequences returns the key sequences of the enabled bindings.
+func (k KeyMap) Sequences() []string {
+	var sequences []string
+	v := reflect.ValueOf(k)
+	for i := range v.NumField() {
+		b := v.Field(i).Interface().(key.Binding)
+		if !b.Enabled() {
+			continue
+		}
+		for _, kb := range b.Keys() {
+			if IsSequence(kb) {
+				sequences = append(sequences, kb)
+			}
+		}
+	}
+	return sequences
+}
+
+// helpKeys shows keys the way the built-in help does: "↑/w", with sequences
+// like "g g" as "gg".
+func helpKeys(keys []string) string {
+	arrows := map[string]string{"up": "↑", "down": "↓", "left": "←", "right": "→"}
+	shown := make([]string, len(keys))
+	for i, k := range keys {
+		if arrow, ok := arrows[k]; ok {
+			k = arrow
+		}
+		if IsSequence(k) {
+			k = strings.Join(strings.Fields(k), "")
+		}
+		shown[i] = k
+	}
+	return strings.Join(shown, "/")
+}
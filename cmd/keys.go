@@ -6,9 +6,31 @@ import "github.com/charmbracelet/bubbles/key"
 // is used to render the menu.
 type KeyMap struct {
 	// AddTaskScreen
-	AddTask key.Binding
+	AddTask    key.Binding
+	SubmitTask key.Binding
+	SplitTitle key.Binding
+	EditNotes  key.Binding
+	EditTitle  key.Binding
 
 	// Keybindings used when browsing the list.
+	ToggleDone   key.Binding
+	DeleteItem   key.Binding
+	ClearDone    key.Binding
+	HideDone     key.Binding
+	SortMode     key.Binding
+	ApplySort    key.Binding
+	Someday      key.Binding
+	ShowSomeday  key.Binding
+	Undo         key.Binding
+	Redo         key.Binding
+	CopyItem     key.Binding
+	CopyList     key.Binding
+	CopyLink     key.Binding
+	NextOverdue  key.Binding
+	Agenda       key.Binding
+	Board        key.Binding
+	CycleTheme   key.Binding
+	Reload       key.Binding
 	CursorUp     key.Binding
 	CursorDown   key.Binding
 	MoveItemUp   key.Binding
@@ -17,12 +39,131 @@ type KeyMap struct {
 	PrevPage     key.Binding
 	GoToStart    key.Binding
 	GoToEnd      key.Binding
+	PageSummary  key.Binding
+	ToggleDetail key.Binding
+	Zen          key.Binding
+	ToggleNotes  key.Binding
+	DetailUp     key.Binding
+	DetailDown   key.Binding
+	Stats        key.Binding
+	Activity     key.Binding
+	Dedupe       key.Binding
+	RaisePrio    key.Binding
+	LowerPrio    key.Binding
+	Waiting      key.Binding
+	AddSubtask   key.Binding
+	EditItem     key.Binding
+	OpenDetail   key.Binding
+	Workspace    key.Binding
+	PrevList     key.Binding
+	NextList     key.Binding
+	NewList      key.Binding
+	MoveToList   key.Binding
 	Filter       key.Binding
 	ClearFilter  key.Binding
+	Jump         key.Binding
+	GoToNumber   key.Binding
+	PickTags     key.Binding
+	Palette      key.Binding
 
 	// Keybindings used when setting a filter.
 	CancelWhileFiltering key.Binding
 	AcceptWhileFiltering key.Binding
+	ToggleRegex          key.Binding
+
+	// Keybindings used in the jump prompt.
+	CancelWhileJumping key.Binding
+	AcceptWhileJumping key.Binding
+	JumpUp             key.Binding
+	JumpDown           key.Binding
+
+	// Keybindings used while typing the number of a row to go to.
+	CancelGoTo key.Binding
+	AcceptGoTo key.Binding
+
+	// Keybindings used when confirming a change over the WIP limit.
+	ConfirmWIP key.Binding
+	CancelWIP  key.Binding
+
+	// Keybindings used in the command palette.
+	CancelPalette key.Binding
+	AcceptPalette key.Binding
+	PaletteUp     key.Binding
+	PaletteDown   key.Binding
+
+	// Keybindings used while picking tag chips.
+	PrevChip   key.Binding
+	NextChip   key.Binding
+	ToggleChip key.Binding
+	CloseChips key.Binding
+
+	// Keybindings used while a tip is shown.
+	DismissTip key.Binding
+
+	// Keybindings used in the stale task prompt.
+	NagComplete key.Binding
+	NagSnooze   key.Binding
+	NagDelete   key.Binding
+	NagKeep     key.Binding
+	NagSkip     key.Binding
+
+	// Keybindings used in the prompt about a storage.json in the working
+	// directory.
+	LegacyMigrate key.Binding
+	LegacyKeep    key.Binding
+	LegacyIgnore  key.Binding
+	LegacyLater   key.Binding
+
+	// Keybindings used when going through possible duplicates.
+	DedupeMerge   key.Binding
+	DedupeDismiss key.Binding
+	DedupeSkip    key.Binding
+	DedupeStop    key.Binding
+
+	// Keybindings used in the board.
+	PrevColumn    key.Binding
+	NextColumn    key.Binding
+	MoveCardLeft  key.Binding
+	MoveCardRight key.Binding
+	CloseBoard    key.Binding
+
+	// Keybindings used in the stats screen.
+	CloseStats key.Binding
+
+	// Keybindings used in the detail screen.
+	CloseDetail key.Binding
+	FollowLink  key.Binding
+
+	// Keybindings used in the activity screen.
+	GoToActivity     key.Binding
+	CloseActivity    key.Binding
+	PrevActivityPage key.Binding
+	NextActivityPage key.Binding
+
+	// Keybindings used in the workspace picker.
+	AcceptWorkspace key.Binding
+	CancelWorkspace key.Binding
+
+	// Keybindings used when naming a new list.
+	AcceptListName key.Binding
+	CancelListName key.Binding
+
+	// Keybindings used in the edit screen.
+	AcceptEdit key.Binding
+	CancelEdit key.Binding
+
+	// Keybindings used in the waiting prompt.
+	AcceptWait    key.Binding
+	NextWaitField key.Binding
+	CancelWait    key.Binding
+
+	// Keybindings used while an import is running.
+	CancelWhileImporting key.Binding
+
+	// Keybindings used in the import preview.
+	ToggleImportRow key.Binding
+	AcceptImport    key.Binding
+	CancelPreview   key.Binding
 
 	// Help toggle keybindings.
 	ShowFullHelp  key.Binding
@@ -35,16 +176,113 @@ type KeyMap struct {
 	ForceQuit key.Binding
 }
 
-// DefaultKeyMap returns a default set of keybindings.
+// DefaultKeyMap returns the keybindings of the preset picked with
+// SetKeyPreset, with the keys set by SetKeyOverrides.
 func DefaultKeyMap() KeyMap {
+	k := keyPreset()
+	// The overrides were checked when they were set.
+	_ = k.Remap(keyOverrides)
+	return k
+}
+
+// builtinKeyMap returns the keybindings clitodo comes with.
+func builtinKeyMap() KeyMap {
 	return KeyMap{
 		//AddTaskScreen
 		AddTask: key.NewBinding(
 			key.WithKeys("enter"),
 			key.WithHelp("enter", "add task"),
 		),
+		SubmitTask: key.NewBinding(
+			key.WithKeys("ctrl+s"),
+			key.WithHelp("ctrl+s", "add task"),
+		),
+		SplitTitle: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "move overflow to notes"),
+		),
+		EditNotes: key.NewBinding(
+			key.WithKeys("tab", "down"),
+			key.WithHelp("tab", "notes"),
+		),
+		EditTitle: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", "title"),
+		),
 
 		// Browsing.
+		ToggleDone: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "toggle"),
+		),
+		DeleteItem: key.NewBinding(
+			key.WithKeys("ctrl+d"),
+			key.WithHelp("ctrl+d", "delete"),
+		),
+		ClearDone: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "clear completed"),
+		),
+		HideDone: key.NewBinding(
+			key.WithKeys("H"),
+			key.WithHelp("H", "hide/show completed"),
+		),
+		SortMode: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "sort"),
+		),
+		ApplySort: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "apply sort"),
+		),
+		Someday: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "someday"),
+		),
+		ShowSomeday: key.NewBinding(
+			key.WithKeys("Z"),
+			key.WithHelp("Z", "show/hide someday"),
+		),
+		Undo: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "undo"),
+		),
+		Redo: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "redo"),
+		),
+		CopyItem: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "copy task"),
+		),
+		CopyList: key.NewBinding(
+			key.WithKeys("Y"),
+			key.WithHelp("Y", "copy list"),
+		),
+		CopyLink: key.NewBinding(
+			key.WithKeys("ctrl+y"),
+			key.WithHelp("ctrl+y", "copy link"),
+		),
+		NextOverdue: key.NewBinding(
+			key.WithKeys("!"),
+			key.WithHelp("!", "first overdue"),
+		),
+		Agenda: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "agenda"),
+		),
+		Board: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "board"),
+		),
+		CycleTheme: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "next theme"),
+		),
+		Reload: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "reload storage"),
+		),
 		CursorUp: key.NewBinding(
 			key.WithKeys("up", "k"),
 			key.WithHelp("↑/k", "up"),
@@ -55,14 +293,14 @@ func DefaultKeyMap() KeyMap {
 		),
 		MoveItemUp: key.NewBinding(
 			key.WithKeys("ctrl+up", "ctrl+k"),
-			key.WithHelp("ctrl + ↑/k", "ctrl+up"),
+			key.WithHelp("ctrl+↑/k", "move up"),
 		),
 		MoveItemDown: key.NewBinding(
-			key.WithKeys("ctrl+down", "ctrl+j"),
-			key.WithHelp("ctrl + ↓/j", "ctrl+down"),
+			key.WithKeys("ctrl+down"),
+			key.WithHelp("ctrl+↓", "move down"),
 		),
 		PrevPage: key.NewBinding(
-			key.WithKeys("left", "h", "pgup", "b", "u"),
+			key.WithKeys("left", "h", "pgup", "b"),
 			key.WithHelp("←/h/pgup", "prev page"),
 		),
 		NextPage: key.NewBinding(
@@ -77,6 +315,86 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("end", "G"),
 			key.WithHelp("G/end", "go to end"),
 		),
+		PageSummary: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "page summary"),
+		),
+		ToggleDetail: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "toggle details"),
+		),
+		Zen: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "zen mode"),
+		),
+		ToggleNotes: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "show notes"),
+		),
+		DetailUp: key.NewBinding(
+			key.WithKeys("shift+up", "K"),
+			key.WithHelp("K/shift+↑", "scroll details up"),
+		),
+		DetailDown: key.NewBinding(
+			key.WithKeys("shift+down", "J"),
+			key.WithHelp("J/shift+↓", "scroll details down"),
+		),
+		Stats: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "stats"),
+		),
+		Activity: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "activity"),
+		),
+		Dedupe: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "find duplicates"),
+		),
+		RaisePrio: key.NewBinding(
+			key.WithKeys("+", "="),
+			key.WithHelp("+", "raise priority"),
+		),
+		LowerPrio: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", "lower priority"),
+		),
+		Waiting: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "waiting"),
+		),
+		AddSubtask: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "add subtask"),
+		),
+		EditItem: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "edit title"),
+		),
+		OpenDetail: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "details"),
+		),
+		Workspace: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "workspace"),
+		),
+		PrevList: key.NewBinding(
+			key.WithKeys("["),
+			key.WithHelp("[", "prev list"),
+		),
+		NextList: key.NewBinding(
+			key.WithKeys("]"),
+			key.WithHelp("]", "next list"),
+		),
+		NewList: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "new list"),
+		),
+		MoveToList: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "move to list"),
+		),
 		Filter: key.NewBinding(
 			key.WithKeys("/"),
 			key.WithHelp("/", "filter"),
@@ -85,6 +403,22 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("esc"),
 			key.WithHelp("esc", "clear filter"),
 		),
+		Jump: key.NewBinding(
+			key.WithKeys("ctrl+j"),
+			key.WithHelp("ctrl+j", "jump to task"),
+		),
+		GoToNumber: key.NewBinding(
+			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"),
+			key.WithHelp("1-9", "go to number"),
+		),
+		PickTags: key.NewBinding(
+			key.WithKeys("#"),
+			key.WithHelp("#", "pick tags"),
+		),
+		Palette: key.NewBinding(
+			key.WithKeys("ctrl+p", ":"),
+			key.WithHelp(":", "commands"),
+		),
 
 		// Filtering.
 		CancelWhileFiltering: key.NewBinding(
@@ -95,6 +429,270 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("enter", "tab", "shift+tab", "ctrl+k", "up", "ctrl+j", "down"),
 			key.WithHelp("enter", "apply filter"),
 		),
+		ToggleRegex: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "regex/fuzzy"),
+		),
+
+		// Jumping.
+		CancelWhileJumping: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
+		AcceptWhileJumping: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "jump"),
+		),
+		JumpUp: key.NewBinding(
+			key.WithKeys("up", "ctrl+p"),
+			key.WithHelp("↑", "previous match"),
+		),
+		JumpDown: key.NewBinding(
+			key.WithKeys("down", "ctrl+n"),
+			key.WithHelp("↓", "next match"),
+		),
+
+		// Going to a number.
+		CancelGoTo: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
+		AcceptGoTo: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "go"),
+		),
+
+		// Command palette.
+		CancelPalette: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
+		AcceptPalette: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "run"),
+		),
+		PaletteUp: key.NewBinding(
+			key.WithKeys("up", "ctrl+p"),
+			key.WithHelp("↑", "previous command"),
+		),
+		PaletteDown: key.NewBinding(
+			key.WithKeys("down", "ctrl+n"),
+			key.WithHelp("↓", "next command"),
+		),
+
+		// Going over the WIP limit.
+		ConfirmWIP: key.NewBinding(
+			key.WithKeys("y", "enter"),
+			key.WithHelp("y", "go ahead"),
+		),
+		CancelWIP: key.NewBinding(
+			key.WithKeys("n", "esc"),
+			key.WithHelp("n", "cancel"),
+		),
+
+		// Tag chips.
+		PrevChip: key.NewBinding(
+			key.WithKeys("left", "h", "shift+tab"),
+			key.WithHelp("←/h", "prev tag"),
+		),
+		NextChip: key.NewBinding(
+			key.WithKeys("right", "l", "tab"),
+			key.WithHelp("→/l", "next tag"),
+		),
+		ToggleChip: key.NewBinding(
+			key.WithKeys("enter", " "),
+			key.WithHelp("enter", "filter by tag"),
+		),
+		CloseChips: key.NewBinding(
+			key.WithKeys("esc", "#"),
+			key.WithHelp("esc", "done"),
+		),
+
+		// Stale task prompt.
+		NagComplete: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "complete"),
+		),
+		NagSnooze: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "ask tomorrow"),
+		),
+		NagDelete: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "delete"),
+		),
+		NagKeep: key.NewBinding(
+			key.WithKeys("k", "enter"),
+			key.WithHelp("k", "keep"),
+		),
+		NagSkip: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "skip the rest"),
+		),
+
+		// Storage.json in the working directory.
+		LegacyMigrate: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "move the tasks over"),
+		),
+		LegacyKeep: key.NewBinding(
+			key.WithKeys("k"),
+			key.WithHelp("k", "keep using it here"),
+		),
+		LegacyIgnore: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "ignore it"),
+		),
+		LegacyLater: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "ask next time"),
+		),
+
+		// Duplicates.
+		DedupeMerge: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "merge"),
+		),
+		DedupeDismiss: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "not duplicates"),
+		),
+		DedupeSkip: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "skip"),
+		),
+		DedupeStop: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "done"),
+		),
+
+		// Board.
+		PrevColumn: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "prev column"),
+		),
+		NextColumn: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "next column"),
+		),
+		MoveCardLeft: key.NewBinding(
+			key.WithKeys("shift+left", "<", "H"),
+			key.WithHelp("<", "move left"),
+		),
+		MoveCardRight: key.NewBinding(
+			key.WithKeys("shift+right", ">", "L"),
+			key.WithHelp(">", "move right"),
+		),
+		CloseBoard: key.NewBinding(
+			key.WithKeys("esc", "q", "B"),
+			key.WithHelp("esc", "back"),
+		),
+
+		// Stats.
+		CloseStats: key.NewBinding(
+			key.WithKeys("esc", "q", "i"),
+			key.WithHelp("esc", "back"),
+		),
+
+		// Edit screen.
+		AcceptEdit: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "save"),
+		),
+		CancelEdit: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
+
+		// Detail screen.
+		CloseDetail: key.NewBinding(
+			key.WithKeys("esc", "enter", "o", "q"),
+			key.WithHelp("esc", "back"),
+		),
+		// The first key follows the first link in the notes, the second the
+		// second one, and so on.
+		FollowLink: key.NewBinding(
+			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"),
+			key.WithHelp("1-9", "follow link"),
+		),
+
+		// Activity screen.
+		GoToActivity: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "go to task"),
+		),
+		CloseActivity: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back"),
+		),
+		PrevActivityPage: key.NewBinding(
+			key.WithKeys("pgup"),
+			key.WithHelp("pgup", "prev page"),
+		),
+		NextActivityPage: key.NewBinding(
+			key.WithKeys("pgdown"),
+			key.WithHelp("pgdown", "next page"),
+		),
+
+		// Workspace picker.
+		AcceptWorkspace: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "switch"),
+		),
+		CancelWorkspace: key.NewBinding(
+			key.WithKeys("esc", "W"),
+			key.WithHelp("esc", "cancel"),
+		),
+
+		// New list prompt.
+		AcceptListName: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "create"),
+		),
+		CancelListName: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
+
+		// Waiting prompt.
+		AcceptWait: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "mark waiting"),
+		),
+		NextWaitField: key.NewBinding(
+			key.WithKeys("tab", "shift+tab"),
+			key.WithHelp("tab", "next field"),
+		),
+		CancelWait: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
+
+		// Importing.
+		CancelWhileImporting: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel import"),
+		),
+
+		// Import preview.
+		ToggleImportRow: key.NewBinding(
+			key.WithKeys(" ", "x"),
+			key.WithHelp("space", "include/skip"),
+		),
+		AcceptImport: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "import"),
+		),
+		CancelPreview: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "cancel"),
+		),
+
+		// Tips.
+		DismissTip: key.NewBinding(
+			key.WithKeys("ctrl+x"),
+			key.WithHelp("ctrl+x", "dismiss"),
+		),
 
 		// Toggle help.
 		ShowFullHelp: key.NewBinding(
@@ -114,3 +712,31 @@ func DefaultKeyMap() KeyMap {
 		ForceQuit: key.NewBinding(key.WithKeys("ctrl+c")),
 	}
 }
+
+// VimKeyMap returns the keybindings of the vim preset: x completes a task,
+// d d deletes it, g g goes to the start and G to the end. Keys separated by
+// a space are pressed one after the other.
+func VimKeyMap() KeyMap {
+	k := builtinKeyMap()
+	k.ToggleDone = key.NewBinding(
+		key.WithKeys("x", "enter"),
+		key.WithHelp("x", "toggle"),
+	)
+	k.DeleteItem = key.NewBinding(
+		key.WithKeys("d d"),
+		key.WithHelp("dd", "delete"),
+	)
+	k.PrevPage = key.NewBinding(
+		key.WithKeys("left", "h", "pgup", "ctrl+b", "ctrl+u"),
+		key.WithHelp("←/h/pgup", "prev page"),
+	)
+	k.NextPage = key.NewBinding(
+		key.WithKeys("right", "l", "pgdown", "ctrl+f", "ctrl+d"),
+		key.WithHelp("→/l/pgdn", "next page"),
+	)
+	k.GoToStart = key.NewBinding(
+		key.WithKeys("home", "g g"),
+		key.WithHelp("gg/home", "go to start"),
+	)
+	return k
+}
@@ -26,12 +26,52 @@ type Styles struct {
 	StatusEmpty           lipgloss.Style
 	StatusBarActiveFilter lipgloss.Style
 	StatusBarFilterCount  lipgloss.Style
+	StatusBarSafeMode     lipgloss.Style
+	StatusBarHint         lipgloss.Style
+	StatusBarFollowUp     lipgloss.Style
+	StatusBarWIP          lipgloss.Style
+	StatusBarOverdue      lipgloss.Style
+
+	// Stays in the status bar once the last of a series of reminders fired,
+	// until the task is done.
+	StatusBarReminder lipgloss.Style
+
+	// Tips for getting started, drawn over the list.
+	Tip lipgloss.Style
+
+	// The chip row under the title, and the tags in it: as they are,
+	// filtered by, and picked with the keyboard.
+	ChipBar     lipgloss.Style
+	Chip        lipgloss.Style
+	ActiveChip  lipgloss.Style
+	FocusedChip lipgloss.Style
 
 	NoItems lipgloss.Style
 
 	PaginationStyle lipgloss.Style
+	PageSummary     lipgloss.Style
 	HelpStyle       lipgloss.Style
 
+	// The short help's hint at how many more bindings the full help has.
+	HelpMore lipgloss.Style
+
+	// Heatmap cells from no completions to the busiest day.
+	HeatCell [5]lipgloss.Style
+
+	// The details pane next to the list on wide terminals.
+	DetailPane  lipgloss.Style
+	DetailTitle lipgloss.Style
+	DetailLabel lipgloss.Style
+
+	// [[task:ID]] links in the notes, to a task that exists and to one
+	// that doesn't anymore.
+	DetailLink        lipgloss.Style
+	DetailMissingLink lipgloss.Style
+
+	// Rows of the import preview: items to add and duplicates to skip.
+	ImportAdded     lipgloss.Style
+	ImportDuplicate lipgloss.Style
+
 	// Styled characters.
 	ActivePaginationDot   lipgloss.Style
 	InactivePaginationDot lipgloss.Style
@@ -41,58 +81,136 @@ type Styles struct {
 
 // DefaultStyles returns a set of default style definitions for this list
 // component.
-func DefaultStyles() (s Styles) {
-	verySubduedColor := lipgloss.AdaptiveColor{Light: "#DDDADA", Dark: "#3C3C3C"}
-	subduedColor := lipgloss.AdaptiveColor{Light: "#9B9B9B", Dark: "#5C5C5C"}
+func DefaultStyles() Styles {
+	return NewStyles(DefaultTheme())
+}
 
+// NewStyles returns the list style definitions built from the given theme.
+func NewStyles(t Theme) (s Styles) {
 	s.TitleBar = lipgloss.NewStyle().Padding(0, 0, 1, 2) //nolint:mnd
 
 	s.Title = lipgloss.NewStyle().
-		Background(lipgloss.Color("62")).
-		Foreground(lipgloss.Color("230")).
+		Background(t.TitleBackground).
+		Foreground(t.TitleForeground).
 		Padding(0, 1)
 
 	s.Spinner = lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Light: "#8E8E8E", Dark: "#747373"})
+		Foreground(t.Spinner)
 
 	s.FilterPrompt = lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Light: "#04B575", Dark: "#ECFD65"})
+		Foreground(t.FilterPrompt)
 
 	s.FilterCursor = lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Light: "#EE6FF8", Dark: "#EE6FF8"})
+		Foreground(t.FilterCursor)
 
 	s.DefaultFilterCharacterMatch = lipgloss.NewStyle().Underline(true)
 
 	s.StatusBar = lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Light: "#A49FA5", Dark: "#777777"}).
+		Foreground(t.StatusBar).
 		Padding(0, 0, 1, 2) //nolint:mnd
 
-	s.StatusEmpty = lipgloss.NewStyle().Foreground(subduedColor)
+	s.StatusEmpty = lipgloss.NewStyle().Foreground(t.Subdued)
 
 	s.StatusBarActiveFilter = lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Light: "#1a1a1a", Dark: "#dddddd"})
+		Foreground(t.Text)
 
-	s.StatusBarFilterCount = lipgloss.NewStyle().Foreground(verySubduedColor)
+	s.StatusBarFilterCount = lipgloss.NewStyle().Foreground(t.VerySubdued)
+
+	s.StatusBarSafeMode = lipgloss.NewStyle().
+		Background(t.TitleBackground).
+		Foreground(t.TitleForeground).
+		Padding(0, 1)
+
+	s.StatusBarHint = lipgloss.NewStyle().Foreground(t.Subdued)
+
+	s.StatusBarFollowUp = lipgloss.NewStyle().Foreground(t.PriorityMedium)
+
+	s.StatusBarWIP = lipgloss.NewStyle().Foreground(t.PriorityHigh)
+
+	s.StatusBarOverdue = lipgloss.NewStyle().Foreground(t.PriorityHigh)
+
+	s.StatusBarReminder = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(t.TitleForeground).
+		Background(t.PriorityHigh).
+		Padding(0, 1)
+
+	s.Tip = lipgloss.NewStyle().
+		Foreground(t.TitleForeground).
+		Background(t.TitleBackground).
+		Padding(0, 1)
+
+	s.ChipBar = lipgloss.NewStyle().PaddingLeft(1)
+	s.Chip = lipgloss.NewStyle().
+		Foreground(t.Subdued).
+		Padding(0, 1)
+	s.ActiveChip = lipgloss.NewStyle().
+		Foreground(t.TitleForeground).
+		Background(t.TitleBackground).
+		Bold(true).
+		Reverse(t.Monochrome).
+		Padding(0, 1)
+	s.FocusedChip = lipgloss.NewStyle().Underline(true)
 
 	s.NoItems = lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Light: "#909090", Dark: "#626262"})
+		Foreground(t.NoItems)
 
-	s.ArabicPagination = lipgloss.NewStyle().Foreground(subduedColor)
+	s.ArabicPagination = lipgloss.NewStyle().Foreground(t.Subdued)
 
 	s.PaginationStyle = lipgloss.NewStyle().PaddingLeft(2) //nolint:mnd
 
+	s.PageSummary = lipgloss.NewStyle().
+		Foreground(t.Subdued).
+		PaddingLeft(2) //nolint:mnd
+
 	s.HelpStyle = lipgloss.NewStyle().Padding(1, 0, 0, 2) //nolint:mnd
 
+	s.HelpMore = lipgloss.NewStyle().
+		Foreground(t.Text).
+		Bold(true)
+
+	s.DetailPane = lipgloss.NewStyle().
+		Border(lipgloss.NormalBorder(), false, false, false, true).
+		BorderForeground(t.VerySubdued).
+		Padding(0, 1, 0, 2) //nolint:mnd
+
+	s.DetailTitle = lipgloss.NewStyle().
+		Foreground(t.Text).
+		Bold(true)
+
+	s.DetailLabel = lipgloss.NewStyle().Foreground(t.Subdued)
+
+	s.DetailLink = lipgloss.NewStyle().Underline(true)
+
+	s.DetailMissingLink = lipgloss.NewStyle().
+		Foreground(t.Subdued).
+		Strikethrough(true)
+
+	s.ImportAdded = lipgloss.NewStyle().Foreground(t.Done)
+
+	s.ImportDuplicate = lipgloss.NewStyle().Foreground(t.Subdued)
+
+	// Without colors the shades have to come from the characters.
+	cells := [5]string{"■", "■", "■", "■", "■"}
+	if t.Monochrome {
+		cells = [5]string{".", "-", "+", "*", "#"}
+	}
+	for i := range s.HeatCell {
+		s.HeatCell[i] = lipgloss.NewStyle().
+			Foreground(t.Heat[i]).
+			SetString(cells[i])
+	}
+
 	s.ActivePaginationDot = lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Light: "#847A85", Dark: "#979797"}).
+		Foreground(t.ActiveDot).
 		SetString(bullet)
 
 	s.InactivePaginationDot = lipgloss.NewStyle().
-		Foreground(verySubduedColor).
+		Foreground(t.VerySubdued).
 		SetString(bullet)
 
 	s.DividerDot = lipgloss.NewStyle().
-		Foreground(verySubduedColor).
+		Foreground(t.VerySubdued).
 		SetString(" " + bullet + " ")
 
 	return s
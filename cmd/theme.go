@@ -0,0 +1,301 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Theme is the palette styles are built from. Adding a theme is a data change:
+// define another Theme value and register it in Themes.
+type Theme struct {
+	Name string
+
+	TitleForeground lipgloss.TerminalColor
+	TitleBackground lipgloss.TerminalColor
+
+	// Item text in its normal, dimmed and selected state.
+	Text           lipgloss.TerminalColor
+	Dimmed         lipgloss.TerminalColor
+	Selected       lipgloss.TerminalColor
+	SelectedBorder lipgloss.TerminalColor
+
+	// Check marks of completed items.
+	Done lipgloss.TerminalColor
+
+	// Markers of medium and high priority items. Low priority uses Subdued.
+	PriorityMedium lipgloss.TerminalColor
+	PriorityHigh   lipgloss.TerminalColor
+
+	Spinner      lipgloss.TerminalColor
+	FilterPrompt lipgloss.TerminalColor
+	FilterCursor lipgloss.TerminalColor
+
+	StatusBar   lipgloss.TerminalColor
+	NoItems     lipgloss.TerminalColor
+	ActiveDot   lipgloss.TerminalColor
+	Subdued     lipgloss.TerminalColor
+	VerySubdued lipgloss.TerminalColor
+
+	// Heatmap shades from no completions to the busiest day.
+	Heat [5]lipgloss.TerminalColor
+
+	// Monochrome themes can't tell things apart by color, so shades are
+	// drawn with different characters instead.
+	Monochrome bool
+
+	// How the selected row is marked. The zero value is CursorBorder.
+	Cursor Cursor
+}
+
+// Cursor is how the selected row stands out from the others, apart from its
+// color.
+type Cursor string
+
+const (
+	// CursorBorder draws a bar in front of the row.
+	CursorBorder Cursor = "border"
+	// CursorGlyph puts a ">" in front of the row.
+	CursorGlyph Cursor = "glyph"
+	// CursorReverse shows the title in inverted video.
+	CursorReverse Cursor = "reverse"
+	// CursorUnderline underlines the title.
+	CursorUnderline Cursor = "underline"
+)
+
+// ParseCursor reads the cursor setting of the config file.
+func ParseCursor(s string) (Cursor, error) {
+	switch c := Cursor(s); c {
+	case CursorBorder, CursorGlyph, CursorReverse, CursorUnderline:
+		return c, nil
+	}
+	return "", fmt.Errorf("unknown cursor %q (available: border, glyph, reverse, underline)", s)
+}
+
+// Themes lists the built-in themes by name.
+var Themes = map[string]func() Theme{
+	"default":       DefaultTheme,
+	"light":         LightTheme,
+	"high-contrast": HighContrastTheme,
+	"colorblind":    ColorBlindTheme,
+	"nocolor":       NoColorTheme,
+}
+
+// ThemeOrder is the order the list cycles through the built-in themes in.
+var ThemeOrder = []string{"default", "light", "high-contrast", "colorblind", "nocolor"}
+
+// NextTheme returns the name of the theme after the one called name in
+// ThemeOrder, wrapping around.
+func NextTheme(name string) string {
+	for i, n := range ThemeOrder {
+		if n == name {
+			return ThemeOrder[(i+1)%len(ThemeOrder)]
+		}
+	}
+	return ThemeOrder[0]
+}
+
+// DefaultTheme returns the original pink and green palette.
+func DefaultTheme() Theme {
+	return Theme{
+		Name:            "default",
+		TitleForeground: lipgloss.Color("230"),
+		TitleBackground: lipgloss.Color("62"),
+		Text:            lipgloss.AdaptiveColor{Light: "#1a1a1a", Dark: "#dddddd"},
+		Dimmed:          lipgloss.AdaptiveColor{Light: "#A49FA5", Dark: "#777777"},
+		Selected:        lipgloss.AdaptiveColor{Light: "#EE6FF8", Dark: "#EE6FF8"},
+		SelectedBorder:  lipgloss.AdaptiveColor{Light: "#F793FF", Dark: "#AD58B4"},
+		Done:            lipgloss.AdaptiveColor{Light: "#43BF6D", Dark: "#73F59F"},
+		PriorityMedium:  lipgloss.AdaptiveColor{Light: "#C98A00", Dark: "#F2C94C"},
+		PriorityHigh:    lipgloss.AdaptiveColor{Light: "#D7263D", Dark: "#FF5F6D"},
+		Spinner:         lipgloss.AdaptiveColor{Light: "#8E8E8E", Dark: "#747373"},
+		FilterPrompt:    lipgloss.AdaptiveColor{Light: "#04B575", Dark: "#ECFD65"},
+		FilterCursor:    lipgloss.AdaptiveColor{Light: "#EE6FF8", Dark: "#EE6FF8"},
+		StatusBar:       lipgloss.AdaptiveColor{Light: "#A49FA5", Dark: "#777777"},
+		NoItems:         lipgloss.AdaptiveColor{Light: "#909090", Dark: "#626262"},
+		ActiveDot:       lipgloss.AdaptiveColor{Light: "#847A85", Dark: "#979797"},
+		Subdued:         lipgloss.AdaptiveColor{Light: "#9B9B9B", Dark: "#5C5C5C"},
+		VerySubdued:     lipgloss.AdaptiveColor{Light: "#DDDADA", Dark: "#3C3C3C"},
+		Heat: [5]lipgloss.TerminalColor{
+			lipgloss.AdaptiveColor{Light: "#DDDADA", Dark: "#3C3C3C"},
+			lipgloss.AdaptiveColor{Light: "#B7EBC6", Dark: "#1E5A32"},
+			lipgloss.AdaptiveColor{Light: "#7FD89A", Dark: "#2E8B4E"},
+			lipgloss.AdaptiveColor{Light: "#43BF6D", Dark: "#4FC978"},
+			lipgloss.AdaptiveColor{Light: "#1F7A40", Dark: "#73F59F"},
+		},
+	}
+}
+
+// LightTheme is the default palette for light backgrounds, whatever the
+// terminal reports, for terminals that get the background wrong.
+func LightTheme() Theme {
+	t := DefaultTheme()
+	t.Name = "light"
+	light := func(c lipgloss.TerminalColor) lipgloss.TerminalColor {
+		if a, ok := c.(lipgloss.AdaptiveColor); ok {
+			return lipgloss.Color(a.Light)
+		}
+		return c
+	}
+	for _, c := range []*lipgloss.TerminalColor{
+		&t.Text, &t.Dimmed, &t.Selected, &t.SelectedBorder, &t.Done,
+		&t.PriorityMedium, &t.PriorityHigh, &t.Spinner, &t.FilterPrompt,
+		&t.FilterCursor, &t.StatusBar, &t.NoItems, &t.ActiveDot, &t.Subdued,
+		&t.VerySubdued,
+	} {
+		*c = light(*c)
+	}
+	for i := range t.Heat {
+		t.Heat[i] = light(t.Heat[i])
+	}
+	return t
+}
+
+// HighContrastTheme sticks to the terminal's bright basic colors and keeps
+// dimmed text readable, for low-contrast screens and tired eyes.
+func HighContrastTheme() Theme {
+	fg := lipgloss.AdaptiveColor{Light: "0", Dark: "15"}
+	dim := lipgloss.AdaptiveColor{Light: "8", Dark: "7"}
+	return Theme{
+		Name:            "high-contrast",
+		TitleForeground: lipgloss.Color("0"),
+		TitleBackground: lipgloss.Color("11"),
+		Text:            fg,
+		Dimmed:          dim,
+		Selected:        lipgloss.AdaptiveColor{Light: "4", Dark: "14"},
+		SelectedBorder:  lipgloss.AdaptiveColor{Light: "4", Dark: "14"},
+		Done:            lipgloss.AdaptiveColor{Light: "2", Dark: "10"},
+		PriorityMedium:  lipgloss.AdaptiveColor{Light: "3", Dark: "11"},
+		PriorityHigh:    lipgloss.AdaptiveColor{Light: "1", Dark: "9"},
+		Spinner:         fg,
+		FilterPrompt:    lipgloss.AdaptiveColor{Light: "4", Dark: "14"},
+		FilterCursor:    fg,
+		StatusBar:       fg,
+		NoItems:         dim,
+		ActiveDot:       fg,
+		Subdued:         dim,
+		VerySubdued:     dim,
+		Heat: [5]lipgloss.TerminalColor{
+			dim,
+			lipgloss.AdaptiveColor{Light: "6", Dark: "6"},
+			lipgloss.AdaptiveColor{Light: "2", Dark: "2"},
+			lipgloss.AdaptiveColor{Light: "2", Dark: "10"},
+			fg,
+		},
+		Cursor: CursorGlyph,
+	}
+}
+
+// ColorBlindTheme replaces the pink/green contrasts of the default theme with
+// blue and orange from the Okabe-Ito palette, which stay distinguishable with
+// red-green color blindness.
+func ColorBlindTheme() Theme {
+	t := DefaultTheme()
+	t.Name = "colorblind"
+	t.Cursor = CursorGlyph
+	t.TitleForeground = lipgloss.Color("#FFFFFF")
+	t.TitleBackground = lipgloss.Color("#0072B2")
+	t.Selected = lipgloss.AdaptiveColor{Light: "#0072B2", Dark: "#56B4E9"}
+	t.SelectedBorder = lipgloss.AdaptiveColor{Light: "#0072B2", Dark: "#56B4E9"}
+	t.Done = lipgloss.AdaptiveColor{Light: "#D55E00", Dark: "#E69F00"}
+	t.PriorityMedium = lipgloss.AdaptiveColor{Light: "#0072B2", Dark: "#56B4E9"}
+	t.PriorityHigh = lipgloss.AdaptiveColor{Light: "#D55E00", Dark: "#E69F00"}
+	t.FilterPrompt = lipgloss.AdaptiveColor{Light: "#0072B2", Dark: "#56B4E9"}
+	t.FilterCursor = lipgloss.AdaptiveColor{Light: "#D55E00", Dark: "#E69F00"}
+	t.Heat = [5]lipgloss.TerminalColor{
+		t.VerySubdued,
+		lipgloss.AdaptiveColor{Light: "#C6E2F5", Dark: "#1B3F5C"},
+		lipgloss.AdaptiveColor{Light: "#8CC4EA", Dark: "#2A6A99"},
+		lipgloss.AdaptiveColor{Light: "#56B4E9", Dark: "#3D93D1"},
+		lipgloss.AdaptiveColor{Light: "#0072B2", Dark: "#56B4E9"},
+	}
+	return t
+}
+
+// NoColorTheme uses the terminal's own colors only, for terminals without
+// color support and people who prefer it that way.
+func NoColorTheme() Theme {
+	none := lipgloss.NoColor{}
+	return Theme{
+		Name:            "nocolor",
+		TitleForeground: none,
+		TitleBackground: none,
+		Text:            none,
+		Dimmed:          none,
+		Selected:        none,
+		SelectedBorder:  none,
+		Done:            none,
+		PriorityMedium:  none,
+		PriorityHigh:    none,
+		Spinner:         none,
+		FilterPrompt:    none,
+		FilterCursor:    none,
+		StatusBar:       none,
+		NoItems:         none,
+		ActiveDot:       none,
+		Subdued:         none,
+		VerySubdued:     none,
+		Heat:            [5]lipgloss.TerminalColor{none, none, none, none, none},
+		Monochrome:      true,
+		Cursor:          CursorGlyph,
+	}
+}
+
+// ThemeByName returns the built-in theme called name.
+func ThemeByName(name string) (Theme, error) {
+	if name == "" {
+		return DefaultTheme(), nil
+	}
+	theme, ok := Themes[name]
+	if !ok {
+		names := make([]string, 0, len(Themes))
+		for n := range Themes {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return Theme{}, fmt.Errorf("unknown theme %q (available: %s)", name, strings.Join(names, ", "))
+	}
+	return theme(), nil
+}
+
+// DetectDarkBackground decides whether adaptive colors should use their Dark
+// variant. setting is "dark" or "light" to force a choice, or "auto" (or empty)
+// to detect it: COLORFGBG is trusted first since some terminals misreport the
+// background color when queried, then the terminal itself is asked.
+//
+// Call this once at startup and pass the result to
+// lipgloss.SetHasDarkBackground so the choice doesn't change mid-session.
+func DetectDarkBackground(setting string) (bool, error) {
+	switch setting {
+	case "dark":
+		return true, nil
+	case "light":
+		return false, nil
+	case "", "auto":
+	default:
+		return false, fmt.Errorf("background must be dark, light or auto, got %q", setting)
+	}
+
+	if dark, ok := darkFromColorFgBg(os.Getenv("COLORFGBG")); ok {
+		return dark, nil
+	}
+	return lipgloss.HasDarkBackground(), nil
+}
+
+// darkFromColorFgBg interprets COLORFGBG, which is "fg;bg" (or "fg;default;bg")
+// in ANSI color numbers. Colors 7 and 9-15 are light, everything else is dark.
+func darkFromColorFgBg(v string) (dark, ok bool) {
+	fields := strings.Split(v, ";")
+	if len(fields) < 2 {
+		return false, false
+	}
+	bg, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil || bg < 0 || bg > 15 {
+		return false, false
+	}
+	return bg != 7 && bg < 9, true
+}
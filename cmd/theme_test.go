@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// golden compares got to testdata/name.golden. With -update it writes the
+// file instead.
+func golden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v; run go test -update to write it", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s has changed, run go test -update if that's intended; got\n%s\nwant\n%s", path, got, want)
+	}
+}
+
+func TestDarkFromColorFgBg(t *testing.T) {
+	tests := []struct {
+		value    string
+		dark, ok bool
+	}{
+		{value: "15;0", dark: true, ok: true},
+		{value: "0;15", dark: false, ok: true},
+		{value: "0;7", dark: false, ok: true},
+		{value: "15;8", dark: true, ok: true},
+		{value: "0;9", dark: false, ok: true},
+		{value: "15;default;0", dark: true, ok: true},
+		{value: "0;default;15", dark: false, ok: true},
+		{value: "", ok: false},
+		{value: "15", ok: false},
+		{value: "15;default", ok: false},
+		{value: "15;16", ok: false},
+		{value: "15;-1", ok: false},
+	}
+	for _, tt := range tests {
+		dark, ok := darkFromColorFgBg(tt.value)
+		if dark != tt.dark || ok != tt.ok {
+			t.Errorf("darkFromColorFgBg(%q) = %t, %t, want %t, %t", tt.value, dark, ok, tt.dark, tt.ok)
+		}
+	}
+}
+
+func TestDetectDarkBackground(t *testing.T) {
+	tests := []struct {
+		setting   string
+		colorfgbg string
+		dark      bool
+		err       bool
+	}{
+		{setting: "dark", colorfgbg: "0;15", dark: true},
+		{setting: "light", colorfgbg: "15;0", dark: false},
+		{setting: "auto", colorfgbg: "15;0", dark: true},
+		{setting: "auto", colorfgbg: "0;15", dark: false},
+		{setting: "", colorfgbg: "0;default;15", dark: false},
+		{setting: "dim", err: true},
+	}
+	for _, tt := range tests {
+		t.Setenv("COLORFGBG", tt.colorfgbg)
+		dark, err := DetectDarkBackground(tt.setting)
+		if (err != nil) != tt.err {
+			t.Errorf("DetectDarkBackground(%q) error = %v, want error %t", tt.setting, err, tt.err)
+			continue
+		}
+		if !tt.err && dark != tt.dark {
+			t.Errorf("DetectDarkBackground(%q) with COLORFGBG=%q = %t, want %t", tt.setting, tt.colorfgbg, dark, tt.dark)
+		}
+	}
+}
+
+// TestThemes renders a sample of every style of each built-in theme, in true
+// color on a dark and on a light background, and compares them with the
+// theme's golden file.
+func TestThemes(t *testing.T) {
+	if names := slices.Sorted(func(yield func(string) bool) {
+		for name := range Themes {
+			if !yield(name) {
+				return
+			}
+		}
+	}); !slices.Equal(names, slices.Sorted(slices.Values(ThemeOrder))) {
+		t.Errorf("ThemeOrder has %q, want every theme in Themes: %q", ThemeOrder, names)
+	}
+
+	profile, dark := lipgloss.ColorProfile(), lipgloss.HasDarkBackground()
+	t.Cleanup(func() {
+		lipgloss.SetColorProfile(profile)
+		lipgloss.SetHasDarkBackground(dark)
+	})
+	lipgloss.SetColorProfile(termenv.TrueColor)
+
+	for _, name := range ThemeOrder {
+		t.Run(name, func(t *testing.T) {
+			theme, err := ThemeByName(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if theme.Name != name {
+				t.Errorf("theme %s calls itself %s", name, theme.Name)
+			}
+			var b strings.Builder
+			fmt.Fprintf(&b, "monochrome %t, cursor %q\n", theme.Monochrome, theme.Cursor)
+			for _, background := range []string{"dark", "light"} {
+				lipgloss.SetHasDarkBackground(background == "dark")
+				fmt.Fprintf(&b, "\n%s background:\n", background)
+				styles := reflect.ValueOf(NewStyles(theme))
+				for i := range styles.NumField() {
+					field := styles.Type().Field(i).Name
+					switch style := styles.Field(i).Interface().(type) {
+					case lipgloss.Style:
+						fmt.Fprintf(&b, "%-27s %q\n", field, style.Render("sample"))
+					case [5]lipgloss.Style:
+						for shade, style := range style {
+							fmt.Fprintf(&b, "%-27s %q\n", fmt.Sprintf("%s[%d]", field, shade), style.Render("sample"))
+						}
+					default:
+						t.Fatalf("Styles.%s is a %T", field, style)
+					}
+				}
+			}
+			golden(t, "theme-"+name, b.String())
+		})
+	}
+}
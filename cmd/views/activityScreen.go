@@ -0,0 +1,210 @@
+package views
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/paginator"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"clitodo/cmd"
+	"clitodo/pkg/activity"
+)
+
+// activityChrome is how many rows of the activity screen aren't entries:
+// margins, title, filter, paginator and help.
+const activityChrome = 9
+
+// activityDoneMsg closes the activity screen. The list jumps to entry's task
+// unless it's nil.
+type activityDoneMsg struct {
+	entry *activity.Entry
+}
+
+// activityScreen lists the activity log newest first, a page at a time. Typing
+// filters it with the list's fuzzy filter.
+type activityScreen struct {
+	entries []activity.Entry
+	// Indexes into entries of the ones matching the filter.
+	matches []int
+	cursor  int
+	filter  FilterFunc
+	now     time.Time
+
+	input     textinput.Model
+	paginator paginator.Model
+
+	KeyMap cmd.KeyMap
+	help   help.Model
+	styles cmd.Styles
+}
+
+func newActivityScreen(entries []activity.Entry, filter FilterFunc, now time.Time, height int, styles cmd.Styles) activityScreen {
+	entries = slices.Clone(entries)
+	slices.Reverse(entries)
+
+	input := textinput.New()
+	input.Prompt = "Filter: "
+	input.PromptStyle = styles.FilterPrompt
+	input.Cursor.Style = styles.FilterCursor
+	input.Focus()
+
+	p := paginator.New()
+	p.Type = paginator.Arabic
+
+	m := activityScreen{
+		entries:   entries,
+		filter:    filter,
+		now:       now,
+		input:     input,
+		paginator: p,
+		KeyMap:    cmd.DefaultKeyMap(),
+		help:      help.New(),
+		styles:    styles,
+	}
+	m.setHeight(height)
+	m.refilter()
+	return m
+}
+
+// setHeight fits as many entries on a page as the terminal has rows for.
+func (m *activityScreen) setHeight(height int) {
+	m.paginator.PerPage = max(1, height-activityChrome)
+	m.paginator.SetTotalPages(len(m.matches))
+	m.paginator.Page = m.cursor / m.paginator.PerPage
+}
+
+func (m *activityScreen) refilter() {
+	m.matches = m.matches[:0]
+	if term := m.input.Value(); term == "" {
+		for i := range m.entries {
+			m.matches = append(m.matches, i)
+		}
+	} else {
+		targets := make([]string, len(m.entries))
+		for i, e := range m.entries {
+			targets[i] = e.Verb() + " " + e.Title
+		}
+		for _, r := range m.filter(term, targets) {
+			m.matches = append(m.matches, r.Index)
+		}
+		// Matches stay in log order rather than by how well they match.
+		slices.Sort(m.matches)
+	}
+	m.cursor = 0
+	m.paginator.SetTotalPages(len(m.matches))
+	m.paginator.Page = 0
+}
+
+// move shifts the cursor by n entries, turning pages as needed.
+func (m *activityScreen) move(n int) {
+	m.cursor = max(0, min(len(m.matches)-1, m.cursor+n))
+	m.paginator.Page = m.cursor / m.paginator.PerPage
+}
+
+func (m activityScreen) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m activityScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.setHeight(msg.Height)
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.KeyMap.CloseActivity):
+			return m, func() tea.Msg { return activityDoneMsg{} }
+		case key.Matches(msg, m.KeyMap.GoToActivity):
+			if len(m.matches) == 0 {
+				return m, nil
+			}
+			entry := m.entries[m.matches[m.cursor]]
+			return m, func() tea.Msg { return activityDoneMsg{entry: &entry} }
+		case key.Matches(msg, m.KeyMap.JumpUp):
+			m.move(-1)
+			return m, nil
+		case key.Matches(msg, m.KeyMap.JumpDown):
+			m.move(1)
+			return m, nil
+		case key.Matches(msg, m.KeyMap.PrevActivityPage):
+			m.move(-m.paginator.PerPage)
+			return m, nil
+		case key.Matches(msg, m.KeyMap.NextActivityPage):
+			m.move(m.paginator.PerPage)
+			return m, nil
+		}
+	}
+
+	before := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if m.input.Value() != before {
+		m.refilter()
+	}
+	return m, cmd
+}
+
+func (m activityScreen) View() string {
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render("Activity"))
+	b.WriteString("\n\n")
+	b.WriteString(m.input.View())
+	b.WriteString("\n\n")
+
+	switch {
+	case len(m.entries) == 0:
+		b.WriteString(m.styles.NoItems.Render("  Nothing happened yet") + "\n")
+	case len(m.matches) == 0:
+		b.WriteString(m.styles.NoItems.Render("  Nothing matched") + "\n")
+	}
+
+	start, end := m.paginator.GetSliceBounds(len(m.matches))
+	for i := start; i < end; i++ {
+		line := m.entryView(m.entries[m.matches[i]])
+		if i == m.cursor {
+			line = lipgloss.NewStyle().Reverse(true).Render(line)
+		}
+		fmt.Fprintf(&b, "  %s\n", line)
+	}
+	if m.paginator.TotalPages > 1 {
+		b.WriteString("  " + m.paginator.View() + "\n")
+	}
+
+	b.WriteString(m.styles.HelpStyle.Render(m.help.ShortHelpView([]key.Binding{
+		m.KeyMap.JumpUp,
+		m.KeyMap.JumpDown,
+		m.KeyMap.PrevActivityPage,
+		m.KeyMap.NextActivityPage,
+		m.KeyMap.GoToActivity,
+		m.KeyMap.CloseActivity,
+	})))
+	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
+}
+
+// entryView writes an entry like "14:02 completed “send invoice”", with the
+// date in front unless it happened today.
+func (m activityScreen) entryView(e activity.Entry) string {
+	when := e.Time.Local().Format("15:04")
+	if !sameDay(e.Time, m.now) {
+		when = e.Time.Local().Format("Mon 2006-01-02 15:04")
+	}
+	return fmt.Sprintf("%s %s “%s”", when, e.Verb(), e.Title)
+}
+
+// showActivityEntry selects the task an activity entry is about, if it still
+// exists.
+func (m *ListScreen) showActivityEntry(e activity.Entry) tea.Cmd {
+	i := m.indexOfID(e.ItemID)
+	if i < 0 {
+		return m.NewStatusMessage(fmt.Sprintf("“%s” no longer exists", e.Title))
+	}
+	return m.jumpTo(i)
+}
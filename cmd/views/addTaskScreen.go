@@ -2,11 +2,15 @@ package views
 
 import (
 	"fmt"
+	"strings"
 
 	"clitodo/cmd"
+	"clitodo/pkg/clock"
 	"clitodo/pkg/domain"
 
+	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -14,19 +18,76 @@ import (
 type addTaskScreen struct {
 	textInput textinput.Model
 	KeyMap    cmd.KeyMap
+	help      help.Model
+
+	// Soft limit on the title length, 0 for none. Longer titles are
+	// accepted, but the screen suggests moving the overflow into notes.
+	titleLimit int
+
+	// Stored as the item's notes. Enter starts a new line here, so the task
+	// is added with SubmitTask instead.
+	notes        textarea.Model
+	editingNotes bool
+
+	// The task the new one becomes a subtask of, if any.
+	parent *domain.Item
+
+	// Source of the current time, which due: words are relative to.
+	clock clock.Clock
+
+	// What's wrong with the due: word typed, if anything. The task isn't
+	// added until it's fixed.
+	err error
+
+	// What the task starts with in the list it's added to: the rules for its
+	// due date and priority, which can match the list's name, and its tags.
+	rules       domain.DefaultRules
+	workspace   string
+	defaultTags []string
 }
 
-func NewAddTaskScreen() addTaskScreen {
+func NewAddTaskScreen(titleLimit int) addTaskScreen {
 	ti := textinput.New()
 	ti.Placeholder = "TaskName"
 	ti.Focus()
 	ti.CharLimit = 156
 	ti.Width = 20
 
+	notes := textarea.New()
+	notes.Placeholder = "Notes (optional)"
+	notes.ShowLineNumbers = false
+	notes.SetWidth(40)
+	notes.SetHeight(4)
+
 	return addTaskScreen{
-		textInput: ti,
-		KeyMap:    cmd.DefaultKeyMap(),
+		textInput:  ti,
+		KeyMap:     cmd.DefaultKeyMap(),
+		help:       help.New(),
+		titleLimit: titleLimit,
+		notes:      notes,
+		clock:      clock.Real{},
+	}
+}
+
+// newSubtaskScreen returns the add screen for a subtask of parent.
+func newSubtaskScreen(titleLimit int, parent domain.Item) addTaskScreen {
+	m := NewAddTaskScreen(titleLimit)
+	m.parent = &parent
+	return m
+}
+
+// newAddScreen returns the add screen set up with options, for a subtask of
+// parent unless that's nil.
+func newAddScreen(options Options, parent *domain.Item) addTaskScreen {
+	m := NewAddTaskScreen(options.TitleLimit)
+	if parent != nil {
+		m = newSubtaskScreen(options.TitleLimit, *parent)
 	}
+	m.clock = options.Clock
+	m.rules = options.DefaultRules
+	m.workspace = options.Workspace
+	m.defaultTags = options.DefaultTags
+	return m
 }
 
 func (m addTaskScreen) Init() tea.Cmd {
@@ -37,25 +98,168 @@ func (m addTaskScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if key.Matches(msg, m.KeyMap.SubmitTask) {
+			return m.enterTask()
+		}
+		if m.editingNotes {
+			if key.Matches(msg, m.KeyMap.EditTitle) {
+				m.editingNotes = false
+				m.notes.Blur()
+				return m, m.textInput.Focus()
+			}
+			m.notes, cmd = m.notes.Update(msg)
+			return m, cmd
+		}
 		if key.Matches(msg, m.KeyMap.AddTask) { //"enter"
-			return m, enterTask(m)
+			return m.enterTask()
+		}
+		if key.Matches(msg, m.KeyMap.SplitTitle) && m.overLimit() {
+			m.splitTitle()
+			return m, nil
 		}
+		if key.Matches(msg, m.KeyMap.EditNotes) {
+			m.editingNotes = true
+			m.textInput.Blur()
+			return m, m.notes.Focus()
+		}
+	}
+	if m.editingNotes {
+		m.notes, cmd = m.notes.Update(msg)
+		return m, cmd
 	}
+	value := m.textInput.Value()
 	m.textInput, cmd = m.textInput.Update(msg)
+	if m.textInput.Value() != value {
+		m.err = nil
+	}
 	return m, cmd
 }
 
 func (m addTaskScreen) View() string {
+	var extra string
+	if m.err != nil {
+		extra += m.err.Error() + "\n\n"
+	} else if parsed := m.parsedView(); parsed != "" {
+		extra += parsed + "\n\n"
+	}
+	if m.overLimit() {
+		extra += fmt.Sprintf(
+			"That's %d characters, a bit long for a title. %s to %s.\n\n",
+			domain.TitleLength(m.title()),
+			m.KeyMap.SplitTitle.Help().Key,
+			m.KeyMap.SplitTitle.Help().Desc,
+		)
+	}
+
+	bindings := []key.Binding{m.KeyMap.AddTask, m.KeyMap.EditNotes}
+	if m.editingNotes {
+		bindings = []key.Binding{m.KeyMap.SubmitTask, m.KeyMap.EditTitle}
+	}
+
+	heading := "Task Title"
+	if m.parent != nil {
+		heading = fmt.Sprintf("Subtask of %q", m.parent.Title())
+	}
+
 	return fmt.Sprintf(
-		"Task Title\n\n%s\n\n%s",
+		"%s\n\n%s\n\n%s\n\n%s%s\n%s",
+		heading,
 		m.textInput.View(),
+		m.notes.View(),
+		extra,
+		m.help.ShortHelpView(bindings),
 		"(esc to quit)",
 	) + "\n"
 }
 
-func enterTask(m addTaskScreen) tea.Cmd {
-	return func() tea.Msg {
-		item := domain.NewItem(m.textInput.Value())
+// title returns the typed title without the due:, !priority and #tag words
+// at its end.
+func (m addTaskScreen) title() string {
+	title, _ := domain.SplitQuickEntry(m.textInput.Value())
+	return title
+}
+
+// parsedView shows what the words at the end of the title were read as, and
+// what the default rules give the task, naming the rule, so either can still
+// be changed. It shows nothing if there's neither or one of the words isn't
+// right.
+func (m addTaskScreen) parsedView() string {
+	item, defaulted, err := m.parse()
+	_, tokens := domain.SplitQuickEntry(m.textInput.Value())
+	if err != nil || len(tokens) == 0 && !defaulted.Any() {
+		return ""
+	}
+	fromRule := func(name string) string {
+		if name == "" {
+			return ""
+		}
+		return " (" + name + ")"
+	}
+	var parts []string
+	if item.Due != nil {
+		parts = append(parts, "due "+domain.FormatDue(*item.Due)+fromRule(defaulted.Due))
+	}
+	if item.Priority != domain.PriorityNone {
+		parts = append(parts, item.Priority.String()+" priority"+fromRule(defaulted.Priority))
+	}
+	if len(item.Tags) != 0 {
+		parts = append(parts, domain.FormatTags(item.Tags))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func (m addTaskScreen) overLimit() bool {
+	return m.titleLimit > 0 && domain.TitleLength(m.title()) > m.titleLimit
+}
+
+// splitTitle cuts the title at the soft limit and puts the rest in front of
+// the notes. The due:, !priority and #tag words stay at the end of the title.
+func (m *addTaskScreen) splitTitle() {
+	title, tokens := domain.SplitQuickEntry(m.textInput.Value())
+	head, rest := domain.SplitTitle(title, m.titleLimit)
+	if notes := m.notes.Value(); notes != "" {
+		rest += "\n" + notes
+	}
+	m.notes.SetValue(rest)
+	if len(tokens) != 0 {
+		head += " " + strings.Join(tokens, " ")
+	}
+	m.textInput.SetValue(head)
+	m.textInput.CursorEnd()
+}
+
+// parse reads the task typed, with the due date, priority and tags the words
+// at the end of the title stand for, and what the default rules give it where
+// those don't say.
+func (m addTaskScreen) parse() (domain.Item, domain.Defaulted, error) {
+	entry := m.textInput.Value()
+	item, err := domain.ParseQuickEntry(entry, m.clock.Now())
+	if err != nil {
+		return domain.Item{}, domain.Defaulted{}, err
+	}
+	item.Tags = withDefaultTags(item.Tags, m.defaultTags)
+	item, defaulted := m.rules.Apply(item, m.workspace, domain.QuickEntryGiven(entry), m.clock.Now())
+	return item, defaulted, nil
+}
+
+// enterTask adds the task typed, as parse reads it, unless one of the words
+// at the end of the title isn't right.
+func (m addTaskScreen) enterTask() (tea.Model, tea.Cmd) {
+	item, _, err := m.parse()
+	if err != nil {
+		m.err = err
+		if m.editingNotes {
+			m.editingNotes = false
+			m.notes.Blur()
+			return m, m.textInput.Focus()
+		}
+		return m, nil
+	}
+	item.Notes = strings.TrimSpace(m.notes.Value())
+	if m.parent != nil {
+		item.ParentID = m.parent.ID
+	}
+	return m, func() tea.Msg {
 		return cmd.TaskAdded{IsSucces: true, Item: item}
 	}
 }
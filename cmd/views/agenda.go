@@ -0,0 +1,175 @@
+package views
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"clitodo/pkg/domain"
+)
+
+// agendaHeaderPrefix starts the IDs of the agenda's section headers.
+const agendaHeaderPrefix = "agenda-"
+
+// agendaSections are the agenda's sections in the order they're listed.
+var agendaSections = []string{"Overdue", "Today", "Tomorrow", "This week", "Later", "No date"}
+
+// isHeader reports whether item is the header row of a section rather than a
+// task.
+func isHeader(item domain.Item) bool {
+	return item.ID == doneHeaderID || item.ID == somedayHeaderID || strings.HasPrefix(item.ID, agendaHeaderPrefix)
+}
+
+// SetShowAgenda sets whether the unfiltered list is grouped by due date. The
+// selection stays on the same item.
+func (m *ListScreen) SetShowAgenda(v bool) {
+	var selected string
+	if item := m.SelectedItem(); item != nil {
+		selected = item.ID
+	}
+	m.showAgenda = v
+	m.refreshRows()
+	m.selectID(selected)
+}
+
+// ShowAgenda returns whether the list is grouped by due date.
+func (m ListScreen) ShowAgenda() bool {
+	return m.showAgenda
+}
+
+// toggleAgenda switches between the agenda and the list.
+func (m *ListScreen) toggleAgenda() tea.Cmd {
+	m.SetShowAgenda(!m.showAgenda)
+	if m.showAgenda && !m.hasDueDates() {
+		return m.NewStatusMessage("No task has a due date yet")
+	}
+	return nil
+}
+
+// hasDueDates reports whether any task has a due date.
+func (m ListScreen) hasDueDates() bool {
+	return slices.ContainsFunc(m.items, func(item domain.Item) bool { return item.Due != nil })
+}
+
+// agendaSection returns the index into agendaSections of the section item
+// belongs in at now, or -1 if it's left out of the agenda: a completed task
+// whose date has passed isn't coming up anymore.
+func agendaSection(item domain.Item, now time.Time) int {
+	if item.Due == nil {
+		return len(agendaSections) - 1
+	}
+	if !now.Before(domain.DueBy(*item.Due)) {
+		if item.Completed() {
+			return -1
+		}
+		return 0
+	}
+
+	now, due := now.Local(), item.Due.Local()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	day := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.Local)
+	// Weeks start on Monday.
+	nextWeek := today.AddDate(0, 0, 7-(int(today.Weekday())+6)%7) //nolint:mnd
+	switch {
+	case !day.After(today):
+		return 1
+	case day.Equal(today.AddDate(0, 0, 1)):
+		return 2 //nolint:mnd
+	case day.Before(nextWeek):
+		return 3 //nolint:mnd
+	}
+	return 4 //nolint:mnd
+}
+
+// agendaRows returns the rows of the unfiltered list grouped by due date, each
+// section under a header with an index of -1, earliest date first. A task's
+// subtasks go wherever it goes. Without any due dates the rows are listed as
+// usual.
+func (m ListScreen) agendaRows() filteredItems {
+	if !m.hasDueDates() {
+		m.showAgenda = false
+		return m.rows()
+	}
+
+	now := m.Clock.Now()
+	hidden := m.hiddenItems()
+	sections := make([]filteredItems, len(agendaSections))
+	var someday filteredItems
+	for i := 0; i < len(m.items); {
+		end := m.subtreeEnd(i)
+		section := agendaSection(m.items[i], now)
+		for j := i; j < end; j++ {
+			switch {
+			case hidden[j]:
+			case m.items[i].Someday:
+				someday = append(someday, filteredItem{item: m.items[j], index: j})
+			case section >= 0:
+				sections[section] = append(sections[section], filteredItem{item: m.items[j], index: j})
+			}
+		}
+		i = end
+	}
+
+	rows := make(filteredItems, 0, len(m.items)+len(agendaSections))
+	for s, section := range sections {
+		if len(section) == 0 {
+			continue
+		}
+		section = m.sortRows(section)
+		if s < len(sections)-1 {
+			section = byDueDate(section)
+		}
+		count := 0
+		for _, row := range section {
+			if row.item.Depth == 0 {
+				count++
+			}
+		}
+		id := agendaHeaderPrefix + strings.ToLower(strings.ReplaceAll(agendaSections[s], " ", "-"))
+		rows = append(rows, m.sectionHeader(id, agendaSections[s], count))
+		rows = append(rows, section...)
+	}
+	return m.collapse(m.withSomeday(append(rows, someday...)))
+}
+
+// agendaLeftOut reports for each item of the unfiltered list whether the
+// agenda, if it's shown, leaves it out.
+func (m ListScreen) agendaLeftOut() []bool {
+	leftOut := make([]bool, len(m.items))
+	if !m.showAgenda || !m.hasDueDates() {
+		return leftOut
+	}
+	now := m.Clock.Now()
+	for i := 0; i < len(m.items); {
+		end := m.subtreeEnd(i)
+		if agendaSection(m.items[i], now) < 0 {
+			for j := i; j < end; j++ {
+				leftOut[j] = true
+			}
+		}
+		i = end
+	}
+	return leftOut
+}
+
+// byDueDate orders rows by the due date of their task, keeping subtasks after
+// it and tasks due at the same time in the order they had.
+func byDueDate(rows filteredItems) filteredItems {
+	var groups []filteredItems
+	for _, row := range rows {
+		if row.item.Depth == 0 || len(groups) == 0 {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], row)
+	}
+	slices.SortStableFunc(groups, func(a, b filteredItems) int {
+		return a[0].item.Due.Compare(b[0].item.Due.Time)
+	})
+	sorted := make(filteredItems, 0, len(rows))
+	for _, group := range groups {
+		sorted = append(sorted, group...)
+	}
+	return sorted
+}
@@ -0,0 +1,25 @@
+package views
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// backupDoneMsg reports where the backup bundle went, or why making it
+// failed.
+type backupDoneMsg struct {
+	path string
+	err  error
+}
+
+// createBackup packs everything into a backup bundle in the background, as
+// clitodo backup create does.
+func (m *ListScreen) createBackup() tea.Cmd {
+	if m.Backup == nil {
+		return m.NewStatusMessage("Backups aren't set up")
+	}
+	backup := m.Backup
+	return func() tea.Msg {
+		path, err := backup()
+		return backupDoneMsg{path: path, err: err}
+	}
+}
@@ -0,0 +1,268 @@
+package views
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"clitodo/cmd"
+	"clitodo/pkg/domain"
+)
+
+// boardDoneMsg closes the board. id is the card that was selected, if any.
+type boardDoneMsg struct {
+	id string
+}
+
+// boardScreen shows the tasks as cards in a column per status. It works on
+// the list's items, so a card moved to another column is saved, can be undone
+// and shows up in the list right away. Subtasks and tasks put aside for some
+// day aren't on the board.
+type boardScreen struct {
+	list *ListScreen
+
+	// The focused column and, for each column, the selected card and the
+	// first card shown.
+	focus  int
+	cursor [3]int
+	offset [3]int
+
+	width, height int
+
+	KeyMap     cmd.KeyMap
+	help       help.Model
+	styles     cmd.Styles
+	itemStyles DefaultItemStyles
+}
+
+// The margins around the board.
+var boardScreenStyle = lipgloss.NewStyle().Margin(1, 2)
+
+// The lines taken by the column heads above the cards and by the status and
+// help lines below them.
+const (
+	boardHeadHeight = 2
+	boardFootHeight = 3
+)
+
+func newBoardScreen(list *ListScreen, width, height int, theme cmd.Theme) boardScreen {
+	m := boardScreen{
+		list:       list,
+		KeyMap:     cmd.DefaultKeyMap(),
+		help:       help.New(),
+		styles:     list.Styles,
+		itemStyles: NewItemStyles(theme),
+	}
+	// Start on the selected task if it's on the board.
+	if selected := list.SelectedItem(); selected != nil {
+		m.selectID(selected.ID)
+	}
+	m.setSize(width, height)
+	return m
+}
+
+// columns returns the cards of each column in list order.
+func (m boardScreen) columns() [3][]domain.Item {
+	var columns [3][]domain.Item
+	for _, item := range m.list.items {
+		if item.Depth > 0 || item.Someday {
+			continue
+		}
+		c := slices.Index(domain.Statuses, item.Status())
+		columns[c] = append(columns[c], item)
+	}
+	return columns
+}
+
+// selectID focuses the card with the given ID, if it's on the board.
+func (m *boardScreen) selectID(id string) {
+	for c, cards := range m.columns() {
+		if i := slices.IndexFunc(cards, func(item domain.Item) bool { return item.ID == id }); i >= 0 {
+			m.focus = c
+			m.cursor[c] = i
+		}
+	}
+}
+
+func (m *boardScreen) setSize(width, height int) {
+	m.width = max(1, width-boardScreenStyle.GetHorizontalFrameSize())
+	m.height = max(1, height-boardScreenStyle.GetVerticalFrameSize())
+	m.help.Width = m.width
+	m.clampCursors()
+}
+
+// cardRows is how many cards fit in a column.
+func (m boardScreen) cardRows() int {
+	return max(1, m.height-boardHeadHeight-boardFootHeight)
+}
+
+// clampCursors keeps each column's selection on one of its cards and in
+// view, after cards moved or the board was resized.
+func (m *boardScreen) clampCursors() {
+	rows := m.cardRows()
+	for c, cards := range m.columns() {
+		m.cursor[c] = max(0, min(m.cursor[c], len(cards)-1))
+		if m.cursor[c] < m.offset[c] {
+			m.offset[c] = m.cursor[c]
+		}
+		if m.cursor[c] >= m.offset[c]+rows {
+			m.offset[c] = m.cursor[c] - rows + 1
+		}
+		m.offset[c] = max(0, min(m.offset[c], len(cards)-rows))
+	}
+}
+
+// selected returns the card under the cursor, or nil if the focused column
+// is empty.
+func (m boardScreen) selected() *domain.Item {
+	cards := m.columns()[m.focus]
+	if len(cards) == 0 {
+		return nil
+	}
+	return &cards[m.cursor[m.focus]]
+}
+
+// moveCard moves the selected card to the column by steps to the right, or
+// to the left for negative steps, and keeps it selected there.
+func (m *boardScreen) moveCard(steps int) tea.Cmd {
+	card := m.selected()
+	to := m.focus + steps
+	if card == nil || to < 0 || to >= len(domain.Statuses) {
+		return nil
+	}
+	cmd := m.list.setStatus(card.ID, domain.Statuses[to])
+	m.selectID(card.ID)
+	m.clampCursors()
+	return cmd
+}
+
+func (m boardScreen) Init() tea.Cmd {
+	return nil
+}
+
+func (m boardScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.setSize(msg.Width, msg.Height)
+	case tea.KeyMsg:
+		var cmd tea.Cmd
+		switch {
+		case key.Matches(msg, m.KeyMap.CloseBoard):
+			var id string
+			if card := m.selected(); card != nil {
+				id = card.ID
+			}
+			return m, func() tea.Msg { return boardDoneMsg{id: id} }
+		case key.Matches(msg, m.KeyMap.MoveCardLeft):
+			cmd = m.moveCard(-1)
+		case key.Matches(msg, m.KeyMap.MoveCardRight):
+			cmd = m.moveCard(1)
+		case key.Matches(msg, m.KeyMap.PrevColumn):
+			m.focus = max(0, m.focus-1)
+		case key.Matches(msg, m.KeyMap.NextColumn):
+			m.focus = min(len(domain.Statuses)-1, m.focus+1)
+		case key.Matches(msg, m.KeyMap.CursorUp):
+			m.cursor[m.focus]--
+		case key.Matches(msg, m.KeyMap.CursorDown):
+			m.cursor[m.focus]++
+		}
+		m.clampCursors()
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m boardScreen) View() string {
+	columns := m.columns()
+	// The width is split evenly, whatever doesn't divide is left over on
+	// the right.
+	width := m.width / len(columns)
+	rows := m.cardRows()
+
+	views := make([]string, len(columns))
+	for c, cards := range columns {
+		head := fmt.Sprintf("%s (%d)", domain.Statuses[c], len(cards))
+		if c == m.focus {
+			head = m.styles.Title.Render(head)
+		} else {
+			head = m.styles.DetailLabel.Padding(0, 1).Render(head)
+		}
+
+		lines := []string{ansi.Truncate(head, width-1, cmd.Ellipsis), ""}
+		end := min(len(cards), m.offset[c]+rows)
+		for i := m.offset[c]; i < end; i++ {
+			lines = append(lines, m.cardView(cards[i], width-1, c == m.focus && i == m.cursor[c]))
+		}
+		for len(lines) < rows+boardHeadHeight {
+			lines = append(lines, "")
+		}
+		views[c] = lipgloss.NewStyle().Width(width).Render(strings.Join(lines, "\n"))
+	}
+
+	var b strings.Builder
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, views...))
+	b.WriteString("\n")
+	status := ansi.Truncate(m.list.statusMessage, m.width-m.styles.StatusBar.GetHorizontalFrameSize(), cmd.Ellipsis)
+	b.WriteString(m.styles.StatusBar.Render(status))
+	b.WriteString("\n")
+	b.WriteString(m.styles.HelpStyle.Render(m.help.ShortHelpView([]key.Binding{
+		m.KeyMap.PrevColumn,
+		m.KeyMap.NextColumn,
+		m.KeyMap.MoveCardLeft,
+		m.KeyMap.MoveCardRight,
+		m.KeyMap.CloseBoard,
+	})))
+	return boardScreenStyle.Render(b.String())
+}
+
+// cardView renders one card in width columns, its title cut off to fit.
+func (m boardScreen) cardView(item domain.Item, width int, selected bool) string {
+	s := m.itemStyles
+	style := s.NormalTitle
+	switch {
+	case selected:
+		style = s.SelectedTitle
+	case item.Completed():
+		style = s.DimmedTitle
+	}
+	marker := s.PriorityMarker(item.Priority)
+	room := max(1, width-style.GetHorizontalFrameSize()-lipgloss.Width(marker))
+	title := ansi.Truncate(item.Title(), room, cmd.Ellipsis)
+	if selected {
+		title = s.SelectedText.Render(title)
+	}
+	return style.Render(marker + title)
+}
+
+// setStatus moves the item with the given ID to another column of the board
+// and saves. Moving it to done or out of it completes or reopens it the way
+// checking it off in the list does.
+func (m *ListScreen) setStatus(id string, status domain.Status) tea.Cmd {
+	i := m.indexOfID(id)
+	if i < 0 || m.items[i].Status() == status {
+		return nil
+	}
+	if m.itemRepository.ReadOnly() {
+		return m.refuseReadOnly()
+	}
+	item := m.items[i]
+	title := item.Title()
+
+	var cmds []tea.Cmd
+	if done := status == domain.StatusDone; done != item.Completed() {
+		cmds = append(cmds, m.toggleDone(item))
+	} else {
+		m.remember(m.snapshot(fmt.Sprintf("Moved “%s” back to %s", title, item.Status()), fmt.Sprintf("Moved “%s” to %s", title, status)))
+	}
+	if _, _, err := m.changeItem(id, func(item *domain.Item) { item.SetStatus(status, m.Clock.Now()) }); err != nil {
+		return tea.Batch(append(cmds, m.saveFailed(err))...)
+	}
+	cmds = append(cmds, m.NewStatusMessage(fmt.Sprintf("Moved “%s” to %s", title, status)))
+	return tea.Batch(cmds...)
+}
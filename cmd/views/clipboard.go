@@ -0,0 +1,97 @@
+package views
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"clitodo/pkg/clipboard"
+	"clitodo/pkg/domain"
+)
+
+// copyDoneMsg reports how copying what to the clipboard went.
+type copyDoneMsg struct {
+	what string
+	err  error
+}
+
+// copySelected copies the selected task's title, with its tags and notes, to
+// the clipboard.
+func (m ListScreen) copySelected() tea.Cmd {
+	item := m.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	return copyText(itemText(*item), fmt.Sprintf("“%s”", item.Title()))
+}
+
+// copyList copies the tasks as they're shown, filtered and sorted, to the
+// clipboard as a markdown checklist.
+func (m ListScreen) copyList() tea.Cmd {
+	var rows []domain.Item
+	for _, item := range m.VisibleItems() {
+		if !isHeader(item) {
+			rows = append(rows, item)
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	what := "1 task"
+	if len(rows) != 1 {
+		what = fmt.Sprintf("%d tasks", len(rows))
+	}
+	return copyText(markdownList(rows), what)
+}
+
+// copyLink copies a [[task:ID]] link to the selected task to the clipboard,
+// to paste into another task's notes.
+func (m ListScreen) copyLink() tea.Cmd {
+	item := m.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	return copyText(domain.TaskLinkTo(item.ID), fmt.Sprintf("a link to “%s”", item.Title()))
+}
+
+func copyText(text, what string) tea.Cmd {
+	return func() tea.Msg {
+		return copyDoneMsg{what: what, err: clipboard.Copy(os.Stdout, text)}
+	}
+}
+
+// itemText writes an item like it's typed, "title #tag", followed by its
+// notes after an empty line.
+func itemText(item domain.Item) string {
+	text := item.Title()
+	if tags := domain.FormatTags(item.Tags); tags != "" {
+		text += " " + tags
+	}
+	if item.Notes != "" {
+		text += "\n\n" + item.Notes
+	}
+	return text
+}
+
+// markdownList writes rows as "- [ ] title" lines, subtasks indented under
+// their parents. A subtask shown without its parent, as the filter may do, is
+// only indented as far as the rows above it allow.
+func markdownList(rows []domain.Item) string {
+	var b strings.Builder
+	depth := -1
+	for _, item := range rows {
+		depth = min(item.Depth, depth+1)
+		check := " "
+		if item.Completed() {
+			check = "x"
+		}
+		title := item.Title()
+		if tags := domain.FormatTags(item.Tags); tags != "" {
+			title += " " + tags
+		}
+		fmt.Fprintf(&b, "%s- [%s] %s\n", strings.Repeat("  ", depth), check, title)
+	}
+	return b.String()
+}
@@ -0,0 +1,115 @@
+package views
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"clitodo/cmd"
+	"clitodo/pkg/domain"
+)
+
+// CompactDelegate draws each item on a single line with no gap between them,
+// so a page holds twice as many as with the DefaultDelegate: the check mark,
+// the title and, if there's room after it, the due date. Priorities show only
+// in the title's style, and tags and notes not at all. It's styled by the same
+// DefaultItemStyles.
+type CompactDelegate struct {
+	PlainCompleted bool
+	Styles         DefaultItemStyles
+	UpdateFunc     func(tea.Msg, *ListScreen) tea.Cmd
+}
+
+// NewCompactDelegate creates a compact delegate styled with the given theme.
+func NewCompactDelegate(t cmd.Theme) CompactDelegate {
+	return CompactDelegate{Styles: NewItemStyles(t)}
+}
+
+// Height returns 1, items being a line each.
+func (d CompactDelegate) Height() int {
+	return 1
+}
+
+// Spacing returns 0, items going one right under the other.
+func (d CompactDelegate) Spacing() int {
+	return 0
+}
+
+// Update checks whether the delegate's UpdateFunc is set and calls it.
+func (d CompactDelegate) Update(msg tea.Msg, m *ListScreen) tea.Cmd {
+	if d.UpdateFunc == nil {
+		return nil
+	}
+	return d.UpdateFunc(msg, m)
+}
+
+// Render prints an item.
+func (d CompactDelegate) Render(w io.Writer, m ListScreen, index int, item domain.Item) {
+	base := d.base()
+	if isHeader(item) {
+		base.renderSectionHeader(w, m, index, item)
+		return
+	}
+	if m.width <= 0 {
+		return
+	}
+
+	s := &d.Styles
+	mark, titleStyle := base.checkMark(item)
+	prefix := strings.Repeat(" ", item.Depth*subtaskIndent) + mark + " "
+	textwidth := m.width - s.NormalTitle.GetHorizontalFrameSize() - lipgloss.Width(prefix)
+
+	title := ansi.Truncate(item.Title(), textwidth, cmd.Ellipsis)
+	row := prefix + base.styledTitle(m, index, item, title, titleStyle)
+	if due := s.Due(item, m.Clock.Now()); due != "" && title == item.Title() &&
+		lipgloss.Width(title)+1+lipgloss.Width(due) <= textwidth {
+		row += " " + due
+	}
+
+	if index == m.Index() && m.FilterState() != Filtering {
+		row = s.SelectedTitle.Render(row)
+	} else {
+		row = s.NormalTitle.Render(row)
+	}
+	fmt.Fprint(w, cutLines(row, m.width)) //nolint: errcheck
+}
+
+// base returns the DefaultDelegate the compact one borrows its check marks
+// and section headers from.
+func (d CompactDelegate) base() DefaultDelegate {
+	return DefaultDelegate{Styles: d.Styles, PlainCompleted: d.PlainCompleted, height: 1}
+}
+
+// styledTitle renders text, item's title or the start of it, in style, as the
+// selected row's title if the item at index is selected, and with the runes
+// the filter matched in it highlighted. Matches past the end of a title cut
+// off with an ellipsis aren't shown.
+func (d DefaultDelegate) styledTitle(m ListScreen, index int, item domain.Item, text string, style lipgloss.Style) string {
+	s := &d.Styles
+	style = style.UnsetPaddingLeft()
+	if index == m.Index() && m.FilterState() != Filtering {
+		if d.strikesThrough(item) {
+			style = s.SelectedCompletedTitle.UnsetPaddingLeft()
+		}
+		style = style.Inherit(s.SelectedText)
+	}
+	if !highlightsMatches(m, index) {
+		return style.Render(text)
+	}
+
+	shown := len([]rune(text))
+	if text != item.Title() {
+		shown -= len([]rune(cmd.Ellipsis))
+	}
+	var runes []int
+	for _, r := range m.MatchesForItem(index) {
+		if r < shown {
+			runes = append(runes, r)
+		}
+	}
+	return lipgloss.StyleRunes(text, runes, style.Inherit(s.FilterMatch), style)
+}
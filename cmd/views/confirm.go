@@ -0,0 +1,38 @@
+package views
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// confirmation is something held back until the user says whether to do it,
+// asked in the status bar: a change over the WIP limit, or quitting with a
+// deadline coming up.
+type confirmation struct {
+	question string
+	apply    func() tea.Cmd
+
+	// Status message shown when the answer is no.
+	cancelled string
+}
+
+// handleConfirm does what was held back or drops it.
+func (m *ListScreen) handleConfirm(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.KeyMap.ConfirmWIP):
+		apply := m.confirm.apply
+		m.confirm = nil
+		m.updateKeybindings()
+		return apply()
+	case key.Matches(msg, m.KeyMap.CancelWIP):
+		cancelled := m.confirm.cancelled
+		m.confirm = nil
+		m.updateKeybindings()
+		return m.NewStatusMessage(cancelled)
+	}
+	return nil
+}
+
+func (m ListScreen) confirmHelp() []key.Binding {
+	return []key.Binding{m.KeyMap.ConfirmWIP, m.KeyMap.CancelWIP}
+}
@@ -0,0 +1,187 @@
+package views
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"clitodo/cmd"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/hooks"
+	"clitodo/pkg/state"
+)
+
+// dedupeDecision is the answer for one cluster of possible duplicates, given
+// by the IDs of its items in list order. Clusters that were skipped have no
+// decision.
+type dedupeDecision struct {
+	ids   []string
+	merge bool
+}
+
+type dedupeDoneMsg struct {
+	decisions []dedupeDecision
+}
+
+// dedupeScreen shows each cluster of tasks that look like duplicates in turn
+// and asks whether to merge them into the first one.
+type dedupeScreen struct {
+	clusters [][]domain.Item
+	current  int
+
+	decisions []dedupeDecision
+	KeyMap    cmd.KeyMap
+	help      help.Model
+	styles    cmd.Styles
+}
+
+func newDedupeScreen(items []domain.Item, clusters [][]int, styles cmd.Styles) dedupeScreen {
+	m := dedupeScreen{
+		KeyMap: cmd.DefaultKeyMap(),
+		help:   help.New(),
+		styles: styles,
+	}
+	for _, cluster := range clusters {
+		group := make([]domain.Item, len(cluster))
+		for k, i := range cluster {
+			group[k] = items[i]
+		}
+		m.clusters = append(m.clusters, group)
+	}
+	return m
+}
+
+// findDuplicates returns the clusters of possible duplicates in the list that
+// weren't dismissed before.
+func (m ListScreen) findDuplicates() [][]int {
+	dismissed := func(a, b string) bool { return false }
+	if st, err := state.Load(); err == nil {
+		dismissed = st.DuplicateDismissed
+	}
+	return domain.DuplicateClusters(m.items, dismissed)
+}
+
+func (m dedupeScreen) Init() tea.Cmd {
+	return nil
+}
+
+func (m dedupeScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	cluster := m.clusters[m.current]
+	ids := make([]string, len(cluster))
+	for i, item := range cluster {
+		ids[i] = item.ID
+	}
+	switch {
+	case key.Matches(keyMsg, m.KeyMap.DedupeStop):
+		return m, m.done()
+	case key.Matches(keyMsg, m.KeyMap.DedupeMerge):
+		m.decisions = append(m.decisions, dedupeDecision{ids: ids, merge: true})
+	case key.Matches(keyMsg, m.KeyMap.DedupeDismiss):
+		m.decisions = append(m.decisions, dedupeDecision{ids: ids})
+	case key.Matches(keyMsg, m.KeyMap.DedupeSkip):
+	default:
+		return m, nil
+	}
+
+	m.current++
+	if m.current == len(m.clusters) {
+		return m, m.done()
+	}
+	return m, nil
+}
+
+func (m dedupeScreen) done() tea.Cmd {
+	decisions := m.decisions
+	return func() tea.Msg {
+		return dedupeDoneMsg{decisions: decisions}
+	}
+}
+
+func (m dedupeScreen) View() string {
+	cluster := m.clusters[m.current]
+
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render("Duplicates?"))
+	fmt.Fprintf(&b, "  %d/%d\n\n", m.current+1, len(m.clusters))
+	for _, item := range cluster {
+		line := item.Title()
+		if tags := domain.FormatTags(item.Tags); tags != "" {
+			line += " " + m.styles.DetailLabel.Render(tags)
+		}
+		b.WriteString("  " + line + "\n")
+	}
+	b.WriteString(m.styles.StatusBar.Render(fmt.Sprintf("merging keeps “%s”", cluster[0].Title())) + "\n")
+	b.WriteString(m.styles.HelpStyle.Render(m.help.ShortHelpView([]key.Binding{
+		m.KeyMap.DedupeMerge,
+		m.KeyMap.DedupeDismiss,
+		m.KeyMap.DedupeSkip,
+		m.KeyMap.DedupeStop,
+	})))
+	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
+}
+
+// applyDedupe merges the clusters that were answered with merge into their
+// first task and remembers the dismissed ones so they aren't suggested again.
+// The merges are saved at once and can be undone together.
+func (m *ListScreen) applyDedupe(decisions []dedupeDecision) tea.Cmd {
+	var merges, dismissals [][]string
+	for _, d := range decisions {
+		if d.merge {
+			merges = append(merges, d.ids)
+		} else {
+			dismissals = append(dismissals, d.ids)
+		}
+	}
+
+	var cmds []tea.Cmd
+	if len(dismissals) > 0 {
+		if st, err := state.Load(); err == nil {
+			for _, ids := range dismissals {
+				st.DismissDuplicates(ids)
+			}
+			st.Save()
+		}
+	}
+	if len(merges) == 0 {
+		if len(dismissals) > 0 {
+			cmds = append(cmds, m.NewStatusMessage(fmt.Sprintf("Dismissed %d clusters", len(dismissals))))
+		}
+		return tea.Batch(cmds...)
+	}
+
+	m.remember(m.snapshot("Unmerged the duplicates", "Merged the duplicates again"))
+	tree := domain.Nest(slices.Clone(m.items))
+	var removed []domain.Item
+	for _, ids := range merges {
+		var gone []domain.Item
+		tree, gone = domain.MergeItems(tree, ids)
+		removed = append(removed, gone...)
+	}
+	m.items = domain.Flatten(tree)
+	now := m.Clock.Now()
+	for _, ids := range merges {
+		if i := m.indexOfID(ids[0]); i >= 0 {
+			m.items[i].Touch(now)
+		}
+	}
+	m.refreshRows()
+
+	if err := m.saveItems(); err != nil {
+		return m.saveFailed(err)
+	}
+	for _, item := range removed {
+		cmds = append(cmds, m.runHook(hooks.EventDelete, item))
+	}
+	cmds = append(cmds, m.NewStatusMessage(fmt.Sprintf("Merged %d tasks into %d", len(removed), len(merges))))
+	return tea.Batch(cmds...)
+}
@@ -3,6 +3,10 @@ package views
 import (
 	"fmt"
 	"io"
+	"math"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -19,9 +23,14 @@ type DefaultItemStyles struct {
 	// The Normal state.
 	NormalTitle lipgloss.Style
 
-	// The selected item state.
+	// The selected item state. The indicator takes the place of NormalTitle's
+	// padding, so titles line up whether they're selected or not.
 	SelectedTitle lipgloss.Style
 
+	// Applied to the title of the selected item on top of its own style, for
+	// cursors that mark the text rather than the row.
+	SelectedText lipgloss.Style
+
 	// The dimmed state, for when the filter input is initially activated.
 	DimmedTitle lipgloss.Style
 
@@ -31,43 +40,225 @@ type DefaultItemStyles struct {
 	CheckMark lipgloss.Style
 
 	EmptyCheckMark lipgloss.Style
+
+	// Items waiting on someone else get an hourglass instead of the check
+	// mark and a dimmer title.
+	WaitingMark  lipgloss.Style
+	WaitingTitle lipgloss.Style
+
+	// Tasks put aside for some day are dimmed.
+	SomedayTitle lipgloss.Style
+
+	// Completed tasks are struck through and dimmed, unless the delegate's
+	// PlainCompleted is set, and so is the selection while it's on one.
+	CompletedTitle         lipgloss.Style
+	SelectedCompletedTitle lipgloss.Style
+
+	// The tags after the title, each in brackets like [home].
+	Tags lipgloss.Style
+
+	// Shown after the title of recurring items.
+	Recurring lipgloss.Style
+
+	// The due date after the title, in red once it has passed and in yellow
+	// on the day it's due.
+	DueDate     lipgloss.Style
+	OverdueDate lipgloss.Style
+	DueToday    lipgloss.Style
+
+	// The header of the "Done today" and "Someday" sections and the arrows
+	// showing whether they're expanded.
+	SectionHeader lipgloss.Style
+	Expanded      lipgloss.Style
+	Collapsed     lipgloss.Style
+
+	// The first line of the notes under the title, see ShowDescription, and
+	// what's shown there for items without notes.
+	NormalDesc   lipgloss.Style
+	SelectedDesc lipgloss.Style
+	NoDesc       lipgloss.Style
+
+	// Markers in front of the check mark of items with a priority.
+	LowPriority    lipgloss.Style
+	MediumPriority lipgloss.Style
+	HighPriority   lipgloss.Style
+
+	// Open tasks of high priority stand out a little from the others.
+	HighPriorityTitle lipgloss.Style
+
+	// The row numbers in front of the items, see ShowNumbers.
+	Number lipgloss.Style
+}
+
+// PriorityMarker returns the rendered marker for p, or "" for no priority.
+func (s DefaultItemStyles) PriorityMarker(p domain.Priority) string {
+	switch p {
+	case domain.PriorityLow:
+		return s.LowPriority.String()
+	case domain.PriorityMedium:
+		return s.MediumPriority.String()
+	case domain.PriorityHigh:
+		return s.HighPriority.String()
+	}
+	return ""
+}
+
+// Due returns the rendered due date of item relative to now, such as
+// "tomorrow", or "" if it has none. Completed items keep the date itself,
+// since they can't be overdue anymore.
+func (s DefaultItemStyles) Due(item domain.Item, now time.Time) string {
+	if item.Due == nil {
+		return ""
+	}
+	due := domain.RelativeDue(*item.Due, now)
+	if item.Completed() {
+		due = domain.FormatDue(*item.Due)
+	}
+	switch {
+	case item.Overdue(now):
+		return s.OverdueDate.Render(due)
+	case item.DueToday(now):
+		return s.DueToday.Render(due)
+	}
+	return s.DueDate.Render(due)
 }
 
 // NewDefaultItemStyles returns style definitions for a default item. See
 // DefaultItemView for when these come into play.
-func NewDefaultItemStyles() (s DefaultItemStyles) {
+func NewDefaultItemStyles() DefaultItemStyles {
+	return NewItemStyles(cmd.DefaultTheme())
+}
+
+// NewItemStyles returns style definitions for a default item built from the
+// given theme.
+func NewItemStyles(t cmd.Theme) (s DefaultItemStyles) {
 	s.NormalTitle = lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Light: "#1a1a1a", Dark: "#dddddd"}).
+		Foreground(t.Text).
 		Padding(0, 0, 0, 2) //nolint:mnd
 
 	s.SelectedTitle = lipgloss.NewStyle().
 		Border(lipgloss.NormalBorder(), false, false, false, true).
-		BorderForeground(lipgloss.AdaptiveColor{Light: "#F793FF", Dark: "#AD58B4"}).
-		Foreground(lipgloss.AdaptiveColor{Light: "#EE6FF8", Dark: "#EE6FF8"}).
+		BorderForeground(t.SelectedBorder).
+		Foreground(t.Selected).
 		Padding(0, 0, 0, 1)
+	switch t.Cursor {
+	case cmd.CursorGlyph:
+		s.SelectedTitle = s.SelectedTitle.Border(lipgloss.Border{Left: ">"}, false, false, false, true)
+	case cmd.CursorReverse:
+		s.SelectedTitle = lipgloss.NewStyle().Foreground(t.Selected).Padding(0, 0, 0, 2) //nolint:mnd
+		s.SelectedText = lipgloss.NewStyle().Reverse(true)
+	case cmd.CursorUnderline:
+		s.SelectedTitle = lipgloss.NewStyle().Foreground(t.Selected).Padding(0, 0, 0, 2) //nolint:mnd
+		s.SelectedText = lipgloss.NewStyle().Underline(true)
+	}
 
 	s.DimmedTitle = lipgloss.NewStyle().
-		Foreground(lipgloss.AdaptiveColor{Light: "#A49FA5", Dark: "#777777"}).
+		Foreground(t.Dimmed).
 		Padding(0, 0, 0, 2) //nolint:mnd
 
 	s.FilterMatch = lipgloss.NewStyle().Underline(true)
 
 	s.CheckMark = lipgloss.NewStyle().SetString("✓").
-		Foreground(lipgloss.AdaptiveColor{Light: "#43BF6D", Dark: "#73F59F"}).
+		Foreground(t.Done).
 		PaddingRight(1)
 
 	s.EmptyCheckMark = lipgloss.NewStyle().SetString("").
-		Foreground(lipgloss.AdaptiveColor{Light: "#43BF6D", Dark: "#73F59F"}).
+		Foreground(t.Done).
 		PaddingRight(2)
 
+	s.WaitingMark = lipgloss.NewStyle().SetString("⌛").
+		Foreground(t.Subdued)
+
+	s.WaitingTitle = s.DimmedTitle.
+		Foreground(t.Subdued).
+		Italic(true)
+
+	s.SomedayTitle = s.DimmedTitle.
+		Foreground(t.Subdued)
+
+	s.CompletedTitle = s.DimmedTitle.
+		Foreground(t.Subdued).
+		Strikethrough(true)
+
+	s.SelectedCompletedTitle = s.CompletedTitle.
+		Foreground(t.Selected).
+		Faint(true)
+
+	s.Tags = lipgloss.NewStyle().Foreground(t.Subdued)
+
+	s.Recurring = lipgloss.NewStyle().SetString("↻").
+		Foreground(t.Subdued).
+		PaddingLeft(1)
+
+	s.DueDate = lipgloss.NewStyle().Foreground(t.Subdued)
+
+	s.OverdueDate = lipgloss.NewStyle().
+		Foreground(t.PriorityHigh).
+		Bold(true)
+
+	s.DueToday = lipgloss.NewStyle().Foreground(t.PriorityMedium)
+
+	s.SectionHeader = lipgloss.NewStyle().
+		Foreground(t.Subdued).
+		Bold(true)
+
+	s.Expanded = lipgloss.NewStyle().SetString("▾").
+		Foreground(t.Subdued).
+		PaddingRight(1)
+
+	s.Collapsed = lipgloss.NewStyle().SetString("▸").
+		Foreground(t.Subdued).
+		PaddingRight(1)
+
+	// Lined up with the title text, past the check mark column.
+	s.NormalDesc = s.DimmedTitle.
+		Foreground(t.Subdued).
+		Padding(0, 0, 0, 6) //nolint:mnd
+
+	s.SelectedDesc = s.SelectedTitle.
+		Foreground(t.Subdued).
+		PaddingLeft(6 - s.SelectedTitle.GetBorderLeftSize()) //nolint:mnd
+	s.NoDesc = lipgloss.NewStyle().SetString("no notes").
+		Foreground(t.Dimmed).
+		Italic(true)
+	if t.Cursor == cmd.CursorGlyph {
+		// One ">" per item is enough.
+		s.SelectedDesc = s.SelectedDesc.Border(lipgloss.Border{Left: " "}, false, false, false, true)
+	}
+
+	s.LowPriority = lipgloss.NewStyle().SetString("↓").
+		Foreground(t.Subdued).
+		PaddingRight(1)
+
+	s.MediumPriority = lipgloss.NewStyle().SetString("!").
+		Foreground(t.PriorityMedium).
+		PaddingRight(1)
+
+	s.HighPriority = lipgloss.NewStyle().SetString("‼").
+		Foreground(t.PriorityHigh).
+		Bold(true).
+		PaddingRight(1)
+
+	s.HighPriorityTitle = s.DimmedTitle.
+		Foreground(t.Text)
+
+	s.Number = lipgloss.NewStyle().Foreground(t.Subdued)
+
 	return s
 }
 
+// subtaskIndent is how far subtasks are indented per level.
+const subtaskIndent = 2
+
 // DefaultDelegate is a standard delegate designed to work in lists. It's
 // styled by DefaultItemStyles, which can be customized as you like.
 //
-// The description line can be hidden by setting Description to false, which
-// renders the list as single-line-items. The spacing between items can be set
+// The first line of an item's notes is shown under its title when
+// ShowDescription is true; otherwise the list renders single-line items. With
+// ShowNumbers each row starts with its number as the list is shown, "3. ".
+// With WrapTitles long titles go on over as many lines as they need instead
+// of being cut off, see HeightFor. Completed items are struck through and
+// dimmed unless PlainCompleted is set. The spacing between items can be set
 // with the SetSpacing method.
 //
 // Setting UpdateFunc is optional. If it's set it will be called when the
@@ -77,25 +268,39 @@ func NewDefaultItemStyles() (s DefaultItemStyles) {
 // Settings ShortHelpFunc and FullHelpFunc is optional. They can be set to
 // include items in the list's default short and full help menus.
 type DefaultDelegate struct {
-	Styles        DefaultItemStyles
-	UpdateFunc    func(tea.Msg, *ListScreen) tea.Cmd
-	ShortHelpFunc func() []key.Binding
-	FullHelpFunc  func() [][]key.Binding
-	height        int
-	spacing       int
+	ShowDescription bool
+	ShowNumbers     bool
+	WrapTitles      bool
+	PlainCompleted  bool
+	Styles          DefaultItemStyles
+	UpdateFunc      func(tea.Msg, *ListScreen) tea.Cmd
+	ShortHelpFunc   func() []key.Binding
+	FullHelpFunc    func() [][]key.Binding
+	height          int
+	spacing         int
 }
 
 // NewDefaultDelegate creates a new delegate with default styles.
 func NewDefaultDelegate() DefaultDelegate {
+	return NewThemedDelegate(cmd.DefaultTheme())
+}
+
+// NewThemedDelegate creates a new delegate styled with the given theme.
+func NewThemedDelegate(t cmd.Theme) DefaultDelegate {
 	const defaultHeight = 2
 	const defaultSpacing = 1
 	return DefaultDelegate{
-		Styles:  NewDefaultItemStyles(),
+		Styles:  NewItemStyles(t),
 		height:  defaultHeight,
 		spacing: defaultSpacing,
 	}
 }
 
+// SetStyles sets the styles items are rendered with.
+func (d *DefaultDelegate) SetStyles(s DefaultItemStyles) {
+	d.Styles = s
+}
+
 // SetHeight sets delegate's preferred height.
 func (d *DefaultDelegate) SetHeight(i int) {
 	d.height = i
@@ -105,9 +310,41 @@ func (d *DefaultDelegate) SetHeight(i int) {
 // This has effect only if ShowDescription is true,
 // otherwise height is always 1.
 func (d DefaultDelegate) Height() int {
+	if d.ShowDescription {
+		return d.height
+	}
 	return 1
 }
 
+// HeightFor returns the height of the item at index, which is more than
+// Height for a title wrapped onto several lines.
+func (d DefaultDelegate) HeightFor(m ListScreen, index int, item domain.Item) int {
+	if !d.WrapTitles || isHeader(item) || m.width <= 0 {
+		return d.Height()
+	}
+	s := &d.Styles
+	mark, titleStyle := d.checkMark(item)
+	prefix := lipgloss.Width(d.number(m, index)) + item.Depth*subtaskIndent + lipgloss.Width(d.priority(m, item)) + lipgloss.Width(mark)
+	if !highlightsMatches(m, index) {
+		prefix += titleStyle.GetPaddingLeft()
+	}
+	suffix := 0
+	if item.Recurrence != nil {
+		suffix += lipgloss.Width(s.Recurring.String())
+	}
+	if due := s.Due(item, m.Clock.Now()); due != "" {
+		suffix += 1 + lipgloss.Width(due)
+	}
+	if chips := tagChips(item.Tags, math.MaxInt, nil); len(chips) > 0 {
+		suffix += 1 + chipsWidth(chips)
+	}
+	lines, suffixLine := wrapTitle(item.Title(), m.width-s.NormalTitle.GetHorizontalFrameSize()-prefix, suffix)
+	if suffixLine {
+		return d.Height() + len(lines)
+	}
+	return d.Height() + len(lines) - 1
+}
+
 // SetSpacing sets the delegate's spacing.
 func (d *DefaultDelegate) SetSpacing(i int) {
 	d.spacing = i
@@ -126,6 +363,24 @@ func (d DefaultDelegate) Update(msg tea.Msg, m *ListScreen) tea.Cmd {
 	return d.UpdateFunc(msg, m)
 }
 
+// checkMarkColumns returns the columns the check mark of the selected item
+// takes up, from the first to just past the last.
+func (d DefaultDelegate) checkMarkColumns(m ListScreen, item domain.Item) (from, to int) {
+	s := &d.Styles
+	style := s.SelectedTitle
+	if m.FilterState() == Filtering {
+		style = s.NormalTitle
+	}
+	mark := s.EmptyCheckMark
+	if item.Completed() {
+		mark = s.CheckMark
+	} else if item.IsWaiting() {
+		mark = s.WaitingMark
+	}
+	from = style.GetMarginLeft() + style.GetBorderLeftSize() + style.GetPaddingLeft() + lipgloss.Width(d.number(m, m.Index())) + item.Depth*subtaskIndent + lipgloss.Width(d.priority(m, item))
+	return from, from + lipgloss.Width(mark.String())
+}
+
 // Render prints an item.
 func (d DefaultDelegate) Render(w io.Writer, m ListScreen, index int, item domain.Item) {
 	var (
@@ -134,46 +389,377 @@ func (d DefaultDelegate) Render(w io.Writer, m ListScreen, index int, item domai
 		s            = &d.Styles
 	)
 
-	completed := s.EmptyCheckMark.String()
-	if item.Completed() {
-		completed = s.CheckMark.String()
+	if isHeader(item) {
+		d.renderSectionHeader(w, m, index, item)
+		return
+	}
+
+	completed, titleStyle := d.checkMark(item)
+	var recurring string
+	if item.Recurrence != nil {
+		recurring = s.Recurring.String()
 	}
 
 	title = item.Title()
 
 	if m.width <= 0 {
-		// short-circuit
+		// Nothing fits, but the lines are still taken up.
+		fmt.Fprint(w, strings.Repeat("\n", d.Height()-1)) //nolint: errcheck
 		return
 	}
 
-	// Prevent text from exceeding list width
-	textwidth := m.width - s.NormalTitle.GetPaddingLeft() - s.NormalTitle.GetPaddingRight()
-	title = ansi.Truncate(title, textwidth, cmd.Ellipsis)
+	// Subtasks are indented under their parent, priority, check mark and
+	// all, and the number stays in front of that. The title's padding goes
+	// after the check mark, unless matches are highlighted.
+	indent := strings.Repeat(" ", item.Depth*subtaskIndent)
+	number := d.number(m, index)
+	prefix := number + indent + d.priority(m, item) + completed
+	if !highlightsMatches(m, index) {
+		prefix += strings.Repeat(" ", titleStyle.GetPaddingLeft())
+	}
+
+	// The due date and then the tags share the width with the title and give
+	// way to it, the last tags first, unless it's wrapped and there's room
+	// for everything.
+	due := s.Due(item, m.Clock.Now())
+	tagsRoom := math.MaxInt
+	if !d.WrapTitles {
+		// Prevent the row from exceeding list width
+		textwidth := m.width - s.NormalTitle.GetHorizontalFrameSize() - lipgloss.Width(prefix) - lipgloss.Width(recurring)
+		title = ansi.Truncate(title, textwidth, cmd.Ellipsis)
+
+		room := textwidth - lipgloss.Width(title) - 1
+		if due != "" && lipgloss.Width(due) <= room && title == item.Title() {
+			room -= lipgloss.Width(due) + 1
+		} else {
+			due = ""
+		}
+		tagsRoom = room
+		if title != item.Title() {
+			tagsRoom = 0
+		}
+	}
 
 	// Conditions
 	var (
 		isSelected = index == m.Index()
-		isFiltered = m.FilterState() == Filtering || m.FilterState() == FilterApplied
+		isCursor   = isSelected && m.FilterState() != Filtering
 	)
 
-	if isFiltered && index < len(m.filteredItems) {
+	// The selection's styling goes on over the tags.
+	tagStyle := s.Tags
+	if isCursor {
+		tagStyle = tagStyle.Inherit(s.SelectedText)
+	}
+
+	if isCursor {
+		if d.strikesThrough(item) {
+			titleStyle = s.SelectedCompletedTitle
+		}
+		titleStyle = titleStyle.Inherit(s.SelectedText)
+	}
+
+	// The title is put together from what goes in front of its text, the
+	// text, styled a line at a time when it's wrapped, and what follows it.
+	var (
+		suffix     string
+		styleTitle func(text string, from int) string
+	)
+	if highlightsMatches(m, index) {
 		// Get indices of matched characters
 		matchedRunes = m.MatchesForItem(index)
+		// Matches in the part of a long title that's cut off aren't shown,
+		// nor are those in the tags and notes on the ellipsis.
+		shown := len([]rune(title))
+		if title != item.Title() {
+			shown -= len([]rune(cmd.Ellipsis))
+		}
 		// Highlight matches
 		unmatched := s.SelectedTitle.Inline(true)
+		if d.strikesThrough(item) {
+			unmatched = s.SelectedCompletedTitle.Inline(true)
+		}
+		if isCursor {
+			unmatched = unmatched.Inherit(s.SelectedText)
+		}
 		matched := unmatched.Inherit(s.FilterMatch)
-		title = lipgloss.StyleRunes(title, matchedRunes, matched, unmatched)
+		styleTitle = func(text string, from int) string {
+			to := min(shown, from+len([]rune(text)))
+			var titleRunes []int
+			for _, r := range matchedRunes {
+				if r >= from && r < to {
+					titleRunes = append(titleRunes, r-from)
+				}
+			}
+			return lipgloss.StyleRunes(text, titleRunes, matched, unmatched)
+		}
+		suffix = recurring
+		if due != "" {
+			suffix += " " + due
+		}
+		// The filter matched against the title and tags joined by a space,
+		// so matches in the tags are offset by that much.
+		offset := len([]rune(item.Title())) + 1
+		var tagRunes []int
+		for _, r := range matchedRunes {
+			if r >= offset {
+				tagRunes = append(tagRunes, r-offset)
+			}
+		}
+		if chips := tagChips(item.Tags, tagsRoom, tagRunes); len(chips) > 0 {
+			suffix += " " + d.renderTagChips(chips, tagStyle)
+		}
+	} else {
+		styleTitle = func(text string, _ int) string {
+			return titleStyle.UnsetPaddingLeft().Render(text)
+		}
+		suffix = recurring
+		if due != "" {
+			suffix += " " + due
+		}
+		if chips := tagChips(item.Tags, tagsRoom, nil); len(chips) > 0 {
+			suffix += " " + d.renderTagChips(chips, tagStyle)
+		}
+	}
+
+	if d.WrapTitles {
+		title = wrappedTitle(title, prefix, suffix, m.width-s.NormalTitle.GetHorizontalFrameSize(), styleTitle)
 	} else {
-		title = s.DimmedTitle.Render(title)
+		title = prefix + styleTitle(title, 0) + suffix
 	}
 
-	title = completed + title
+	var desc string
+	if d.ShowDescription {
+		desc, _, _ = strings.Cut(item.Notes, "\n")
+		if strings.TrimSpace(desc) == "" {
+			desc = s.NoDesc.String()
+		}
+		descWidth := m.width - s.NormalDesc.GetHorizontalFrameSize() - len(indent) - lipgloss.Width(number)
+		desc = strings.Repeat(" ", lipgloss.Width(number)) + indent + ansi.Truncate(desc, descWidth, cmd.Ellipsis)
+	}
 
-	if isSelected && m.FilterState() != Filtering {
+	if isCursor {
 		title = s.SelectedTitle.Render(title)
+		desc = s.SelectedDesc.Render(desc)
 	} else {
 		title = s.NormalTitle.Render(title)
+		desc = s.NormalDesc.Render(desc)
+	}
+
+	if d.ShowDescription {
+		// Items set higher than two lines are padded to their height.
+		title += "\n" + desc + strings.Repeat("\n", max(0, d.height-2)) //nolint:mnd
+	}
+	fmt.Fprint(w, cutLines(title, m.width)) //nolint: errcheck
+}
+
+// cutLines cuts the lines of s off at width, for lists too narrow for even the
+// marks in front of the titles.
+func cutLines(s string, width int) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		lines[i] = ansi.Truncate(line, width, "")
 	}
+	return strings.Join(lines, "\n")
+}
+
+// checkMark returns what's shown in front of the title of item, the check mark
+// or an hourglass, and the style of its title.
+func (d DefaultDelegate) checkMark(item domain.Item) (string, lipgloss.Style) {
+	s := &d.Styles
+	switch {
+	case d.strikesThrough(item):
+		return s.CheckMark.String(), s.CompletedTitle
+	case item.Completed():
+		return s.CheckMark.String(), s.DimmedTitle
+	case item.IsWaiting():
+		return s.WaitingMark.String(), s.WaitingTitle
+	case item.Someday:
+		return s.EmptyCheckMark.String(), s.SomedayTitle
+	case item.Priority == domain.PriorityHigh:
+		return s.EmptyCheckMark.String(), s.HighPriorityTitle
+	}
+	return s.EmptyCheckMark.String(), s.DimmedTitle
+}
+
+// priority returns the marker of item's priority, padded to the widest one
+// among the visible items so the check marks line up, or "" while none of
+// them has a priority.
+func (d DefaultDelegate) priority(m ListScreen, item domain.Item) string {
+	s := &d.Styles
+	var widths [domain.PriorityHigh + 1]int
+	widest := 0
+	for p := range widths {
+		widths[p] = lipgloss.Width(s.PriorityMarker(domain.Priority(p)))
+		widest = max(widest, widths[p])
+	}
+	width := 0
+	for _, it := range m.VisibleItems() {
+		if p := it.Priority; p >= domain.PriorityNone && p <= domain.PriorityHigh {
+			width = max(width, widths[p])
+		}
+		if width == widest {
+			break
+		}
+	}
+	marker := s.PriorityMarker(item.Priority)
+	return marker + strings.Repeat(" ", max(0, width-lipgloss.Width(marker)))
+}
 
-	fmt.Fprintf(w, "%s", title) //nolint: errcheck
+// strikesThrough reports whether item's title is styled as completed.
+func (d DefaultDelegate) strikesThrough(item domain.Item) bool {
+	return item.Completed() && !d.PlainCompleted
+}
+
+// tagChip is a tag as it's shown after the title, "[home]", and the runes of
+// it the filter matched.
+type tagChip struct {
+	text    string
+	matches []int
+}
+
+// tagChips returns the chips of tags, the first ones first, as many of them
+// as fit in width with a space between them. matches are the runes the
+// filter matched in the tags as FormatTags writes them, "#home #urgent".
+func tagChips(tags []string, width int, matches []int) []tagChip {
+	var chips []tagChip
+	used, from := 0, 0
+	for _, tag := range tags {
+		chip := tagChip{text: "[" + tag + "]"}
+		w := lipgloss.Width(chip.text)
+		if len(chips) > 0 {
+			w++
+		}
+		if w > width-used {
+			break
+		}
+		used += w
+		// The chip's bracket stands where the tag's # is, and the tag
+		// follows it in both.
+		n := len([]rune(tag))
+		for _, r := range matches {
+			if r >= from && r <= from+n {
+				chip.matches = append(chip.matches, r-from)
+			}
+		}
+		chips = append(chips, chip)
+		from += n + 2 //nolint:mnd
+	}
+	return chips
+}
+
+// chipsWidth returns how wide chips are with a space between them.
+func chipsWidth(chips []tagChip) int {
+	width := len(chips) - 1
+	for _, c := range chips {
+		width += lipgloss.Width(c.text)
+	}
+	return width
+}
+
+// renderTagChips renders chips in style with the matched runes highlighted.
+func (d DefaultDelegate) renderTagChips(chips []tagChip, style lipgloss.Style) string {
+	matched := style.Inherit(d.Styles.FilterMatch)
+	rendered := make([]string, len(chips))
+	for i, c := range chips {
+		rendered[i] = lipgloss.StyleRunes(c.text, c.matches, matched, style)
+	}
+	return strings.Join(rendered, style.Render(" "))
+}
+
+// highlightsMatches reports whether the item at index is shown with the
+// characters the filter matched highlighted.
+func highlightsMatches(m ListScreen, index int) bool {
+	filtered := m.FilterState() == Filtering || m.FilterState() == FilterApplied
+	return filtered && index < len(m.filteredItems)
+}
+
+// titleLine is a line of a wrapped title and the rune it starts at.
+type titleLine struct {
+	text string
+	from int
+}
+
+// wrapTitle wraps title to lines of width, breaking between words where it
+// can, and reports whether what follows the title, suffix columns wide, needs
+// a line of its own after them.
+func wrapTitle(title string, width, suffix int) (lines []titleLine, suffixLine bool) {
+	runes := []rune(title)
+	from := 0
+	for _, text := range strings.Split(ansi.Wrap(title, max(1, width), ""), "\n") {
+		line := []rune(text)
+		// The spaces a line is broken at are left out.
+		for from < len(runes) && len(line) > 0 && runes[from] != line[0] && unicode.IsSpace(runes[from]) {
+			from++
+		}
+		lines = append(lines, titleLine{text: text, from: from})
+		from += len(line)
+	}
+	last := lines[len(lines)-1].text
+	return lines, suffix > 0 && lipgloss.Width(last)+suffix > width
+}
+
+// wrappedTitle returns the rows of a title wrapped to width: prefix and the
+// first line, then the other lines lined up under it, and suffix after the
+// last one or, if it doesn't fit there, on a row of its own. The lines are
+// styled one at a time, so each has its own escape codes.
+func wrappedTitle(title, prefix, suffix string, width int, style func(text string, from int) string) string {
+	hang := strings.Repeat(" ", lipgloss.Width(prefix))
+	lines, suffixLine := wrapTitle(title, width-len(hang), lipgloss.Width(suffix))
+	rows := make([]string, len(lines))
+	for i, line := range lines {
+		rows[i] = hang + style(line.text, line.from)
+	}
+	rows[0] = prefix + strings.TrimPrefix(rows[0], hang)
+	switch {
+	case suffixLine:
+		rows = append(rows, hang+ansi.Truncate(strings.TrimPrefix(suffix, " "), width-len(hang), cmd.Ellipsis))
+	case suffix != "":
+		rows[len(rows)-1] += suffix
+	}
+	return strings.Join(rows, "\n")
+}
+
+// number returns the row number to show in front of the row at index, padded
+// to line up with the others, blank for section headers, or "" without
+// ShowNumbers.
+func (d DefaultDelegate) number(m ListScreen, index int) string {
+	if !d.ShowNumbers {
+		return ""
+	}
+	width := m.numberWidth()
+	n := m.rowNumber(index)
+	if n == 0 {
+		return strings.Repeat(" ", width+2) //nolint:mnd
+	}
+	return d.Styles.Number.Render(fmt.Sprintf("%*d.", width, n)) + " "
+}
+
+// renderSectionHeader prints the header row of a section in place of an item, taking up as many lines as one.
+func (d DefaultDelegate) renderSectionHeader(w io.Writer, m ListScreen, index int, item domain.Item) {
+	s := &d.Styles
+	if m.width <= 0 {
+		fmt.Fprint(w, strings.Repeat("\n", d.Height()-1)) //nolint: errcheck
+		return
+	}
+
+	arrow := s.Expanded.String()
+	if m.sectionCollapsed(item.ID) {
+		arrow = s.Collapsed.String()
+	}
+	number := d.number(m, index)
+	textwidth := m.width - s.NormalTitle.GetHorizontalFrameSize() - lipgloss.Width(arrow) - lipgloss.Width(number)
+	isCursor := index == m.Index() && m.FilterState() != Filtering
+	header := s.SectionHeader
+	if isCursor {
+		header = header.Inherit(s.SelectedText)
+	}
+	title := number + arrow + header.Render(ansi.Truncate(item.Title(), textwidth, cmd.Ellipsis))
+
+	if isCursor {
+		title = s.SelectedTitle.Render(title)
+	} else {
+		title = s.NormalTitle.Render(title)
+	}
+	fmt.Fprint(w, cutLines(title, m.width)+strings.Repeat("\n", d.Height()-1)) //nolint: errcheck
 }
@@ -0,0 +1,85 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"clitodo/cmd"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/storage"
+)
+
+func TestDefaultDelegateContract(t *testing.T) {
+	withNotes := NewThemedDelegate(cmd.DefaultTheme())
+	withNotes.ShowDescription = true
+	taller := withNotes
+	taller.SetHeight(3)
+	numbered := NewThemedDelegate(cmd.DefaultTheme())
+	numbered.ShowNumbers = true
+	wrapped := NewThemedDelegate(cmd.DefaultTheme())
+	wrapped.WrapTitles = true
+	wrapped.ShowDescription = true
+	wrapped.ShowNumbers = true
+
+	tests := []struct {
+		name string
+		d    ItemDelegate
+	}{
+		{"plain", NewThemedDelegate(cmd.DefaultTheme())},
+		{"notes", withNotes},
+		{"taller", taller},
+		{"numbers", numbered},
+		{"wrapped", wrapped},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			TestDelegateContract(t, tt.d)
+		})
+	}
+}
+
+func TestCompactDelegateContract(t *testing.T) {
+	TestDelegateContract(t, NewCompactDelegate(cmd.DefaultTheme()))
+}
+
+func TestTableDelegateContract(t *testing.T) {
+	TestDelegateContract(t, NewTableDelegate(cmd.DefaultTheme()))
+}
+
+func TestDefaultDelegatePriorities(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	render := func(m *ListScreen, i int) string {
+		var b strings.Builder
+		NewThemedDelegate(cmd.DefaultTheme()).Render(&b, *m, i, m.VisibleItems()[i])
+		return strings.TrimRight(ansi.Strip(b.String()), " ")
+	}
+
+	items := titledItems("water the plants", "pay the rent", "call mum", "renew the passport")
+	for i := range items {
+		items[i].Priority = domain.Priority(i)
+	}
+	m := NewListScreen(cmd.DefaultTheme(), storage.NewMemoryItemRepository(items))
+	m.SetSize(40, 24)
+	m.Select(3)
+	// The markers take up a column of their own, blank for no priority, so
+	// the check marks and titles line up.
+	for i, want := range []string{
+		"        water the plants",
+		"  ↓     pay the rent",
+		"  !     call mum",
+		"│ ‼     renew the passport",
+	} {
+		if got := render(m, i); got != want {
+			t.Errorf("priority %s renders %q, want %q", items[i].Priority, got, want)
+		}
+	}
+
+	// Without priorities there's no column for them.
+	m = NewListScreen(cmd.DefaultTheme(), storage.NewMemoryItemRepository(titledItems("water the plants")))
+	m.SetSize(40, 24)
+	if got, want := render(m, 0), "│     water the plants"; got != want {
+		t.Errorf("without priorities the row renders %q, want %q", got, want)
+	}
+}
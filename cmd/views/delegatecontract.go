@@ -0,0 +1,159 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"clitodo/cmd"
+	"clitodo/pkg/clock"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/storage"
+)
+
+// contractWidths are the list widths TestDelegateContract renders at: none at
+// all, narrower than any row's decorations, narrow and wide.
+var contractWidths = []int{0, 1, 4, 12, 40, 120}
+
+// TestDelegateContract checks that d keeps to what ItemDelegate asks of a
+// delegate, for delegates written outside this package as much as for the
+// ones in it. It renders every row of a few canonical lists, plain, filtered,
+// while filtering and with section headers, at widths from 0 to wide, and
+// fails t unless each row has exactly Height lines, HeightFor's for a
+// VariableHeightDelegate, none of them wider than the list, and the same
+// row rendered twice comes out the same. At width 0 the lines are empty.
+//
+// It sets XDG_STATE_HOME to a temporary directory, so it can't run in a
+// parallel test.
+func TestDelegateContract(t *testing.T, d ItemDelegate) {
+	t.Helper()
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+
+	for _, state := range contractStates() {
+		for _, width := range contractWidths {
+			t.Run(fmt.Sprintf("%s/width %d", state.name, width), func(t *testing.T) {
+				m := contractList(d)
+				state.set(m)
+				m.SetSize(width, 40) //nolint:mnd
+				items := m.VisibleItems()
+				if len(items) == 0 {
+					t.Fatal("no rows to render")
+				}
+				for i, item := range items {
+					checkRow(t, d, *m, i, item)
+				}
+			})
+		}
+	}
+}
+
+// contractState is one of the states of the list TestDelegateContract renders
+// rows in.
+type contractState struct {
+	name string
+	set  func(m *ListScreen)
+}
+
+func contractStates() []contractState {
+	return []contractState{
+		{"plain", func(*ListScreen) {}},
+		{"last selected", func(m *ListScreen) { m.Select(len(m.VisibleItems()) - 1) }},
+		{"filter applied", func(m *ListScreen) { m.SetFilterText("the") }},
+		{"filtering", func(m *ListScreen) {
+			m.SetFilterText("pa")
+			m.SetFilterState(Filtering)
+		}},
+		{"done section", func(m *ListScreen) {
+			m.SetShowDoneSection(true)
+			m.SetDoneExpanded(true)
+		}},
+		{"agenda", func(m *ListScreen) { m.SetShowAgenda(true) }},
+	}
+}
+
+// contractList returns a list drawn by d holding one of each kind of task:
+// plain, long with wide characters, due, tagged, recurring, of every
+// priority, waiting, with notes or none, a subtask and a completed one.
+func contractList(d ItemDelegate) *ListScreen {
+	now := time.Date(2026, time.March, 10, 9, 30, 0, 0, time.Local) //nolint:mnd
+	day := func(days int) *domain.Date {
+		due := domain.Day(now.AddDate(0, 0, days))
+		return &due
+	}
+
+	plain := domain.NewItem("water the plants")
+
+	long := domain.NewItem("renew the passport before the trip to 東京 in the spring, and book the appointment early enough")
+	long.Due = day(1)
+	long.Tags = []string{"errands", "travel"}
+	long.Priority = domain.PriorityHigh
+	long.Recurrence = &domain.Recurrence{Every: 1, Unit: domain.Weeks}
+	long.Notes = "bring two photos\nand the old passport"
+
+	waiting := domain.NewItem("hear back about the flat")
+	waiting.Waiting = &domain.Waiting{On: "the landlord"}
+	waiting.Priority = domain.PriorityLow
+
+	parent := domain.NewItem("plan the party")
+	parent.Due = day(0)
+	parent.Tags = []string{"home"}
+	sub := domain.NewItem("order the cake")
+	sub.Due = day(-2)
+	sub.Priority = domain.PriorityMedium
+	parent.Children = []domain.Item{sub}
+
+	done := domain.NewItem("pay the rent")
+	done.ItemCompleted = true
+	completedAt := now.Add(-time.Hour)
+	done.CompletedAt = &completedAt
+	done.Due = day(0)
+
+	m := NewListScreen(cmd.DefaultTheme(), storage.NewMemoryItemRepository([]domain.Item{plain, long, waiting, parent, done}))
+	m.Clock = clock.Fixed(now)
+	m.SetDelegate(d)
+	return m
+}
+
+// checkRow renders the row at index twice and checks what d wrote.
+func checkRow(t *testing.T, d ItemDelegate, m ListScreen, index int, item domain.Item) {
+	t.Helper()
+	name := fmt.Sprintf("row %d (%q)", index, item.Title())
+	first, ok := renderRow(t, d, m, index, item)
+	if !ok {
+		return
+	}
+
+	height := d.Height()
+	if v, ok := d.(VariableHeightDelegate); ok {
+		height = v.HeightFor(m, index, item)
+	}
+	lines := strings.Split(first, "\n")
+	if len(lines) != height {
+		t.Errorf("%s: %d lines, want %d:\n%s", name, len(lines), height, first)
+	}
+	for n, line := range lines {
+		if w := ansi.StringWidth(line); w > m.width {
+			t.Errorf("%s: line %d is %d wide, wider than the list's %d: %q", name, n+1, w, m.width, line)
+		}
+	}
+
+	if second, ok := renderRow(t, d, m, index, item); ok && second != first {
+		t.Errorf("%s: rendered differently the second time:\n%s\n---\n%s", name, first, second)
+	}
+}
+
+// renderRow returns what d writes for the row, failing t if it panics.
+func renderRow(t *testing.T, d ItemDelegate, m ListScreen, index int, item domain.Item) (s string, ok bool) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("row %d (%q): Render panicked at width %d: %v", index, item.Title(), m.width, r)
+		}
+	}()
+	var b strings.Builder
+	d.Render(&b, m, index, item)
+	return b.String(), true
+}
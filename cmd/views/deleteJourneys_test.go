@@ -0,0 +1,95 @@
+package views
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// numberedTasks returns n new items titled "task 1" to "task n".
+func numberedTasks(n int) []string {
+	titles := make([]string, n)
+	for i := range titles {
+		titles[i] = fmt.Sprintf("task %d", i+1)
+	}
+	return titles
+}
+
+// selectedLine returns the line of the view the selected row starts on.
+func (h *harness) selectedLine() string {
+	for _, line := range strings.Split(ansi.Strip(h.view()), "\n") {
+		if strings.HasPrefix(line, "│") {
+			return line
+		}
+	}
+	return ""
+}
+
+func TestJourneyDeleteLastOnPage(t *testing.T) {
+	tests := []struct {
+		name   string
+		tasks  int
+		delete int
+		next   bool
+		want   string
+		page   int
+		pages  int
+	}{
+		{"only task", 1, 0, false, "", 0, 1},
+		{"only task, selecting the next", 1, 0, true, "", 0, 1},
+		// Seven rows fit on a page, so task 15 is alone on the third.
+		{"last task, alone on the last page", 15, 14, false, "task 14", 1, 2},
+		{"last task, selecting the next", 15, 14, true, "task 14", 1, 2},
+		{"last task of the first page", 15, 6, false, "task 6", 0, 2},
+		{"last task of the first page, selecting the next", 15, 6, true, "task 8", 0, 2},
+		{"in the middle", 15, 9, false, "task 9", 1, 2},
+		{"in the middle, selecting the next", 15, 9, true, "task 11", 1, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			titles := numberedTasks(tt.tasks)
+			h := newHarnessWith(t, titledItems(titles...), func(o *Options) {
+				o.SelectNextOnDelete = tt.next
+			})
+			if tt.tasks > 7 && !slices.Equal(h.list().pageStarts, []int{0, 7, 14}) {
+				t.Fatalf("pages start at %v, want seven rows to a page", h.list().pageStarts)
+			}
+			h.list().Select(tt.delete)
+			deleted := selectedTitle(h.list())
+
+			h.press("ctrl+d")
+			if got := stored(t, h.repo); slices.Contains(got, deleted) || len(got) != tt.tasks-1 {
+				t.Fatalf("deleting %q left %q stored", deleted, got)
+			}
+			m := h.list()
+			if got := selectedTitle(m); got != tt.want {
+				t.Errorf("after deleting %q, %q is selected, want %q", deleted, got, tt.want)
+			}
+			if m.Paginator.Page != tt.page || m.Paginator.TotalPages != tt.pages {
+				t.Errorf("page %d of %d is shown, want %d of %d", m.Paginator.Page+1, m.Paginator.TotalPages, tt.page+1, tt.pages)
+			}
+			if tt.want != "" && !strings.Contains(h.selectedLine(), tt.want) {
+				t.Errorf("the selected row shows %q, want %q:\n%s", h.selectedLine(), tt.want, ansi.Strip(h.view()))
+			}
+			for _, line := range strings.Split(ansi.Strip(h.view()), "\n") {
+				if strings.TrimLeft(strings.TrimSpace(line), "│ ") == deleted {
+					t.Errorf("%q is still shown:\n%s", deleted, ansi.Strip(h.view()))
+				}
+			}
+
+			// The keys go on working on what's left, and undo puts the task
+			// back where it was, selected.
+			h.press("down", "up")
+			h.press("u")
+			if got := stored(t, h.repo); !slices.Equal(got, titles) {
+				t.Errorf("undoing the delete left %q stored", got)
+			}
+			if got := selectedTitle(h.list()); got != deleted {
+				t.Errorf("after undo %q is selected, want %q", got, deleted)
+			}
+		})
+	}
+}
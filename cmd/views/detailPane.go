@@ -0,0 +1,202 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+
+	"clitodo/cmd"
+	"clitodo/pkg/domain"
+)
+
+// detailPane shows the selected item next to the list when the terminal is
+// wide enough. It only keeps the scroll position; the content is rendered
+// from the selection every time, so it can't go stale.
+type detailPane struct {
+	viewport viewport.Model
+
+	// Index of the item the scroll position belongs to. Selecting another
+	// item starts at the top again.
+	index int
+}
+
+// SetSplitWidth sets the terminal width from which the selected item's details
+// are shown next to the list. 0 never splits.
+func (m *ListScreen) SetSplitWidth(v int) {
+	m.splitWidth = v
+	m.layout()
+}
+
+// SplitWidth returns the terminal width from which the list is split.
+func (m ListScreen) SplitWidth() int {
+	return m.splitWidth
+}
+
+// Split returns whether the details are shown next to the list.
+func (m ListScreen) Split() bool {
+	return m.canSplit() && !m.splitHidden
+}
+
+func (m ListScreen) canSplit() bool {
+	return m.splitWidth > 0 && m.fullWidth >= m.splitWidth
+}
+
+// ToggleSplit hides or shows the details pane. It has no effect while the
+// terminal is too narrow to split.
+func (m *ListScreen) ToggleSplit() {
+	m.splitHidden = !m.splitHidden
+	m.layout()
+}
+
+// layout divides the full width between the list and the details pane. The
+// list is resized through setSize, so the delegate truncates titles to the
+// width it actually has and the selection survives crossing the threshold.
+func (m *ListScreen) layout() {
+	defer m.updateKeybindings()
+
+	if !m.Split() {
+		m.setSize(m.fullWidth, m.fullHeight)
+		return
+	}
+
+	listWidth := m.fullWidth / 2 //nolint:mnd
+	m.setSize(listWidth, m.fullHeight)
+	m.detail.viewport.Width = m.fullWidth - listWidth - m.Styles.DetailPane.GetHorizontalFrameSize()
+	m.detail.viewport.Height = m.fullHeight - m.Styles.DetailPane.GetVerticalFrameSize()
+}
+
+// syncDetail renders the selected item into the viewport, scrolling back to
+// the top when the selection changed since the last call.
+func (m *ListScreen) syncDetail() {
+	if m.Index() != m.detail.index {
+		m.detail.index = m.Index()
+		m.detail.viewport.GotoTop()
+	}
+	m.detail.viewport.SetContent(m.detailContent())
+}
+
+func (m ListScreen) detailView() string {
+	m.syncDetail()
+	return m.Styles.DetailPane.Render(m.detail.viewport.View())
+}
+
+func (m ListScreen) detailContent() string {
+	item := m.SelectedItem()
+	if item == nil {
+		return m.Styles.NoItems.Render("Nothing selected.")
+	}
+	return itemDetails(*item, m.detail.viewport.Width, m.itemByID, m.Styles)
+}
+
+// itemByID returns the item with the given ID in the list.
+func (m ListScreen) itemByID(id string) (domain.Item, bool) {
+	if i := m.indexOfID(id); i >= 0 {
+		return m.items[i], true
+	}
+	return domain.Item{}, false
+}
+
+// itemDetails renders every field of item, wrapped to width, looking up the
+// tasks its notes link to with resolve. The details pane and the detail
+// screen both show this.
+func itemDetails(item domain.Item, width int, resolve func(id string) (domain.Item, bool), styles cmd.Styles) string {
+	var b strings.Builder
+
+	b.WriteString(styles.DetailTitle.Width(width).Render(item.Title()))
+	b.WriteString("\n\n")
+
+	status := "open"
+	switch item.Status() {
+	case domain.StatusDoing:
+		status = "doing"
+	case domain.StatusDone:
+		status = "done"
+	}
+	b.WriteString(detailField(styles, "Status", status))
+	if item.IsWaiting() {
+		on := item.Waiting.On
+		if on == "" {
+			on = "yes"
+		}
+		b.WriteString(detailField(styles, "Waiting on", on))
+		if item.Waiting.FollowUp != nil {
+			b.WriteString(detailField(styles, "Follow up", domain.FormatDue(*item.Waiting.FollowUp)))
+		}
+	}
+	if len(item.Tags) != 0 {
+		b.WriteString(detailField(styles, "Tags", domain.FormatTags(item.Tags)))
+	}
+	if item.Priority != domain.PriorityNone {
+		b.WriteString(detailField(styles, "Priority", item.Priority.String()))
+	}
+	if item.Estimate != 0 {
+		b.WriteString(detailField(styles, "Estimate", item.Estimate.String()))
+	}
+	if item.Due != nil {
+		b.WriteString(detailField(styles, "Due", domain.FormatDue(*item.Due)))
+	}
+	if item.Recurrence != nil {
+		b.WriteString(detailField(styles, "Repeats", item.Recurrence.String()))
+	}
+	if item.Remind != nil {
+		remind := item.Remind.String()
+		if item.Remind.Fired > 0 {
+			remind += fmt.Sprintf(" (fired %d×)", item.Remind.Fired)
+		}
+		b.WriteString(detailField(styles, "Remind", remind))
+	}
+	if item.CreatedAt != nil {
+		b.WriteString(detailField(styles, "Created", item.CreatedAt.Local().Format("2006-01-02 15:04")))
+	}
+	if item.CompletedAt != nil {
+		b.WriteString(detailField(styles, "Completed", item.CompletedAt.Local().Format("2006-01-02 15:04")))
+	}
+	if item.TouchedAt != nil {
+		b.WriteString(detailField(styles, "Touched", item.TouchedAt.Local().Format("2006-01-02 15:04")))
+	}
+
+	if item.Notes != "" {
+		b.WriteString("\n")
+		b.WriteString(styles.DetailLabel.Render("Notes"))
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().Width(width).Render(renderNotes(item.Notes, resolve, styles)))
+	}
+
+	return b.String()
+}
+
+// renderNotes replaces each [[task:ID]] link in notes with its number, which
+// follows it in the detail screen, and the linked task's title, checked off
+// if it's done. A link to a task that no longer exists reads "missing",
+// struck through.
+func renderNotes(notes string, resolve func(id string) (domain.Item, bool), styles cmd.Styles) string {
+	n := 0
+	return domain.ReplaceTaskLinks(notes, func(id string) string {
+		n++
+		item, ok := resolve(id)
+		if !ok {
+			return fmt.Sprintf("[%d] ", n) + styles.DetailMissingLink.Render("missing")
+		}
+		title := item.Title()
+		if item.Completed() {
+			title = "✓ " + title
+		}
+		return fmt.Sprintf("[%d] ", n) + styles.DetailLink.Render(title)
+	})
+}
+
+func detailField(styles cmd.Styles, label, value string) string {
+	return styles.DetailLabel.Render(label+": ") + value + "\n"
+}
+
+// scrollDetail scrolls the details pane by n lines, up for negative n.
+func (m *ListScreen) scrollDetail(n int) {
+	m.syncDetail()
+	if n < 0 {
+		m.detail.viewport.LineUp(-n)
+	} else {
+		m.detail.viewport.LineDown(n)
+	}
+}
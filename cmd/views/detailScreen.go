@@ -0,0 +1,107 @@
+package views
+
+import (
+	"slices"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"clitodo/cmd"
+	"clitodo/pkg/domain"
+)
+
+type detailDoneMsg struct{}
+
+// followLinkMsg closes the detail screen and selects the task with the given
+// ID in the list.
+type followLinkMsg struct {
+	id string
+}
+
+// detailScreen shows everything about one item on the whole screen, for
+// terminals too narrow for the details pane. It's read-only; the list keeps
+// its selection while it's open.
+type detailScreen struct {
+	item     domain.Item
+	viewport viewport.Model
+
+	// Looks up the tasks the notes link to.
+	resolve func(id string) (domain.Item, bool)
+
+	KeyMap cmd.KeyMap
+	help   help.Model
+	styles cmd.Styles
+}
+
+// The margins around the screen and the help line below the details.
+var detailScreenStyle = lipgloss.NewStyle().Margin(1, 2)
+
+const detailScreenHelpHeight = 2
+
+func newDetailScreen(item domain.Item, resolve func(id string) (domain.Item, bool), width, height int, styles cmd.Styles) detailScreen {
+	m := detailScreen{
+		item:     item,
+		resolve:  resolve,
+		viewport: viewport.New(0, 0),
+		KeyMap:   cmd.DefaultKeyMap(),
+		help:     help.New(),
+		styles:   styles,
+	}
+	m.setSize(width, height)
+	return m
+}
+
+// setSize fits the details to the terminal, wrapping long titles and notes.
+func (m *detailScreen) setSize(width, height int) {
+	m.viewport.Width = max(1, width-detailScreenStyle.GetHorizontalFrameSize())
+	m.viewport.Height = max(1, height-detailScreenStyle.GetVerticalFrameSize()-detailScreenHelpHeight)
+	m.viewport.SetContent(itemDetails(m.item, m.viewport.Width, m.resolve, m.styles))
+}
+
+func (m detailScreen) Init() tea.Cmd {
+	return nil
+}
+
+func (m detailScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.setSize(msg.Width, msg.Height)
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.KeyMap.CloseDetail):
+			return m, func() tea.Msg { return detailDoneMsg{} }
+		case key.Matches(msg, m.KeyMap.FollowLink):
+			links := domain.FindTaskLinks(m.item.Notes)
+			if n := slices.Index(m.KeyMap.FollowLink.Keys(), msg.String()); n >= 0 && n < len(links) {
+				id := links[n].ID
+				return m, func() tea.Msg { return followLinkMsg{id: id} }
+			}
+		case key.Matches(msg, m.KeyMap.CursorUp):
+			m.viewport.LineUp(1)
+		case key.Matches(msg, m.KeyMap.CursorDown):
+			m.viewport.LineDown(1)
+		}
+	}
+	return m, nil
+}
+
+func (m detailScreen) View() string {
+	scrollUp, scrollDown := m.KeyMap.CursorUp, m.KeyMap.CursorDown
+	scrollUp.SetHelp("↑/k", "scroll up")
+	scrollDown.SetHelp("↓/j", "scroll down")
+
+	var bindings []key.Binding
+	if !m.viewport.AtTop() || !m.viewport.AtBottom() {
+		bindings = append(bindings, scrollUp, scrollDown)
+	}
+	if len(domain.FindTaskLinks(m.item.Notes)) != 0 {
+		bindings = append(bindings, m.KeyMap.FollowLink)
+	}
+	bindings = append(bindings, m.KeyMap.CloseDetail)
+
+	return detailScreenStyle.Render(m.viewport.View() + "\n" +
+		m.styles.HelpStyle.UnsetPadding().PaddingTop(1).Render(m.help.ShortHelpView(bindings)))
+}
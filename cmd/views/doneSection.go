@@ -0,0 +1,149 @@
+package views
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"clitodo/pkg/domain"
+	"clitodo/pkg/state"
+	"clitodo/pkg/storage"
+)
+
+// doneHeaderID is the ID of the row heading the done section. It's not a real
+// item: SelectedItem returns nil for it, and enter on it collapses or expands
+// the section.
+const doneHeaderID = "done-today"
+
+// SetShowDoneSection sets whether tasks completed today are listed in a
+// section of their own at the bottom of the unfiltered list.
+func (m *ListScreen) SetShowDoneSection(v bool) {
+	m.showDoneSection = v
+	m.updatePagination()
+}
+
+// ShowDoneSection returns whether the done section is shown.
+func (m ListScreen) ShowDoneSection() bool {
+	return m.showDoneSection
+}
+
+// SetDoneExpanded sets whether the done section lists its tasks or only its
+// header.
+func (m *ListScreen) SetDoneExpanded(v bool) {
+	m.doneExpanded = v
+	m.updatePagination()
+}
+
+// sectioned reports whether the list is currently shown with a done section.
+// Filtering lists matches in stored order as usual, and there's no section
+// while completed items are hidden or the agenda is shown.
+func (m ListScreen) sectioned() bool {
+	return m.showDoneSection && m.showCompleted && !m.showAgenda && m.filterState == Unfiltered
+}
+
+// completedToday reports whether a top-level item was checked off today. Its
+// subtasks go wherever it goes.
+func completedToday(item domain.Item, now time.Time) bool {
+	return item.Depth == 0 && item.Completed() && item.CompletedAt != nil && sameDay(*item.CompletedAt, now)
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Local().Date()
+	by, bm, bd := b.Local().Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// arranged returns the rows of the sectioned list: everything but today's
+// completed tasks in stored order, then the done section's header and, while
+// it's expanded, those tasks, and last the someday section. The headers'
+// index is -1. Collapsed sections only show their header.
+func (m ListScreen) arranged() filteredItems {
+	now := m.Clock.Now()
+	hidden := m.hiddenItems()
+	rows := make(filteredItems, 0, len(m.items)+1)
+	var done filteredItems
+	for i := 0; i < len(m.items); {
+		end := m.subtreeEnd(i)
+		for j := i; j < end; j++ {
+			if hidden[j] {
+				continue
+			}
+			if completedToday(m.items[i], now) {
+				done = append(done, filteredItem{item: m.items[j], index: j})
+			} else {
+				rows = append(rows, filteredItem{item: m.items[j], index: j})
+			}
+		}
+		i = end
+	}
+	rows = m.sortRows(rows)
+	if len(done) == 0 {
+		return m.collapse(m.withSomeday(rows))
+	}
+	done = m.sortRows(done)
+
+	count := 0
+	for _, f := range done {
+		if f.item.Depth == 0 {
+			count++
+		}
+	}
+	rows = append(rows, m.sectionHeader(doneHeaderID, "Done today", count))
+	rows = append(rows, done...)
+	return m.collapse(m.withSomeday(rows))
+}
+
+// toggleDoneSection collapses or expands the done section and remembers that
+// for the next start.
+func (m *ListScreen) toggleDoneSection() {
+	m.SetDoneExpanded(!m.doneExpanded)
+	if st, err := state.Load(); err == nil {
+		st.DoneExpanded = m.doneExpanded
+		st.Save()
+	}
+}
+
+// archiveCompleted moves the tasks completed before today, with their
+// subtasks, from the list into the archive file. Tasks completed before
+// completion times were recorded stay in the list, and so does everything in
+// a read-only one.
+func (m *ListScreen) archiveCompleted(now time.Time) tea.Cmd {
+	if m.itemRepository.ReadOnly() {
+		return nil
+	}
+	now = now.Local()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	kept := make([]domain.Item, 0, len(m.items))
+	var archived []domain.Item
+	for i := 0; i < len(m.items); {
+		end := m.subtreeEnd(i)
+		root := m.items[i]
+		if root.Completed() && root.CompletedAt != nil && root.CompletedAt.Before(today) {
+			archived = append(archived, m.items[i:end]...)
+		} else {
+			kept = append(kept, m.items[i:end]...)
+		}
+		i = end
+	}
+	if len(archived) == 0 {
+		return nil
+	}
+
+	archive := m.itemRepository.Archive()
+	items, err := archive.GetItems()
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return m.NewStatusMessage("Archiving failed: " + err.Error())
+	}
+	if err := archive.StoreItemsState(append(items, domain.Nest(archived)...)); err != nil {
+		return m.NewStatusMessage("Archiving failed: " + err.Error())
+	}
+
+	cmd := m.SetItems(kept)
+	if err := m.saveItems(); err != nil {
+		return tea.Batch(cmd, m.NewStatusMessage("Archiving failed: "+err.Error()))
+	}
+	return tea.Batch(cmd, m.NewStatusMessage(fmt.Sprintf("Archived %d completed tasks", len(domain.Nest(archived)))))
+}
@@ -0,0 +1,89 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"clitodo/cmd"
+	"clitodo/pkg/domain"
+)
+
+// editTaskScreen renames an item. The title is typed like in the add screen,
+// so "#tag" words at its end replace the item's tags.
+type editTaskScreen struct {
+	id        string
+	textInput textinput.Model
+	err       string
+
+	KeyMap cmd.KeyMap
+	help   help.Model
+	styles cmd.Styles
+}
+
+func newEditTaskScreen(item domain.Item, styles cmd.Styles) editTaskScreen {
+	ti := textinput.New()
+	ti.CharLimit = 156
+	ti.Width = 40
+	ti.SetValue(strings.TrimSpace(item.Title() + " " + domain.FormatTags(item.Tags)))
+	ti.Focus()
+
+	return editTaskScreen{
+		id:        item.ID,
+		textInput: ti,
+		KeyMap:    cmd.DefaultKeyMap(),
+		help:      help.New(),
+		styles:    styles,
+	}
+}
+
+func (m editTaskScreen) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m editTaskScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(keyMsg, m.KeyMap.CancelEdit):
+			id := m.id
+			return m, func() tea.Msg { return cmd.TaskEdited{ID: id} }
+		case key.Matches(keyMsg, m.KeyMap.AcceptEdit):
+			return m.accept()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+func (m editTaskScreen) accept() (tea.Model, tea.Cmd) {
+	title, tags := domain.SplitTags(m.textInput.Value())
+	title = strings.TrimSpace(title)
+	if title == "" {
+		m.err = "The title can't be empty."
+		return m, nil
+	}
+
+	id := m.id
+	return m, func() tea.Msg { return cmd.TaskEdited{ID: id, Title: title, Tags: tags} }
+}
+
+func (m editTaskScreen) View() string {
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render("Edit Task"))
+	b.WriteString("\n\n" + m.textInput.View() + "\n")
+	if m.err != "" {
+		fmt.Fprintf(&b, "\n%s\n", m.styles.StatusBar.UnsetPadding().Render(m.err))
+	}
+	b.WriteString(m.styles.HelpStyle.Render(m.help.ShortHelpView([]key.Binding{
+		m.KeyMap.AcceptEdit,
+		m.KeyMap.CancelEdit,
+	})))
+	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
+}
@@ -0,0 +1,31 @@
+package views
+
+import (
+	"strings"
+
+	"clitodo/pkg/match"
+)
+
+// Sigils at the start of the filter that pick how the rest of it is matched,
+// whatever the configured filter is: 'milk matches "milk" as typed and ~milk
+// fuzzily.
+const (
+	substringSigil = "'"
+	fuzzySigil     = "~"
+)
+
+// queryFilter returns the filter to match value, what's typed into the
+// filter, with, and the value without its sigil. Regular expressions switched
+// on with ctrl+r take no sigils, as both are valid in a pattern.
+func (m ListScreen) queryFilter(value string) (FilterFunc, string) {
+	if m.fuzzyFilter != nil {
+		return m.Filter, value
+	}
+	if rest, ok := strings.CutPrefix(value, substringSigil); ok {
+		return match.NewSubstringFilter(m.filterFolder), rest
+	}
+	if rest, ok := strings.CutPrefix(value, fuzzySigil); ok {
+		return match.NewFilter(m.filterFolder), rest
+	}
+	return m.Filter, value
+}
@@ -0,0 +1,152 @@
+package views
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+
+	"clitodo/pkg/domain"
+)
+
+// filterTokens are words in the filter that select items by state instead of
+// being matched against the text.
+var filterTokens = map[string]func(domain.Item) bool{
+	"is:waiting": domain.Item.IsWaiting,
+	"is:done":    domain.Item.Completed,
+	"is:open":    func(item domain.Item) bool { return !item.Completed() },
+}
+
+// filterTokensHelp lists the filter tokens in the help while filtering. They
+// have no keys of their own and never match one.
+var filterTokensHelp = []key.Binding{
+	key.NewBinding(key.WithKeys(""), key.WithHelp("is:open/done/waiting", "by state")),
+	key.NewBinding(key.WithKeys(""), key.WithHelp("#tag", "by tag")),
+	key.NewBinding(key.WithKeys(""), key.WithHelp("due:today/tomorrow/week/overdue/none", "by due date")),
+	key.NewBinding(key.WithKeys(""), key.WithHelp("!high/medium/low/none", "by priority")),
+	key.NewBinding(key.WithKeys(""), key.WithHelp("'text/~text", "as typed/fuzzy")),
+}
+
+// tagToken starts filter words that keep the items with a tag: tag:work.
+const tagToken = "tag:"
+
+// tagWord returns the tag a filter word like "tag:work" or "tag:#work" keeps
+// the items of, lowercased.
+func tagWord(word string) (string, bool) {
+	tag, ok := strings.CutPrefix(strings.ToLower(word), tagToken)
+	tag = strings.TrimPrefix(tag, "#")
+	return tag, ok && tag != ""
+}
+
+// hasTag returns a predicate that keeps the items tagged tag, ignoring case.
+func hasTag(tag string) func(domain.Item) bool {
+	return func(item domain.Item) bool {
+		for _, t := range item.Tags {
+			if strings.EqualFold(t, tag) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// splitTagWords returns the tags the tag: words of term keep and the other
+// words.
+func splitTagWords(term string) (tags []string, rest string) {
+	var words []string
+	for _, word := range strings.Fields(term) {
+		if tag, ok := tagWord(word); ok {
+			tags = append(tags, tag)
+		} else {
+			words = append(words, word)
+		}
+	}
+	return tags, strings.Join(words, " ")
+}
+
+// filterToken returns the predicate a filter word like "is:open", "#work",
+// "due:today" or "!high" stands for. Other words, including ones that only
+// look like tokens such as "is:later", are plain text.
+func filterToken(word string, now time.Time) (func(domain.Item) bool, bool) {
+	word = strings.ToLower(word)
+	if pred, ok := filterTokens[word]; ok {
+		return pred, true
+	}
+	if tag, ok := tagWord(word); ok {
+		return hasTag(tag), true
+	}
+	if prefix, ok := strings.CutPrefix(word, "#"); ok && prefix != "" {
+		return hasTagPrefix(prefix), true
+	}
+	if when, ok := strings.CutPrefix(word, "due:"); ok {
+		if pred, ok := dueTokens[when]; ok {
+			return func(item domain.Item) bool { return pred(item, now) }, true
+		}
+	}
+	if name, ok := strings.CutPrefix(word, "!"); ok {
+		var p domain.Priority
+		if err := p.UnmarshalText([]byte(name)); err == nil {
+			return func(item domain.Item) bool { return item.Priority == p }, true
+		}
+	}
+	return nil, false
+}
+
+// dueTokens are the due: words, which select open items by when they're due.
+// Overdue items count as due today.
+var dueTokens = map[string]func(domain.Item, time.Time) bool{
+	"overdue": domain.Item.Overdue,
+	"today": func(item domain.Item, now time.Time) bool {
+		return item.Overdue(now) || item.DueToday(now)
+	},
+	"tomorrow": func(item domain.Item, now time.Time) bool {
+		return item.DueToday(domain.ShiftDate(now, 0, 1))
+	},
+	"week": func(item domain.Item, now time.Time) bool {
+		return item.Due != nil && !item.Completed() && domain.DueBy(*item.Due).Before(domain.ShiftDate(now, 0, 7))
+	},
+	"none": func(item domain.Item, _ time.Time) bool {
+		return item.Due == nil && !item.Completed()
+	},
+}
+
+// hasTagPrefix returns a predicate that keeps the items with a tag starting
+// with prefix, ignoring case, so "#err" finds #errands while it's typed.
+func hasTagPrefix(prefix string) func(domain.Item) bool {
+	return func(item domain.Item) bool {
+		for _, t := range item.Tags {
+			if strings.HasPrefix(strings.ToLower(t), prefix) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// parseFilterTokens removes the filter tokens from term and returns the rest
+// along with a predicate that keeps the items all tokens select.
+func parseFilterTokens(term string, now time.Time) (rest string, keep func(domain.Item) bool) {
+	var preds []func(domain.Item) bool
+	var words []string
+	for _, word := range strings.Fields(term) {
+		if pred, ok := filterToken(word, now); ok {
+			preds = append(preds, pred)
+			continue
+		}
+		words = append(words, word)
+	}
+
+	keep = func(item domain.Item) bool {
+		for _, pred := range preds {
+			if !pred(item) {
+				return false
+			}
+		}
+		return true
+	}
+	if len(preds) == 0 {
+		// Leave the term alone, spaces included, when there's no token.
+		return term, keep
+	}
+	return strings.Join(words, " "), keep
+}
@@ -0,0 +1,118 @@
+package views
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"clitodo/pkg/domain"
+)
+
+func TestFilterToken(t *testing.T) {
+	// A Tuesday morning.
+	now := time.Date(2026, time.March, 10, 9, 30, 0, 0, time.Local)
+	day := func(days int) *domain.Date {
+		due := domain.Day(now.AddDate(0, 0, days))
+		return &due
+	}
+
+	item := func(title string, change func(*domain.Item)) domain.Item {
+		item := domain.NewItem(title)
+		change(&item)
+		return item
+	}
+	items := []domain.Item{
+		item("open", func(*domain.Item) {}),
+		item("done", func(i *domain.Item) { i.ItemCompleted = true; i.Due = day(0) }),
+		item("waiting", func(i *domain.Item) { i.Waiting = &domain.Waiting{On: "Bob"} }),
+		item("errands", func(i *domain.Item) { i.Tags = []string{"Errands", "home"} }),
+		item("overdue", func(i *domain.Item) { i.Due = day(-2); i.Priority = domain.PriorityHigh }),
+		item("earlier today", func(i *domain.Item) { i.Due = &domain.Date{Time: now.Add(-time.Hour)} }),
+		item("today", func(i *domain.Item) { i.Due = day(0); i.Priority = domain.PriorityLow }),
+		item("tomorrow", func(i *domain.Item) { i.Due = day(1) }),
+		item("in six days", func(i *domain.Item) { i.Due = day(6) }),
+		item("in a week", func(i *domain.Item) { i.Due = day(7) }),
+	}
+
+	tests := []struct {
+		word string
+		want []string
+	}{
+		{"is:open", []string{"open", "waiting", "errands", "overdue", "earlier today", "today", "tomorrow", "in six days", "in a week"}},
+		{"IS:DONE", []string{"done"}},
+		{"is:waiting", []string{"waiting"}},
+		{"#errands", []string{"errands"}},
+		{"#err", []string{"errands"}},
+		{"#HOME", []string{"errands"}},
+		{"tag:errands", []string{"errands"}},
+		{"tag:#errands", []string{"errands"}},
+		{"tag:err", nil},
+		{"due:overdue", []string{"overdue", "earlier today"}},
+		{"due:today", []string{"overdue", "earlier today", "today"}},
+		{"due:tomorrow", []string{"tomorrow"}},
+		{"due:week", []string{"overdue", "earlier today", "today", "tomorrow", "in six days"}},
+		{"due:none", []string{"open", "waiting", "errands"}},
+		{"!high", []string{"overdue"}},
+		{"!low", []string{"today"}},
+	}
+	for _, tt := range tests {
+		pred, ok := filterToken(tt.word, now)
+		if !ok {
+			t.Errorf("filterToken(%q) isn't a token", tt.word)
+			continue
+		}
+		var got []string
+		for _, item := range items {
+			if pred(item) {
+				got = append(got, item.Title())
+			}
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("filterToken(%q) keeps %q, want %q", tt.word, got, tt.want)
+		}
+	}
+
+	for _, word := range []string{"is:later", "is:", "#", "tag:", "due:someday", "due:", "!urgent", "!", "plain"} {
+		if _, ok := filterToken(word, now); ok {
+			t.Errorf("filterToken(%q) is a token, want plain text", word)
+		}
+	}
+}
+
+func TestParseFilterTokens(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 9, 30, 0, 0, time.Local)
+	work := domain.NewItem("report")
+	work.Tags = []string{"work"}
+	done := work
+	done.ItemCompleted = true
+
+	tests := []struct {
+		term, rest string
+		keepsWork  bool
+		keepsDone  bool
+	}{
+		// Without a token the term stays as typed, spaces included.
+		{"  pay  rent ", "  pay  rent ", true, true},
+		{"is:open pay rent", "pay rent", true, false},
+		{"pay is:open  rent", "pay rent", true, false},
+		{"#work is:done", "", false, true},
+		{"#home", "", false, false},
+		{"is:later", "is:later", true, true},
+	}
+	for _, tt := range tests {
+		rest, keep := parseFilterTokens(tt.term, now)
+		if rest != tt.rest {
+			t.Errorf("parseFilterTokens(%q) left %q, want %q", tt.term, rest, tt.rest)
+		}
+		if keep(work) != tt.keepsWork || keep(done) != tt.keepsDone {
+			t.Errorf("parseFilterTokens(%q) keeps the open item %t and the done one %t, want %t and %t", tt.term, keep(work), keep(done), tt.keepsWork, tt.keepsDone)
+		}
+	}
+}
+
+func TestSplitTagWords(t *testing.T) {
+	tags, rest := splitTagWords("tag:Work pay tag:#home  rent tag:")
+	if !slices.Equal(tags, []string{"work", "home"}) || rest != "pay rent tag:" {
+		t.Errorf("splitTagWords() = %q, %q, want work and home, and pay rent tag:", tags, rest)
+	}
+}
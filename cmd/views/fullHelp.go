@@ -0,0 +1,147 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+
+	"clitodo/pkg/state"
+)
+
+// The full help splits a group of bindings into columns no shorter than
+// this, so a long group doesn't turn into a row of one-line columns.
+const minHelpColumn = 4
+
+// SetShowFullHelp opens or closes the full help.
+func (m *ListScreen) SetShowFullHelp(v bool) {
+	m.Help.ShowAll = v
+	m.updatePagination()
+	m.syncZen()
+}
+
+// toggleFullHelp opens or closes the full help and remembers that for the
+// next start.
+func (m *ListScreen) toggleFullHelp() {
+	m.SetShowFullHelp(!m.Help.ShowAll)
+	if st, err := state.Load(); err == nil {
+		st.FullHelp = m.Help.ShowAll
+		st.Save()
+	}
+}
+
+// moreHelp returns how many of the enabled bindings of the full help aren't
+// among shown.
+func (m ListScreen) moreHelp(shown []key.Binding) int {
+	seen := make(map[key.Help]bool, len(shown))
+	for _, b := range shown {
+		seen[b.Help()] = true
+	}
+	seen[m.KeyMap.CloseFullHelp.Help()] = true
+
+	more := 0
+	for _, group := range m.FullHelp() {
+		for _, b := range group {
+			if b.Enabled() && !seen[b.Help()] {
+				more++
+			}
+		}
+	}
+	return more
+}
+
+// moreHint renders the short help's hint at the full help, saying how many
+// bindings it has that the short help doesn't show.
+func (m ListScreen) moreHint(more int) string {
+	if more == 0 {
+		return m.Help.ShortHelpView([]key.Binding{m.KeyMap.ShowFullHelp})
+	}
+	return m.Styles.HelpMore.Render(fmt.Sprintf("%s %d more", m.KeyMap.ShowFullHelp.Help().Key, more))
+}
+
+// fullHelpView lays out the groups of the full help in columns side by side
+// as far as width allows, and the rest in further rows of columns below.
+// Groups too long for that are split into several columns, as evenly as
+// they go, whichever way takes up the fewest lines.
+func (m ListScreen) fullHelpView(width int) string {
+	var groups [][]key.Binding
+	longest := 0
+	for _, group := range m.FullHelp() {
+		var enabled []key.Binding
+		for _, b := range group {
+			if b.Enabled() {
+				enabled = append(enabled, b)
+			}
+		}
+		if len(enabled) > 0 {
+			groups = append(groups, enabled)
+			longest = max(longest, len(enabled))
+		}
+	}
+
+	var best string
+	for limit := longest; limit >= min(minHelpColumn, longest); limit-- {
+		view := strings.Join(m.flowHelpColumns(m.helpColumns(groups, limit), width), "\n\n")
+		if best == "" || lipgloss.Height(view) < lipgloss.Height(best) {
+			best = view
+		}
+	}
+	return best
+}
+
+// helpColumns renders groups as columns of at most limit bindings each.
+func (m ListScreen) helpColumns(groups [][]key.Binding, limit int) []string {
+	var columns []string
+	for _, group := range groups {
+		n := (len(group) + limit - 1) / limit
+		size := (len(group) + n - 1) / n
+		for start := 0; start < len(group); start += size {
+			columns = append(columns, m.helpColumn(group[start:min(start+size, len(group))]))
+		}
+	}
+	return columns
+}
+
+// helpColumn renders bindings the way the help's full view does: their keys
+// lined up on the left and what they do on the right.
+func (m ListScreen) helpColumn(bindings []key.Binding) string {
+	keys := make([]string, len(bindings))
+	descriptions := make([]string, len(bindings))
+	for i, b := range bindings {
+		keys[i] = b.Help().Key
+		descriptions[i] = b.Help().Desc
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		m.Help.Styles.FullKey.Render(strings.Join(keys, "\n")),
+		m.Help.Styles.FullKey.Render(" "),
+		m.Help.Styles.FullDesc.Render(strings.Join(descriptions, "\n")),
+	)
+}
+
+// flowHelpColumns puts columns side by side on rows no wider than width, at
+// least one on each row.
+func (m ListScreen) flowHelpColumns(columns []string, width int) []string {
+	separator := m.Help.Styles.FullSeparator.Render(m.Help.FullSeparator)
+
+	var rows []string
+	var row []string
+	rowWidth := 0
+	for _, column := range columns {
+		w := lipgloss.Width(column)
+		if len(row) > 0 && width > 0 && rowWidth+lipgloss.Width(separator)+w > width {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row, rowWidth = nil, 0
+		}
+		if len(row) > 0 {
+			row = append(row, separator)
+			rowWidth += lipgloss.Width(separator)
+		}
+		row = append(row, column)
+		rowWidth += w
+	}
+	if len(row) > 0 {
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+	return rows
+}
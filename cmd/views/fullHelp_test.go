@@ -0,0 +1,82 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+
+	"clitodo/cmd"
+	"clitodo/pkg/storage"
+)
+
+// helpList returns a list of a few tasks, width columns wide.
+func helpList(t *testing.T, width int) *ListScreen {
+	t.Helper()
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	m := NewListScreen(cmd.DefaultTheme(), storage.NewMemoryItemRepository(titledItems("water the plants", "pay the rent", "call mum")))
+	m.SetSize(width, 24)
+	return m
+}
+
+func TestFlowHelpColumns(t *testing.T) {
+	for _, width := range []int{60, 80, 120} {
+		t.Run(fmt.Sprint(width), func(t *testing.T) {
+			m := helpList(t, width)
+			var groups [][]key.Binding
+			for _, group := range m.FullHelp() {
+				var enabled []key.Binding
+				for _, b := range group {
+					if b.Enabled() {
+						enabled = append(enabled, b)
+					}
+				}
+				if len(enabled) > 0 {
+					groups = append(groups, enabled)
+				}
+			}
+
+			rows := m.flowHelpColumns(m.helpColumns(groups, minHelpColumn), width)
+			for i, row := range rows {
+				if w := lipgloss.Width(row); w > width {
+					t.Errorf("row %d is %d wide", i, w)
+				}
+			}
+			golden(t, fmt.Sprintf("flowHelpColumns-%d", width), strings.Join(rows, "\n\n"))
+		})
+	}
+
+	// A column wider than the rows still gets one of its own.
+	m := helpList(t, 80)
+	columns := []string{"a\nb", strings.Repeat("wide ", 20), "c"}
+	if rows := m.flowHelpColumns(columns, 40); len(rows) != 3 {
+		t.Errorf("flowHelpColumns() put the columns on %d rows, want 3", len(rows))
+	}
+	if rows := m.flowHelpColumns(columns[:1], 0); len(rows) != 1 {
+		t.Errorf("flowHelpColumns() without a width gave %d rows", len(rows))
+	}
+}
+
+func TestHelpView(t *testing.T) {
+	for _, width := range []int{40, 120} {
+		for _, full := range []bool{false, true} {
+			density := "short"
+			if full {
+				density = "full"
+			}
+			t.Run(fmt.Sprintf("%s %d", density, width), func(t *testing.T) {
+				m := helpList(t, width)
+				m.SetShowFullHelp(full)
+				view := m.helpView()
+				for i, line := range strings.Split(view, "\n") {
+					if w := lipgloss.Width(line); w > width {
+						t.Errorf("line %d is %d wide", i, w)
+					}
+				}
+				golden(t, fmt.Sprintf("help-%s-%d", density, width), view)
+			})
+		}
+	}
+}
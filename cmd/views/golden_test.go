@@ -0,0 +1,42 @@
+package views
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// golden compares view, without its styling and the spaces lines are padded
+// with, to testdata/name.golden. With -update it writes the file instead.
+func golden(t *testing.T, name, view string) {
+	t.Helper()
+	lines := strings.Split(strings.TrimRight(ansi.Strip(view), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	got := strings.Join(lines, "\n") + "\n"
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll("testdata", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v; run go test -update to write it", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s has changed, run go test -update if that's intended; got\n%s\nwant\n%s", path, got, want)
+	}
+}
@@ -0,0 +1,200 @@
+package views
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"clitodo/pkg/clock"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/storage"
+)
+
+// cmdWait is how long the harness waits for a command's message. Commands
+// still running by then are timers, such as the cursor's blink or a status
+// message's timeout, and their messages are dropped.
+const cmdWait = 50 * time.Millisecond
+
+// harness drives a MainView the way a tea.Program does, without a terminal:
+// it sends messages to Update, runs the commands that come back and sends
+// their messages too, until there are none left.
+type harness struct {
+	t     testing.TB
+	model tea.Model
+	repo  storage.MemoryItemStorage
+	quit  bool
+}
+
+// newHarness starts a MainView on a storage in memory holding items, in a
+// terminal of 80 by 24, and waits for the items to load.
+func newHarness(t testing.TB, items []domain.Item) *harness {
+	t.Helper()
+	return newHarnessWith(t, items, nil)
+}
+
+// newHarnessWith is newHarness with the options changed by configure first.
+func newHarnessWith(t testing.TB, items []domain.Item, configure func(*Options)) *harness {
+	t.Helper()
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	h := startHarness(t, storage.NewMemoryItemRepository(items), configure)
+	h.process(h.run(h.model.Init()))
+	if h.list().loading {
+		t.Fatal("items didn't load")
+	}
+	return h
+}
+
+// startHarness makes the MainView newHarnessWith starts on repo, without
+// starting it.
+func startHarness(t testing.TB, repo storage.MemoryItemStorage, configure func(*Options)) *harness {
+	h := &harness{t: t, repo: repo}
+	options := Options{
+		Storage: h.repo,
+		Clock:   clock.Fixed(time.Date(2026, time.March, 10, 9, 30, 0, 0, time.Local)),
+		Width:   80,
+		Height:  24,
+	}
+	if configure != nil {
+		configure(&options)
+	}
+	h.model = NewMainView(options)
+	return h
+}
+
+// list returns the list screen.
+func (h *harness) list() *ListScreen {
+	h.t.Helper()
+	main, ok := h.model.(MainView)
+	if !ok {
+		h.t.Fatalf("model is a %T, not a MainView", h.model)
+	}
+	return main.view1.(*ListScreen)
+}
+
+// current returns the view shown.
+func (h *harness) current() ViewID {
+	return h.model.(MainView).currentView
+}
+
+// send sends msgs one after the other, each with everything it sets off.
+func (h *harness) send(msgs ...tea.Msg) {
+	for _, msg := range msgs {
+		h.process([]tea.Msg{msg})
+	}
+}
+
+// press presses keys, named as key.WithKeys names them.
+func (h *harness) press(keys ...string) {
+	h.t.Helper()
+	for _, k := range keys {
+		h.send(keyMsg(h.t, k))
+	}
+}
+
+// typeText types s a rune at a time.
+func (h *harness) typeText(s string) {
+	for _, r := range s {
+		h.send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+// view renders what the terminal shows.
+func (h *harness) view() string {
+	return h.model.View()
+}
+
+// process sends the queued messages to Update in turn, and the ones their
+// commands give after them, until the queue is empty or the program quit.
+func (h *harness) process(queue []tea.Msg) {
+	for len(queue) > 0 && !h.quit {
+		msg := queue[0]
+		queue = queue[1:]
+		var cmd tea.Cmd
+		h.model, cmd = h.model.Update(msg)
+		queue = append(queue, h.run(cmd)...)
+	}
+}
+
+// run runs cmds side by side and returns the messages they give within
+// cmdWait, in the order of cmds. Batches and sequences are run the same way,
+// and tea.Quit marks the program as quit instead of giving a message.
+func (h *harness) run(cmds ...tea.Cmd) []tea.Msg {
+	results := make([]chan tea.Msg, len(cmds))
+	for i, cmd := range cmds {
+		if cmd == nil {
+			continue
+		}
+		results[i] = make(chan tea.Msg, 1)
+		go func(cmd tea.Cmd, result chan<- tea.Msg) { result <- cmd() }(cmd, results[i])
+	}
+
+	var msgs []tea.Msg
+	deadline := time.After(cmdWait)
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		var msg tea.Msg
+		select {
+		case msg = <-result:
+		case <-deadline:
+			continue
+		}
+		switch msg := msg.(type) {
+		case nil:
+		case tea.QuitMsg:
+			h.quit = true
+		case tea.BatchMsg:
+			msgs = append(msgs, h.run(msg...)...)
+		default:
+			if sequence, ok := sequenceCmds(msg); ok {
+				for _, cmd := range sequence {
+					msgs = append(msgs, h.run(cmd)...)
+				}
+				break
+			}
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs
+}
+
+// sequenceCmds returns the commands of what tea.Sequence gives, whose type
+// Bubble Tea doesn't export.
+func sequenceCmds(msg tea.Msg) ([]tea.Cmd, bool) {
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Slice || v.Type().Elem() != reflect.TypeOf(tea.Cmd(nil)) {
+		return nil, false
+	}
+	cmds := make([]tea.Cmd, v.Len())
+	for i := range cmds {
+		cmds[i], _ = v.Index(i).Interface().(tea.Cmd)
+	}
+	return cmds, true
+}
+
+// keyMsg returns the message a terminal sends for the key named k.
+func keyMsg(t testing.TB, k string) tea.KeyMsg {
+	t.Helper()
+	named := map[string]tea.KeyType{
+		"enter":     tea.KeyEnter,
+		"esc":       tea.KeyEscape,
+		"backspace": tea.KeyBackspace,
+		"up":        tea.KeyUp,
+		"down":      tea.KeyDown,
+		"ctrl+a":    tea.KeyCtrlA,
+		"ctrl+c":    tea.KeyCtrlC,
+		"ctrl+d":    tea.KeyCtrlD,
+		"ctrl+r":    tea.KeyCtrlR,
+	}
+	if typ, ok := named[k]; ok {
+		return tea.KeyMsg{Type: typ}
+	}
+	if runes := []rune(k); len(runes) == 1 {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: runes}
+	}
+	t.Fatalf("no key named %q", k)
+	return tea.KeyMsg{}
+}
@@ -0,0 +1,100 @@
+package views
+
+import "clitodo/pkg/state"
+
+// SetShowCompleted sets whether completed items are listed. Hiding them hides
+// their subtasks too and composes with the filter: the filter only matches
+// the items that are shown.
+func (m *ListScreen) SetShowCompleted(v bool) {
+	m.showCompleted = v
+	m.refreshRows()
+}
+
+// refreshRows updates the rows after items were shown, hidden or removed,
+// filtering again and keeping the cursor on the list.
+func (m *ListScreen) refreshRows() {
+	if m.filterState != Unfiltered {
+		matches, _ := filterItems(*m)().(FilterMatchesMsg)
+		m.filteredItems = filteredItems(matches)
+	}
+	m.updatePagination()
+	m.updateKeybindings()
+	if n := len(m.VisibleItems()); m.Index() >= n {
+		m.Select(max(0, n-1))
+	}
+}
+
+// ShowCompleted returns whether completed items are listed.
+func (m ListScreen) ShowCompleted() bool {
+	return m.showCompleted
+}
+
+// toggleShowCompleted shows or hides completed items and remembers that for
+// the next start.
+func (m *ListScreen) toggleShowCompleted() {
+	m.SetShowCompleted(!m.showCompleted)
+	if st, err := state.Load(); err == nil {
+		st.HideCompleted = !m.showCompleted
+		st.Save()
+	}
+}
+
+// hiddenItems reports for each item of the unfiltered list whether it's
+// hidden because it, or a task it's a subtask of, is completed or put aside
+// for some day and those aren't shown.
+func (m ListScreen) hiddenItems() []bool {
+	hidden := make([]bool, len(m.items))
+	if m.showCompleted && m.showSomeday {
+		return hidden
+	}
+	for i := 0; i < len(m.items); i++ {
+		item := m.items[i]
+		if (m.showCompleted || !item.Completed()) && (m.showSomeday || !item.Someday) {
+			continue
+		}
+		end := m.subtreeEnd(i)
+		for j := i; j < end; j++ {
+			hidden[j] = true
+		}
+		i = end - 1
+	}
+	return hidden
+}
+
+// hiddenCount returns how many items are hidden because they're completed,
+// or left out of the agenda because they're done and past their date. The
+// status bar counts the someday ones separately.
+func (m ListScreen) hiddenCount() int {
+	n := 0
+	leftOut := m.agendaLeftOut()
+	for i, h := range m.hiddenItems() {
+		if (h || leftOut[i]) && !m.items[i].Someday {
+			n++
+		}
+	}
+	return n
+}
+
+// projected reports whether the rows of the unfiltered list differ from the
+// stored items, because items are hidden, the list is sorted or grouped by
+// due date or there's a someday section.
+func (m ListScreen) projected() bool {
+	return !m.showCompleted || m.sortMode != SortManual || m.showAgenda || m.hasSomeday()
+}
+
+// rows returns the rows of the unfiltered list without the hidden items, in
+// the order of the sort mode and followed by the someday section, or as the
+// agenda. Collapsed sections only show their header.
+func (m ListScreen) rows() filteredItems {
+	if m.showAgenda {
+		return m.agendaRows()
+	}
+	hidden := m.hiddenItems()
+	rows := make(filteredItems, 0, len(m.items))
+	for i, item := range m.items {
+		if !hidden[i] {
+			rows = append(rows, filteredItem{item: item, index: i})
+		}
+	}
+	return m.collapse(m.withSomeday(m.sortRows(rows)))
+}
@@ -0,0 +1,182 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"clitodo/cmd"
+	"clitodo/pkg/domain"
+)
+
+// importStaged is what an import read from its source: the items it would add
+// and the ones it skips because the list already has a task with that title.
+type importStaged struct {
+	added      []domain.Item
+	duplicates []domain.Item
+
+	// Records read, including ones that weren't items at all.
+	parsed int
+}
+
+// importPreviewMsg opens the preview for a finished import.
+type importPreviewMsg struct {
+	staged importStaged
+}
+
+// importPreviewDoneMsg carries the items accepted in the preview.
+type importPreviewDoneMsg struct {
+	accepted  []domain.Item
+	parsed    int
+	cancelled bool
+}
+
+type previewRow struct {
+	item      domain.Item
+	duplicate bool
+	include   bool
+}
+
+// importPreview lists what an import is about to do before anything is
+// stored. New items are included and duplicates skipped by default; each row
+// can be toggled. Only confirming adds anything to the list.
+type importPreview struct {
+	rows   []previewRow
+	parsed int
+	cursor int
+	offset int
+
+	width, height int
+
+	KeyMap cmd.KeyMap
+	help   help.Model
+	styles cmd.Styles
+}
+
+func newImportPreview(staged importStaged, width, height int, styles cmd.Styles) importPreview {
+	m := importPreview{
+		parsed: staged.parsed,
+		width:  width,
+		height: height,
+		KeyMap: cmd.DefaultKeyMap(),
+		help:   help.New(),
+		styles: styles,
+	}
+	for _, item := range staged.added {
+		m.rows = append(m.rows, previewRow{item: item, include: true})
+	}
+	for _, item := range staged.duplicates {
+		m.rows = append(m.rows, previewRow{item: item, duplicate: true})
+	}
+	return m
+}
+
+// accepted returns the items of the included rows.
+func (m importPreview) accepted() []domain.Item {
+	var items []domain.Item
+	for _, row := range m.rows {
+		if row.include {
+			items = append(items, row.item)
+		}
+	}
+	return items
+}
+
+func (m importPreview) Init() tea.Cmd {
+	return nil
+}
+
+func (m importPreview) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, m.KeyMap.CancelPreview):
+			return m, func() tea.Msg { return importPreviewDoneMsg{cancelled: true} }
+		case key.Matches(msg, m.KeyMap.AcceptImport):
+			done := importPreviewDoneMsg{accepted: m.accepted(), parsed: m.parsed}
+			return m, func() tea.Msg { return done }
+		case key.Matches(msg, m.KeyMap.ToggleImportRow):
+			if m.cursor < len(m.rows) {
+				m.rows[m.cursor].include = !m.rows[m.cursor].include
+			}
+		case key.Matches(msg, m.KeyMap.CursorUp):
+			m.cursor = max(0, m.cursor-1)
+		case key.Matches(msg, m.KeyMap.CursorDown):
+			m.cursor = max(0, min(len(m.rows)-1, m.cursor+1))
+		}
+	}
+
+	// Keep the cursor in the visible window of rows.
+	visible := m.visibleRows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	} else if m.cursor >= m.offset+visible {
+		m.offset = m.cursor - visible + 1
+	}
+	return m, nil
+}
+
+// The lines around the rows: margins, heading, summary and help.
+const previewChrome = 8
+
+func (m importPreview) visibleRows() int {
+	return max(1, m.height-previewChrome)
+}
+
+func (m importPreview) View() string {
+	added, duplicates, included := 0, 0, 0
+	for _, row := range m.rows {
+		if row.duplicate {
+			duplicates++
+		} else {
+			added++
+		}
+		if row.include {
+			included++
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render("Import preview"))
+	fmt.Fprintf(&b, "  %d new · %d already in the list · %d selected\n\n", added, duplicates, included)
+
+	if len(m.rows) == 0 {
+		b.WriteString(m.styles.NoItems.Render("Nothing to import.") + "\n")
+	}
+	width := m.width - 4 - 6 //nolint:mnd // margins and the "[x] + " prefix
+	end := min(len(m.rows), m.offset+m.visibleRows())
+	for i := m.offset; i < end; i++ {
+		row := m.rows[i]
+		check := "[ ]"
+		if row.include {
+			check = "[x]"
+		}
+		line := "+ " + row.item.Title()
+		style := m.styles.ImportAdded
+		if row.duplicate {
+			line = "= " + row.item.Title() + " (already in the list)"
+			style = m.styles.ImportDuplicate
+		}
+		line = check + " " + style.Render(ansi.Truncate(line, max(1, width), cmd.Ellipsis))
+		if i == m.cursor {
+			line = lipgloss.NewStyle().Reverse(true).Render(check) + strings.TrimPrefix(line, check)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString(m.styles.HelpStyle.Render(m.help.ShortHelpView([]key.Binding{
+		m.KeyMap.CursorUp,
+		m.KeyMap.CursorDown,
+		m.KeyMap.ToggleImportRow,
+		m.KeyMap.AcceptImport,
+		m.KeyMap.CancelPreview,
+	})))
+	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
+}
@@ -0,0 +1,162 @@
+package views
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"clitodo/pkg/domain"
+	"clitodo/pkg/importer"
+	"clitodo/pkg/notify"
+)
+
+// Number of records read per step of the import pipeline. Each step is a
+// separate command, so the UI stays responsive between batches.
+const importBatchSize = 200
+
+// importJob holds the state of a running import. Items are collected in a
+// staging slice and only added to the list once the whole source was read and
+// the preview was confirmed.
+type importJob struct {
+	source     *importer.Source
+	seen       map[string]bool
+	staged     []domain.Item
+	duplicates []domain.Item
+}
+
+type importProgressMsg struct {
+	job        *importJob
+	items      []domain.Item
+	duplicates []domain.Item
+	progress   importer.Progress
+	fraction   float64
+	done       bool
+	err        error
+}
+
+// StartImport opens the file at path and imports its items incrementally,
+// reporting progress in the status bar. Note that this returns a command.
+func (m *ListScreen) StartImport(path string) tea.Cmd {
+	if m.importJob != nil {
+		return m.NewStatusMessage("An import is already running")
+	}
+
+	src, err := importer.Open(path)
+	if err != nil {
+		return m.NewStatusMessage("Import failed: " + err.Error())
+	}
+
+	seen := make(map[string]bool, len(m.items))
+	for _, item := range m.items {
+		seen[item.Title()] = true
+	}
+
+	m.importJob = &importJob{source: src, seen: seen}
+	m.importProgress = importer.Progress{}
+	m.importFraction = 0
+	m.updateKeybindings()
+	return m.importJob.step(m.importProgress)
+}
+
+// CancelImport stops the running import, if any. Staged items are discarded so
+// the list stays in its pre-import state.
+func (m *ListScreen) CancelImport() {
+	if m.importJob == nil {
+		return
+	}
+	m.importJob.source.Close()
+	m.importJob = nil
+	m.updateKeybindings()
+}
+
+// Importing returns whether an import is currently running.
+func (m ListScreen) Importing() bool {
+	return m.importJob != nil
+}
+
+func (j *importJob) step(p importer.Progress) tea.Cmd {
+	return func() tea.Msg {
+		items, duplicates, done, err := importer.Batch(j.source, importBatchSize, j.seen, &p)
+		return importProgressMsg{
+			job:        j,
+			items:      items,
+			duplicates: duplicates,
+			progress:   p,
+			fraction:   j.source.Fraction(),
+			done:       done,
+			err:        err,
+		}
+	}
+}
+
+func (m *ListScreen) handleImportProgress(msg importProgressMsg) tea.Cmd {
+	// Messages from a cancelled job may still arrive; drop them.
+	if msg.job != m.importJob {
+		return nil
+	}
+
+	if msg.err != nil {
+		m.CancelImport()
+		return m.NewStatusMessage(fmt.Sprintf("Import failed after %d records: %v", msg.progress.Parsed, msg.err))
+	}
+
+	now := m.Clock.Now()
+	for i, item := range msg.items {
+		msg.items[i], _ = m.DefaultRules.Apply(item, m.Workspace, domain.GivenIn(item), now)
+	}
+	m.importJob.staged = append(m.importJob.staged, msg.items...)
+	m.importJob.duplicates = append(m.importJob.duplicates, msg.duplicates...)
+	m.importProgress = msg.progress
+	m.importFraction = msg.fraction
+
+	if !msg.done {
+		return m.importJob.step(msg.progress)
+	}
+
+	staged := importStaged{
+		added:      m.importJob.staged,
+		duplicates: m.importJob.duplicates,
+		parsed:     msg.progress.Parsed,
+	}
+	m.CancelImport()
+	return func() tea.Msg { return importPreviewMsg{staged} }
+}
+
+// finishImport adds the items accepted in the import preview to the list and
+// saves. Nothing is stored if the preview was cancelled.
+func (m *ListScreen) finishImport(msg importPreviewDoneMsg) tea.Cmd {
+	if msg.cancelled {
+		return m.NewStatusMessage("Import cancelled")
+	}
+	add := func(items []domain.Item) []domain.Item { return append(items, msg.accepted...) }
+	return m.guardWIP(add, func() tea.Cmd { return m.addImported(msg) })
+}
+
+// addImported adds the accepted items of an import to the list and saves.
+func (m *ListScreen) addImported(msg importPreviewDoneMsg) tea.Cmd {
+	var cmds []tea.Cmd
+	cmds = append(cmds, m.SetItems(append(m.items, msg.accepted...)))
+
+	if err := m.saveItems(); err != nil {
+		cmds = append(cmds, m.NewStatusMessage("Import failed: "+err.Error()))
+		return tea.Batch(cmds...)
+	}
+
+	summary := fmt.Sprintf("Imported %d items (%d skipped)", len(msg.accepted), msg.parsed-len(msg.accepted))
+	cmds = append(cmds,
+		m.NewStatusMessage(summary),
+		m.notify(notify.Notification{Title: "Import finished", Body: summary}),
+	)
+	return tea.Batch(cmds...)
+}
+
+func (m ListScreen) importView() string {
+	bar := progress.New(progress.WithDefaultGradient(), progress.WithWidth(20), progress.WithoutPercentage()) //nolint:mnd
+	return fmt.Sprintf("%s  Importing… %d parsed · %d created · %d skipped",
+		bar.ViewAs(m.importFraction),
+		m.importProgress.Parsed,
+		m.importProgress.Created,
+		m.importProgress.Skipped,
+	)
+}
@@ -0,0 +1,281 @@
+package views
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"clitodo/cmd"
+	"clitodo/pkg/config"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/hooks"
+	"clitodo/pkg/storage"
+)
+
+// titledItems returns new items with the given titles.
+func titledItems(titles ...string) []domain.Item {
+	items := make([]domain.Item, len(titles))
+	for i, title := range titles {
+		items[i] = domain.NewItem(title)
+	}
+	return items
+}
+
+// storedItem returns the stored item titled title.
+func (h *harness) storedItem(title string) (domain.Item, bool) {
+	h.t.Helper()
+	items, err := h.repo.GetItems()
+	if err != nil {
+		h.t.Fatal(err)
+	}
+	i := slices.IndexFunc(items, func(item domain.Item) bool { return item.Title() == title })
+	if i < 0 {
+		return domain.Item{}, false
+	}
+	return items[i], true
+}
+
+func TestJourneyAddCompleteDeleteUndo(t *testing.T) {
+	h := newHarness(t, titledItems("water the plants", "pay the rent"))
+
+	h.press("ctrl+a")
+	if h.current() != View2Const {
+		t.Fatal("ctrl+a didn't open the add screen")
+	}
+	h.typeText("buy milk")
+	h.press("enter")
+	if h.current() != View1Const {
+		t.Fatal("adding didn't go back to the list")
+	}
+	if _, ok := h.storedItem("buy milk"); !ok {
+		t.Fatalf("the new task wasn't stored: %q", stored(t, h.repo))
+	}
+	if !strings.Contains(h.view(), "buy milk") {
+		t.Errorf("the new task isn't shown:\n%s", h.view())
+	}
+
+	h.list().Select(slices.Index(visibleTitles(h.list()), "buy milk"))
+	h.press("enter")
+	if item, _ := h.storedItem("buy milk"); !item.Completed() {
+		t.Error("enter didn't complete the task")
+	}
+
+	h.press("ctrl+d")
+	if _, ok := h.storedItem("buy milk"); ok {
+		t.Errorf("ctrl+d didn't delete the task: %q", stored(t, h.repo))
+	}
+
+	// Each undo takes back one step, newest first.
+	h.press("u")
+	if item, ok := h.storedItem("buy milk"); !ok || !item.Completed() {
+		t.Errorf("undoing the delete didn't bring the completed task back: %q", stored(t, h.repo))
+	}
+	if selectedTitle(h.list()) != "buy milk" {
+		t.Errorf("after undo %q is selected, want buy milk", selectedTitle(h.list()))
+	}
+	h.press("u")
+	if item, _ := h.storedItem("buy milk"); item.Completed() {
+		t.Error("undoing the completion left the task done")
+	}
+	h.press("u")
+	if got := stored(t, h.repo); !slices.Equal(got, []string{"water the plants", "pay the rent"}) {
+		t.Errorf("undoing the add left %q stored", got)
+	}
+	if got := visibleTitles(h.list()); slices.Contains(got, "buy milk") {
+		t.Errorf("the undone task is still shown: %q", got)
+	}
+}
+
+func TestJourneyFilterToggleClear(t *testing.T) {
+	h := newHarness(t, titledItems("pay bill 1", "task 2", "pay bill 3", "task 4", "pay bill 5"))
+
+	h.press("/")
+	h.typeText("bill")
+	h.press("enter")
+	if h.list().FilterState() != FilterApplied {
+		t.Fatalf("filter %s, want it applied", h.list().FilterState())
+	}
+	if got := visibleTitles(h.list()); len(got) != 3 {
+		t.Fatalf("filter shows %q, want the three bills", got)
+	}
+
+	h.press("down", "enter")
+	if item, _ := h.storedItem("pay bill 3"); !item.Completed() {
+		t.Errorf("enter didn't complete pay bill 3, %q is selected", selectedTitle(h.list()))
+	}
+	if h.list().FilterState() != FilterApplied {
+		t.Errorf("completing a task left the filter %s", h.list().FilterState())
+	}
+
+	h.press("esc")
+	if h.list().FilterState() != Unfiltered {
+		t.Errorf("esc left the filter %s", h.list().FilterState())
+	}
+	if got := visibleTitles(h.list()); len(got) != 5 {
+		t.Errorf("after clearing the filter the list shows %q", got)
+	}
+	if item, _ := h.storedItem("pay bill 3"); !item.Completed() {
+		t.Error("clearing the filter reopened pay bill 3")
+	}
+	if h.quit {
+		t.Error("esc clearing the filter quit as well")
+	}
+}
+
+func TestJourneyResizeStorm(t *testing.T) {
+	titles := make([]string, 40)
+	for i := range titles {
+		titles[i] = strings.Repeat("a long task title ", i%4+1) + string(rune('A'+i%26))
+	}
+	h := newHarness(t, titledItems(titles...))
+	h.list().Select(25)
+
+	sizes := []tea.WindowSizeMsg{
+		{Width: 0, Height: 0}, {Width: 1, Height: 1}, {Width: 300, Height: 100},
+		{Width: 20, Height: 5}, {Width: 79, Height: 23}, {Width: 2, Height: 40},
+		{Width: 120, Height: 3}, {Width: 45, Height: 17}, {Width: 0, Height: 24},
+	}
+	for round := 0; round < 3; round++ {
+		for _, size := range sizes {
+			h.send(size)
+			view := h.view()
+			if size.Width < 20 || size.Height < 5 {
+				continue
+			}
+			for n, line := range strings.Split(view, "\n") {
+				if w := ansi.StringWidth(line); w > size.Width {
+					t.Errorf("at %dx%d line %d is %d wide: %q", size.Width, size.Height, n+1, w, line)
+				}
+			}
+		}
+	}
+
+	h.send(tea.WindowSizeMsg{Width: 80, Height: 24})
+	if h.list().Index() != 25 {
+		t.Errorf("after the resizes row %d is selected, want 25", h.list().Index())
+	}
+	if page := h.list().Paginator.Page; page >= h.list().Paginator.TotalPages || !strings.Contains(h.view(), titles[25]) {
+		t.Errorf("page %d of %d is shown, not the one with the selected task", page, h.list().Paginator.TotalPages)
+	}
+
+	// Back at the size it started at, it looks as if nothing happened.
+	calm := newHarness(t, titledItems(titles...))
+	calm.list().Select(25)
+	if h.view() != calm.view() {
+		t.Errorf("after the resizes the list shows\n%s\nwant\n%s", h.view(), calm.view())
+	}
+}
+
+func TestJourneyQuitWithUnsaved(t *testing.T) {
+	h := newHarness(t, titledItems("water the plants", "pay the rent"))
+	h.press("enter")
+
+	// q while filtering is part of the filter, not a way out.
+	h.press("/")
+	h.typeText("q")
+	if h.quit || h.list().FilterValue() != "q" {
+		t.Fatalf("q while filtering quit or wasn't typed, filter %q", h.list().FilterValue())
+	}
+	h.press("esc")
+	if h.quit {
+		t.Fatal("esc leaving the filter quit as well")
+	}
+
+	// A task typed but not added is dropped when quitting.
+	h.press("ctrl+a")
+	h.typeText("buy milk")
+	h.press("ctrl+c")
+	if !h.quit {
+		t.Fatal("ctrl+c on the add screen didn't quit")
+	}
+	h.model.(MainView).Close()
+
+	if _, ok := h.storedItem("buy milk"); ok {
+		t.Error("the task that wasn't added was stored")
+	}
+	if item, _ := h.storedItem("water the plants"); !item.Completed() {
+		t.Error("the change made before quitting wasn't stored")
+	}
+	if got := stored(t, h.repo); len(got) != 2 {
+		t.Errorf("the storage holds %q after quitting", got)
+	}
+}
+
+// recordingExecutor records the hook commands it's asked to run instead of
+// running them.
+type recordingExecutor struct {
+	mu  sync.Mutex
+	ran []string
+}
+
+func (e *recordingExecutor) Execute(_ context.Context, command string, _ []byte) (int, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.ran = append(e.ran, command)
+	return 0, nil
+}
+
+func TestJourneyReadOnly(t *testing.T) {
+	executor := &recordingExecutor{}
+	h := newHarnessWith(t, titledItems("water the plants", "pay the rent"), func(o *Options) {
+		// As when the list is open in another clitodo.
+		o.Storage = storage.ReadOnly(o.Storage)
+		o.Hooks = hooks.New(config.Hooks{Enabled: true, OnAdd: "added", OnComplete: "completed", OnDelete: "deleted"}, executor)
+	})
+
+	for _, action := range []string{"delete_item", "raise_prio", "edit_item", "add_subtask", "someday", "clear_done"} {
+		if b, _ := h.list().KeyMap.Binding(action); b.Enabled() {
+			t.Errorf("%s is enabled on a read-only list", action)
+		}
+	}
+
+	h.press("enter")
+	if !strings.Contains(h.list().statusMessage, "read-only") {
+		t.Errorf("completing said %q, want it to say the list is read-only", h.list().statusMessage)
+	}
+	h.press("ctrl+d")
+	h.press("ctrl+a")
+	if h.current() != View1Const {
+		t.Error("ctrl+a opened the add screen on a read-only list")
+	}
+	h.send(cmd.TaskAdded{IsSucces: true, Item: domain.NewItem("buy milk")})
+
+	if got := visibleTitles(h.list()); !slices.Equal(got, []string{"water the plants", "pay the rent"}) {
+		t.Errorf("the list shows %q, want it unchanged", got)
+	}
+	if h.list().Items()[0].Completed() {
+		t.Error("the plants were checked off in the list")
+	}
+	if item, _ := h.storedItem("water the plants"); item.Completed() {
+		t.Error("the plants were checked off in the storage")
+	}
+	if len(executor.ran) != 0 {
+		t.Errorf("hooks %q ran for changes that weren't made", executor.ran)
+	}
+}
+
+func TestJourneySafeMode(t *testing.T) {
+	h := newHarnessWith(t, titledItems("water the plants", "pay the rent"), func(o *Options) {
+		o.SafeMode = true
+	})
+	if !strings.Contains(h.view(), "safe mode · read-only") {
+		t.Errorf("the status bar doesn't say the list is read-only:\n%s", h.view())
+	}
+
+	h.press("enter", "ctrl+d", "u")
+	h.send(cmd.TaskAdded{IsSucces: true, Item: domain.NewItem("buy milk")})
+	if got := visibleTitles(h.list()); !slices.Equal(got, []string{"water the plants", "pay the rent"}) || h.list().Items()[0].Completed() {
+		t.Errorf("in safe mode the list was changed to %q", got)
+	}
+	if got := stored(t, h.repo); !slices.Equal(got, []string{"water the plants", "pay the rent"}) {
+		t.Errorf("in safe mode the storage was changed to %q", got)
+	}
+	if item, _ := h.storedItem("water the plants"); item.Completed() {
+		t.Error("in safe mode the plants were checked off in the storage")
+	}
+}
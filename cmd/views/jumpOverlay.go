@@ -0,0 +1,194 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"clitodo/cmd"
+	"clitodo/pkg/domain"
+)
+
+// jumpOverlay is the transient "go to task" prompt. Unlike filtering it doesn't
+// narrow the list: it only moves the selection to the chosen item.
+type jumpOverlay struct {
+	input   textinput.Model
+	matches []Rank // ranks over the unfiltered items
+	cursor  int
+}
+
+// OpenJump opens the jump prompt. Note that this returns a command.
+func (m *ListScreen) OpenJump() tea.Cmd {
+	input := textinput.New()
+	input.Prompt = "Jump to: "
+	input.PromptStyle = m.Styles.FilterPrompt
+	input.Cursor.Style = m.Styles.FilterCursor
+	input.CharLimit = 64
+	input.Width = m.FilterInput.Width
+	input.Focus()
+
+	m.hideStatusMessage()
+	m.jump = &jumpOverlay{input: input}
+	m.jump.match(m.items, m.filterTarget, m.Filter)
+	m.updateKeybindings()
+	return textinput.Blink
+}
+
+// CloseJump closes the jump prompt without moving the selection.
+func (m *ListScreen) CloseJump() {
+	m.jump = nil
+	m.updateKeybindings()
+}
+
+// Jumping returns whether the jump prompt is open.
+func (m ListScreen) Jumping() bool {
+	return m.jump != nil
+}
+
+func (j *jumpOverlay) match(items []domain.Item, target func(domain.Item) string, filter FilterFunc) {
+	targets := make([]string, len(items))
+	for i, item := range items {
+		targets[i] = target(item)
+	}
+
+	if j.input.Value() == "" {
+		j.matches = make([]Rank, len(items))
+		for i := range items {
+			j.matches[i] = Rank{Index: i}
+		}
+	} else {
+		j.matches = filter(j.input.Value(), targets)
+	}
+	j.cursor = min(j.cursor, max(0, len(j.matches)-1))
+}
+
+// Updates for when the jump prompt is open. The list's own keybindings are
+// disabled meanwhile, see updateKeybindings.
+func (m *ListScreen) handleJumping(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.KeyMap.CancelWhileJumping):
+			m.CloseJump()
+			return nil
+
+		case key.Matches(msg, m.KeyMap.AcceptWhileJumping):
+			if len(m.jump.matches) == 0 {
+				return nil
+			}
+			return m.jumpTo(m.jump.matches[m.jump.cursor].Index)
+
+		case key.Matches(msg, m.KeyMap.JumpUp):
+			m.jump.cursor = max(0, m.jump.cursor-1)
+			return nil
+
+		case key.Matches(msg, m.KeyMap.JumpDown):
+			m.jump.cursor = min(len(m.jump.matches)-1, m.jump.cursor+1)
+			return nil
+		}
+	}
+
+	var cmd tea.Cmd
+	before := m.jump.input.Value()
+	m.jump.input, cmd = m.jump.input.Update(msg)
+	if m.jump.input.Value() != before {
+		m.jump.cursor = 0
+		m.jump.match(m.items, m.filterTarget, m.Filter)
+	}
+	return cmd
+}
+
+// jumpTo closes the prompt and selects the item at the given index of the
+// unfiltered list. An active filter is left alone, so items it hides can't be
+// selected.
+func (m *ListScreen) jumpTo(index int) tea.Cmd {
+	m.CloseJump()
+
+	if m.items[index].Someday && !m.showSomeday {
+		m.SetShowSomeday(true)
+	}
+	if m.showAgenda {
+		root := index
+		for root > 0 && m.items[root].Depth > 0 {
+			root--
+		}
+		if agendaSection(m.items[root], m.Clock.Now()) < 0 {
+			// Tasks done and past their date aren't in the agenda.
+			m.SetShowAgenda(false)
+		}
+	}
+	m.revealSection(index)
+	if m.sectioned() {
+		for i, row := range m.arranged() {
+			if row.index == index {
+				m.Select(i)
+				return nil
+			}
+		}
+	}
+	if !m.showCompleted && m.hiddenItems()[index] {
+		return m.NewStatusMessage(fmt.Sprintf("“%s” is hidden with the completed tasks", m.items[index].Title()))
+	}
+	if m.filterState == Unfiltered && m.projected() {
+		for i, row := range m.rows() {
+			if row.index == index {
+				m.Select(i)
+				return nil
+			}
+		}
+	}
+	if m.filterState == Unfiltered {
+		m.Select(index)
+		return nil
+	}
+	for i, fi := range m.filteredItems {
+		if fi.index == index {
+			m.Select(i)
+			return nil
+		}
+	}
+	return m.NewStatusMessage(fmt.Sprintf("“%s” is hidden by the current filter", m.items[index].Title()))
+}
+
+func (m ListScreen) jumpView(height int) string {
+	var (
+		b strings.Builder
+		s = NewDefaultItemStyles()
+	)
+	if d, ok := m.delegate.(DefaultDelegate); ok {
+		s = d.Styles
+	}
+	width := m.width - s.NormalTitle.GetHorizontalFrameSize()
+
+	if len(m.jump.matches) == 0 {
+		return m.Styles.NoItems.Render("  Nothing matched")
+	}
+
+	// Keep the highlighted match on screen.
+	start := max(0, m.jump.cursor-height+1)
+	end := min(len(m.jump.matches), start+height)
+	for i, r := range m.jump.matches[start:end] {
+		title := ansi.Truncate(m.items[r.Index].Title(), width, cmd.Ellipsis)
+		if start+i == m.jump.cursor {
+			b.WriteString(s.SelectedTitle.Render(title))
+		} else {
+			b.WriteString(s.NormalTitle.Render(title))
+		}
+		if start+i != end-1 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+func (m ListScreen) jumpHelp() []key.Binding {
+	return []key.Binding{
+		m.KeyMap.JumpUp,
+		m.KeyMap.JumpDown,
+		m.KeyMap.AcceptWhileJumping,
+		m.KeyMap.CancelWhileJumping,
+	}
+}
@@ -0,0 +1,46 @@
+package views
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sequenceTimeout is how long the list waits for the next key of a sequence
+// like "g g" before it forgets the first one.
+const sequenceTimeout = time.Second
+
+// pendingKey is the first key of a sequence, pressed while browsing.
+type pendingKey struct {
+	key string
+}
+
+type sequenceTimeoutMsg struct {
+	pending *pendingKey
+}
+
+// sequence collects the keys of a sequence. It returns nil while waiting for
+// the rest of one, and once it's complete a key message that matches bindings
+// of the whole sequence, such as "g g". Any other key is returned as it is,
+// and drops the key that was waiting.
+func (m *ListScreen) sequence(msg tea.KeyMsg) (tea.Msg, tea.Cmd) {
+	sequences := m.KeyMap.Sequences()
+	if m.pending != nil {
+		keys := m.pending.key + " " + msg.String()
+		m.pending = nil
+		if slices.Contains(sequences, keys) {
+			return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(keys)}, nil
+		}
+	}
+
+	for _, s := range sequences {
+		if strings.Fields(s)[0] == msg.String() {
+			pending := &pendingKey{key: msg.String()}
+			m.pending = pending
+			return nil, tea.Tick(sequenceTimeout, func(time.Time) tea.Msg { return sequenceTimeoutMsg{pending} })
+		}
+	}
+	return msg, nil
+}
@@ -0,0 +1,154 @@
+package views
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"clitodo/cmd"
+	"clitodo/pkg/state"
+	"clitodo/pkg/storage"
+)
+
+// legacyDoneMsg carries what was decided about the storage.json in the
+// working directory. An empty decision asks again next time.
+type legacyDoneMsg struct {
+	decision state.LegacyDecision
+}
+
+// legacyPrompt asks once what to do with a storage.json left in the working
+// directory by older versions, which kept the tasks wherever clitodo was
+// started, while the configured storage is still empty.
+type legacyPrompt struct {
+	path   string
+	target string
+	count  int
+
+	KeyMap cmd.KeyMap
+	help   help.Model
+	styles cmd.Styles
+}
+
+func newLegacyPrompt(path, target string, styles cmd.Styles) legacyPrompt {
+	repository := storage.NewFileItemRepository(path)
+	items, _ := repository.GetItems()
+	return legacyPrompt{
+		path:   path,
+		target: target,
+		count:  len(items),
+		KeyMap: cmd.DefaultKeyMap(),
+		help:   help.New(),
+		styles: styles,
+	}
+}
+
+func (m legacyPrompt) Init() tea.Cmd {
+	return nil
+}
+
+func (m legacyPrompt) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	var decision state.LegacyDecision
+	switch {
+	case key.Matches(keyMsg, m.KeyMap.LegacyMigrate):
+		decision = state.LegacyMigrated
+	case key.Matches(keyMsg, m.KeyMap.LegacyKeep):
+		decision = state.LegacyKept
+	case key.Matches(keyMsg, m.KeyMap.LegacyIgnore):
+		decision = state.LegacyIgnored
+	case key.Matches(keyMsg, m.KeyMap.LegacyLater):
+	default:
+		return m, nil
+	}
+	return m, func() tea.Msg { return legacyDoneMsg{decision: decision} }
+}
+
+func (m legacyPrompt) View() string {
+	tasks := "tasks"
+	if m.count == 1 {
+		tasks = "task"
+	}
+
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render("Tasks found here"))
+	b.WriteString("\n\n")
+	fmt.Fprintf(&b, "  %s has %d %s from before the storage was configured.\n", m.path, m.count, tasks)
+	fmt.Fprintf(&b, "  The configured storage, %s, is still empty.\n\n", m.target)
+	fmt.Fprintf(&b, "  Moving them over keeps the file as %s.\n", filepath.Base(m.path)+storage.MigratedSuffix)
+	b.WriteString(m.styles.HelpStyle.Render(m.help.ShortHelpView([]key.Binding{
+		m.KeyMap.LegacyMigrate,
+		m.KeyMap.LegacyKeep,
+		m.KeyMap.LegacyIgnore,
+		m.KeyMap.LegacyLater,
+	})))
+	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
+}
+
+// applyLegacyDecision acts on the answer about the storage.json in the
+// working directory and remembers it for that directory: its tasks are moved
+// to the configured storage, or the list is opened from it instead, or it's
+// left alone.
+func (m MainView) applyLegacyDecision(decision state.LegacyDecision) (tea.Model, tea.Cmd) {
+	legacy := m.options.LegacyStorage
+	m.options.LegacyStorage = ""
+	list, ok := m.view1.(*ListScreen)
+	if !ok || decision == "" {
+		return m, nil
+	}
+
+	var status string
+	switch decision {
+	case state.LegacyMigrated:
+		target := storage.NewFileItemRepository(m.options.StoragePath)
+		unlock, err := target.Lock()
+		if err != nil {
+			return m, list.NewStatusMessage("Moving the tasks failed: " + err.Error())
+		}
+		n, err := storage.Migrate(legacy, m.options.StoragePath)
+		unlock()
+		if err != nil {
+			return m, list.NewStatusMessage("Moving the tasks failed: " + err.Error())
+		}
+		status = fmt.Sprintf("Moved %d tasks from %s", n, legacy)
+		if n == 1 {
+			status = "Moved 1 task from " + legacy
+		}
+	case state.LegacyKept:
+		m.options.StoragePath = legacy
+		status = "Using " + legacy + " in this directory"
+	}
+
+	if st, err := state.Load(); err == nil {
+		if st.LegacyStorage == nil {
+			st.LegacyStorage = map[string]state.LegacyDecision{}
+		}
+		st.LegacyStorage[filepath.Dir(legacy)] = decision
+		st.Save()
+	}
+	if decision == state.LegacyIgnored {
+		return m, nil
+	}
+	return m.reopenList(list, status)
+}
+
+// reopenList replaces the list with a fresh one for m.options.StoragePath,
+// keeping the terminal size, after the storage changed under it.
+func (m MainView) reopenList(old *ListScreen, status string) (tea.Model, tea.Cmd) {
+	old.releaseStorage()
+	list := newList(m.options)
+	list.tips = m.tips
+	list.loadNow()
+	h, v := docStyle.GetFrameSize()
+	list.Update(tea.WindowSizeMsg{Width: old.fullWidth + h, Height: old.fullHeight + v})
+	m.view1 = list
+	return m, list.NewStatusMessage(status)
+}
@@ -0,0 +1,57 @@
+package views
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"clitodo/pkg/domain"
+)
+
+// followLink selects the task a [[task:ID]] link in the notes points to.
+func (m *ListScreen) followLink(id string) tea.Cmd {
+	i := m.indexOfID(id)
+	if i < 0 {
+		return m.NewStatusMessage("The linked task no longer exists")
+	}
+	return m.jumpTo(i)
+}
+
+// offerLinkCleanup asks whether to remove the links to the tasks with the
+// given IDs, just removed, from the notes of the tasks still in the list.
+// what names the removed tasks in the question. Kept links show as missing.
+func (m *ListScreen) offerLinkCleanup(what string, ids map[string]bool) tea.Cmd {
+	var linking []string
+	for _, item := range m.items {
+		if domain.LinksTo(item.Notes, ids) {
+			linking = append(linking, item.ID)
+		}
+	}
+	if len(linking) == 0 {
+		return nil
+	}
+
+	tasks := "1 task links"
+	if len(linking) > 1 {
+		tasks = fmt.Sprintf("%d tasks link", len(linking))
+	}
+	m.confirm = &confirmation{
+		question: fmt.Sprintf("%s to %s. Remove the links?", tasks, what),
+		apply: func() tea.Cmd {
+			for _, id := range linking {
+				_, _, err := m.changeItem(id, func(item *domain.Item) {
+					for removedID := range ids {
+						item.Notes = domain.RemoveTaskLinks(item.Notes, removedID)
+					}
+				})
+				if err != nil {
+					return m.saveFailed(err)
+				}
+			}
+			return m.NewStatusMessage("Removed the links")
+		},
+		cancelled: "Kept the links",
+	}
+	m.updateKeybindings()
+	return nil
+}
@@ -4,16 +4,18 @@
 package views
 
 import (
+	"errors"
 	"fmt"
 	"io"
-	"sort"
+	"log"
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
-	"github.com/sahilm/fuzzy"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
@@ -22,15 +24,27 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 
 	"clitodo/cmd"
+	"clitodo/pkg/activity"
+	"clitodo/pkg/chime"
+	"clitodo/pkg/clock"
 	"clitodo/pkg/domain"
+	"clitodo/pkg/fold"
+	"clitodo/pkg/hooks"
+	"clitodo/pkg/importer"
+	"clitodo/pkg/match"
+	"clitodo/pkg/notify"
 	"clitodo/pkg/storage"
 )
 
 type ItemDelegate interface {
-	// Render renders the item's view.
+	// Render renders the item's view. It should write exactly Height() lines,
+	// or HeightFor's for a VariableHeightDelegate, with no newline after the
+	// last one, none of them wider than the list.
+	// The list cuts off extra lines and pads missing ones, so pagination
+	// holds up either way.
 	Render(w io.Writer, m ListScreen, index int, item domain.Item)
 
-	// Height is the height of the list item.
+	// Height is the height of the list item in lines.
 	Height() int
 
 	// Spacing is the size of the horizontal gap between list items in cells.
@@ -66,44 +80,10 @@ type FilterMatchesMsg []filteredItem
 // FilterFunc takes a term and a list of strings to search through
 // (defined by domain.Item#FilterValue).
 // It should return a sorted list of ranks.
-type FilterFunc func(string, []string) []Rank
+type FilterFunc = match.FilterFunc
 
 // Rank defines a rank for a given item.
-type Rank struct {
-	// The index of the item in the original input.
-	Index int
-	// Indices of the actual word that were matched against the filter term.
-	MatchedIndexes []int
-}
-
-// DefaultFilter uses the sahilm/fuzzy to filter through the list.
-// This is set by default.
-func DefaultFilter(term string, targets []string) []Rank {
-	ranks := fuzzy.Find(term, targets)
-	sort.Stable(ranks)
-	result := make([]Rank, len(ranks))
-	for i, r := range ranks {
-		result[i] = Rank{
-			Index:          r.Index,
-			MatchedIndexes: r.MatchedIndexes,
-		}
-	}
-	return result
-}
-
-// UnsortedFilter uses the sahilm/fuzzy to filter through the list. It does not
-// sort the results.
-func UnsortedFilter(term string, targets []string) []Rank {
-	ranks := fuzzy.FindNoSort(term, targets)
-	result := make([]Rank, len(ranks))
-	for i, r := range ranks {
-		result[i] = Rank{
-			Index:          r.Index,
-			MatchedIndexes: r.MatchedIndexes,
-		}
-	}
-	return result
-}
+type Rank = match.Rank
 
 type statusMessageTimeoutMsg struct{}
 
@@ -134,9 +114,21 @@ type ListScreen struct {
 	showFilter       bool
 	showStatusBar    bool
 	showPagination   bool
+	showPageSummary  bool
+	showStatusHints  bool
+	showDoneSection  bool
+	showCompleted    bool
+	showSomeday      bool
+	showAgenda       bool
+	sortMode         SortMode
 	showHelp         bool
+	showTagChips     bool
 	filteringEnabled bool
 
+	// Zen mode, and the parts of the view shown before it was turned on.
+	zen       bool
+	beforeZen chrome
+
 	itemNameSingular string
 	itemNamePlural   string
 
@@ -150,6 +142,90 @@ type ListScreen struct {
 	// Filter is used to filter the list.
 	Filter FilterFunc
 
+	// How the filters the sigils pick fold the term and the items.
+	filterFolder fold.Folder
+
+	// While the filter is a regular expression, the one it replaced and
+	// what's wrong with the pattern typed so far, if anything.
+	fuzzyFilter FilterFunc
+	patternErr  error
+
+	// Hooks runs user commands when items are added, completed or deleted.
+	// Nil disables hooks.
+	Hooks *hooks.Runner
+
+	// Activity logs the changes made to items for the activity screen. Nil
+	// logs nothing and disables the screen.
+	Activity *activity.Log
+
+	// Notifications delivers desktop notifications, honoring quiet hours.
+	// Nil disables notifications.
+	Notifications *notify.Center
+
+	// Chime sounds when a task is completed. Nil keeps completing silent.
+	Chime *chime.Chime
+
+	// Backup packs everything into a backup bundle and returns where it
+	// went. Nil if backups aren't set up.
+	Backup func() (string, error)
+
+	// Clock is the source of the current time.
+	Clock clock.Clock
+
+	// SafeMode marks the list as started with --safe-mode: optional
+	// subsystems are off and changes aren't saved.
+	SafeMode bool
+
+	// OpenElsewhere marks the list as opened read-only because another
+	// instance already shows it.
+	OpenElsewhere bool
+
+	// CompleteParents checks a task off once all of its subtasks are done.
+	CompleteParents bool
+
+	// SelectNextOnDelete selects the row after a deleted item instead of
+	// the one before it.
+	SelectNextOnDelete bool
+
+	// QuitWarning is how far ahead quitting looks for open tasks that are
+	// due. If one is due that soon, quitting asks first. 0 never asks.
+	QuitWarning time.Duration
+
+	// SinkCompletedAfter is how long the list has to be left alone before
+	// completed tasks move below the open ones. 0 never moves them.
+	SinkCompletedAfter time.Duration
+
+	// Debug gets a line a minute on how often the list was redrawn. Nil
+	// logs nothing.
+	Debug *log.Logger
+
+	// FilterTitlesOnly matches the filter and the jump prompt against the
+	// titles alone instead of also the tags, notes and subtasks.
+	FilterTitlesOnly bool
+
+	// HasWorkspaces enables the keys that open the workspace picker and
+	// switch to the previous or next one.
+	HasWorkspaces bool
+
+	// CanCreateLists enables the key that creates a new list.
+	CanCreateLists bool
+
+	// DefaultTags are added to every new task.
+	DefaultTags []string
+
+	// DefaultRules give imported tasks a due date or priority, as they do
+	// tasks added in the add screen. Workspace is the name of the list shown,
+	// which rules can match.
+	DefaultRules domain.DefaultRules
+	Workspace    string
+
+	// UndoDepth is how many changes can be undone. Zero turns undo off.
+	UndoDepth int
+
+	// WIPLimit caps the tasks in progress. Changes going over it have to be
+	// confirmed.
+	WIPLimit domain.WIPLimit
+
 	disableQuitKeybindings bool
 
 	// Additional key mappings for the short and full help views. This allows
@@ -160,11 +236,29 @@ type ListScreen struct {
 	AdditionalShortHelpKeys func() []key.Binding
 	AdditionalFullHelpKeys  func() []key.Binding
 
+	// Actions whose bindings are added to the short help, such as
+	// "sort_mode", unless it has them already. See cmd.ListHelp.
+	PinnedHelp []string
+
 	spinner     spinner.Model
 	showSpinner bool
 	width       int
 	height      int
 	Paginator   paginator.Model
+
+	// Where each page starts among the visible items and how many lines the
+	// pages have, from updatePagination; see paginate.
+	pageStarts []int
+	pageHeight int
+
+	// The size given to SetSize. While the details pane is shown, width and
+	// height only cover the list half of it.
+	fullWidth   int
+	fullHeight  int
+	splitWidth  int
+	splitHidden bool
+	detail      detailPane
+
 	cursor      int
 	Help        help.Model
 	FilterInput textinput.Model
@@ -177,30 +271,101 @@ type ListScreen struct {
 	statusMessage      string
 	statusMessageTimer *time.Timer
 
-	// The master set of items we're working with.
+	// The master set of items we're working with. Subtasks are items of
+	// their own, right after their parent, see domain.Flatten.
 	items []domain.Item
 
+	// Whether the done section lists its tasks, see SetShowDoneSection.
+	doneExpanded bool
+
+	// Header IDs of the other sections that only show their header.
+	collapsedSections []string
+
 	// Filtered items we're currently displaying. Filtering, toggles and so on
 	// will alter this slice so we can show what is relevant. For that reason,
 	// this field should be considered ephemeral.
 	filteredItems filteredItems
 
 	delegate ItemDelegate
+
+	// Where items are loaded from and saved to.
+	itemRepository storage.ItemRepository
+
+	// Set until the items are read. Everything but resizing waits for them
+	// in deferred, so no change is saved over items that weren't read yet.
+	loading  bool
+	deferred []tea.Msg
+
+	// Why the items couldn't be loaded, if they couldn't.
+	loadErr error
+
+	// Runs again what failed because the storage was locked or open in
+	// another clitodo, when r is pressed. Nil if nothing did.
+	retry func() tea.Cmd
+
+	// Gives up the claim on the storage, nil if the list doesn't hold one.
+	release func() error
+
+	// Changes that can be undone, oldest first, and those undone that can be
+	// made again.
+	undo, redo []undoStep
+
+	// The jump prompt, if open.
+	jump *jumpOverlay
+
+	// The number prompt, if open.
+	goTo *goToPrompt
+
+	// A question waiting for a yes or no, such as whether to go over the
+	// WIP limit, if any.
+	confirm *confirmation
+
+	// The command palette, if open.
+	palette *paletteOverlay
+
+	// The first key of a sequence like "g g", while waiting for the rest.
+	pending *pendingKey
+
+	// When a key was last pressed or the mouse last used, or another screen
+	// was last seen open, for SinkCompletedAfter.
+	lastInput time.Time
+
+	// The periodic tick; see ticks.go.
+	ticks ticker
+
+	// The tag chip picked with the keyboard, while the chips have the focus.
+	chipFocus *chipFocus
+
+	// Tips for getting started, drawn over the list. Nil shows none.
+	tips *tipEngine
+
+	// The running import, if any, and its latest progress report.
+	importJob      *importJob
+	importProgress importer.Progress
+	importFraction float64
 }
 
-// NewListScreen returns a new model with sensible defaults.
-func NewListScreen() *ListScreen {
-	items := getTasks()
-	var delegate ItemDelegate = NewDefaultDelegate()
+// NewListScreen returns a new model with sensible defaults, styled with the
+// given theme and showing the items of itemRepository.
+func NewListScreen(theme cmd.Theme, itemRepository storage.ItemRepository) *ListScreen {
+	m := newLoadingListScreen(theme, itemRepository)
+	m.loadNow()
+	return m
+}
+
+// newLoadingListScreen returns a list that reads its items in the background
+// once it's started, see Init.
+func newLoadingListScreen(theme cmd.Theme, itemRepository storage.ItemRepository) *ListScreen {
+	var delegate ItemDelegate = NewThemedDelegate(theme)
 
-	styles := cmd.DefaultStyles()
+	styles := cmd.NewStyles(theme)
 
 	sp := spinner.New()
 	sp.Spinner = spinner.Line
 	sp.Style = styles.Spinner
 
 	filterInput := textinput.New()
-	filterInput.Prompt = "Filter: "
+	filterInput.Prompt = fuzzyPrompt
 	filterInput.PromptStyle = styles.FilterPrompt
 	filterInput.Cursor.Style = styles.FilterCursor
 	filterInput.CharLimit = 64
@@ -217,23 +382,28 @@ func NewListScreen() *ListScreen {
 		showStatusBar:         true,
 		showPagination:        true,
 		showHelp:              true,
+		showCompleted:         true,
 		itemNameSingular:      "item",
 		itemNamePlural:        "items",
 		filteringEnabled:      true,
 		KeyMap:                cmd.DefaultKeyMap(),
-		Filter:                DefaultFilter,
+		Filter:                match.DefaultFilter,
+		filterFolder:          match.DefaultFolder,
 		Styles:                styles,
 		Title:                 "Todo List",
 		FilterInput:           filterInput,
 		StatusMessageLifetime: time.Second,
+		Clock:                 clock.Real{},
 
 		width:     0,
 		height:    0,
 		delegate:  delegate,
-		items:     items,
 		Paginator: p,
 		spinner:   sp,
 		Help:      help.New(),
+
+		itemRepository: itemRepository,
+		loading:        true,
 	}
 
 	m.updatePagination()
@@ -351,6 +521,29 @@ func (m *ListScreen) ShowPagination() bool {
 	return m.showPagination
 }
 
+// SetShowPageSummary hides or shows the row above the items that counts the
+// items on the current page. It's only shown when there is more than one page.
+func (m *ListScreen) SetShowPageSummary(v bool) {
+	m.showPageSummary = v
+	m.updatePagination()
+}
+
+// SetShowStatusHints hides or shows the key hints for the current state at
+// the end of the status bar.
+func (m *ListScreen) SetShowStatusHints(v bool) {
+	m.showStatusHints = v
+}
+
+// ShowStatusHints returns whether the status bar hints are set to be rendered.
+func (m ListScreen) ShowStatusHints() bool {
+	return m.showStatusHints
+}
+
+// ShowPageSummary returns whether the page summary is set to be rendered.
+func (m ListScreen) ShowPageSummary() bool {
+	return m.showPageSummary
+}
+
 // SetShowHelp shows or hides the help view.
 func (m *ListScreen) SetShowHelp(v bool) {
 	m.showHelp = v
@@ -384,8 +577,7 @@ func (m *ListScreen) SetItems(i []domain.Item) tea.Cmd {
 
 // Select selects the given index of the list and goes to its respective page.
 func (m *ListScreen) Select(index int) {
-	m.Paginator.Page = index / m.Paginator.PerPage
-	m.cursor = index % m.Paginator.PerPage
+	m.Paginator.Page, m.cursor = m.pageOf(index)
 }
 
 // ResetSelected resets the selected item to the first item in the first page of the list.
@@ -430,8 +622,159 @@ func (m *ListScreen) InsertItem(index int, item domain.Item) tea.Cmd {
 // this will be a no-op. O(n) complexity, which probably won't matter in the
 // case of a TUI.
 func (m *ListScreen) RemoveItem(index int) {
+	selected, row := m.selectedID(), m.Index()
 	m.items = removeItemFromSlice(m.items, index)
 	m.updatePagination()
+	m.reselect(selected, row)
+}
+
+// ClearCompleted removes every completed item, with its subtasks, in one pass
+// and saves the list. A filter stays applied to what's left.
+func (m *ListScreen) ClearCompleted() tea.Cmd {
+	kept := make([]domain.Item, 0, len(m.items))
+	var removed []domain.Item
+	removedIDs := map[string]bool{}
+	for i := 0; i < len(m.items); {
+		if !m.items[i].Completed() {
+			kept = append(kept, m.items[i])
+			i++
+			continue
+		}
+		removed = append(removed, m.items[i])
+		end := m.subtreeEnd(i)
+		maps.Copy(removedIDs, m.subtreeIDs(i, end))
+		i = end
+	}
+	if len(removed) == 0 {
+		return m.NewStatusMessage("No completed items to clear")
+	}
+
+	m.remember(m.snapshot(
+		fmt.Sprintf("Restored %d completed items", len(removed)),
+		fmt.Sprintf("Removed %d completed items", len(removed)),
+	))
+	m.items = kept
+	m.refreshRows()
+
+	if err := m.saveItems(); err != nil {
+		return m.saveFailed(err)
+	}
+	cmds := []tea.Cmd{m.NewStatusMessage(fmt.Sprintf("Removed %d completed items", len(removed)))}
+	for _, item := range removed {
+		cmds = append(cmds, m.runHook(hooks.EventDelete, item))
+	}
+	cmds = append(cmds, m.offerLinkCleanup("them", removedIDs))
+	return tea.Batch(cmds...)
+}
+
+// subtreeIDs returns the IDs of the items from start up to end, as for a task
+// and its subtasks.
+func (m ListScreen) subtreeIDs(start, end int) map[string]bool {
+	ids := make(map[string]bool, end-start)
+	for _, item := range m.items[start:end] {
+		ids[item.ID] = true
+	}
+	return ids
+}
+
+// indexOfID returns the index of the item with the given ID in the unfiltered
+// list, or -1.
+func (m ListScreen) indexOfID(id string) int {
+	for i, item := range m.items {
+		if item.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// SetItemByID replaces the item with the given ID, also in the filtered items,
+// and reports whether it was found. Unlike SetItem it doesn't refilter, so the
+// item stays in view even if it no longer matches.
+func (m *ListScreen) SetItemByID(id string, item domain.Item) bool {
+	i := m.indexOfID(id)
+	if i < 0 {
+		return false
+	}
+	m.items[i] = item
+	for j := range m.filteredItems {
+		if m.filteredItems[j].index == i {
+			m.filteredItems[j].item = item
+		}
+	}
+	return true
+}
+
+// RemoveItemByID removes the item with the given ID and its subtasks, also
+// from the filtered items, and returns it. It returns false if there is no such
+// item.
+func (m *ListScreen) RemoveItemByID(id string) (domain.Item, bool) {
+	i := m.indexOfID(id)
+	if i < 0 {
+		return domain.Item{}, false
+	}
+	selected, row := m.selectedID(), m.Index()
+	removed := m.items[i]
+	end := m.subtreeEnd(i)
+	m.items = append(m.items[:i], m.items[end:]...)
+
+	if m.filteredItems != nil {
+		kept := make(filteredItems, 0, len(m.filteredItems))
+		for _, f := range m.filteredItems {
+			if f.index >= i && f.index < end {
+				continue
+			}
+			if f.index >= end {
+				f.index -= end - i
+			}
+			kept = append(kept, f)
+		}
+		m.filteredItems = kept
+	}
+
+	m.updatePagination()
+	m.reselect(selected, row)
+	m.updateKeybindings()
+	return removed, true
+}
+
+// selectedID returns the ID of the selected item, or "" if no item is
+// selected.
+func (m ListScreen) selectedID() string {
+	if selected := m.SelectedItem(); selected != nil {
+		return selected.ID
+	}
+	return ""
+}
+
+// reselect fixes the selection after rows were removed. The item with the
+// given ID stays selected if it's still shown. If it was removed from the
+// given row, the row before it is selected, or with SelectNextOnDelete the
+// one that took its place, so deleting the last row of a page or of the list
+// never leaves the cursor past the end.
+func (m *ListScreen) reselect(id string, row int) {
+	if id != "" {
+		for i, item := range m.VisibleItems() {
+			if item.ID == id {
+				m.Select(i)
+				return
+			}
+		}
+	}
+	if !m.SelectNextOnDelete {
+		row--
+	}
+	m.Select(max(0, min(row, len(m.VisibleItems())-1)))
+}
+
+// subtreeEnd returns the index just past the subtasks of the item at index i,
+// which follow it in the list.
+func (m ListScreen) subtreeEnd(i int) int {
+	end := i + 1
+	for end < len(m.items) && m.items[end].Depth > m.items[i].Depth {
+		end++
+	}
+	return end
 }
 
 // SetDelegate sets the item delegate.
@@ -440,20 +783,70 @@ func (m *ListScreen) SetDelegate(d ItemDelegate) {
 	m.updatePagination()
 }
 
+// SetStyles restyles the list around the items: the title, filter prompt,
+// status bar, spinner and pagination.
+func (m *ListScreen) SetStyles(styles cmd.Styles) {
+	m.Styles = styles
+	m.spinner.Style = styles.Spinner
+	m.FilterInput.PromptStyle = styles.FilterPrompt
+	m.FilterInput.Cursor.Style = styles.FilterCursor
+	m.Paginator.ActiveDot = styles.ActivePaginationDot.String()
+	m.Paginator.InactiveDot = styles.InactivePaginationDot.String()
+	m.updatePagination()
+}
+
+// SetTheme restyles the list and, if the delegate is one of this package's,
+// its items with the given theme. The next frame shows it.
+func (m *ListScreen) SetTheme(t cmd.Theme) {
+	m.SetStyles(cmd.NewStyles(t))
+	switch d := m.delegate.(type) {
+	case DefaultDelegate:
+		d.SetStyles(NewItemStyles(t))
+		m.SetDelegate(d)
+	case CompactDelegate:
+		d.Styles = NewItemStyles(t)
+		m.SetDelegate(d)
+	case TableDelegate:
+		d.Styles = NewItemStyles(t)
+		m.SetDelegate(d)
+	}
+}
+
+// ToggleDescription shows or hides the first line of each item's notes under
+// its title, if the delegate is a DefaultDelegate. Fewer items fit on a page
+// with them; the same item stays selected.
+func (m *ListScreen) ToggleDescription() {
+	d, ok := m.delegate.(DefaultDelegate)
+	if !ok {
+		return
+	}
+	index := m.Index()
+	d.ShowDescription = !d.ShowDescription
+	m.SetDelegate(d)
+	m.Select(index)
+}
+
 // VisibleItems returns the total items available to be shown.
 func (m ListScreen) VisibleItems() []domain.Item {
 	if m.filterState != Unfiltered {
 		return m.filteredItems.items()
 	}
+	if m.sectioned() {
+		return m.arranged().items()
+	}
+	if m.projected() {
+		return m.rows().items()
+	}
 	return m.items
 }
 
-// SelectedItem returns the current selected item in the list.
+// SelectedItem returns the current selected item in the list. The section
+// headers are not items, so it returns nil for those too.
 func (m ListScreen) SelectedItem() *domain.Item {
 	i := m.Index()
 
 	items := m.VisibleItems()
-	if i < 0 || len(items) == 0 || len(items) <= i {
+	if i < 0 || len(items) == 0 || len(items) <= i || isHeader(items[i]) {
 		return nil
 	}
 
@@ -476,7 +869,8 @@ func (m ListScreen) MatchesForItem(index int) []int {
 // Using this value with SetItem() might be incorrect, consider using
 // GlobalIndex() instead.
 func (m ListScreen) Index() int {
-	return m.Paginator.Page*m.Paginator.PerPage + m.cursor
+	start, _ := m.pageBounds(m.Paginator.Page)
+	return start + m.cursor
 }
 
 // GlobalIndex returns the index of the currently selected item as it is stored
@@ -484,6 +878,19 @@ func (m ListScreen) Index() int {
 func (m ListScreen) GlobalIndex() int {
 	index := m.Index()
 
+	if m.sectioned() {
+		if rows := m.arranged(); index < len(rows) {
+			return rows[index].index
+		}
+		return index
+	}
+	if m.filterState == Unfiltered && m.projected() {
+		if rows := m.rows(); index < len(rows) {
+			return rows[index].index
+		}
+		return index
+	}
+
 	if m.filteredItems == nil || index >= len(m.filteredItems) {
 		return index
 	}
@@ -506,7 +913,7 @@ func (m *ListScreen) CursorUp() {
 		// if infinite scrolling is enabled, go to the last item
 		if m.InfiniteScrolling {
 			m.Paginator.Page = m.Paginator.TotalPages - 1
-			m.cursor = m.Paginator.ItemsOnPage(len(m.VisibleItems())) - 1
+			m.cursor = m.itemsOnPage() - 1
 			return
 		}
 
@@ -521,13 +928,13 @@ func (m *ListScreen) CursorUp() {
 
 	// Go to the previous page
 	m.Paginator.PrevPage()
-	m.cursor = m.Paginator.ItemsOnPage(len(m.VisibleItems())) - 1
+	m.cursor = m.itemsOnPage() - 1
 }
 
 // CursorDown moves the cursor down. This can also advance the state to the
 // next page.
 func (m *ListScreen) CursorDown() {
-	itemsOnPage := m.Paginator.ItemsOnPage(len(m.VisibleItems()))
+	itemsOnPage := m.itemsOnPage()
 
 	m.cursor++
 
@@ -561,7 +968,7 @@ func (m *ListScreen) CursorDown() {
 }
 
 func (m *ListScreen) MoveItemUp() {
-	if m.cursor <= 0 || m.cursor >= len(m.items) {
+	if m.cursor <= 0 || m.cursor >= len(m.items) || !m.canSwap(m.cursor-1, m.cursor) {
 		return
 	}
 
@@ -569,13 +976,20 @@ func (m *ListScreen) MoveItemUp() {
 }
 
 func (m *ListScreen) MoveItemDown() {
-	if m.cursor < 0 || m.cursor >= len(m.items)-1 {
+	if m.cursor < 0 || m.cursor >= len(m.items)-1 || !m.canSwap(m.cursor, m.cursor+1) {
 		return
 	}
 
 	m.items[m.cursor], m.items[m.cursor+1] = m.items[m.cursor+1], m.items[m.cursor]
 }
 
+// canSwap reports whether the items at i and i+1 can trade places without
+// tearing a task from its subtasks: they have to be siblings and the first
+// one can't have subtasks, or they would end up under the second one.
+func (m ListScreen) canSwap(i, j int) bool {
+	return m.items[i].ParentID == m.items[j].ParentID && m.subtreeEnd(i) == j && m.subtreeEnd(j) == j+1
+}
+
 // PrevPage moves to the previous page, if available.
 func (m *ListScreen) PrevPage() {
 	m.Paginator.PrevPage()
@@ -667,6 +1081,7 @@ func (m *ListScreen) NewStatusMessage(s string) tea.Cmd {
 	}
 
 	m.statusMessageTimer = time.NewTimer(m.StatusMessageLifetime)
+	m.syncZen()
 
 	// Wait for timeout
 	return func() tea.Msg {
@@ -675,19 +1090,22 @@ func (m *ListScreen) NewStatusMessage(s string) tea.Cmd {
 	}
 }
 
-// SetSize sets the width and height of this component.
+// SetSize sets the width and height of this component, including the details
+// pane if the width is enough to split.
 func (m *ListScreen) SetSize(width, height int) {
-	m.setSize(width, height)
+	m.fullWidth = width
+	m.fullHeight = height
+	m.layout()
 }
 
 // SetWidth sets the width of this component.
 func (m *ListScreen) SetWidth(v int) {
-	m.setSize(v, m.height)
+	m.SetSize(v, m.fullHeight)
 }
 
 // SetHeight sets the height of this component.
 func (m *ListScreen) SetHeight(v int) {
-	m.setSize(m.width, v)
+	m.SetSize(m.fullWidth, v)
 }
 
 func (m *ListScreen) setSize(width, height int) {
@@ -695,9 +1113,14 @@ func (m *ListScreen) setSize(width, height int) {
 
 	m.width = width
 	m.height = height
-	m.Help.Width = width
+	m.Help.Width = width - m.Styles.HelpStyle.GetHorizontalFrameSize()
 	m.FilterInput.Width = width - promptWidth - lipgloss.Width(m.spinnerView())
+	if !m.chipsShown() {
+		// The chips collapse on narrow terminals, focused or not.
+		m.chipFocus = nil
+	}
 	m.updatePagination()
+	m.updateKeybindings()
 }
 
 func (m *ListScreen) resetFiltering() {
@@ -708,55 +1131,139 @@ func (m *ListScreen) resetFiltering() {
 	m.filterState = Unfiltered
 	m.FilterInput.Reset()
 	m.filteredItems = nil
+	m.patternErr = nil
 	m.updatePagination()
 	m.updateKeybindings()
 }
 
 func (m ListScreen) itemsAsFilterItems() filteredItems {
-	fi := make([]filteredItem, len(m.items))
+	hidden := m.hiddenItems()
+	fi := make([]filteredItem, 0, len(m.items))
 	for i, item := range m.items {
-		fi[i] = filteredItem{
-			item: item,
+		if !hidden[i] {
+			fi = append(fi, filteredItem{
+				item:  item,
+				index: i,
+			})
 		}
 	}
-	return fi
+	return m.sortRows(fi)
 }
 
 // Set keybindings according to the filter state.
 func (m *ListScreen) updateKeybindings() {
+	jumping := m.jump != nil
+	m.KeyMap.CancelWhileJumping.SetEnabled(jumping)
+	m.KeyMap.AcceptWhileJumping.SetEnabled(jumping)
+	m.KeyMap.JumpUp.SetEnabled(jumping)
+	m.KeyMap.JumpDown.SetEnabled(jumping)
+
+	goingTo := m.goTo != nil
+	m.KeyMap.CancelGoTo.SetEnabled(goingTo)
+	m.KeyMap.AcceptGoTo.SetEnabled(goingTo)
+
+	confirming := m.confirm != nil
+	m.KeyMap.ConfirmWIP.SetEnabled(confirming)
+	m.KeyMap.CancelWIP.SetEnabled(confirming)
+
+	picking := m.chipFocus != nil
+	m.KeyMap.PrevChip.SetEnabled(picking)
+	m.KeyMap.NextChip.SetEnabled(picking)
+	m.KeyMap.ToggleChip.SetEnabled(picking)
+	m.KeyMap.CloseChips.SetEnabled(picking)
+
+	paletteOpen := m.palette != nil
+	m.KeyMap.CancelPalette.SetEnabled(paletteOpen)
+	m.KeyMap.AcceptPalette.SetEnabled(paletteOpen)
+	m.KeyMap.PaletteUp.SetEnabled(paletteOpen)
+	m.KeyMap.PaletteDown.SetEnabled(paletteOpen)
+
+	if jumping || goingTo || confirming || picking || paletteOpen {
+		// The jump prompt, the number prompt, the WIP confirmation, the tag
+		// chips and the command palette own the keyboard until they're
+		// closed.
+		m.disableListBindings()
+		return
+	}
+
 	switch m.filterState { //nolint:exhaustive
 	case Filtering:
-		m.KeyMap.CursorUp.SetEnabled(false)
-		m.KeyMap.CursorDown.SetEnabled(false)
-		m.KeyMap.NextPage.SetEnabled(false)
-		m.KeyMap.PrevPage.SetEnabled(false)
-		m.KeyMap.GoToStart.SetEnabled(false)
-		m.KeyMap.GoToEnd.SetEnabled(false)
-		m.KeyMap.Filter.SetEnabled(false)
-		m.KeyMap.ClearFilter.SetEnabled(false)
+		m.disableListBindings()
 		m.KeyMap.CancelWhileFiltering.SetEnabled(true)
 		m.KeyMap.AcceptWhileFiltering.SetEnabled(m.FilterInput.Value() != "")
-		m.KeyMap.Quit.SetEnabled(false)
-		m.KeyMap.ShowFullHelp.SetEnabled(false)
-		m.KeyMap.CloseFullHelp.SetEnabled(false)
+		m.KeyMap.ToggleRegex.SetEnabled(true)
 
 	default:
 		hasItems := len(m.items) != 0
+		// A read-only list, open in another clitodo, started in safe mode
+		// or failing to load, can be looked at but not changed.
+		changeable := hasItems && !m.itemRepository.ReadOnly()
 		m.KeyMap.CursorUp.SetEnabled(hasItems)
 		m.KeyMap.CursorDown.SetEnabled(hasItems)
 
 		hasPages := m.Paginator.TotalPages > 1
 		m.KeyMap.NextPage.SetEnabled(hasPages)
 		m.KeyMap.PrevPage.SetEnabled(hasPages)
+		m.KeyMap.PageSummary.SetEnabled(hasPages)
+
+		m.KeyMap.ToggleDetail.SetEnabled(m.canSplit())
+		m.KeyMap.Zen.SetEnabled(true)
+		_, defaultDelegate := m.delegate.(DefaultDelegate)
+		m.KeyMap.ToggleNotes.SetEnabled(defaultDelegate)
+		m.KeyMap.Stats.SetEnabled(true)
+		m.KeyMap.Activity.SetEnabled(m.Activity != nil)
+		m.KeyMap.Dedupe.SetEnabled(changeable)
+		m.KeyMap.Board.SetEnabled(hasItems)
+		m.KeyMap.CycleTheme.SetEnabled(!m.SafeMode)
+		m.KeyMap.DetailUp.SetEnabled(m.Split())
+		m.KeyMap.DetailDown.SetEnabled(m.Split())
 
 		m.KeyMap.GoToStart.SetEnabled(hasItems)
 		m.KeyMap.GoToEnd.SetEnabled(hasItems)
+		m.KeyMap.RaisePrio.SetEnabled(changeable)
+		m.KeyMap.LowerPrio.SetEnabled(changeable)
+		m.KeyMap.Waiting.SetEnabled(changeable)
+		m.KeyMap.AddSubtask.SetEnabled(changeable)
+		m.KeyMap.EditItem.SetEnabled(changeable)
+		m.KeyMap.OpenDetail.SetEnabled(hasItems)
+		m.KeyMap.Workspace.SetEnabled(m.HasWorkspaces)
+		m.KeyMap.PrevList.SetEnabled(m.HasWorkspaces)
+		m.KeyMap.NextList.SetEnabled(m.HasWorkspaces)
+		m.KeyMap.NewList.SetEnabled(m.CanCreateLists)
+		m.KeyMap.MoveToList.SetEnabled(changeable && m.HasWorkspaces)
+		// Also expands and collapses sections, so a read-only list keeps it
+		// and toggleSelected refuses to change tasks.
+		m.KeyMap.ToggleDone.SetEnabled(hasItems)
+		m.KeyMap.DeleteItem.SetEnabled(changeable)
+		m.KeyMap.ClearDone.SetEnabled(changeable)
+		m.KeyMap.HideDone.SetEnabled(hasItems)
+		m.KeyMap.SortMode.SetEnabled(hasItems)
+		m.KeyMap.ApplySort.SetEnabled(changeable && m.sortMode != SortManual)
+		m.KeyMap.Someday.SetEnabled(changeable)
+		m.KeyMap.ShowSomeday.SetEnabled(m.hasSomeday())
+		m.KeyMap.Undo.SetEnabled(len(m.undo) > 0 && !m.itemRepository.ReadOnly())
+		m.KeyMap.Redo.SetEnabled(len(m.redo) > 0 && !m.itemRepository.ReadOnly())
+		m.KeyMap.CopyItem.SetEnabled(hasItems)
+		m.KeyMap.CopyList.SetEnabled(hasItems)
+		m.KeyMap.CopyLink.SetEnabled(hasItems)
+		m.KeyMap.NextOverdue.SetEnabled(m.overdueCount() > 0)
+		m.KeyMap.Agenda.SetEnabled(hasItems)
+		// Moving items only makes sense in the order they're stored in.
+		m.KeyMap.MoveItemUp.SetEnabled(changeable && m.sortMode == SortManual && !m.showAgenda)
+		m.KeyMap.MoveItemDown.SetEnabled(changeable && m.sortMode == SortManual && !m.showAgenda)
+		m.KeyMap.Reload.SetEnabled(m.loadErr != nil || m.retry != nil)
 
 		m.KeyMap.Filter.SetEnabled(m.filteringEnabled && hasItems)
 		m.KeyMap.ClearFilter.SetEnabled(m.filterState == FilterApplied)
+		m.KeyMap.Jump.SetEnabled(hasItems)
+		m.KeyMap.GoToNumber.SetEnabled(hasItems && m.numbered())
+		m.KeyMap.PickTags.SetEnabled(m.chipsShown())
+		m.KeyMap.Palette.SetEnabled(true)
 		m.KeyMap.CancelWhileFiltering.SetEnabled(false)
 		m.KeyMap.AcceptWhileFiltering.SetEnabled(false)
+		m.KeyMap.ToggleRegex.SetEnabled(false)
 		m.KeyMap.Quit.SetEnabled(!m.disableQuitKeybindings)
+		m.KeyMap.CancelWhileImporting.SetEnabled(m.importJob != nil)
 
 		if m.Help.ShowAll {
 			m.KeyMap.ShowFullHelp.SetEnabled(true)
@@ -769,6 +1276,14 @@ func (m *ListScreen) updateKeybindings() {
 	}
 }
 
+// disableListBindings switches off every binding of the list's help, see
+// cmd.ListHelp.
+func (m *ListScreen) disableListBindings() {
+	for _, b := range m.KeyMap.ListBindings() {
+		b.SetEnabled(false)
+	}
+}
+
 // Update pagination according to the amount of items for the current state.
 func (m *ListScreen) updatePagination() {
 	index := m.Index()
@@ -777,6 +1292,9 @@ func (m *ListScreen) updatePagination() {
 	if m.showTitle || (m.showFilter && m.filteringEnabled) {
 		availHeight -= lipgloss.Height(m.titleView())
 	}
+	if m.chipsShown() {
+		availHeight -= lipgloss.Height(m.chipsView())
+	}
 	if m.showStatusBar {
 		availHeight -= lipgloss.Height(m.statusView())
 	}
@@ -787,17 +1305,18 @@ func (m *ListScreen) updatePagination() {
 		availHeight -= lipgloss.Height(m.helpView())
 	}
 
-	m.Paginator.PerPage = max(1, availHeight/(m.delegate.Height()+m.delegate.Spacing()))
+	m.paginate(availHeight)
 
-	if pages := len(m.VisibleItems()); pages < 1 {
-		m.Paginator.SetTotalPages(1)
-	} else {
-		m.Paginator.SetTotalPages(pages)
+	// The page summary only takes up a row once the items don't fit on a
+	// single page, and then there is one row less for them.
+	if m.showPageSummary && len(m.pageStarts) > 1 {
+		m.paginate(availHeight - pageSummaryHeight)
 	}
+	start, end := m.pageBounds(0)
+	m.Paginator.PerPage = max(1, end-start)
 
 	// Restore index
-	m.Paginator.Page = index / m.Paginator.PerPage
-	m.cursor = index % m.Paginator.PerPage
+	m.Paginator.Page, m.cursor = m.pageOf(index)
 
 	// Make sure the page stays in bounds
 	if m.Paginator.Page >= m.Paginator.TotalPages-1 {
@@ -813,40 +1332,494 @@ func (m *ListScreen) hideStatusMessage() {
 }
 
 func (m *ListScreen) Init() tea.Cmd {
-	return nil
+	var cmds []tea.Cmd
+	if m.loading {
+		cmds = append(cmds, loadItems(m.itemRepository))
+	}
+	now := m.Clock.Now()
+	m.lastInput = now
+	cmds = append(cmds, m.startTicks(now))
+	if m.showDoneSection && !m.loading {
+		cmds = append(cmds, m.archiveCompleted(now))
+	}
+	return tea.Batch(cmds...)
+}
+
+// startAdding opens the add screen, unless the list is read-only.
+func (m *ListScreen) startAdding() tea.Cmd {
+	if m.itemRepository.ReadOnly() {
+		return m.refuseReadOnly()
+	}
+	return addTask
 }
 
 func addTask() tea.Msg {
 	return cmd.AddTaskTrigger(true)
 }
 
+// addSubtask opens the add screen for a subtask of the selected item.
+func (m ListScreen) addSubtask() tea.Cmd {
+	selected := m.SelectedItem()
+	if selected == nil {
+		return nil
+	}
+	parent := *selected
+	return func() tea.Msg { return cmd.AddSubtaskTrigger{Parent: parent} }
+}
+
+// editSelected opens the edit screen for the selected item.
+func (m ListScreen) editSelected() tea.Cmd {
+	selected := m.SelectedItem()
+	if selected == nil {
+		return nil
+	}
+	item := *selected
+	return func() tea.Msg { return cmd.EditTaskTrigger{Item: item} }
+}
+
+func (m ListScreen) openDetail() tea.Cmd {
+	selected := m.SelectedItem()
+	if selected == nil {
+		return nil
+	}
+	item := *selected
+	return func() tea.Msg { return cmd.DetailTrigger{Item: item} }
+}
+
+func showStats() tea.Msg {
+	return cmd.StatsTrigger{}
+}
+
+func showActivity() tea.Msg {
+	return cmd.ActivityTrigger{}
+}
+
+func showDuplicates() tea.Msg {
+	return cmd.DedupeTrigger{}
+}
+
+func showBoard() tea.Msg {
+	return cmd.BoardTrigger{}
+}
+
+func cycleTheme() tea.Msg {
+	return cmd.CycleThemeTrigger{}
+}
+
+type hookFailedMsg struct {
+	err error
+}
+
+// runHook logs event in the activity log and runs the user hook for it in the
+// background. Hooks never block or revert the change that fired them; failures
+// only show up as a status message.
+func (m *ListScreen) runHook(event hooks.Event, item domain.Item) tea.Cmd {
+	entry := activity.Entry{Time: m.Clock.Now(), Event: event, ItemID: item.ID, Title: item.Title()}
+	if err := m.Activity.Append(entry); err != nil {
+		return m.NewStatusMessage("Activity log: " + err.Error())
+	}
+	if !m.Hooks.Enabled(event) {
+		return nil
+	}
+	runner := m.Hooks
+	return func() tea.Msg {
+		if err := runner.Run(event, item); err != nil {
+			return hookFailedMsg{err}
+		}
+		return nil
+	}
+}
+
+// changeItem applies change to the item with the given ID, marks it touched
+// and saves. It returns the changed item, or false if there is no such item,
+// and why saving failed.
+func (m *ListScreen) changeItem(id string, change func(*domain.Item)) (domain.Item, bool, error) {
+	item, ok := m.updateItem(id, change)
+	if !ok {
+		return domain.Item{}, false, nil
+	}
+	return item, true, m.saveItems()
+}
+
+// updateItem is changeItem without saving, for changes saved together.
+func (m *ListScreen) updateItem(id string, change func(*domain.Item)) (domain.Item, bool) {
+	i := m.indexOfID(id)
+	if i < 0 {
+		return domain.Item{}, false
+	}
+	item := m.items[i]
+	change(&item)
+	item.Touch(m.Clock.Now())
+	m.SetItemByID(id, item)
+	return item, true
+}
+
+// saveItems writes the items to the storage, with subtasks nested in their
+// parents again.
+func (m *ListScreen) saveItems() error {
+	return m.storeItems(true)
+}
+
+// completeParent checks off the parent of the item with the given ID if
+// CompleteParents is set and all of the parent's subtasks are done now. It
+// returns the parent if it was completed. It doesn't save.
+func (m *ListScreen) completeParent(id string) (domain.Item, bool) {
+	i := m.indexOfID(id)
+	if !m.CompleteParents || i < 0 {
+		return domain.Item{}, false
+	}
+	parentID := m.items[i].ParentID
+	p := m.indexOfID(parentID)
+	if p < 0 || m.items[p].Completed() {
+		return domain.Item{}, false
+	}
+	for _, item := range m.items[p+1 : m.subtreeEnd(p)] {
+		if item.ParentID == parentID && !item.Completed() {
+			return domain.Item{}, false
+		}
+	}
+	return m.updateItem(parentID, func(item *domain.Item) {
+		item.SetCompleted(true, m.Clock.Now())
+	})
+}
+
+// recur puts the next occurrence of a just completed recurring item after it.
+// The completed one stops recurring, so reopening and completing it again
+// doesn't create a second copy. It returns the new item, or false if the item
+// doesn't recur. It doesn't save.
+func (m *ListScreen) recur(item domain.Item) (domain.Item, bool) {
+	if item.Recurrence == nil {
+		return domain.Item{}, false
+	}
+	next := item.NextOccurrence(m.Clock.Now())
+	m.updateItem(item.ID, func(item *domain.Item) { item.Recurrence = nil })
+	m.InsertItem(m.subtreeEnd(m.indexOfID(item.ID)), next)
+	return next, true
+}
+
+// insertPosition returns where a new item goes: after the selected one, or
+// for a subtask after the last of its parent's subtasks. Top-level items never
+// go between a task and its subtasks.
+func (m ListScreen) insertPosition(item domain.Item) int {
+	if item.ParentID != "" {
+		if i := m.indexOfID(item.ParentID); i >= 0 {
+			return m.subtreeEnd(i)
+		}
+	}
+	position := min(m.Cursor()+1, len(m.items))
+	for position < len(m.items) && m.items[position].Depth > 0 {
+		position++
+	}
+	return position
+}
+
+// changePriority applies change to the selected item's priority and saves.
+func (m *ListScreen) changePriority(change func(domain.Priority) domain.Priority) tea.Cmd {
+	selected := m.SelectedItem()
+	if selected == nil {
+		return nil
+	}
+	_, _, err := m.changeItem(selected.ID, func(item *domain.Item) {
+		item.Priority = change(item.Priority)
+	})
+	if err != nil {
+		return m.saveFailed(err)
+	}
+	return nil
+}
+
+// toggleWaiting clears the selected item's waiting state, or opens the
+// waiting prompt for it.
+func (m *ListScreen) toggleWaiting() tea.Cmd {
+	selected := m.SelectedItem()
+	if selected == nil {
+		return nil
+	}
+	if selected.Waiting != nil {
+		if _, _, err := m.changeItem(selected.ID, func(item *domain.Item) { item.Waiting = nil }); err != nil {
+			return m.saveFailed(err)
+		}
+		return m.NewStatusMessage("No longer waiting")
+	}
+	item := *selected
+	return func() tea.Msg { return cmd.WaitTrigger{Item: item} }
+}
+
+// applyWaiting stores the answer from the waiting prompt.
+func (m *ListScreen) applyWaiting(msg waitDoneMsg) tea.Cmd {
+	if msg.waiting == nil {
+		return nil
+	}
+	_, ok, err := m.changeItem(msg.id, func(item *domain.Item) { item.Waiting = msg.waiting })
+	if !ok {
+		return nil
+	}
+	if err != nil {
+		return m.saveFailed(err)
+	}
+
+	status := "Waiting"
+	if msg.waiting.On != "" {
+		status += " on " + msg.waiting.On
+	}
+	if msg.waiting.FollowUp != nil {
+		status += ", follow up on " + domain.FormatDue(*msg.waiting.FollowUp)
+	}
+	return m.NewStatusMessage(status)
+}
+
+// applyEdit stores the new title and tags from the edit screen.
+func (m *ListScreen) applyEdit(msg cmd.TaskEdited) tea.Cmd {
+	if msg.Title == "" {
+		return nil
+	}
+	edit := func(item *domain.Item) {
+		item.ItemTitle = msg.Title
+		item.Tags = msg.Tags
+	}
+	return m.guardWIP(changingItem(msg.ID, edit), func() tea.Cmd {
+		_, ok, err := m.changeItem(msg.ID, edit)
+		if !ok {
+			return nil
+		}
+		if err != nil {
+			return m.saveFailed(err)
+		}
+		return m.NewStatusMessage("Renamed to " + msg.Title)
+	})
+}
+
+// toggleDone checks the item off, or reopens it if it's completed, and saves
+// once with the next occurrence and the completed parent, if any.
+func (m *ListScreen) toggleDone(selected domain.Item) tea.Cmd {
+	if selected.ItemCompleted {
+		m.remember(m.snapshot(fmt.Sprintf("Completed “%s” again", selected.Title()), fmt.Sprintf("Reopened “%s”", selected.Title())))
+	} else {
+		m.remember(m.snapshot(fmt.Sprintf("Reopened “%s”", selected.Title()), fmt.Sprintf("Completed “%s”", selected.Title())))
+	}
+	item, ok := m.updateItem(selected.ID, func(item *domain.Item) {
+		item.SetCompleted(!item.ItemCompleted, m.Clock.Now())
+	})
+	if !ok {
+		return nil
+	}
+	var next, parent domain.Item
+	var recurred, parentDone bool
+	if item.ItemCompleted {
+		next, recurred = m.recur(item)
+		parent, parentDone = m.completeParent(item.ID)
+	}
+	if !m.showCompleted {
+		m.refreshRows()
+	}
+	if err := m.saveItems(); err != nil {
+		return m.saveFailed(err)
+	}
+
+	var cmds []tea.Cmd
+	if item.ItemCompleted {
+		cmds = append(cmds, m.runHook(hooks.EventComplete, item), m.chime())
+	}
+	if recurred {
+		cmds = append(cmds, m.runHook(hooks.EventAdd, next))
+	}
+	if parentDone {
+		cmds = append(cmds, m.runHook(hooks.EventComplete, parent))
+	}
+	return tea.Batch(cmds...)
+}
+
+// toggleSelected completes or reopens the selected task, or expands or
+// collapses the selected section.
+func (m *ListScreen) toggleSelected() tea.Cmd {
+	if header := m.selectedHeader(); header != "" {
+		m.toggleSection(header)
+		return nil
+	}
+	selected := m.SelectedItem()
+	if selected == nil {
+		return nil
+	}
+	if m.itemRepository.ReadOnly() {
+		return m.refuseReadOnly()
+	}
+	if selected.Someday {
+		return m.NewStatusMessage("Pull it back into the list with z to complete it")
+	}
+	// Reopening a task can put one more in progress.
+	item := *selected
+	return m.guardWIP(
+		changingItem(item.ID, func(item *domain.Item) { item.ItemCompleted = !item.ItemCompleted }),
+		func() tea.Cmd { return m.toggleDone(item) },
+	)
+}
+
+// deleteSelected deletes the selected task, with its subtasks.
+func (m *ListScreen) deleteSelected() tea.Cmd {
+	selected := m.SelectedItem()
+	if selected == nil {
+		return nil
+	}
+	step := m.snapshot(fmt.Sprintf("Restored “%s”", selected.Title()), fmt.Sprintf("Deleted “%s”", selected.Title()))
+	i := m.indexOfID(selected.ID)
+	if i < 0 {
+		return nil
+	}
+	ids := m.subtreeIDs(i, m.subtreeEnd(i))
+	removed, _ := m.RemoveItemByID(selected.ID)
+	m.remember(step)
+	if err := m.saveItems(); err != nil {
+		return m.saveFailed(err)
+	}
+	return tea.Batch(
+		m.runHook(hooks.EventDelete, removed),
+		m.offerLinkCleanup(fmt.Sprintf("“%s”", removed.Title()), ids),
+	)
+}
+
+// addItem inserts a new item where it belongs, under its parent if it has
+// one, and saves.
+func (m *ListScreen) addItem(item domain.Item) tea.Cmd {
+	if m.itemRepository.ReadOnly() {
+		return m.refuseReadOnly()
+	}
+	hooked := item
+	if i := m.indexOfID(item.ParentID); i >= 0 {
+		item.Depth = m.items[i].Depth + 1
+	}
+	m.remember(m.snapshot(fmt.Sprintf("Removed “%s”", item.Title()), fmt.Sprintf("Added “%s”", item.Title())))
+	m.InsertItem(m.insertPosition(item), item)
+	if err := m.saveItems(); err != nil {
+		return m.saveFailed(err)
+	}
+	return m.runHook(hooks.EventAdd, hooked)
+}
+
+type chimeFailedMsg struct {
+	err error
+}
+
+// chime plays the done sound in the background, unless one played moments
+// ago.
+func (m *ListScreen) chime() tea.Cmd {
+	if !m.Chime.Allow() {
+		return nil
+	}
+	c := m.Chime
+	return func() tea.Msg {
+		if err := c.Play(); err != nil {
+			return chimeFailedMsg{err}
+		}
+		return nil
+	}
+}
+
 // Update is the Bubble Tea update loop.
 func (m *ListScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
+	defer m.syncZen()
+	m.ticks.updates++
+
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg:
+		m.lastInput = m.Clock.Now()
+	}
+
+	if m.loading {
+		switch msg := msg.(type) {
+		case itemsLoadedMsg:
+			return m, m.finishLoading(msg)
+		case tea.WindowSizeMsg:
+		default:
+			m.deferred = append(m.deferred, msg)
+			return m, nil
+		}
+	}
+
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && m.jump == nil && m.goTo == nil && m.confirm == nil && m.chipFocus == nil && m.palette == nil && m.filterState != Filtering {
+		var cmd tea.Cmd
+		if msg, cmd = m.sequence(keyMsg); msg == nil {
+			return m, cmd
+		}
+	}
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.jump != nil {
+			return m, m.handleJumping(msg)
+		}
+		if m.goTo != nil {
+			return m, m.handleGoTo(msg)
+		}
+		if m.confirm != nil {
+			return m, m.handleConfirm(msg)
+		}
+		if m.chipFocus != nil {
+			return m, m.handleChips(msg)
+		}
+		if m.palette != nil {
+			return m, m.handlePalette(msg)
+		}
+		if m.importJob != nil && key.Matches(msg, m.KeyMap.CancelWhileImporting) {
+			m.CancelImport()
+			return m, m.NewStatusMessage("Import cancelled")
+		}
 		if msg.String() == "ctrl+a" {
-			return m, addTask
+			return m, m.startAdding()
 		}
-		if msg.String() == "ctrl+d" {
-			m.RemoveItem(m.Cursor())
-			var itemRepository storage.FileItemStorage = storage.NewFileItemRepository()
-			itemRepository.StoreItemsState(m.Items())
+		if key.Matches(msg, m.KeyMap.DeleteItem) {
+			cmds = append(cmds, m.deleteSelected())
 		}
-		if msg.String() == "enter" {
-			var item *domain.Item = m.SelectedItem()
-			item.ItemCompleted = !item.ItemCompleted
-			var itemRepository storage.FileItemStorage = storage.NewFileItemRepository()
-			itemRepository.StoreItemsState(m.Items())
+		if key.Matches(msg, m.KeyMap.ToggleDone) {
+			cmds = append(cmds, m.toggleSelected())
 		}
 
 	case cmd.TaskAdded:
-		position := m.Cursor()
-		m.InsertItem(position+1, msg.Item)
-		var itemRepository storage.FileItemStorage = storage.NewFileItemRepository()
-		itemRepository.StoreItemsState(m.Items())
-		return m, tea.Batch(cmds...)
+		msg.Item.Tags = withDefaultTags(msg.Item.Tags, m.DefaultTags)
+		add := func(items []domain.Item) []domain.Item { return append(items, msg.Item) }
+		return m, m.guardWIP(add, func() tea.Cmd { return m.addItem(msg.Item) })
+
+	case hookFailedMsg:
+		return m, m.NewStatusMessage(msg.err.Error())
+
+	case chimeFailedMsg:
+		return m, m.NewStatusMessage(msg.err.Error())
+
+	case copyDoneMsg:
+		if msg.err != nil {
+			return m, m.NewStatusMessage("Copying failed: " + msg.err.Error())
+		}
+		return m, m.NewStatusMessage("Copied " + msg.what)
+
+	case backupDoneMsg:
+		if msg.err != nil {
+			return m, m.NewStatusMessage("Backup failed: " + msg.err.Error())
+		}
+		return m, m.NewStatusMessage("Backed up to " + msg.path)
+
+	case nagDoneMsg:
+		return m, m.applyNagDecisions(msg.decisions, m.Clock.Now())
+
+	case dedupeDoneMsg:
+		return m, m.applyDedupe(msg.decisions)
+
+	case waitDoneMsg:
+		return m, m.applyWaiting(msg)
+
+	case cmd.TaskEdited:
+		return m, m.applyEdit(msg)
+
+	case notifyFailedMsg:
+		return m, m.NewStatusMessage("Notification failed: " + msg.err.Error())
+
+	case tickMsg:
+		return m, m.onTick(m.Clock.Now())
+
+	case tea.FocusMsg:
+		cmds = append(cmds, m.focus())
+
+	case tea.BlurMsg:
+		m.ticks.blurred = true
 
 	case tea.WindowSizeMsg:
 		h, v := docStyle.GetFrameSize()
@@ -856,41 +1829,69 @@ func (m *ListScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.filteredItems = filteredItems(msg)
 		return m, nil
 
+	case cmd.ImportTrigger:
+		return m, m.StartImport(msg.Path)
+
+	case importProgressMsg:
+		return m, m.handleImportProgress(msg)
+
+	case importPreviewDoneMsg:
+		return m, m.finishImport(msg)
+
 	case spinner.TickMsg:
 		newSpinnerModel, cmd := m.spinner.Update(msg)
 		m.spinner = newSpinnerModel
-		if m.showSpinner {
+		// Stopped while the terminal doesn't have focus; focus starts
+		// it again.
+		if m.showSpinner && !m.ticks.blurred {
 			cmds = append(cmds, cmd)
 		}
 
 	case statusMessageTimeoutMsg:
 		m.hideStatusMessage()
+
+	case sequenceTimeoutMsg:
+		if m.pending == msg.pending {
+			m.pending = nil
+		}
+		return m, nil
 	}
 
-	if m.filterState == Filtering {
+	switch {
+	case m.jump != nil:
+		cmds = append(cmds, m.handleJumping(msg))
+	case m.palette != nil:
+		cmds = append(cmds, m.handlePalette(msg))
+	case m.filterState == Filtering:
 		cmds = append(cmds, m.handleFiltering(msg))
-	} else {
+	default:
 		cmds = append(cmds, m.handleBrowsing(msg))
 	}
 
 	return m, tea.Batch(cmds...)
 }
 
-func getTasks() []domain.Item {
-	var itemRepository storage.FileItemStorage = storage.NewFileItemRepository()
-
+// getTasks loads the items of itemRepository as list rows. A storage file that
+// doesn't exist yet is an empty list.
+func getTasks(itemRepository storage.ItemRepository) ([]domain.Item, error) {
 	items, err := itemRepository.GetItems()
+	if errors.Is(err, storage.ErrNotFound) {
+		return []domain.Item{}, nil
+	}
 	if err != nil {
-		return []domain.Item{}
+		return []domain.Item{}, err
 	}
 
-	return items
+	someday, err := getSomedayTasks(itemRepository)
+	if err != nil {
+		return []domain.Item{}, err
+	}
+	return append(domain.Flatten(items), someday...), nil
 }
 
 // Updates for when a user is browsing the list.
 func (m *ListScreen) handleBrowsing(msg tea.Msg) tea.Cmd {
 	var cmds []tea.Cmd
-	numItems := len(m.VisibleItems())
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
@@ -901,7 +1902,7 @@ func (m *ListScreen) handleBrowsing(msg tea.Msg) tea.Cmd {
 			m.resetFiltering()
 
 		case key.Matches(msg, m.KeyMap.Quit):
-			return tea.Quit
+			return m.quit()
 
 		case key.Matches(msg, m.KeyMap.CursorUp):
 			m.CursorUp()
@@ -910,12 +1911,20 @@ func (m *ListScreen) handleBrowsing(msg tea.Msg) tea.Cmd {
 			m.CursorDown()
 
 		case key.Matches(msg, m.KeyMap.MoveItemUp):
+			step := m.snapshot(m.movedMessage("back down"), m.movedMessage("up again"))
 			m.MoveItemUp()
 			m.CursorUp()
+			if !sameOrder(step.items, m.items) {
+				m.remember(step)
+			}
 
 		case key.Matches(msg, m.KeyMap.MoveItemDown):
+			step := m.snapshot(m.movedMessage("back up"), m.movedMessage("down again"))
 			m.MoveItemDown()
 			m.CursorDown()
+			if !sameOrder(step.items, m.items) {
+				m.remember(step)
+			}
 
 		case key.Matches(msg, m.KeyMap.PrevPage):
 			m.Paginator.PrevPage()
@@ -929,7 +1938,7 @@ func (m *ListScreen) handleBrowsing(msg tea.Msg) tea.Cmd {
 
 		case key.Matches(msg, m.KeyMap.GoToEnd):
 			m.Paginator.Page = m.Paginator.TotalPages - 1
-			m.cursor = m.Paginator.ItemsOnPage(numItems) - 1
+			m.cursor = m.itemsOnPage() - 1
 
 		case key.Matches(msg, m.KeyMap.Filter):
 			m.hideStatusMessage()
@@ -945,11 +1954,130 @@ func (m *ListScreen) handleBrowsing(msg tea.Msg) tea.Cmd {
 			m.updateKeybindings()
 			return textinput.Blink
 
+		case key.Matches(msg, m.KeyMap.Jump):
+			return m.OpenJump()
+
+		case key.Matches(msg, m.KeyMap.PickTags):
+			m.openChips()
+
+		case key.Matches(msg, m.KeyMap.Palette):
+			return m.OpenPalette()
+
+		case key.Matches(msg, m.KeyMap.PageSummary):
+			m.SetShowPageSummary(!m.showPageSummary)
+
+		case key.Matches(msg, m.KeyMap.ToggleDetail):
+			m.ToggleSplit()
+
+		case key.Matches(msg, m.KeyMap.Zen):
+			m.toggleZen()
+
+		case key.Matches(msg, m.KeyMap.ToggleNotes):
+			m.ToggleDescription()
+
+		case key.Matches(msg, m.KeyMap.GoToNumber):
+			m.openGoTo(msg)
+
+		case key.Matches(msg, m.KeyMap.Stats):
+			return showStats
+
+		case key.Matches(msg, m.KeyMap.Activity):
+			return showActivity
+
+		case key.Matches(msg, m.KeyMap.Dedupe):
+			return showDuplicates
+
+		case key.Matches(msg, m.KeyMap.Board):
+			return showBoard
+
+		case key.Matches(msg, m.KeyMap.CycleTheme):
+			return cycleTheme
+
+		case key.Matches(msg, m.KeyMap.RaisePrio):
+			cmds = append(cmds, m.changePriority(domain.Priority.Raise))
+
+		case key.Matches(msg, m.KeyMap.LowerPrio):
+			cmds = append(cmds, m.changePriority(domain.Priority.Lower))
+
+		case key.Matches(msg, m.KeyMap.Waiting):
+			return m.toggleWaiting()
+
+		case key.Matches(msg, m.KeyMap.AddSubtask):
+			return m.addSubtask()
+
+		case key.Matches(msg, m.KeyMap.EditItem):
+			return m.editSelected()
+
+		case key.Matches(msg, m.KeyMap.SortMode):
+			m.cycleSortMode()
+
+		case key.Matches(msg, m.KeyMap.ApplySort):
+			return m.applySort()
+
+		case key.Matches(msg, m.KeyMap.Someday):
+			return m.moveSomeday()
+
+		case key.Matches(msg, m.KeyMap.ShowSomeday):
+			m.SetShowSomeday(!m.showSomeday)
+
+		case key.Matches(msg, m.KeyMap.Undo):
+			return m.undoChange()
+
+		case key.Matches(msg, m.KeyMap.Redo):
+			return m.redoChange()
+
+		case key.Matches(msg, m.KeyMap.CopyItem):
+			return m.copySelected()
+
+		case key.Matches(msg, m.KeyMap.CopyList):
+			return m.copyList()
+
+		case key.Matches(msg, m.KeyMap.CopyLink):
+			return m.copyLink()
+
+		case key.Matches(msg, m.KeyMap.NextOverdue):
+			return m.jumpToOverdue()
+
+		case key.Matches(msg, m.KeyMap.Agenda):
+			return m.toggleAgenda()
+
+		case key.Matches(msg, m.KeyMap.ClearDone):
+			return m.ClearCompleted()
+
+		case key.Matches(msg, m.KeyMap.HideDone):
+			m.toggleShowCompleted()
+
+		case key.Matches(msg, m.KeyMap.Reload):
+			return m.reload()
+
+		case key.Matches(msg, m.KeyMap.OpenDetail):
+			return m.openDetail()
+
+		case key.Matches(msg, m.KeyMap.Workspace):
+			return m.openWorkspaces()
+
+		case key.Matches(msg, m.KeyMap.PrevList):
+			return m.cycleWorkspace(-1)
+
+		case key.Matches(msg, m.KeyMap.NextList):
+			return m.cycleWorkspace(1)
+
+		case key.Matches(msg, m.KeyMap.NewList):
+			return m.newList()
+
+		case key.Matches(msg, m.KeyMap.MoveToList):
+			return m.moveToList()
+
+		case key.Matches(msg, m.KeyMap.DetailUp):
+			m.scrollDetail(-1)
+
+		case key.Matches(msg, m.KeyMap.DetailDown):
+			m.scrollDetail(1)
+
 		case key.Matches(msg, m.KeyMap.ShowFullHelp):
 			fallthrough
 		case key.Matches(msg, m.KeyMap.CloseFullHelp):
-			m.Help.ShowAll = !m.Help.ShowAll
-			m.updatePagination()
+			m.toggleFullHelp()
 		}
 	case tea.BatchMsg:
 	case tea.BlurMsg:
@@ -963,6 +2091,7 @@ func (m *ListScreen) handleBrowsing(msg tea.Msg) tea.Cmd {
 	case tea.MouseEvent:
 	case tea.MouseEventType:
 	case tea.MouseMsg:
+		return m.handleMouse(msg)
 	case tea.Program:
 	case tea.ProgramOption:
 	case tea.QuitMsg:
@@ -977,7 +2106,7 @@ func (m *ListScreen) handleBrowsing(msg tea.Msg) tea.Cmd {
 	cmds = append(cmds, cmd)
 
 	// Keep the index in bounds when paginating
-	itemsOnPage := m.Paginator.ItemsOnPage(len(m.VisibleItems()))
+	itemsOnPage := m.itemsOnPage()
 	if m.cursor > itemsOnPage-1 {
 		m.cursor = max(0, itemsOnPage-1)
 	}
@@ -997,6 +2126,9 @@ func (m *ListScreen) handleFiltering(msg tea.Msg) tea.Cmd {
 			m.KeyMap.Filter.SetEnabled(true)
 			m.KeyMap.ClearFilter.SetEnabled(false)
 
+		case key.Matches(msg, m.KeyMap.ToggleRegex):
+			return m.toggleRegex()
+
 		case key.Matches(msg, m.KeyMap.AcceptWhileFiltering):
 			m.hideStatusMessage()
 
@@ -1030,6 +2162,7 @@ func (m *ListScreen) handleFiltering(msg tea.Msg) tea.Cmd {
 
 	// If the filtering input has changed, request updated filtering
 	if filterChanged {
+		m.checkPattern()
 		cmds = append(cmds, filterItems(*m))
 		m.KeyMap.AcceptWhileFiltering.SetEnabled(m.FilterInput.Value() != "")
 	}
@@ -1043,10 +2176,24 @@ func (m *ListScreen) handleFiltering(msg tea.Msg) tea.Cmd {
 // ShortHelp returns bindings to show in the abbreviated help view. It's part
 // of the help.KeyMap interface.
 func (m ListScreen) ShortHelp() []key.Binding {
-	kb := []key.Binding{
-		m.KeyMap.CursorUp,
-		m.KeyMap.CursorDown,
+	if m.jump != nil {
+		return m.jumpHelp()
+	}
+	if m.goTo != nil {
+		return m.goToHelp()
+	}
+	if m.confirm != nil {
+		return m.confirmHelp()
+	}
+	if m.chipFocus != nil {
+		return m.chipsHelp()
 	}
+	if m.palette != nil {
+		return m.paletteHelp()
+	}
+
+	groups := m.KeyMap.ShortHelpBindings()
+	kb := slices.Clone(groups[cmd.HelpNavigation])
 
 	filtering := m.filterState == Filtering
 
@@ -1058,34 +2205,64 @@ func (m ListScreen) ShortHelp() []key.Binding {
 		}
 	}
 
-	kb = append(kb,
-		m.KeyMap.Filter,
-		m.KeyMap.ClearFilter,
-		m.KeyMap.AcceptWhileFiltering,
-		m.KeyMap.CancelWhileFiltering,
-	)
+	for c := cmd.HelpView; c < cmd.HelpApp; c++ {
+		kb = append(kb, groups[c]...)
+	}
+	if filtering {
+		kb = append(kb, filterTokensHelp...)
+	}
 
 	if !filtering && m.AdditionalShortHelpKeys != nil {
 		kb = append(kb, m.AdditionalShortHelpKeys()...)
 	}
+	if !filtering {
+		kb = append(kb, m.pinnedHelp()...)
+	}
 
-	return append(kb,
-		m.KeyMap.Quit,
-		m.KeyMap.ShowFullHelp,
-	)
+	return append(kb, groups[cmd.HelpApp]...)
+}
+
+// pinnedHelp returns the bindings of PinnedHelp that the short help doesn't
+// show anyway.
+func (m ListScreen) pinnedHelp() []key.Binding {
+	var kb []key.Binding
+	for _, action := range m.PinnedHelp {
+		i := slices.IndexFunc(cmd.ListHelp, func(e cmd.HelpEntry) bool { return e.Action == action })
+		if i >= 0 && cmd.ListHelp[i].Short {
+			continue
+		}
+		if b, ok := m.KeyMap.Binding(action); ok {
+			kb = append(kb, b)
+		}
+	}
+	return kb
 }
 
 // FullHelp returns bindings to show the full help view. It's part of the
 // help.KeyMap interface.
 func (m ListScreen) FullHelp() [][]key.Binding {
-	kb := [][]key.Binding{{
-		m.KeyMap.CursorUp,
-		m.KeyMap.CursorDown,
-		m.KeyMap.NextPage,
-		m.KeyMap.PrevPage,
-		m.KeyMap.GoToStart,
-		m.KeyMap.GoToEnd,
-	}}
+	if m.jump != nil {
+		return [][]key.Binding{m.jumpHelp()}
+	}
+	if m.goTo != nil {
+		return [][]key.Binding{m.goToHelp()}
+	}
+	if m.confirm != nil {
+		return [][]key.Binding{m.confirmHelp()}
+	}
+	if m.chipFocus != nil {
+		return [][]key.Binding{m.chipsHelp()}
+	}
+	if m.palette != nil {
+		return [][]key.Binding{m.paletteHelp()}
+	}
+
+	groups := m.KeyMap.FullHelpBindings()
+	kb := [][]key.Binding{
+		groups[cmd.HelpNavigation],
+		groups[cmd.HelpView],
+		groups[cmd.HelpTasks],
+	}
 
 	filtering := m.filterState == Filtering
 
@@ -1097,27 +2274,24 @@ func (m ListScreen) FullHelp() [][]key.Binding {
 		}
 	}
 
-	listLevelBindings := []key.Binding{
-		m.KeyMap.Filter,
-		m.KeyMap.ClearFilter,
-		m.KeyMap.AcceptWhileFiltering,
-		m.KeyMap.CancelWhileFiltering,
-	}
-
+	listLevelBindings := groups[cmd.HelpList]
 	if !filtering && m.AdditionalFullHelpKeys != nil {
 		listLevelBindings = append(listLevelBindings, m.AdditionalFullHelpKeys()...)
 	}
 
-	return append(kb,
-		listLevelBindings,
-		[]key.Binding{
-			m.KeyMap.Quit,
-			m.KeyMap.CloseFullHelp,
-		})
+	return append(kb, listLevelBindings, groups[cmd.HelpApp])
 }
 
 // View renders the component.
 func (m ListScreen) View() string {
+	if !m.Split() {
+		return m.listView()
+	}
+	list := lipgloss.NewStyle().Width(m.width).Render(m.listView())
+	return lipgloss.JoinHorizontal(lipgloss.Top, list, m.detailView())
+}
+
+func (m ListScreen) listView() string {
 	var (
 		sections    []string
 		availHeight = m.height
@@ -1129,6 +2303,12 @@ func (m ListScreen) View() string {
 		availHeight -= lipgloss.Height(v)
 	}
 
+	if m.chipsShown() {
+		v := m.chipsView()
+		sections = append(sections, v)
+		availHeight -= lipgloss.Height(v)
+	}
+
 	if m.showStatusBar {
 		v := m.statusView()
 		sections = append(sections, v)
@@ -1147,7 +2327,23 @@ func (m ListScreen) View() string {
 		availHeight -= lipgloss.Height(help)
 	}
 
-	content := lipgloss.NewStyle().Height(availHeight).Render(m.populatedView())
+	if summary := m.pageSummaryView(); summary != "" {
+		sections = append(sections, summary)
+		availHeight -= lipgloss.Height(summary)
+	}
+
+	var content string
+	if m.jump != nil {
+		content = lipgloss.NewStyle().Height(availHeight).Render(m.jumpView(availHeight))
+	} else if m.palette != nil {
+		content = lipgloss.NewStyle().Height(availHeight).Render(m.paletteView(availHeight))
+	} else {
+		rows := m.populatedView()
+		if pad := m.zenPadding(); pad > 0 {
+			rows = strings.Repeat("\n", pad) + strings.TrimRight(rows, "\n")
+		}
+		content = lipgloss.NewStyle().Height(availHeight).Render(rows)
+	}
 	sections = append(sections, content)
 
 	if m.showPagination {
@@ -1158,7 +2354,7 @@ func (m ListScreen) View() string {
 		sections = append(sections, help)
 	}
 
-	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+	return m.withTip(lipgloss.JoinVertical(lipgloss.Left, sections...))
 }
 
 func (m ListScreen) titleView() string {
@@ -1174,8 +2370,15 @@ func (m ListScreen) titleView() string {
 		spinnerOnLeft  = titleBarStyle.GetPaddingLeft() >= spinnerWidth+lipgloss.Width(spinnerLeftGap) && m.showSpinner
 	)
 
-	// If the filter's showing, draw that. Otherwise draw the title.
-	if m.showFilter && m.filterState == Filtering {
+	// If the jump prompt, the number prompt, the command palette or the
+	// filter is showing, draw that. Otherwise draw the title.
+	if m.jump != nil {
+		view += m.jump.input.View()
+	} else if m.goTo != nil {
+		view += m.goToView()
+	} else if m.palette != nil {
+		view += m.palette.input.View()
+	} else if m.showFilter && m.filterState == Filtering {
 		view += m.FilterInput.View()
 	} else if m.showTitle {
 		if m.showSpinner && spinnerOnLeft {
@@ -1212,8 +2415,24 @@ func (m ListScreen) titleView() string {
 func (m ListScreen) statusView() string {
 	var status string
 
-	totalItems := len(m.items)
-	visibleItems := len(m.VisibleItems())
+	// Tasks put aside for some day are counted on their own.
+	someday := m.somedayCount()
+	totalItems := len(m.items) - someday
+	visibleItems := 0
+	for _, item := range m.VisibleItems() {
+		if !item.Someday && !isHeader(item) {
+			visibleItems++
+		}
+	}
+	if m.filterState == Unfiltered {
+		// Collapsed sections hide items, but they aren't filtered.
+		visibleItems = 0
+		for _, item := range m.expanded().VisibleItems() {
+			if !item.Someday && !isHeader(item) {
+				visibleItems++
+			}
+		}
+	}
 
 	var itemName string
 	if visibleItems != 1 {
@@ -1224,13 +2443,21 @@ func (m ListScreen) statusView() string {
 
 	itemsDisplay := fmt.Sprintf("%d %s", visibleItems, itemName)
 
+	if m.importJob != nil {
+		return m.Styles.StatusBar.Render(m.importView())
+	}
+
 	if m.filterState == Filtering { //nolint:nestif
 		// Filter results
-		if visibleItems == 0 {
+		if m.patternErr != nil {
+			status = m.Styles.StatusEmpty.Render("Bad pattern: " + patternErrorMessage(m.patternErr))
+		} else if visibleItems == 0 {
 			status = m.Styles.StatusEmpty.Render("Nothing matched")
 		} else {
 			status = itemsDisplay
 		}
+	} else if m.loading {
+		status = ""
 	} else if len(m.items) == 0 {
 		// Not filtering: no items.
 		status = m.Styles.StatusEmpty.Render("No " + m.itemNamePlural)
@@ -1239,33 +2466,180 @@ func (m ListScreen) statusView() string {
 		filtered := m.FilterState() == FilterApplied
 
 		if filtered {
-			f := strings.TrimSpace(m.FilterInput.Value())
-			f = ansi.Truncate(f, 10, "…") //nolint:mnd
-			status += fmt.Sprintf("“%s” ", f)
+			status += m.filterDescription() + " "
 		}
 
 		status += itemsDisplay
 	}
 
-	numFiltered := totalItems - visibleItems
+	hidden := m.hiddenCount()
+	numFiltered := totalItems - visibleItems - hidden
 	if numFiltered > 0 {
 		status += m.Styles.DividerDot.String()
 		status += m.Styles.StatusBarFilterCount.Render(fmt.Sprintf("%d filtered", numFiltered))
 	}
+	if hidden > 0 {
+		status += m.Styles.DividerDot.String()
+		status += m.Styles.StatusBarFilterCount.Render(fmt.Sprintf("%d hidden", hidden))
+	}
+	if someday > 0 {
+		status += m.Styles.DividerDot.String()
+		status += m.Styles.StatusBarFilterCount.Render(fmt.Sprintf("%d someday", someday))
+	}
+	if m.sortMode != SortManual {
+		status += m.Styles.DividerDot.String()
+		status += m.Styles.StatusBarFilterCount.Render("sorted " + m.sortMode.String())
+	}
+	if m.showAgenda {
+		status += m.Styles.DividerDot.String()
+		status += m.Styles.StatusBarFilterCount.Render("agenda")
+	}
+
+	if m.Notifications.Quiet() {
+		status = quietHoursIndicator(m.Notifications.Queued()) + " " + status
+	}
+
+	if wip := m.wipView(); wip != "" {
+		status = wip + m.Styles.DividerDot.String() + status
+	}
+
+	if n := m.overdueCount(); n > 0 {
+		status = m.Styles.StatusBarOverdue.Render(fmt.Sprintf("%d overdue", n)) + m.Styles.DividerDot.String() + status
+	}
+
+	if n := m.followUpsDue(); n > 0 {
+		status = m.Styles.StatusBarFollowUp.Render(fmt.Sprintf("⌛ %d to follow up", n)) + m.Styles.DividerDot.String() + status
+	}
+
+	if reminder := m.reminderView(); reminder != "" {
+		status = reminder + " " + status
+	}
+
+	if m.SafeMode {
+		status = m.Styles.StatusBarSafeMode.Render("safe mode · read-only") + " " + status
+	}
+	if m.OpenElsewhere {
+		status = m.Styles.StatusBarSafeMode.Render("read-only · open in another clitodo") + " " + status
+	}
+	if m.loadErr != nil {
+		status = m.Styles.StatusBarSafeMode.Render(storageErrorMessage(m.loadErr)) + " " + status
+	}
+	if m.confirm != nil {
+		// The question stands in for the status until it's answered.
+		status = m.Styles.StatusBarWIP.Render(m.confirm.question)
+	}
+
+	if m.showStatusHints {
+		divider := m.Styles.DividerDot.String()
+		width := m.width - m.Styles.StatusBar.GetHorizontalFrameSize() - lipgloss.Width(status) - lipgloss.Width(divider)
+		if hint := m.hintView(width); hint != "" {
+			status += divider + hint
+		}
+	}
 
 	return m.Styles.StatusBar.Render(status)
 }
 
+// followUpsDue counts the waiting items whose follow-up date has arrived.
+func (m ListScreen) followUpsDue() int {
+	now := m.Clock.Now()
+	n := 0
+	for _, item := range m.items {
+		if item.FollowUpDue(now) {
+			n++
+		}
+	}
+	return n
+}
+
+// overdueCount counts the open items whose due date has passed.
+func (m ListScreen) overdueCount() int {
+	now := m.Clock.Now()
+	n := 0
+	for _, item := range m.items {
+		if item.Overdue(now) {
+			n++
+		}
+	}
+	return n
+}
+
+// jumpToOverdue selects the first overdue item as the list is shown, or the
+// first one in the file if the filter hides them all.
+func (m *ListScreen) jumpToOverdue() tea.Cmd {
+	now := m.Clock.Now()
+	for _, item := range m.VisibleItems() {
+		if item.Overdue(now) {
+			return m.jumpTo(m.indexOfID(item.ID))
+		}
+	}
+	for i, item := range m.items {
+		if item.Overdue(now) {
+			return m.jumpTo(i)
+		}
+	}
+	return m.NewStatusMessage("Nothing is overdue")
+}
+
+// minHintWidth is the narrowest space a status bar hint is squeezed into;
+// below that it's left out rather than shown as a few letters.
+const minHintWidth = 12
+
+// hintBindings returns the keys worth pointing out in the current state.
+func (m ListScreen) hintBindings() []key.Binding {
+	switch {
+	case m.jump != nil:
+		return []key.Binding{m.KeyMap.AcceptWhileJumping, m.KeyMap.CancelWhileJumping}
+	case m.goTo != nil:
+		return m.goToHelp()
+	case m.confirm != nil:
+		return m.confirmHelp()
+	case m.chipFocus != nil:
+		return []key.Binding{m.KeyMap.ToggleChip, m.KeyMap.CloseChips}
+	case m.palette != nil:
+		return []key.Binding{m.KeyMap.AcceptPalette, m.KeyMap.CancelPalette}
+	case m.filterState == Filtering:
+		return []key.Binding{m.KeyMap.CancelWhileFiltering, m.KeyMap.AcceptWhileFiltering}
+	case m.SelectedItem() != nil:
+		return m.KeyMap.HintBindings()
+	}
+	return nil
+}
+
+// hintView renders the hints for the current state from the key map, so
+// remapped keys show up as they are, truncated to width. The counts are
+// rendered first, so on narrow terminals the hint gives way to them.
+func (m ListScreen) hintView(width int) string {
+	if width < minHintWidth {
+		return ""
+	}
+	var hints []string
+	for _, b := range m.hintBindings() {
+		if b.Enabled() {
+			h := b.Help()
+			hints = append(hints, h.Key+" "+h.Desc)
+		}
+	}
+	if len(hints) == 0 {
+		return ""
+	}
+	return m.Styles.StatusBarHint.Render(ansi.Truncate(strings.Join(hints, " · "), width, cmd.Ellipsis))
+}
+
 func (m ListScreen) paginationView() string {
 	if m.Paginator.TotalPages < 2 { //nolint:mnd
 		return ""
 	}
 
-	s := m.Paginator.View()
-
-	// If the dot pagination is wider than the width of the window
-	// use the arabic paginator.
-	if ansi.StringWidth(s) > m.width {
+	// If the dot pagination is wider than the width of the window use the
+	// arabic paginator. Every page gets a dot, so with thousands of pages
+	// that's clear without drawing them all.
+	dot := max(ansi.StringWidth(m.Paginator.ActiveDot), ansi.StringWidth(m.Paginator.InactiveDot))
+	var s string
+	if m.Paginator.Type != paginator.Dots || m.Paginator.TotalPages*dot <= m.width {
+		s = m.Paginator.View()
+	}
+	if s == "" || ansi.StringWidth(s) > m.width {
 		m.Paginator.Type = paginator.Arabic
 		s = m.Styles.ArabicPagination.Render(m.Paginator.View())
 	}
@@ -1278,40 +2652,122 @@ func (m ListScreen) paginationView() string {
 	return style.Render(s)
 }
 
+// pageSummaryHeight is the number of rows pageSummaryView takes up.
+const pageSummaryHeight = 1
+
+// pageSummaryView counts the items on the current page, e.g.
+// "items 11–20 of 54 · 3 done on this page". It's empty when the summary is
+// hidden or everything fits on one page.
+func (m ListScreen) pageSummaryView() string {
+	if !m.showPageSummary || m.jump != nil || m.Paginator.TotalPages < 2 { //nolint:mnd
+		return ""
+	}
+
+	items := m.VisibleItems()
+	start, end := m.pageBounds(m.Paginator.Page)
+	done := 0
+	for _, item := range items[start:end] {
+		if item.Completed() {
+			done++
+		}
+	}
+
+	s := fmt.Sprintf("%s %d–%d of %d · %d done on this page", m.itemNamePlural, start+1, end, len(items), done)
+	width := m.width - m.Styles.PageSummary.GetHorizontalFrameSize()
+	return m.Styles.PageSummary.Render(ansi.Truncate(s, width, cmd.Ellipsis))
+}
+
+// renderItem renders an item with the delegate and makes it exactly as many
+// lines high as the delegate says, cutting off or padding what it wrote, so a
+// delegate that gets it wrong can't push the rest of the page around.
+func (m ListScreen) renderItem(index int, item domain.Item) string {
+	var b strings.Builder
+	m.delegate.Render(&b, m, index, item)
+
+	height := m.itemHeight(index, item)
+	lines := strings.Split(b.String(), "\n")
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (m ListScreen) populatedView() string {
 	items := m.VisibleItems()
 
 	var b strings.Builder
 
 	if len(items) > 0 {
-		start, end := m.Paginator.GetSliceBounds(len(items))
+		start, end := m.pageBounds(m.Paginator.Page)
 		docs := items[start:end]
 
 		for i, item := range docs {
-			m.delegate.Render(&b, m, i+start, item)
+			b.WriteString(m.renderItem(i+start, item))
 			if i != len(docs)-1 {
 				fmt.Fprint(&b, strings.Repeat("\n", m.delegate.Spacing()+1))
 			}
 		}
 	}
 
-	// If there aren't enough items to fill up this page (always the last page)
-	// then we need to add some newlines to fill up the space where items would
-	// have been.
-	itemsOnPage := m.Paginator.ItemsOnPage(len(items))
-	if itemsOnPage < m.Paginator.PerPage {
-		n := (m.Paginator.PerPage - itemsOnPage) * (m.delegate.Height() + m.delegate.Spacing())
-		if len(items) == 0 {
-			n -= m.delegate.Height() - 1
-		}
-		fmt.Fprint(&b, strings.Repeat("\n", n))
-	}
-
+	// A page the items don't fill is padded to the full height by listView.
 	return b.String()
 }
 
+// The short help soft-wraps onto at most this many lines before it collapses
+// into a single "? N more" hint.
+const maxShortHelpLines = 2
+
 func (m ListScreen) helpView() string {
-	return m.Styles.HelpStyle.Render(m.Help.View(m))
+	width := m.width - m.Styles.HelpStyle.GetHorizontalFrameSize()
+	if m.Help.ShowAll {
+		return m.Styles.HelpStyle.Render(m.fullHelpView(width))
+	}
+
+	lines := m.wrapShortHelp(width)
+	if len(lines) > maxShortHelpLines {
+		lines = []string{m.moreHint(m.moreHelp(nil))}
+	}
+	return m.Styles.HelpStyle.Render(strings.Join(lines, "\n"))
+}
+
+// wrapShortHelp lays out the short help bindings on as many lines of the
+// given width as needed, breaking only between bindings. The full help's
+// binding says how many more bindings that has.
+func (m ListScreen) wrapShortHelp(width int) []string {
+	h := m.Help
+	h.Width = 0
+
+	var (
+		lines     []string
+		line      string
+		separator = h.Styles.ShortSeparator.Inline(true).Render(h.ShortSeparator)
+		shown     = m.ShortHelp()
+	)
+	for _, b := range shown {
+		if !b.Enabled() {
+			continue
+		}
+		item := h.ShortHelpView([]key.Binding{b})
+		if b.Help() == m.KeyMap.ShowFullHelp.Help() {
+			item = m.moreHint(m.moreHelp(shown))
+		}
+		switch {
+		case line == "":
+			line = item
+		case width > 0 && lipgloss.Width(line+separator+item) > width:
+			lines = append(lines, line)
+			line = item
+		default:
+			line += separator + item
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
 }
 
 func (m ListScreen) spinnerView() string {
@@ -1324,24 +2780,48 @@ func filterItems(m ListScreen) tea.Cmd {
 			return FilterMatchesMsg(m.itemsAsFilterItems()) // return nothing
 		}
 
-		items := m.items
-		targets := make([]string, len(items))
-
-		for i, t := range items {
-			targets[i] = t.FilterValue()
+		filter, value := m.queryFilter(m.FilterInput.Value())
+		term, keep := parseFilterTokens(value, m.Clock.Now())
+
+		// Only the items the tokens keep are matched against the rest of
+		// the term; indices maps them back to the unfiltered list.
+		var indices []int
+		var targets []string
+		hidden := m.hiddenItems()
+		for i, item := range m.items {
+			if !hidden[i] && keep(item) {
+				indices = append(indices, i)
+				targets = append(targets, m.filterTarget(item))
+			}
 		}
 
 		filterMatches := []filteredItem{}
-		for _, r := range m.Filter(m.FilterInput.Value(), targets) {
+		if term == "" {
+			for _, i := range indices {
+				filterMatches = append(filterMatches, filteredItem{index: i, item: m.items[i]})
+			}
+			return FilterMatchesMsg(m.sortRows(filterMatches))
+		}
+		for _, r := range filter(term, targets) {
+			i := indices[r.Index]
 			filterMatches = append(filterMatches, filteredItem{
-				index:   r.Index,
-				item:    items[r.Index],
+				index:   i,
+				item:    m.items[i],
 				matches: r.MatchedIndexes,
 			})
 		}
 
-		return FilterMatchesMsg(filterMatches)
+		return FilterMatchesMsg(m.sortRows(filterMatches))
+	}
+}
+
+// filterTarget returns what the filter and the jump prompt match item
+// against.
+func (m ListScreen) filterTarget(item domain.Item) string {
+	if m.FilterTitlesOnly {
+		return item.Title()
 	}
+	return item.FilterValue()
 }
 
 func insertItemIntoSlice(items []domain.Item, item domain.Item, index int) []domain.Item {
@@ -0,0 +1,125 @@
+package views
+
+import (
+	"testing"
+
+	"clitodo/cmd"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/storage"
+)
+
+// enabledListBindings returns the actions of the list's help whose bindings
+// are enabled.
+func enabledListBindings(m *ListScreen) []string {
+	var enabled []string
+	for _, e := range cmd.ListHelp {
+		if b, _ := m.KeyMap.Binding(e.Action); b.Enabled() {
+			enabled = append(enabled, e.Action)
+		}
+	}
+	return enabled
+}
+
+func TestOverlaysTakeTheKeyboard(t *testing.T) {
+	h := newHarness(t, titledItems("water the plants", "pay the rent"))
+
+	h.press(":")
+	if h.list().palette == nil {
+		t.Fatal(": didn't open the palette")
+	}
+	if got := enabledListBindings(h.list()); len(got) != 0 {
+		t.Errorf("with the palette open %q are enabled", got)
+	}
+	h.press("esc")
+
+	h.press("/")
+	h.typeText("pay")
+	got := enabledListBindings(h.list())
+	want := []string{"accept_while_filtering", "cancel_while_filtering", "toggle_regex"}
+	if len(got) != len(want) {
+		t.Errorf("while filtering %q are enabled, want %q", got, want)
+	}
+	for _, action := range want {
+		if b, _ := h.list().KeyMap.Binding(action); !b.Enabled() {
+			t.Errorf("%s is off while filtering", action)
+		}
+	}
+
+	h.press("esc")
+	if b, _ := h.list().KeyMap.Binding("toggle_done"); !b.Enabled() {
+		t.Error("leaving the filter didn't switch the list's keys back on")
+	}
+}
+
+// countingRepository counts how often the items are stored.
+type countingRepository struct {
+	storage.MemoryItemStorage
+	stores *int
+}
+
+func (r countingRepository) StoreItemsState(items []domain.Item) error {
+	*r.stores++
+	return r.MemoryItemStorage.StoreItemsState(items)
+}
+
+func TestCompletingSavesOnce(t *testing.T) {
+	tests := []struct {
+		name       string
+		recurs     bool
+		parentDone bool
+		children   int
+	}{
+		// The next occurrence is an open subtask, so the parent stays open.
+		{"recurring", true, false, 3},
+		{"last subtask", false, true, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_STATE_HOME", t.TempDir())
+			parent := domain.NewItem("move flat")
+			sub := domain.NewItem("water the plants")
+			sub.ParentID = parent.ID
+			if tt.recurs {
+				sub.Recurrence = &domain.Recurrence{Every: 1, Unit: domain.Weeks}
+			}
+			done := domain.NewItem("pack the books")
+			done.ParentID = parent.ID
+			done.ItemCompleted = true
+			parent.Children = []domain.Item{sub, done}
+
+			stores := 0
+			repo := countingRepository{storage.NewMemoryItemRepository([]domain.Item{parent}), &stores}
+			m := NewListScreen(cmd.DefaultTheme(), repo)
+			m.CompleteParents = true
+			m.Select(1)
+
+			m.toggleDone(*m.SelectedItem())
+			if stores != 1 {
+				t.Errorf("completing saved %d times, want once", stores)
+			}
+			items, _ := repo.GetItems()
+			if len(items) != 1 || len(items[0].Children) != tt.children || items[0].Completed() != tt.parentDone {
+				t.Errorf("stored %v, want the parent completed %t with %d subtasks", items, tt.parentDone, tt.children)
+			}
+		})
+	}
+}
+
+func TestPaletteCreatesBackup(t *testing.T) {
+	made := 0
+	h := newHarnessWith(t, titledItems("water the plants"), func(o *Options) {
+		o.Backup = func() (string, error) {
+			made++
+			return "/home/me/clitodo-2026-03-10-093000.tar.gz", nil
+		}
+	})
+	h.press(":")
+	h.typeText("backup")
+	h.press("enter")
+	if made != 1 {
+		t.Fatalf("the palette made %d backups, want 1", made)
+	}
+	if want := "Backed up to /home/me/clitodo-2026-03-10-093000.tar.gz"; h.list().statusMessage != want {
+		t.Errorf("status %q, want %q", h.list().statusMessage, want)
+	}
+}
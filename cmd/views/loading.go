@@ -0,0 +1,60 @@
+package views
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"clitodo/pkg/domain"
+	"clitodo/pkg/storage"
+)
+
+// itemsLoadedMsg carries the items read in the background, or why they
+// couldn't be.
+type itemsLoadedMsg struct {
+	items []domain.Item
+	err   error
+}
+
+func loadItems(itemRepository storage.ItemRepository) tea.Cmd {
+	return func() tea.Msg {
+		items, err := getTasks(itemRepository)
+		return itemsLoadedMsg{items, err}
+	}
+}
+
+// loadNow reads the items right away instead of in the background.
+func (m *ListScreen) loadNow() {
+	items, err := getTasks(m.itemRepository)
+	m.setLoaded(items, err)
+}
+
+// setLoaded shows the items read from the storage. With err the list stays
+// empty and read-only.
+func (m *ListScreen) setLoaded(items []domain.Item, err error) {
+	m.loading = false
+	m.items = items
+	if err != nil {
+		m.setLoadError(err)
+	}
+	m.updatePagination()
+	m.updateKeybindings()
+}
+
+// finishLoading shows the items read in the background, archives what's due,
+// fires the reminders missed while clitodo wasn't running and then handles
+// what arrived in the meantime, in order.
+func (m *ListScreen) finishLoading(msg itemsLoadedMsg) tea.Cmd {
+	m.setLoaded(msg.items, msg.err)
+	var cmds []tea.Cmd
+	if m.showDoneSection {
+		cmds = append(cmds, m.archiveCompleted(m.Clock.Now()))
+	}
+	cmds = append(cmds, m.fireReminders(m.Clock.Now()))
+
+	deferred := m.deferred
+	m.deferred = nil
+	for _, msg := range deferred {
+		_, cmd := m.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	return tea.Batch(cmds...)
+}
@@ -1,7 +1,26 @@
 package views
 
 import (
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
 	"clitodo/cmd"
+	"clitodo/pkg/activity"
+	"clitodo/pkg/chime"
+	"clitodo/pkg/clock"
+	"clitodo/pkg/config"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/fold"
+	"clitodo/pkg/hooks"
+	"clitodo/pkg/match"
+	"clitodo/pkg/notify"
+	"clitodo/pkg/startup"
+	"clitodo/pkg/state"
+	"clitodo/pkg/stats"
+	"clitodo/pkg/storage"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -12,40 +31,667 @@ type ViewID int
 const (
 	View1Const ViewID = iota
 	View2Const
+	// DetailViewConst shows the selected task on its own screen.
+	DetailViewConst
+	// BoardViewConst shows the tasks in columns by status.
+	BoardViewConst
 )
 
+// AddTaskView is the InitialView that opens the add screen right away.
+const AddTaskView = View2Const
+
+// DefaultInlineHeight is the height of the view when running inline and no
+// other height is configured.
+const DefaultInlineHeight = 15
+
+// DefaultUndoDepth is how many changes can be undone when no other depth is
+// configured.
+const DefaultUndoDepth = 100
+
+// Options configures the main view at startup.
+type Options struct {
+	// File to import into the list once the program starts, if any.
+	ImportPath string
+
+	// The view shown at startup: View1Const for the list or AddTaskView.
+	InitialView ViewID
+
+	// Quit after the first task is added instead of returning to the list.
+	Quick bool
+
+	// File the items are stored in. Empty means storage.DefaultFilePath.
+	StoragePath string
+
+	// Storage to use instead of the file at StoragePath, such as one from
+	// storage.NewMemoryItemRepository to drive the views without touching
+	// the disk. Nil uses StoragePath.
+	Storage storage.ItemRepository
+
+	// Terminal size to lay the views out for before the terminal reports
+	// its own, so they can be driven and rendered without one. 0 waits for
+	// the terminal.
+	Width, Height int
+
+	// A storage.json in the working directory to ask about moving to
+	// StoragePath, which is still empty. Empty asks nothing.
+	LegacyStorage string
+
+	// Color theme of the list. The zero value means cmd.DefaultTheme.
+	Theme cmd.Theme
+
+	// Cursor from the config, kept when another theme is picked. Empty
+	// uses each theme's own.
+	Cursor cmd.Cursor
+
+	// User commands to run when items change. Nil disables hooks.
+	Hooks *hooks.Runner
+
+	// Desktop notifications. Nil disables notifications.
+	Notifications *notify.Center
+
+	// Sound when a task is completed. Nil disables it.
+	Chime *chime.Chime
+
+	// Packs everything into a backup bundle and returns where it went, for
+	// the palette's backup command. Nil leaves backups to the command line.
+	Backup func() (string, error)
+
+	// The daily prompt about stale tasks.
+	Nag config.Nag
+
+	// Soft limit on title length in the add screen, 0 for none.
+	TitleLimit int
+
+	// Whether to count the items on the current page above the list.
+	PageSummary bool
+
+	// Whether to show key hints for the current state in the status bar.
+	StatusHints bool
+
+	// Whether items show the first line of their notes under the title.
+	ShowNotes bool
+
+	// Whether rows start with their number, which goes to them when typed.
+	ShowNumbers bool
+
+	// Actions whose keys the short help shows too.
+	PinnedKeys []string
+
+	// Whether long titles wrap onto more lines instead of being cut off.
+	WrapTitles bool
+
+	// Whether completed items keep the look of open ones instead of being
+	// struck through.
+	PlainCompleted bool
+
+	// How the rows are laid out: LayoutDefault, LayoutCompact or
+	// LayoutTable. The compact and table layouts leave out the notes and
+	// numbers and don't wrap titles.
+	Layout string
+
+	// Whether to point out the first things to try during the first few
+	// launches.
+	Tips bool
+
+	// Check a task off once all of its subtasks are done.
+	CompleteParents bool
+
+	// Select the row after a deleted task instead of the one before it.
+	SelectNextOnDelete bool
+
+	// How far ahead quitting looks for tasks that are due, and asks first if
+	// there is one. 0 never asks.
+	QuitWarning time.Duration
+
+	// List the tasks completed today in a section of their own and archive
+	// older completed ones.
+	DoneSection bool
+
+	// How long the list is left alone before completed tasks move below the
+	// open ones, 0 for never.
+	SinkCompletedAfter time.Duration
+
+	// Terminal width from which the details pane is shown, 0 for never.
+	SplitWidth int
+
+	// Match accents exactly when filtering instead of ignoring them.
+	KeepAccents bool
+
+	// How the filter matches items, one of the names in match.Filters. Empty
+	// matches fuzzily.
+	FilterMatch string
+
+	// Match the filter against titles only.
+	FilterTitlesOnly bool
+
+	// Show the tags under the title as chips the list can be filtered by.
+	TagChips bool
+
+	// Name of the active workspace, shown in the title bar. Empty for none.
+	Workspace string
+
+	// Workspaces the list can be switched to at runtime. Empty disables
+	// the workspace picker.
+	Workspaces []Workspace
+
+	// Tags every new task starts with.
+	DefaultTags []string
+
+	// Rules for the due date and priority new tasks start with.
+	DefaultRules domain.DefaultRules
+
+	// Sets up a new list of the given name, to switch to. Nil disables
+	// creating lists.
+	NewList func(name string) (Workspace, error)
+
+	// Opens the storage read-only and marks the status bar, for starting
+	// with --safe-mode.
+	SafeMode bool
+
+	// Inline is set when the program runs in the normal screen instead of
+	// the alt screen. The view is then at most InlineHeight rows high, so it
+	// doesn't push the terminal's scrollback away.
+	Inline       bool
+	InlineHeight int
+
+	// How many changes to the list can be undone.
+	UndoDepth int
+
+	// Cap on the tasks in progress.
+	WIP domain.WIPLimit
+
+	// Source of the current time. Nil means the system clock.
+	Clock clock.Clock
+
+	// Records how long startup took, for --trace-startup. Nil records
+	// nothing.
+	Trace *startup.Trace
+
+	// Where the list logs how often it's redrawn, for --debug-log. Nil logs
+	// nothing.
+	Debug *log.Logger
+}
+
 type MainView struct {
 	currentView ViewID
 	view1       tea.Model
 	view2       tea.Model
+	detail      detailScreen
+	board       boardScreen
 	KeyMap      cmd.KeyMap
+	options     Options
+
+	// Tips for getting started, shared with the list. Nil shows none.
+	tips *tipEngine
+
+	// Name of the theme picked in the list, which wins over the configured
+	// ones. Empty if none was picked.
+	theme string
+
+	// Workspace state to restore once the list knows its size.
+	restore *state.Workspace
+
+	// An import that finished while another screen was shown over the list,
+	// previewed once the list is back.
+	pendingPreview *importPreviewMsg
 }
 
-func NewMainView() tea.Model {
-	return MainView{
+func NewMainView(options Options) tea.Model {
+	if options.Theme.Name == "" {
+		options.Theme = cmd.DefaultTheme()
+	}
+
+	if options.Clock == nil {
+		options.Clock = clock.Real{}
+	}
+	if options.StoragePath == "" {
+		options.StoragePath = storage.DefaultFilePath
+	}
+	if options.Inline && options.InlineHeight <= 0 {
+		options.InlineHeight = DefaultInlineHeight
+	}
+	if options.UndoDepth <= 0 {
+		options.UndoDepth = DefaultUndoDepth
+	}
+	var picked string
+	if st, err := state.Load(); err == nil && st.Theme != "" && !options.SafeMode {
+		if theme, err := cmd.ThemeByName(st.Theme); err == nil {
+			picked = st.Theme
+			options.Theme = withCursor(theme, options.Cursor)
+		}
+	}
+
+	list := newList(options)
+
+	m := MainView{
 		0,
-		NewListScreen(),
+		list,
 		nil,
+		detailScreen{},
+		boardScreen{},
 		cmd.DefaultKeyMap(),
+		options,
+		nil,
+		picked,
+		nil,
+		nil,
+	}
+	if options.Tips {
+		m.tips = newTipEngine()
+		list.tips = m.tips
+	}
+	if len(options.Workspaces) != 0 {
+		if st, err := state.Load(); err == nil {
+			if ws, ok := st.Workspaces[options.Workspace]; ok {
+				m.restore = &ws
+			}
+		}
+	}
+
+	if options.InitialView == AddTaskView {
+		m.view2 = newAddScreen(options, nil)
+		m.currentView = AddTaskView
+	} else if options.LegacyStorage != "" {
+		m.view2 = newLegacyPrompt(options.LegacyStorage, options.StoragePath, list.Styles)
+		m.currentView = View2Const
+	} else if !options.SafeMode {
+		if whatsNew := newWhatsNew(list.Styles); whatsNew != nil {
+			m.view2 = *whatsNew
+			m.currentView = View2Const
+		}
+	}
+	if options.Width > 0 && options.Height > 0 {
+		sized, _ := m.Update(tea.WindowSizeMsg{Width: options.Width, Height: options.Height})
+		return sized
+	}
+	return m
+}
+
+// newList sets up the list screen for the storage file and settings in
+// options.
+func newList(options Options) *ListScreen {
+	var repository storage.ItemRepository = storage.NewFileItemRepository(options.StoragePath)
+	var release func() error
+	var inUse *storage.InUseError
+	if options.Storage != nil && options.SafeMode {
+		repository = storage.ReadOnly(options.Storage)
+	} else if options.Storage != nil {
+		repository = options.Storage
+	} else if options.SafeMode {
+		repository = storage.NewReadOnlyFileItemRepository(options.StoragePath)
+	} else if r, err := repository.Claim(); errors.As(err, &inUse) {
+		// Another instance shows this list; two writers would undo each
+		// other's changes.
+		repository = storage.NewReadOnlyFileItemRepository(options.StoragePath)
+	} else {
+		release = r
+	}
+
+	list := newLoadingListScreen(options.Theme, repository)
+	list.release = release
+	list.OpenElsewhere = inUse != nil
+	if options.Workspace != "" {
+		list.Title += " · " + options.Workspace
+	}
+	list.Hooks = options.Hooks
+	list.Notifications = options.Notifications
+	list.Chime = options.Chime
+	list.Backup = options.Backup
+	list.Clock = options.Clock
+	list.SetShowPageSummary(options.PageSummary)
+	list.SetShowStatusHints(options.StatusHints)
+	list.PinnedHelp = options.PinnedKeys
+	switch options.Layout {
+	case LayoutCompact:
+		delegate := NewCompactDelegate(options.Theme)
+		delegate.PlainCompleted = options.PlainCompleted
+		list.SetDelegate(delegate)
+	case LayoutTable:
+		delegate := NewTableDelegate(options.Theme)
+		delegate.PlainCompleted = options.PlainCompleted
+		list.SetDelegate(delegate)
+	default:
+		delegate := NewThemedDelegate(options.Theme)
+		delegate.ShowDescription = options.ShowNotes
+		delegate.ShowNumbers = options.ShowNumbers
+		delegate.WrapTitles = options.WrapTitles
+		delegate.PlainCompleted = options.PlainCompleted
+		list.SetDelegate(delegate)
+	}
+	list.SetSplitWidth(options.SplitWidth)
+	if options.KeepAccents {
+		list.filterFolder = fold.Folder{}
+	}
+	if options.FilterMatch == "regex" {
+		// Like switching to regular expressions with ctrl+r, which then
+		// switches to fuzzy matching.
+		list.Filter, list.fuzzyFilter = match.RegexFilter, match.NewFilter(list.filterFolder)
+		list.FilterInput.Prompt = regexPrompt
+	} else if filter, err := match.FilterByName(options.FilterMatch, list.filterFolder); err == nil {
+		list.Filter = filter
+	}
+	list.SafeMode = options.SafeMode
+	if !options.SafeMode && !list.OpenElsewhere && options.Storage == nil {
+		list.Activity = activity.New(activity.PathFor(options.StoragePath))
+	}
+	list.CompleteParents = options.CompleteParents
+	list.SelectNextOnDelete = options.SelectNextOnDelete
+	list.QuitWarning = options.QuitWarning
+	list.SinkCompletedAfter = options.SinkCompletedAfter
+	list.Debug = options.Debug
+	list.FilterTitlesOnly = options.FilterTitlesOnly
+	list.SetShowTagChips(options.TagChips)
+	list.UndoDepth = options.UndoDepth
+	list.WIPLimit = options.WIP
+	if options.DoneSection {
+		list.SetShowDoneSection(true)
+		if st, err := state.Load(); err == nil {
+			list.SetDoneExpanded(st.DoneExpanded)
+		}
+	}
+	if st, err := state.Load(); err == nil {
+		if st.HideCompleted {
+			list.SetShowCompleted(false)
+		}
+		list.SetZen(st.Zen)
+		list.SetShowFullHelp(st.FullHelp)
+		list.SetCollapsedSections(st.CollapsedSections)
+	}
+	list.HasWorkspaces = len(options.Workspaces) != 0
+	list.CanCreateLists = options.NewList != nil
+	list.DefaultTags = options.DefaultTags
+	list.DefaultRules = options.DefaultRules
+	list.Workspace = options.Workspace
+	return list
+}
+
+// newStaleNag returns the stale task prompt if it's enabled, hasn't been shown
+// yet today and there is something to ask about, and why saving the items
+// failed if it did.
+func newStaleNag(list *ListScreen, options Options) (*nagScreen, error) {
+	if !options.Nag.Enabled || list.itemRepository.ReadOnly() {
+		return nil, nil
+	}
+
+	st, err := state.Load()
+	if err != nil {
+		return nil, nil
+	}
+	now := options.Clock.Now()
+	today := now.Format(time.DateOnly)
+	if st.LastNag == today {
+		return nil, nil
+	}
+	st.LastNag = today
+	st.Save()
+
+	if list.backfillTouched(now) {
+		if err := list.saveItems(); err != nil {
+			return nil, err
+		}
+	}
+
+	after := time.Duration(options.Nag.AfterDays) * 24 * time.Hour
+	stale := domain.StaleItems(list.Items(), now, after, options.Nag.Count)
+	if len(stale) == 0 {
+		return nil, nil
 	}
+	nag := newNagScreen(list.Items(), stale, now, after, list.Styles)
+	return &nag, nil
 }
 
 func (m MainView) Init() tea.Cmd {
-	return nil
+	cmds := []tea.Cmd{m.view1.Init()}
+	if m.currentView == View2Const {
+		cmds = append(cmds, m.view2.Init())
+	}
+	if m.options.ImportPath != "" {
+		path := m.options.ImportPath
+		cmds = append(cmds, func() tea.Msg { return cmd.ImportTrigger{Path: path} })
+	}
+	return tea.Batch(cmds...)
 }
 
 func (m MainView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+	m, ok := model.(MainView)
+	if !ok || m.pendingPreview == nil || m.currentView != View1Const {
+		return model, cmd
+	}
+	preview := *m.pendingPreview
+	m.pendingPreview = nil
+	model, previewCmd := m.update(preview)
+	return model, tea.Batch(cmd, previewCmd)
+}
+
+func (m MainView) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok && m.options.Inline {
+		size.Height = min(size.Height, m.options.InlineHeight)
+		msg = size
+	}
+	if list, ok := m.view1.(*ListScreen); ok && m.currentView != View1Const {
+		// The list isn't left alone while another screen is open over it.
+		list.lastInput = list.Clock.Now()
+	}
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if key.Matches(msg, m.KeyMap.ForceQuit) {
 			return m, tea.Quit
 		}
+		if list, ok := m.view1.(*ListScreen); ok && m.currentView == View1Const {
+			if tip := m.tips.current(list); tip != nil && key.Matches(msg, m.KeyMap.DismissTip) {
+				m.tips.finish(tip.name)
+				return m, nil
+			}
+		}
 	case cmd.AddTaskTrigger:
-		m.view2 = NewAddTaskScreen()
+		m.view2 = newAddScreen(m.options, nil)
+		m.currentView = View2Const
+	case cmd.AddSubtaskTrigger:
+		m.view2 = newAddScreen(m.options, &msg.Parent)
 		m.currentView = View2Const
 	case cmd.TaskAdded:
 		m.currentView = View1Const
+		if m.options.Quick {
+			// Let the list save the task and its hooks finish before quitting.
+			var listCmd tea.Cmd
+			m.view1, listCmd = m.view1.Update(msg)
+			return m, tea.Sequence(listCmd, tea.Quit)
+		}
+	case nagDoneMsg:
+		m.currentView = View1Const
+	case legacyDoneMsg:
+		m.currentView = View1Const
+		return m.applyLegacyDecision(msg.decision)
+	case cmd.StatsTrigger:
+		if list, ok := m.view1.(*ListScreen); ok {
+			now := m.options.Clock.Now()
+			screen := newStatsScreen(list.Items(), now, list.Styles)
+			if st, err := state.Load(); err == nil {
+				screen.wipLimit = list.WIPLimit
+				screen.wipExceeded = stats.ThisWeek(st.WIPExceeded, now)
+			}
+			m.view2 = screen
+			m.currentView = View2Const
+		}
+		return m, nil
+	case statsDoneMsg:
+		m.currentView = View1Const
+		return m, nil
+	case cmd.VersionTrigger:
+		if list, ok := m.view1.(*ListScreen); ok {
+			m.view2 = newVersionScreen(list.Styles)
+			m.currentView = View2Const
+		}
+		return m, nil
+	case whatsNewDoneMsg:
+		m.currentView = View1Const
+		return m, nil
+	case cmd.ActivityTrigger:
+		list, ok := m.view1.(*ListScreen)
+		if !ok || list.Activity == nil {
+			return m, nil
+		}
+		entries, err := list.Activity.Entries(time.Time{})
+		if err != nil {
+			return m, list.NewStatusMessage("Activity log: " + err.Error())
+		}
+		_, v := docStyle.GetFrameSize()
+		m.view2 = newActivityScreen(entries, list.Filter, m.options.Clock.Now(), list.fullHeight+v, list.Styles)
+		m.currentView = View2Const
+		return m, m.view2.Init()
+	case activityDoneMsg:
+		m.currentView = View1Const
+		if list, ok := m.view1.(*ListScreen); ok && msg.entry != nil {
+			return m, list.showActivityEntry(*msg.entry)
+		}
+		return m, nil
+	case cmd.DedupeTrigger:
+		list, ok := m.view1.(*ListScreen)
+		if !ok {
+			return m, nil
+		}
+		clusters := list.findDuplicates()
+		if len(clusters) == 0 {
+			return m, list.NewStatusMessage("No duplicates found")
+		}
+		m.view2 = newDedupeScreen(list.items, clusters, list.Styles)
+		m.currentView = View2Const
+		return m, nil
+	case dedupeDoneMsg:
+		m.currentView = View1Const
+	case cmd.WaitTrigger:
+		if list, ok := m.view1.(*ListScreen); ok {
+			m.view2 = newWaitScreen(msg.Item, m.options.Clock.Now(), list.Styles)
+			m.currentView = View2Const
+			return m, m.view2.Init()
+		}
+		return m, nil
+	case waitDoneMsg:
+		m.currentView = View1Const
+	case cmd.EditTaskTrigger:
+		if list, ok := m.view1.(*ListScreen); ok {
+			m.view2 = newEditTaskScreen(msg.Item, list.Styles)
+			m.currentView = View2Const
+			return m, m.view2.Init()
+		}
+		return m, nil
+	case cmd.TaskEdited:
+		m.currentView = View1Const
+	case importPreviewMsg:
+		if m.currentView != View1Const {
+			m.pendingPreview = &msg
+			return m, nil
+		}
+		if list, ok := m.view1.(*ListScreen); ok {
+			h, v := docStyle.GetFrameSize()
+			m.view2 = newImportPreview(msg.staged, list.fullWidth+h, list.fullHeight+v, list.Styles)
+			m.currentView = View2Const
+		}
+		return m, nil
+	case importPreviewDoneMsg:
+		m.currentView = View1Const
+	case cmd.DetailTrigger:
+		if list, ok := m.view1.(*ListScreen); ok {
+			h, v := docStyle.GetFrameSize()
+			m.detail = newDetailScreen(msg.Item, list.itemByID, list.fullWidth+h, list.fullHeight+v, list.Styles)
+			m.currentView = DetailViewConst
+		}
+		return m, nil
+	case detailDoneMsg:
+		m.currentView = View1Const
+		return m, nil
+	case followLinkMsg:
+		m.currentView = View1Const
+		if list, ok := m.view1.(*ListScreen); ok {
+			return m, list.followLink(msg.id)
+		}
+		return m, nil
+	case cmd.BoardTrigger:
+		if list, ok := m.view1.(*ListScreen); ok {
+			h, v := docStyle.GetFrameSize()
+			m.board = newBoardScreen(list, list.fullWidth+h, list.fullHeight+v, m.options.Theme)
+			m.currentView = BoardViewConst
+		}
+		return m, nil
+	case boardDoneMsg:
+		m.currentView = View1Const
+		if list, ok := m.view1.(*ListScreen); ok && msg.id != "" {
+			list.selectID(msg.id)
+		}
+		return m, nil
+	case cmd.CycleThemeTrigger:
+		list, ok := m.view1.(*ListScreen)
+		if !ok {
+			return m, nil
+		}
+		theme, err := cmd.ThemeByName(cmd.NextTheme(m.options.Theme.Name))
+		if err != nil {
+			return m, list.NewStatusMessage(err.Error())
+		}
+		m.theme = theme.Name
+		m.options.Theme = withCursor(theme, m.options.Cursor)
+		list.SetTheme(m.options.Theme)
+		if st, err := state.Load(); err == nil {
+			st.Theme = theme.Name
+			st.Save()
+		}
+		return m, list.NewStatusMessage("Theme: " + theme.Name)
+	case cmd.WorkspaceTrigger:
+		if list, ok := m.view1.(*ListScreen); ok && len(m.options.Workspaces) != 0 {
+			m.view2 = newWorkspacePicker(m.options.Workspaces, m.options.Workspace, list.Styles)
+			m.currentView = View2Const
+		}
+		return m, nil
+	case workspaceDoneMsg:
+		m.currentView = View1Const
+		if msg.cancelled || msg.name == m.options.Workspace {
+			return m, nil
+		}
+		return m.switchWorkspace(msg.name)
+	case cmd.CycleWorkspaceTrigger:
+		n := len(m.options.Workspaces)
+		i := slices.IndexFunc(m.options.Workspaces, func(w Workspace) bool { return w.Name == m.options.Workspace })
+		if n < 2 || i < 0 {
+			return m, nil
+		}
+		return m.switchWorkspace(m.options.Workspaces[((i+msg.Step)%n+n)%n].Name)
+	case cmd.NewListTrigger:
+		if list, ok := m.view1.(*ListScreen); ok && m.options.NewList != nil {
+			m.view2 = newNewListPrompt(m.options.NewList, list.Styles)
+			m.currentView = View2Const
+			return m, m.view2.Init()
+		}
+		return m, nil
+	case newListDoneMsg:
+		m.currentView = View1Const
+		if msg.workspace == nil {
+			return m, nil
+		}
+		return m.addWorkspace(*msg.workspace)
+	case cmd.MoveToListTrigger:
+		if list, ok := m.view1.(*ListScreen); ok && len(m.options.Workspaces) > 1 {
+			m.view2 = newMovePicker(m.options.Workspaces, m.options.Workspace, msg.Item, list.Styles)
+			m.currentView = View2Const
+		}
+		return m, nil
+	case moveDoneMsg:
+		m.currentView = View1Const
+		list, ok := m.view1.(*ListScreen)
+		i := slices.IndexFunc(m.options.Workspaces, func(w Workspace) bool { return w.Name == msg.name })
+		if !ok || msg.cancelled || i < 0 {
+			return m, nil
+		}
+		return m, list.moveItem(msg.id, m.options.Workspaces[i])
+	}
+
+	// Tips are done by what reaches the list, which only sees keys while
+	// it's shown.
+	if list, ok := m.view1.(*ListScreen); ok && m.tips != nil {
+		if _, isKey := msg.(tea.KeyMsg); !isKey || m.currentView == View1Const {
+			m.tips.observe(list, msg)
+		}
 	}
 
 	var cmd tea.Cmd
@@ -55,18 +701,207 @@ func (m MainView) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.view1, cmd = m.view1.Update(msg)
 	case View2Const:
 		m.view2, cmd = m.view2.Update(msg)
+		// The list keeps working in the background while another view is
+		// shown, so its own messages must still reach it.
+		if isListBackgroundMsg(msg) {
+			var listCmd tea.Cmd
+			m.view1, listCmd = m.view1.Update(msg)
+			cmd = tea.Batch(cmd, listCmd)
+		}
+	case DetailViewConst:
+		var detail tea.Model
+		detail, cmd = m.detail.Update(msg)
+		m.detail = detail.(detailScreen)
+		if isListBackgroundMsg(msg) {
+			var listCmd tea.Cmd
+			m.view1, listCmd = m.view1.Update(msg)
+			cmd = tea.Batch(cmd, listCmd)
+		}
+	case BoardViewConst:
+		var board tea.Model
+		board, cmd = m.board.Update(msg)
+		m.board = board.(boardScreen)
+		if isListBackgroundMsg(msg) {
+			var listCmd tea.Cmd
+			m.view1, listCmd = m.view1.Update(msg)
+			cmd = tea.Batch(cmd, listCmd)
+		}
+	}
+
+	switch msg.(type) {
+	case tea.WindowSizeMsg:
+		m.restoreWorkspaceState()
+	case itemsLoadedMsg:
+		m.options.Trace.Mark("items loaded")
+		m.restoreWorkspaceState()
+		// The stale task prompt needs the items, so it shows up once they're
+		// read, unless the add screen was opened first.
+		if list, ok := m.view1.(*ListScreen); ok && m.currentView == View1Const {
+			nag, err := newStaleNag(list, m.options)
+			if err != nil {
+				cmd = tea.Batch(cmd, list.saveFailed(err))
+			}
+			if nag != nil {
+				m.view2 = *nag
+				m.currentView = View2Const
+				cmd = tea.Batch(cmd, nag.Init())
+			}
+		}
 	}
 
 	return m, cmd
 }
 
+// restoreWorkspaceState brings back where the workspace was left once the
+// list is both sized and loaded.
+func (m *MainView) restoreWorkspaceState() {
+	list, ok := m.view1.(*ListScreen)
+	if !ok || m.restore == nil || list.loading || list.height == 0 {
+		return
+	}
+	list.restoreWorkspaceState(*m.restore)
+	m.restore = nil
+}
+
+// switchWorkspace replaces the list with the one of the named workspace,
+// keeping the terminal size and remembering where the old one was left.
+func (m MainView) switchWorkspace(name string) (tea.Model, tea.Cmd) {
+	old, ok := m.view1.(*ListScreen)
+	if !ok {
+		return m, nil
+	}
+	i := slices.IndexFunc(m.options.Workspaces, func(w Workspace) bool { return w.Name == name })
+	if i < 0 {
+		return m, nil
+	}
+	m.SaveWorkspaceState()
+	old.releaseStorage()
+
+	ws := m.options.Workspaces[i]
+	m.options.Workspace = ws.Name
+	m.options.StoragePath = ws.StoragePath
+	m.options.Storage = nil
+	m.options.Theme = ws.Theme
+	if m.theme != "" {
+		if theme, err := cmd.ThemeByName(m.theme); err == nil {
+			m.options.Theme = withCursor(theme, m.options.Cursor)
+		}
+	}
+	m.options.DefaultTags = ws.DefaultTags
+
+	list := newList(m.options)
+	list.tips = m.tips
+	list.loadNow()
+	h, v := docStyle.GetFrameSize()
+	list.Update(tea.WindowSizeMsg{Width: old.fullWidth + h, Height: old.fullHeight + v})
+	if st, err := state.Load(); err == nil {
+		list.restoreWorkspaceState(st.Workspaces[ws.Name])
+	}
+	m.view1 = list
+
+	// The old list's ticks keep arriving and now reach the new one, so only
+	// the parts of Init that don't tick are run.
+	var archive tea.Cmd
+	if list.showDoneSection {
+		archive = list.archiveCompleted(m.options.Clock.Now())
+	}
+	return m, tea.Batch(archive, list.NewStatusMessage("Switched to "+ws.label()))
+}
+
+// addWorkspace adds w to the workspaces the list can be switched to, unless
+// it's one of them already, and switches to it. Without workspaces so far the
+// list that's open becomes the first.
+func (m MainView) addWorkspace(w Workspace) (tea.Model, tea.Cmd) {
+	if !slices.ContainsFunc(m.options.Workspaces, func(o Workspace) bool { return o.Name == w.Name }) {
+		if len(m.options.Workspaces) == 0 {
+			m.options.Workspaces = []Workspace{{
+				StoragePath: m.options.StoragePath,
+				Theme:       m.options.Theme,
+				DefaultTags: m.options.DefaultTags,
+			}}
+		}
+		m.options.Workspaces = append(m.options.Workspaces, w)
+		rememberList(w.Name)
+	}
+	if w.Name == m.options.Workspace {
+		return m, nil
+	}
+	return m.switchWorkspace(w.Name)
+}
+
+// withCursor returns theme with the given cursor, or its own if that's empty.
+func withCursor(theme cmd.Theme, cursor cmd.Cursor) cmd.Theme {
+	if cursor != "" {
+		theme.Cursor = cursor
+	}
+	return theme
+}
+
+// SaveWorkspaceState remembers the filter and selection of the active
+// workspace in the state file. It does nothing unless workspaces are
+// configured.
+func (m MainView) SaveWorkspaceState() {
+	list, ok := m.view1.(*ListScreen)
+	if !ok || list.loading || len(m.options.Workspaces) == 0 {
+		return
+	}
+	st, err := state.Load()
+	if err != nil {
+		return
+	}
+	if st.Workspaces == nil {
+		st.Workspaces = map[string]state.Workspace{}
+	}
+	st.Workspaces[m.options.Workspace] = list.workspaceState()
+	st.Save()
+}
+
+// Close lets other instances open the list for writing again. Call it once
+// the program has exited.
+func (m MainView) Close() {
+	if list, ok := m.view1.(*ListScreen); ok {
+		list.releaseStorage()
+	}
+}
+
+// isListBackgroundMsg reports whether msg goes to the list even while another
+// view is shown. The import --import asks for is among them: the stale task
+// screen, the legacy storage prompt or --add may come first.
+func isListBackgroundMsg(msg tea.Msg) bool {
+	switch msg.(type) {
+	case tea.WindowSizeMsg, itemsLoadedMsg, cmd.ImportTrigger, importProgressMsg, hookFailedMsg, chimeFailedMsg, copyDoneMsg, notifyFailedMsg, tickMsg, tea.FocusMsg, tea.BlurMsg, statusMessageTimeoutMsg, sequenceTimeoutMsg:
+		return true
+	}
+	return false
+}
+
+// Summary describes the list in one line, printed when the program exits.
+func (m MainView) Summary() string {
+	list, ok := m.view1.(*ListScreen)
+	if !ok {
+		return ""
+	}
+	done := 0
+	for _, item := range list.Items() {
+		if item.Completed() {
+			done++
+		}
+	}
+	return fmt.Sprintf("%d open, %d done", len(list.Items())-done, done)
+}
+
 // The main view, which just calls the appropriate sub-view
 func (m MainView) View() string {
+	m.options.Trace.Mark("first frame")
 	switch m.currentView {
 	case View1Const:
 		return m.view1.View()
 	case View2Const:
 		return m.view2.View()
+	case DetailViewConst:
+		return m.detail.View()
+	case BoardViewConst:
+		return m.board.View()
 	default:
 		return "Unknown view"
 	}
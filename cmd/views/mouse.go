@@ -0,0 +1,94 @@
+package views
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// handleMouse moves the cursor with the wheel and selects the row that's
+// clicked. A click on a task's check mark also completes or reopens it.
+func (m *ListScreen) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	if msg.Action != tea.MouseActionPress || m.confirm != nil {
+		return nil
+	}
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.CursorUp()
+	case tea.MouseButtonWheelDown:
+		m.CursorDown()
+	case tea.MouseButtonLeft:
+		if msg.X < 0 || msg.X >= m.width {
+			return nil
+		}
+		if m.chipsShown() && msg.Y == m.chipsTop() {
+			if tag, ok := m.chipAt(msg.X); ok {
+				m.toggleTag(tag)
+			}
+			return nil
+		}
+		index, ok := m.rowAt(msg.Y)
+		if !ok {
+			return nil
+		}
+		m.Select(index)
+		if m.onCheckMark(msg.X) && m.KeyMap.ToggleDone.Enabled() {
+			return m.toggleSelected()
+		}
+	}
+	return nil
+}
+
+// itemsTop returns the line of the list view the rows start on, below the
+// title, the tag chips, the status bar and the page summary, and the lines
+// zen mode leaves to center them.
+func (m ListScreen) itemsTop() int {
+	top := m.chipsTop()
+	if m.chipsShown() {
+		top += lipgloss.Height(m.chipsView())
+	}
+	if m.showStatusBar {
+		top += lipgloss.Height(m.statusView())
+	}
+	if summary := m.pageSummaryView(); summary != "" {
+		top += lipgloss.Height(summary)
+	}
+	return top + m.zenPadding()
+}
+
+// rowAt returns the index in VisibleItems of the row drawn on line y of the
+// list view. Lines above and below the rows of the current page, and the
+// spacing between rows, aren't on a row.
+func (m ListScreen) rowAt(y int) (int, bool) {
+	if m.jump != nil || m.palette != nil {
+		return 0, false
+	}
+	line := y - m.itemsTop()
+	if line < 0 {
+		return 0, false
+	}
+	start, end := m.pageBounds(m.Paginator.Page)
+	items := m.VisibleItems()
+	for i := start; i < end; i++ {
+		height := m.itemHeight(i, items[i])
+		if line < height {
+			return i, true
+		}
+		line -= height + m.delegate.Spacing()
+		if line < 0 {
+			return 0, false
+		}
+	}
+	return 0, false
+}
+
+// onCheckMark reports whether column x is on the check mark of the selected
+// task. Only the DefaultDelegate is known to draw one.
+func (m ListScreen) onCheckMark(x int) bool {
+	d, ok := m.delegate.(DefaultDelegate)
+	selected := m.SelectedItem()
+	if !ok || selected == nil {
+		return false
+	}
+	from, to := d.checkMarkColumns(m, *selected)
+	return x >= from && x < to
+}
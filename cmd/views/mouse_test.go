@@ -0,0 +1,174 @@
+package views
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"clitodo/pkg/domain"
+)
+
+// mouseItems are enough tasks for several pages, some of them too long for
+// one line and some tagged so there are chips.
+func mouseItems() []domain.Item {
+	items := titledItems(
+		"water the plants",
+		"renew the passport before the trip in the spring and book the appointment early",
+		"pay the rent",
+		"sort out the insurance for the car and the flat before the end of the month",
+		"call mum",
+		"book the flights",
+		"return the library books before they charge a fine and pick up the reserved ones",
+		"clean the windows",
+		"fix the bike",
+		"order new glasses",
+	)
+	items[2].Tags = []string{"home"}
+	items[6].Tags = []string{"errands"}
+	items[8].SetCompleted(true, time.Date(2026, time.March, 9, 18, 0, 0, 0, time.Local))
+	return items
+}
+
+// clickAt clicks the left button on column x of line y.
+func (h *harness) clickAt(x, y int) {
+	h.send(tea.MouseMsg{X: x, Y: y, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+}
+
+// rowLines returns, for every line of the view, the index in VisibleItems of
+// the row drawn on it, or -1. Rows are found by where their title starts.
+func rowLines(t *testing.T, m *ListScreen, lines []string) []int {
+	t.Helper()
+	rows := make([]int, len(lines))
+	for y := range rows {
+		rows[y] = -1
+	}
+	items := m.VisibleItems()
+	start, end := m.pageBounds(m.Paginator.Page)
+	for i := start; i < end; i++ {
+		words := strings.Fields(items[i].Title())
+		first := -1
+		for y, line := range lines {
+			if strings.Contains(line, strings.Join(words[:min(3, len(words))], " ")) {
+				first = y
+				break
+			}
+		}
+		if first < 0 {
+			t.Fatalf("%q isn't drawn", items[i].Title())
+		}
+		for y := first; y < first+m.itemHeight(i, items[i]); y++ {
+			rows[y] = i
+		}
+	}
+	return rows
+}
+
+func TestMouseRows(t *testing.T) {
+	tests := []struct {
+		name  string
+		keys  []string
+		typed string
+		top   int
+	}{
+		// Title and its margin, chips, status bar and its margin, summary.
+		{"first page", nil, "", 6},
+		{"second page", []string{"l"}, "", 6},
+		// The filter takes the title's place; a single page has no summary.
+		{"filtering", []string{"/"}, "'before", 5},
+		{"filtered", []string{"/", "enter"}, "'before", 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHarnessWith(t, mouseItems(), func(o *Options) {
+				o.WrapTitles = true
+				o.PageSummary = true
+				o.TagChips = true
+			})
+			for _, k := range tt.keys {
+				h.press(k)
+				if k == "/" {
+					h.typeText(tt.typed)
+				}
+			}
+
+			m := h.list()
+			if got := m.itemsTop(); got != tt.top {
+				t.Errorf("itemsTop() = %d, want %d", got, tt.top)
+			}
+			lines := strings.Split(ansi.Strip(h.view()), "\n")
+			want := rowLines(t, m, lines)
+			wrapped := false
+			for y := range lines {
+				got, ok := m.rowAt(y)
+				if !ok {
+					got = -1
+				}
+				if got != want[y] {
+					t.Errorf("rowAt(%d) = %d, want %d on %q", y, got, want[y], lines[y])
+				}
+				if y > 0 && got >= 0 && want[y-1] == got {
+					wrapped = true
+				}
+			}
+			if !wrapped {
+				t.Error("no row takes up more than one line")
+			}
+		})
+	}
+}
+
+func TestMouseClicks(t *testing.T) {
+	h := newHarnessWith(t, mouseItems(), func(o *Options) {
+		o.WrapTitles = true
+		o.PageSummary = true
+		o.TagChips = true
+	})
+	h.press("l")
+	lines := strings.Split(ansi.Strip(h.view()), "\n")
+	rows := rowLines(t, h.list(), lines)
+	lineOf := func(title string) int {
+		t.Helper()
+		i := slices.IndexFunc(h.list().VisibleItems(), func(item domain.Item) bool { return item.Title() == title })
+		return slices.Index(rows, i)
+	}
+
+	// The second line of a wrapped row selects it.
+	library := "return the library books before they charge a fine and pick up the reserved ones"
+	h.clickAt(20, lineOf(library)+1)
+	if got := h.list().SelectedItem().Title(); got != library {
+		t.Errorf("clicking the second line of %q selected %q", library, got)
+	}
+
+	// Clicking a title selects the task, its check mark reopens it.
+	y := lineOf("fix the bike")
+	h.clickAt(20, y)
+	if got := h.list().SelectedItem().Title(); got != "fix the bike" {
+		t.Fatalf("clicking %q selected %q", "fix the bike", got)
+	}
+	if item, _ := h.storedItem("fix the bike"); !item.Completed() {
+		t.Fatal("clicking the title reopened the task")
+	}
+	mark := ansi.StringWidth(lines[y][:strings.Index(lines[y], "✓")])
+	if h.list().onCheckMark(mark-1) || !h.list().onCheckMark(mark) {
+		t.Errorf("the check mark isn't found at column %d of %q", mark, lines[y])
+	}
+	h.clickAt(mark, y)
+	if item, _ := h.storedItem("fix the bike"); item.Completed() {
+		t.Error("clicking the check mark didn't reopen the task")
+	}
+
+	// Nothing above the rows is one.
+	index := h.list().Index()
+	for y := range h.list().itemsTop() {
+		if y != h.list().chipsTop() {
+			h.clickAt(20, y)
+		}
+	}
+	if h.list().Index() != index {
+		t.Errorf("clicking above the rows selected %d", h.list().Index())
+	}
+}
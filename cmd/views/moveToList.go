@@ -0,0 +1,119 @@
+package views
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"clitodo/cmd"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/storage"
+)
+
+// moveDoneMsg closes the picker of the list to move the item with the given
+// ID to.
+type moveDoneMsg struct {
+	id        string
+	name      string
+	cancelled bool
+}
+
+// newMovePicker lists the workspaces other than current to move item to.
+func newMovePicker(workspaces []Workspace, current string, item domain.Item, styles cmd.Styles) workspacePicker {
+	others := slices.DeleteFunc(slices.Clone(workspaces), func(w Workspace) bool { return w.Name == current })
+	m := newWorkspacePicker(others, current, styles)
+	m.title = fmt.Sprintf("Move “%s” to", item.Title())
+	m.KeyMap.AcceptWorkspace.SetHelp(m.KeyMap.AcceptWorkspace.Help().Key, "move")
+	m.done = func(name string, cancelled bool) tea.Msg {
+		return moveDoneMsg{id: item.ID, name: name, cancelled: cancelled}
+	}
+	return m
+}
+
+func (m ListScreen) moveToList() tea.Cmd {
+	if m.Importing() {
+		return m.NewStatusMessage("Wait for the import to finish before moving tasks")
+	}
+	item := m.SelectedItem()
+	if item == nil {
+		return nil
+	}
+	return func() tea.Msg { return cmd.MoveToListTrigger{Item: *item} }
+}
+
+// moveItem moves the item with the given ID, with its subtasks, to the end of
+// the list of target, or of its someday tasks if the item was put aside. The
+// target's file is created if it doesn't exist yet, and written first, so if
+// saving this list fails the task is in both rather than in neither.
+func (m *ListScreen) moveItem(id string, target Workspace) tea.Cmd {
+	i := m.indexOfID(id)
+	if i < 0 {
+		return nil
+	}
+	rows := slices.Clone(m.items[i:m.subtreeEnd(i)])
+	for j := range rows {
+		rows[j].Depth -= m.items[i].Depth
+	}
+	// A subtask becomes a task of its own there, and takes its place at the
+	// end.
+	rows[0].ParentID = ""
+	rows[0].Position = ""
+
+	title := rows[0].Title()
+	step := m.snapshot(fmt.Sprintf("Moved “%s” back from %s", title, target.label()), fmt.Sprintf("Moved “%s” to %s", title, target.label()))
+	other := otherList{path: target.StoragePath, someday: rows[0].Someday}
+	previous, err := changeOtherList(other.path, other.someday, func(items []domain.Item) []domain.Item {
+		return domain.Nest(append(domain.Flatten(items), rows...))
+	})
+	if err != nil {
+		return m.storageFailed("Moving", err, func() tea.Cmd { return m.moveItem(id, target) })
+	}
+	other.items = previous
+	step.other = &other
+
+	m.RemoveItemByID(id)
+	m.remember(step)
+	if err := m.saveItems(); err != nil {
+		return m.saveFailed(err)
+	}
+	return m.NewStatusMessage(step.redone)
+}
+
+// changeOtherList replaces the items stored for the list at path, a list other
+// than the one shown, with what change makes of them, and returns the ones
+// stored before: nil if it didn't exist yet. With someday set it changes the
+// tasks put aside instead. The list is claimed meanwhile, so this fails while
+// another clitodo shows it rather than have that one write over the change.
+func changeOtherList(path string, someday bool, change func([]domain.Item) []domain.Item) ([]domain.Item, error) {
+	list := storage.NewFileItemRepository(path)
+	release, err := list.Claim()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	unlock, err := list.Lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	var repository storage.ItemRepository = list
+	if someday {
+		repository = list.Someday()
+	}
+	previous, err := repository.GetItems()
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return nil, err
+	}
+	items := change(previous)
+	if items == nil {
+		// An empty list rather than null.
+		items = []domain.Item{}
+	}
+	if err := repository.StoreItemsState(items); err != nil {
+		return nil, err
+	}
+	return previous, nil
+}
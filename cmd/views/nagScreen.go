@@ -0,0 +1,193 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"clitodo/cmd"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/hooks"
+)
+
+type nagAction int
+
+const (
+	nagKeep nagAction = iota
+	nagComplete
+	nagSnooze
+	nagDelete
+)
+
+// nagDecision is the answer for one stale item. touchAt is the touched time
+// recorded for keep and snooze: a snoozed item is backdated so it becomes
+// stale again tomorrow.
+type nagDecision struct {
+	index   int
+	action  nagAction
+	touchAt time.Time
+}
+
+type nagDoneMsg struct {
+	decisions []nagDecision
+}
+
+// nagScreen asks "still relevant?" about each stale item in turn.
+type nagScreen struct {
+	items   []domain.Item
+	indices []int
+	current int
+	now     time.Time
+	after   time.Duration
+
+	decisions []nagDecision
+	KeyMap    cmd.KeyMap
+	help      help.Model
+	styles    cmd.Styles
+}
+
+func newNagScreen(items []domain.Item, indices []int, now time.Time, after time.Duration, styles cmd.Styles) nagScreen {
+	return nagScreen{
+		items:   items,
+		indices: indices,
+		now:     now,
+		after:   after,
+		KeyMap:  cmd.DefaultKeyMap(),
+		help:    help.New(),
+		styles:  styles,
+	}
+}
+
+func (m nagScreen) Init() tea.Cmd {
+	return nil
+}
+
+func (m nagScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	decision := nagDecision{index: m.indices[m.current]}
+	switch {
+	case key.Matches(keyMsg, m.KeyMap.NagSkip):
+		return m, m.done()
+	case key.Matches(keyMsg, m.KeyMap.NagComplete):
+		decision.action = nagComplete
+	case key.Matches(keyMsg, m.KeyMap.NagDelete):
+		decision.action = nagDelete
+	case key.Matches(keyMsg, m.KeyMap.NagSnooze):
+		decision.action = nagSnooze
+		decision.touchAt = m.now.Add(-m.after + 24*time.Hour)
+	case key.Matches(keyMsg, m.KeyMap.NagKeep):
+		decision.action = nagKeep
+		decision.touchAt = m.now
+	default:
+		return m, nil
+	}
+
+	m.decisions = append(m.decisions, decision)
+	m.current++
+	if m.current == len(m.indices) {
+		return m, m.done()
+	}
+	return m, nil
+}
+
+func (m nagScreen) done() tea.Cmd {
+	decisions := m.decisions
+	return func() tea.Msg {
+		return nagDoneMsg{decisions: decisions}
+	}
+}
+
+func (m nagScreen) View() string {
+	item := m.items[m.indices[m.current]]
+
+	age := "a while"
+	if item.TouchedAt != nil {
+		age = fmt.Sprintf("%d days", int(m.now.Sub(*item.TouchedAt).Hours()/24))
+	}
+
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render("Still relevant?"))
+	fmt.Fprintf(&b, "  %d/%d\n\n", m.current+1, len(m.indices))
+	b.WriteString("  " + item.Title() + "\n")
+	b.WriteString(m.styles.StatusBar.Render("untouched for "+age) + "\n")
+	b.WriteString(m.styles.HelpStyle.Render(m.help.ShortHelpView([]key.Binding{
+		m.KeyMap.NagComplete,
+		m.KeyMap.NagSnooze,
+		m.KeyMap.NagDelete,
+		m.KeyMap.NagKeep,
+		m.KeyMap.NagSkip,
+	})))
+	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
+}
+
+// backfillTouched marks items that predate touch tracking as touched now, so
+// they become eligible for the stale prompt once they really are old. It
+// returns whether any item changed.
+func (m *ListScreen) backfillTouched(now time.Time) bool {
+	changed := false
+	for i := range m.items {
+		if m.items[i].TouchedAt == nil {
+			m.items[i].Touch(now)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// applyNagDecisions applies the answers from the stale prompt and saves the
+// list once. The hooks only run once it's saved.
+func (m *ListScreen) applyNagDecisions(decisions []nagDecision, now time.Time) tea.Cmd {
+	if len(decisions) == 0 {
+		return nil
+	}
+
+	var completed, removed []domain.Item
+	var deleted []int
+	for _, d := range decisions {
+		item := &m.items[d.index]
+		switch d.action {
+		case nagComplete:
+			item.SetCompleted(true, now)
+			item.Touch(now)
+			completed = append(completed, *item)
+		case nagDelete:
+			deleted = append(deleted, d.index)
+			removed = append(removed, *item)
+		case nagSnooze:
+			// Asking again tomorrow ends the reminders, like completing.
+			item.Touch(d.touchAt)
+			item.Remind = nil
+		default:
+			item.Touch(d.touchAt)
+		}
+	}
+
+	// Remove from the back so earlier indices stay valid.
+	sort.Sort(sort.Reverse(sort.IntSlice(deleted)))
+	for _, i := range deleted {
+		m.RemoveItem(i)
+	}
+
+	if err := m.saveItems(); err != nil {
+		return m.saveFailed(err)
+	}
+	var cmds []tea.Cmd
+	for _, item := range completed {
+		cmds = append(cmds, m.runHook(hooks.EventComplete, item), m.chime())
+	}
+	for _, item := range removed {
+		cmds = append(cmds, m.runHook(hooks.EventDelete, item))
+	}
+	cmds = append(cmds, m.NewStatusMessage(fmt.Sprintf("Reviewed %d stale tasks", len(decisions))))
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,51 @@
+package views
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"clitodo/pkg/notify"
+)
+
+type notifyFailedMsg struct {
+	err error
+}
+
+// notify sends n in the background. During quiet hours it is queued instead.
+func (m *ListScreen) notify(n notify.Notification) tea.Cmd {
+	if m.Notifications == nil {
+		return nil
+	}
+	center := m.Notifications
+	return func() tea.Msg {
+		if err := center.Notify(n); err != nil {
+			return notifyFailedMsg{err}
+		}
+		return nil
+	}
+}
+
+// flushNotifications delivers notifications held back during quiet hours once
+// they're over, as a single digest.
+func (m *ListScreen) flushNotifications() tea.Cmd {
+	if m.Notifications.Queued() == 0 {
+		return nil
+	}
+	center := m.Notifications
+	return func() tea.Msg {
+		if err := center.Flush(); err != nil {
+			return notifyFailedMsg{err}
+		}
+		return nil
+	}
+}
+
+// quietHoursIndicator is shown in the status bar while quiet hours are active,
+// so held-back notifications aren't a mystery.
+func quietHoursIndicator(queued int) string {
+	if queued == 0 {
+		return "☾"
+	}
+	return fmt.Sprintf("☾ %d held", queued)
+}
@@ -0,0 +1,130 @@
+package views
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// goToPrompt is the number typed so far to go to the row shown with it. It
+// takes the digits while it's open, so they don't reach other bindings.
+type goToPrompt struct {
+	digits string
+}
+
+// numbered reports whether the rows are shown with their numbers.
+func (m ListScreen) numbered() bool {
+	d, ok := m.delegate.(DefaultDelegate)
+	return ok && d.ShowNumbers
+}
+
+// rowNumber returns the number the row at index of the visible items is shown
+// with, counting from 1 as the list is shown. Section headers aren't counted
+// and get 0.
+func (m ListScreen) rowNumber(index int) int {
+	items := m.VisibleItems()
+	if index < 0 || index >= len(items) || isHeader(items[index]) {
+		return 0
+	}
+	n := 0
+	for _, item := range items[:index+1] {
+		if !isHeader(item) {
+			n++
+		}
+	}
+	return n
+}
+
+// rowForNumber returns the index of the visible row shown with number n, or
+// -1 if there's none.
+func (m ListScreen) rowForNumber(n int) int {
+	for i, item := range m.VisibleItems() {
+		if isHeader(item) {
+			continue
+		}
+		n--
+		if n == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// numberWidth returns how many digits the largest row number has, so the
+// numbers line up.
+func (m ListScreen) numberWidth() int {
+	n := 0
+	for _, item := range m.VisibleItems() {
+		if !isHeader(item) {
+			n++
+		}
+	}
+	return len(strconv.Itoa(n))
+}
+
+// openGoTo opens the prompt for a row number, starting with the digit of
+// the key that opened it, if it's one.
+func (m *ListScreen) openGoTo(msg tea.KeyMsg) {
+	m.hideStatusMessage()
+	m.goTo = &goToPrompt{}
+	if s := msg.String(); len(s) == 1 && s[0] >= '0' && s[0] <= '9' {
+		m.goTo.digits = s
+	}
+	m.updateKeybindings()
+}
+
+// closeGoTo closes the number prompt without moving the selection.
+func (m *ListScreen) closeGoTo() {
+	m.goTo = nil
+	m.updateKeybindings()
+}
+
+// Updates for when the number prompt is open. The list's own keybindings are
+// disabled meanwhile, see updateKeybindings; keys other than digits and
+// backspace do nothing.
+func (m *ListScreen) handleGoTo(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, m.KeyMap.CancelGoTo):
+		m.closeGoTo()
+
+	case key.Matches(msg, m.KeyMap.AcceptGoTo):
+		digits := m.goTo.digits
+		m.closeGoTo()
+		if digits == "" {
+			return nil
+		}
+		n, _ := strconv.Atoi(digits)
+		i := m.rowForNumber(n)
+		if i < 0 {
+			return m.NewStatusMessage(fmt.Sprintf("There's no task %d", n))
+		}
+		m.Select(i)
+
+	case msg.Type == tea.KeyBackspace:
+		if m.goTo.digits == "" {
+			m.closeGoTo()
+			return nil
+		}
+		m.goTo.digits = m.goTo.digits[:len(m.goTo.digits)-1]
+
+	case msg.Type == tea.KeyRunes && len(msg.Runes) == 1 && msg.Runes[0] >= '0' && msg.Runes[0] <= '9':
+		// More digits than the largest number can't go anywhere.
+		if len(m.goTo.digits) < m.numberWidth() {
+			m.goTo.digits += string(msg.Runes)
+		}
+	}
+	return nil
+}
+
+func (m ListScreen) goToView() string {
+	return m.Styles.FilterPrompt.Render("Go to: ") + m.goTo.digits
+}
+
+func (m ListScreen) goToHelp() []key.Binding {
+	return []key.Binding{
+		m.KeyMap.AcceptGoTo,
+		m.KeyMap.CancelGoTo,
+	}
+}
@@ -0,0 +1,101 @@
+package views
+
+import "clitodo/pkg/domain"
+
+// VariableHeightDelegate is an ItemDelegate whose items don't all take up the
+// same number of lines, such as ones with titles wrapped onto several. The
+// list fills its pages by HeightFor instead of Height.
+type VariableHeightDelegate interface {
+	ItemDelegate
+
+	// HeightFor is the height in lines of the item at index of the visible
+	// items.
+	HeightFor(m ListScreen, index int, item domain.Item) int
+}
+
+// itemHeight returns how many lines the visible item at index takes up. An
+// item higher than a page is cut off at the page's height.
+func (m ListScreen) itemHeight(index int, item domain.Item) int {
+	height := m.delegate.Height()
+	if d, ok := m.delegate.(VariableHeightDelegate); ok {
+		height = d.HeightFor(m, index, item)
+	}
+	if m.pageHeight > 0 {
+		height = min(height, m.pageHeight)
+	}
+	return max(1, height)
+}
+
+// paginate splits the visible items into pages of at most height lines, each
+// item taking up its own height and the spacing after it, and at least one
+// item on every page. With items of the same height every page but the last
+// has as many of them as fit.
+func (m *ListScreen) paginate(height int) {
+	m.pageHeight = height
+	m.pageStarts = []int{0}
+	used := 0
+	for i, item := range m.VisibleItems() {
+		h := m.itemHeight(i, item) + m.delegate.Spacing()
+		if used > 0 && used+h > height {
+			m.pageStarts = append(m.pageStarts, i)
+			used = 0
+		}
+		used += h
+	}
+	m.Paginator.TotalPages = len(m.pageStarts)
+}
+
+// pageBounds returns the range of the visible items on page.
+func (m ListScreen) pageBounds(page int) (start, end int) {
+	n := len(m.VisibleItems())
+	if page < 0 || page >= len(m.pageStarts) {
+		return n, n
+	}
+	start, end = min(m.pageStarts[page], n), n
+	if page+1 < len(m.pageStarts) {
+		end = min(m.pageStarts[page+1], n)
+	}
+	return start, end
+}
+
+// pageOf returns the page the visible item at index is on and its place on
+// that page.
+func (m ListScreen) pageOf(index int) (page, cursor int) {
+	for page+1 < len(m.pageStarts) && m.pageStarts[page+1] <= index {
+		page++
+	}
+	if page < len(m.pageStarts) {
+		index -= m.pageStarts[page]
+	}
+	return page, index
+}
+
+// itemsOnPage returns how many items the current page has.
+func (m ListScreen) itemsOnPage() int {
+	start, end := m.pageBounds(m.Paginator.Page)
+	return end - start
+}
+
+// pageLines returns how many lines the items of the current page take up,
+// counting the spacing after each.
+func (m ListScreen) pageLines() int {
+	start, end := m.pageBounds(m.Paginator.Page)
+	items := m.VisibleItems()
+	lines := 0
+	for i := start; i < end; i++ {
+		lines += m.itemHeight(i, items[i]) + m.delegate.Spacing()
+	}
+	return lines
+}
+
+// rowTop returns the line the item at cursor on the current page starts on,
+// counted from the first one's.
+func (m ListScreen) rowTop(cursor int) int {
+	start, end := m.pageBounds(m.Paginator.Page)
+	items := m.VisibleItems()
+	line := 0
+	for i := start; i < min(start+cursor, end); i++ {
+		line += m.itemHeight(i, items[i]) + m.delegate.Spacing()
+	}
+	return line
+}
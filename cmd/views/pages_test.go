@@ -0,0 +1,235 @@
+package views
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"clitodo/cmd"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/storage"
+)
+
+func TestWrapTitle(t *testing.T) {
+	tests := []struct {
+		title      string
+		width      int
+		suffix     int
+		lines      []string
+		from       []int
+		suffixLine bool
+	}{
+		{"pay the rent", 20, 0, []string{"pay the rent"}, []int{0}, false},
+		{"pay the rent", 20, 8, []string{"pay the rent"}, []int{0}, false},
+		{"pay the rent", 12, 1, []string{"pay the rent"}, []int{0}, true},
+		{"pay the rent", 8, 0, []string{"pay the", "rent"}, []int{0, 8}, false},
+		{"pay the rent", 8, 4, []string{"pay the", "rent"}, []int{0, 8}, false},
+		{"pay the rent", 8, 5, []string{"pay the", "rent"}, []int{0, 8}, true},
+		{"pay   the rent", 8, 0, []string{"pay", "the rent"}, []int{0, 6}, false},
+		{"supercalifragilistic", 5, 0, []string{"super", "calif", "ragil", "istic"}, []int{0, 5, 10, 15}, false},
+		{"東京 trip", 4, 0, []string{"東京", "trip"}, []int{0, 3}, false},
+		{"pay the rent", 0, 0, []string{"p", "a", "y", "t", "h", "e", "r", "e", "n", "t"}, []int{0, 1, 2, 4, 5, 6, 8, 9, 10, 11}, false},
+	}
+	for _, tt := range tests {
+		lines, suffixLine := wrapTitle(tt.title, tt.width, tt.suffix)
+		var texts []string
+		var from []int
+		for _, line := range lines {
+			texts = append(texts, line.text)
+			from = append(from, line.from)
+		}
+		if !slices.Equal(texts, tt.lines) || !slices.Equal(from, tt.from) || suffixLine != tt.suffixLine {
+			t.Errorf("wrapTitle(%q, %d, %d) = %q from %v, %t, want %q from %v, %t",
+				tt.title, tt.width, tt.suffix, texts, from, suffixLine, tt.lines, tt.from, tt.suffixLine)
+		}
+	}
+}
+
+// heightsDelegate draws items as tall as heights says by their title, one
+// line for the others.
+type heightsDelegate struct {
+	DefaultDelegate
+	heights map[string]int
+}
+
+func (d heightsDelegate) HeightFor(_ ListScreen, _ int, item domain.Item) int {
+	if h, ok := d.heights[item.Title()]; ok {
+		return h
+	}
+	return 1
+}
+
+// pagedList returns a list of items drawn by a heightsDelegate with spacing
+// between them, split into pages of height lines.
+func pagedList(t *testing.T, heights map[string]int, spacing int, height int, titles ...string) *ListScreen {
+	t.Helper()
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	d := heightsDelegate{DefaultDelegate: NewThemedDelegate(cmd.DefaultTheme()), heights: heights}
+	d.SetSpacing(spacing)
+	m := NewListScreen(cmd.DefaultTheme(), storage.NewMemoryItemRepository(titledItems(titles...)))
+	m.SetDelegate(d)
+	m.paginate(height)
+	return m
+}
+
+func TestPaginateMultiLineRows(t *testing.T) {
+	heights := map[string]int{"b": 3, "c": 2, "e": 5, "g": 0}
+	tests := []struct {
+		name    string
+		spacing int
+		height  int
+		starts  []int
+	}{
+		// e is cut off at the page's height and has a page of its own.
+		{"no spacing", 0, 4, []int{0, 2, 4, 5}},
+		{"spacing", 1, 4, []int{0, 1, 2, 3, 4, 5}},
+		{"all on one page", 0, 20, []int{0}},
+		// Every page has an item, even one higher than the page.
+		{"pages of one line", 0, 1, []int{0, 1, 2, 3, 4, 5, 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := pagedList(t, heights, tt.spacing, tt.height, "a", "b", "c", "d", "e", "f", "g")
+			if !slices.Equal(m.pageStarts, tt.starts) {
+				t.Errorf("pages start at %v, want %v", m.pageStarts, tt.starts)
+			}
+			if m.Paginator.TotalPages != len(tt.starts) {
+				t.Errorf("%d pages, want %d", m.Paginator.TotalPages, len(tt.starts))
+			}
+			items := m.VisibleItems()
+			for page := range tt.starts {
+				start, end := m.pageBounds(page)
+				lines := 0
+				for i := start; i < end; i++ {
+					lines += m.itemHeight(i, items[i]) + tt.spacing
+				}
+				if end-start > 1 && lines > tt.height {
+					t.Errorf("page %d takes up %d lines of %d", page, lines, tt.height)
+				}
+			}
+		})
+	}
+}
+
+func TestItemHeight(t *testing.T) {
+	m := pagedList(t, map[string]int{"b": 3, "e": 5, "g": 0}, 0, 4, "a", "b", "e", "g")
+	items := m.VisibleItems()
+	for i, want := range []int{1, 3, 4, 1} {
+		if got := m.itemHeight(i, items[i]); got != want {
+			t.Errorf("item %q is %d lines high, want %d", items[i].Title(), got, want)
+		}
+	}
+
+	// Before the list knows its height nothing is cut off.
+	m.pageHeight = 0
+	if got := m.itemHeight(2, items[2]); got != 5 {
+		t.Errorf("without a page height item e is %d lines high, want 5", got)
+	}
+}
+
+func TestPageBounds(t *testing.T) {
+	m := pagedList(t, map[string]int{"b": 3, "c": 2, "e": 5}, 0, 4, "a", "b", "c", "d", "e", "f")
+	tests := []struct {
+		page, start, end int
+	}{
+		{0, 0, 2},
+		{1, 2, 4},
+		{2, 4, 5},
+		{3, 5, 6},
+		{4, 6, 6},
+		{-1, 6, 6},
+	}
+	for _, tt := range tests {
+		if start, end := m.pageBounds(tt.page); start != tt.start || end != tt.end {
+			t.Errorf("pageBounds(%d) = %d, %d, want %d, %d", tt.page, start, end, tt.start, tt.end)
+		}
+	}
+
+	for index, want := range [][2]int{{0, 0}, {0, 1}, {1, 0}, {1, 1}, {2, 0}, {3, 0}} {
+		if page, cursor := m.pageOf(index); page != want[0] || cursor != want[1] {
+			t.Errorf("pageOf(%d) = %d, %d, want %d, %d", index, page, cursor, want[0], want[1])
+		}
+	}
+}
+
+func TestPaginateWrappedTitles(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	titles := []string{
+		"water the plants",
+		"renew the passport before the trip in the spring and book the appointment early",
+		"pay the rent",
+		"sort out the insurance for the car and the flat before the end of the month",
+		"call mum",
+	}
+	d := NewThemedDelegate(cmd.DefaultTheme())
+	d.WrapTitles = true
+	m := NewListScreen(cmd.DefaultTheme(), storage.NewMemoryItemRepository(titledItems(titles...)))
+	m.SetDelegate(d)
+	m.SetSize(30, 12)
+
+	items := m.VisibleItems()
+	var heights []int
+	for i, item := range items {
+		heights = append(heights, m.itemHeight(i, item))
+	}
+	if heights[0] != 1 || heights[1] < 3 || heights[3] < 3 {
+		t.Fatalf("rows are %v lines high, want the long titles wrapped", heights)
+	}
+
+	// Every page holds what fits of the rows in turn, and the rows on the
+	// page shown take up as many lines as their heights say.
+	for page := 0; page < m.Paginator.TotalPages; page++ {
+		start, end := m.pageBounds(page)
+		lines := 0
+		for i := start; i < end; i++ {
+			lines += heights[i] + d.Spacing()
+		}
+		if lines > m.pageHeight {
+			t.Errorf("page %d takes up %d lines of %d", page, lines, m.pageHeight)
+		}
+		if end < len(items) && lines+heights[end]+d.Spacing() <= m.pageHeight {
+			t.Errorf("page %d ends before %q, which fits on it", page, items[end].Title())
+		}
+	}
+	for i, item := range items[:m.itemsOnPage()] {
+		var b strings.Builder
+		d.Render(&b, *m, i, item)
+		if got := strings.Count(b.String(), "\n") + 1; got != heights[i] {
+			t.Errorf("row %q renders %d lines, want %d", item.Title(), got, heights[i])
+		}
+	}
+}
+
+func TestPageSummaryView(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	items := titledItems(numberedTasks(17)...)
+	for _, i := range []int{1, 8, 9, 15} {
+		items[i].ItemCompleted = true
+	}
+	m := NewListScreen(cmd.DefaultTheme(), storage.NewMemoryItemRepository(items))
+	m.SetShowPageSummary(true)
+
+	// Each golden file has the page at both widths; a narrow list fits
+	// another number of rows on a page.
+	summaries := map[string]*strings.Builder{"first": {}, "middle": {}, "last": {}}
+	for _, width := range []int{80, 30} {
+		m.SetSize(width, 24)
+		pages := m.Paginator.TotalPages
+		if pages < 3 {
+			t.Fatalf("%d pages at %d columns, want at least 3", pages, width)
+		}
+		for name, page := range map[string]int{"first": 0, "middle": pages / 2, "last": pages - 1} {
+			m.Paginator.Page = page
+			fmt.Fprintf(summaries[name], "%d columns, page %d of %d:\n%s\n", width, page+1, pages, m.pageSummaryView())
+		}
+	}
+	for name, b := range summaries {
+		golden(t, "pageSummary-"+name, b.String())
+	}
+
+	m.SetSize(80, 60)
+	if got := m.pageSummaryView(); got != "" {
+		t.Errorf("with everything on one page the summary is %q", got)
+	}
+}
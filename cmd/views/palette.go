@@ -0,0 +1,379 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"clitodo/cmd"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/match"
+)
+
+// paletteCommand is an action of the list that can be run by name from the
+// command palette.
+type paletteCommand struct {
+	name string
+
+	// The key that does the same. The command is offered while that key is
+	// enabled, and the key is shown next to it. Nil for commands that are
+	// always offered.
+	binding func(cmd.KeyMap) key.Binding
+
+	run func(m *ListScreen) tea.Cmd
+}
+
+// paletteCommands are the commands the palette offers, in the order it
+// lists them before anything is typed.
+var paletteCommands = []paletteCommand{
+	{name: "Add task", run: (*ListScreen).startAdding},
+	{
+		name:    "Add subtask",
+		binding: func(k cmd.KeyMap) key.Binding { return k.AddSubtask },
+		run:     func(m *ListScreen) tea.Cmd { return m.addSubtask() },
+	},
+	{
+		name:    "Edit task",
+		binding: func(k cmd.KeyMap) key.Binding { return k.EditItem },
+		run:     func(m *ListScreen) tea.Cmd { return m.editSelected() },
+	},
+	{
+		name:    "Complete or reopen task",
+		binding: func(k cmd.KeyMap) key.Binding { return k.ToggleDone },
+		run:     (*ListScreen).toggleSelected,
+	},
+	{
+		name:    "Delete task",
+		binding: func(k cmd.KeyMap) key.Binding { return k.DeleteItem },
+		run:     (*ListScreen).deleteSelected,
+	},
+	{
+		name:    "Raise priority",
+		binding: func(k cmd.KeyMap) key.Binding { return k.RaisePrio },
+		run: func(m *ListScreen) tea.Cmd {
+			return m.changePriority(domain.Priority.Raise)
+		},
+	},
+	{
+		name:    "Lower priority",
+		binding: func(k cmd.KeyMap) key.Binding { return k.LowerPrio },
+		run: func(m *ListScreen) tea.Cmd {
+			return m.changePriority(domain.Priority.Lower)
+		},
+	},
+	{
+		name:    "Mark waiting",
+		binding: func(k cmd.KeyMap) key.Binding { return k.Waiting },
+		run:     (*ListScreen).toggleWaiting,
+	},
+	{
+		name:    "Put aside for some day",
+		binding: func(k cmd.KeyMap) key.Binding { return k.Someday },
+		run:     (*ListScreen).moveSomeday,
+	},
+	{
+		name:    "Show some day tasks",
+		binding: func(k cmd.KeyMap) key.Binding { return k.ShowSomeday },
+		run: func(m *ListScreen) tea.Cmd {
+			m.SetShowSomeday(!m.showSomeday)
+			return nil
+		},
+	},
+	{
+		name:    "Clear completed",
+		binding: func(k cmd.KeyMap) key.Binding { return k.ClearDone },
+		run:     (*ListScreen).ClearCompleted,
+	},
+	{
+		name:    "Hide completed",
+		binding: func(k cmd.KeyMap) key.Binding { return k.HideDone },
+		run: func(m *ListScreen) tea.Cmd {
+			m.toggleShowCompleted()
+			return nil
+		},
+	},
+	{
+		name:    "Switch sort",
+		binding: func(k cmd.KeyMap) key.Binding { return k.SortMode },
+		run: func(m *ListScreen) tea.Cmd {
+			m.cycleSortMode()
+			return nil
+		},
+	},
+	{
+		name:    "Keep sorted order",
+		binding: func(k cmd.KeyMap) key.Binding { return k.ApplySort },
+		run:     (*ListScreen).applySort,
+	},
+	{
+		name:    "Agenda",
+		binding: func(k cmd.KeyMap) key.Binding { return k.Agenda },
+		run:     (*ListScreen).toggleAgenda,
+	},
+	{
+		name:    "Jump to task",
+		binding: func(k cmd.KeyMap) key.Binding { return k.Jump },
+		run:     (*ListScreen).OpenJump,
+	},
+	{
+		name:    "Go to number",
+		binding: func(k cmd.KeyMap) key.Binding { return k.GoToNumber },
+		run: func(m *ListScreen) tea.Cmd {
+			m.openGoTo(tea.KeyMsg{})
+			return nil
+		},
+	},
+	{
+		name:    "Undo",
+		binding: func(k cmd.KeyMap) key.Binding { return k.Undo },
+		run:     (*ListScreen).undoChange,
+	},
+	{
+		name:    "Redo",
+		binding: func(k cmd.KeyMap) key.Binding { return k.Redo },
+		run:     (*ListScreen).redoChange,
+	},
+	{
+		name:    "Copy task",
+		binding: func(k cmd.KeyMap) key.Binding { return k.CopyItem },
+		run:     (*ListScreen).copySelected,
+	},
+	{
+		name:    "Copy list",
+		binding: func(k cmd.KeyMap) key.Binding { return k.CopyList },
+		run:     (*ListScreen).copyList,
+	},
+	{
+		name:    "Copy link to task",
+		binding: func(k cmd.KeyMap) key.Binding { return k.CopyLink },
+		run:     (*ListScreen).copyLink,
+	},
+	{
+		name:    "Details pane",
+		binding: func(k cmd.KeyMap) key.Binding { return k.ToggleDetail },
+		run: func(m *ListScreen) tea.Cmd {
+			m.ToggleSplit()
+			return nil
+		},
+	},
+	{
+		name:    "Zen mode",
+		binding: func(k cmd.KeyMap) key.Binding { return k.Zen },
+		run: func(m *ListScreen) tea.Cmd {
+			m.toggleZen()
+			return nil
+		},
+	},
+	{
+		name:    "Show or hide all keys",
+		binding: func(k cmd.KeyMap) key.Binding { return k.ShowFullHelp },
+		run: func(m *ListScreen) tea.Cmd {
+			m.toggleFullHelp()
+			return nil
+		},
+	},
+	{
+		name:    "Show or hide notes",
+		binding: func(k cmd.KeyMap) key.Binding { return k.ToggleNotes },
+		run: func(m *ListScreen) tea.Cmd {
+			m.ToggleDescription()
+			return nil
+		},
+	},
+	{
+		name:    "Board",
+		binding: func(k cmd.KeyMap) key.Binding { return k.Board },
+		run:     func(*ListScreen) tea.Cmd { return showBoard },
+	},
+	{
+		name:    "Stats",
+		binding: func(k cmd.KeyMap) key.Binding { return k.Stats },
+		run:     func(*ListScreen) tea.Cmd { return showStats },
+	},
+	{
+		name: "Create backup bundle",
+		run:  (*ListScreen).createBackup,
+	},
+	{
+		name: "Version",
+		run: func(*ListScreen) tea.Cmd {
+			return func() tea.Msg { return cmd.VersionTrigger{} }
+		},
+	},
+	{
+		name:    "Activity",
+		binding: func(k cmd.KeyMap) key.Binding { return k.Activity },
+		run:     func(*ListScreen) tea.Cmd { return showActivity },
+	},
+	{
+		name:    "Find duplicates",
+		binding: func(k cmd.KeyMap) key.Binding { return k.Dedupe },
+		run:     func(*ListScreen) tea.Cmd { return showDuplicates },
+	},
+	{
+		name:    "Change theme",
+		binding: func(k cmd.KeyMap) key.Binding { return k.CycleTheme },
+		run:     func(*ListScreen) tea.Cmd { return cycleTheme },
+	},
+	{
+		name:    "Switch list",
+		binding: func(k cmd.KeyMap) key.Binding { return k.Workspace },
+		run:     (*ListScreen).openWorkspaces,
+	},
+	{
+		name:    "New list",
+		binding: func(k cmd.KeyMap) key.Binding { return k.NewList },
+		run:     (*ListScreen).newList,
+	},
+	{
+		name:    "Move task to list",
+		binding: func(k cmd.KeyMap) key.Binding { return k.MoveToList },
+		run:     (*ListScreen).moveToList,
+	},
+}
+
+// paletteOverlay is the command palette: a prompt that finds a command by
+// name and runs it.
+type paletteOverlay struct {
+	input    textinput.Model
+	commands []paletteCommand // those available when it was opened
+	matches  []Rank           // ranks over commands
+	cursor   int
+}
+
+// OpenPalette opens the command palette. Note that this returns a command.
+func (m *ListScreen) OpenPalette() tea.Cmd {
+	input := textinput.New()
+	input.Prompt = "Command: "
+	input.PromptStyle = m.Styles.FilterPrompt
+	input.Cursor.Style = m.Styles.FilterCursor
+	input.CharLimit = 64
+	input.Width = m.FilterInput.Width
+	input.Focus()
+
+	// Which commands are available is read off the keys before the palette
+	// takes the keyboard and disables them.
+	var commands []paletteCommand
+	for _, c := range paletteCommands {
+		if c.binding == nil || c.binding(m.KeyMap).Enabled() {
+			commands = append(commands, c)
+		}
+	}
+
+	m.hideStatusMessage()
+	m.palette = &paletteOverlay{input: input, commands: commands}
+	m.palette.match()
+	m.updateKeybindings()
+	return textinput.Blink
+}
+
+// ClosePalette closes the command palette without running anything.
+func (m *ListScreen) ClosePalette() {
+	m.palette = nil
+	m.updateKeybindings()
+}
+
+func (p *paletteOverlay) match() {
+	if p.input.Value() == "" {
+		p.matches = make([]Rank, len(p.commands))
+		for i := range p.commands {
+			p.matches[i] = Rank{Index: i}
+		}
+	} else {
+		names := make([]string, len(p.commands))
+		for i, c := range p.commands {
+			names[i] = c.name
+		}
+		p.matches = match.DefaultFilter(p.input.Value(), names)
+	}
+	p.cursor = min(p.cursor, max(0, len(p.matches)-1))
+}
+
+// Updates for when the command palette is open. The list's own keybindings
+// are disabled meanwhile, see updateKeybindings.
+func (m *ListScreen) handlePalette(msg tea.Msg) tea.Cmd {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, m.KeyMap.CancelPalette):
+			m.ClosePalette()
+			return nil
+
+		case key.Matches(msg, m.KeyMap.AcceptPalette):
+			if len(m.palette.matches) == 0 {
+				return nil
+			}
+			c := m.palette.commands[m.palette.matches[m.palette.cursor].Index]
+			m.ClosePalette()
+			return c.run(m)
+
+		case key.Matches(msg, m.KeyMap.PaletteUp):
+			m.palette.cursor = max(0, m.palette.cursor-1)
+			return nil
+
+		case key.Matches(msg, m.KeyMap.PaletteDown):
+			m.palette.cursor = min(len(m.palette.matches)-1, m.palette.cursor+1)
+			return nil
+		}
+	}
+
+	var cmd tea.Cmd
+	before := m.palette.input.Value()
+	m.palette.input, cmd = m.palette.input.Update(msg)
+	if m.palette.input.Value() != before {
+		m.palette.cursor = 0
+		m.palette.match()
+	}
+	return cmd
+}
+
+// paletteView lists the matching commands, each with the key that does the
+// same on the right.
+func (m ListScreen) paletteView(height int) string {
+	var (
+		b strings.Builder
+		s = NewDefaultItemStyles()
+	)
+	if d, ok := m.delegate.(DefaultDelegate); ok {
+		s = d.Styles
+	}
+	width := m.width - s.NormalTitle.GetHorizontalFrameSize()
+
+	if len(m.palette.matches) == 0 {
+		return m.Styles.NoItems.Render("  No such command")
+	}
+
+	// Keep the highlighted command on screen.
+	start := max(0, m.palette.cursor-height+1)
+	end := min(len(m.palette.matches), start+height)
+	for i, r := range m.palette.matches[start:end] {
+		c := m.palette.commands[r.Index]
+		var hint string
+		if c.binding != nil {
+			hint = c.binding(m.KeyMap).Help().Key
+		}
+		name := ansi.Truncate(c.name, width-lipgloss.Width(hint)-1, cmd.Ellipsis)
+		gap := strings.Repeat(" ", max(1, width-lipgloss.Width(name)-lipgloss.Width(hint)))
+		if start+i == m.palette.cursor {
+			b.WriteString(s.SelectedTitle.Render(name + gap + hint))
+		} else {
+			b.WriteString(s.NormalTitle.Render(name + gap + s.DimmedTitle.UnsetPadding().Render(hint)))
+		}
+		if start+i != end-1 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+func (m ListScreen) paletteHelp() []key.Binding {
+	return []key.Binding{
+		m.KeyMap.PaletteUp,
+		m.KeyMap.PaletteDown,
+		m.KeyMap.AcceptPalette,
+		m.KeyMap.CancelPalette,
+	}
+}
@@ -0,0 +1,57 @@
+package views
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"clitodo/pkg/domain"
+)
+
+// dueSoon returns the open task due soonest within QuitWarning of now. Tasks
+// waiting on someone else and those put aside for some day don't count, and
+// neither do overdue ones, which the status bar already points out.
+func (m ListScreen) dueSoon(now time.Time) (domain.Item, bool) {
+	var (
+		soonest domain.Item
+		found   bool
+	)
+	if m.QuitWarning <= 0 {
+		return soonest, false
+	}
+	for _, item := range m.items {
+		if item.Due == nil || item.Completed() || item.IsWaiting() || item.Someday || isHeader(item) {
+			continue
+		}
+		by := domain.DueBy(*item.Due)
+		if !by.After(now) || by.Sub(now) > m.QuitWarning {
+			continue
+		}
+		if !found || by.Before(domain.DueBy(*soonest.Due)) {
+			soonest, found = item, true
+		}
+	}
+	return soonest, found
+}
+
+// quit quits, unless a task is due within QuitWarning. Then it asks first,
+// naming the task.
+func (m *ListScreen) quit() tea.Cmd {
+	now := m.Clock.Now()
+	item, ok := m.dueSoon(now)
+	if !ok {
+		return tea.Quit
+	}
+
+	// Rounded up, so a deadline 30 seconds away isn't "due in 0s".
+	left := domain.DueBy(*item.Due).Sub(now)
+	left = (left + time.Minute - 1).Truncate(time.Minute)
+	m.confirm = &confirmation{
+		question:  fmt.Sprintf("“%s” due in %s — quit anyway?", item.Title(), domain.Duration(left)),
+		apply:     func() tea.Cmd { return tea.Quit },
+		cancelled: "Still here",
+	}
+	m.updateKeybindings()
+	return nil
+}
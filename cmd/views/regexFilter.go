@@ -0,0 +1,52 @@
+package views
+
+import (
+	"errors"
+	"regexp/syntax"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"clitodo/pkg/match"
+)
+
+// Filter prompts for fuzzy matching and for regular expressions.
+const (
+	fuzzyPrompt = "Filter: "
+	regexPrompt = "Regex: "
+)
+
+// toggleRegex switches the filter between fuzzy matching and regular
+// expressions and filters again.
+func (m *ListScreen) toggleRegex() tea.Cmd {
+	if m.fuzzyFilter != nil {
+		m.Filter, m.fuzzyFilter = m.fuzzyFilter, nil
+		m.FilterInput.Prompt = fuzzyPrompt
+	} else {
+		m.Filter, m.fuzzyFilter = match.RegexFilter, m.Filter
+		m.FilterInput.Prompt = regexPrompt
+	}
+	m.setSize(m.width, m.height)
+	m.checkPattern()
+	return filterItems(*m)
+}
+
+// checkPattern notes what's wrong with the regular expression typed into the
+// filter, for the status bar.
+func (m *ListScreen) checkPattern() {
+	m.patternErr = nil
+	if m.fuzzyFilter == nil {
+		return
+	}
+	term, _ := parseFilterTokens(m.FilterInput.Value(), m.Clock.Now())
+	_, m.patternErr = match.CompilePattern(term)
+}
+
+// patternErrorMessage says what's wrong with a pattern, without the pattern
+// itself, which is in the filter anyway.
+func patternErrorMessage(err error) string {
+	var syntaxErr *syntax.Error
+	if errors.As(err, &syntaxErr) {
+		return syntaxErr.Code.String()
+	}
+	return err.Error()
+}
@@ -0,0 +1,66 @@
+package views
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+
+	"clitodo/cmd"
+	"clitodo/pkg/notify"
+	"clitodo/pkg/remind"
+)
+
+// fireReminders fires the reminders due at now and saves how often they
+// fired. Each one sends a notification; the last of a repeating series also
+// rings the bell and stays in the status bar until the task is done.
+func (m *ListScreen) fireReminders(now time.Time) tea.Cmd {
+	// A read-only list leaves them to the clitodo that can save how often
+	// they fired.
+	if m.loading || m.itemRepository.ReadOnly() {
+		return nil
+	}
+	items := slices.Clone(m.items)
+	fired := remind.Fire(items, now)
+	if len(fired) == 0 {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	for _, f := range fired {
+		m.SetItemByID(f.Item.ID, f.Item)
+		n := notify.Notification{Title: "Reminder", Body: f.Item.Title()}
+		if f.Final {
+			n = notify.Notification{Title: "Last reminder", Body: f.Item.Title(), Bell: true}
+		}
+		cmds = append(cmds, m.notify(n))
+	}
+	if err := m.saveItems(); err != nil {
+		return tea.Batch(append(cmds, m.saveFailed(err))...)
+	}
+
+	if last := fired[len(fired)-1]; !last.Final {
+		cmds = append(cmds, m.NewStatusMessage("Reminder: "+last.Item.Title()))
+	}
+	return tea.Batch(cmds...)
+}
+
+// reminderView is the banner for the open tasks whose reminders ran out
+// unanswered, or empty if there are none.
+func (m ListScreen) reminderView() string {
+	var titles []string
+	for _, item := range m.items {
+		if item.Remind != nil && !item.Completed() && item.Remind.Escalated() {
+			titles = append(titles, item.Title())
+		}
+	}
+	switch len(titles) {
+	case 0:
+		return ""
+	case 1:
+		return m.Styles.StatusBarReminder.Render("⏰ “" + ansi.Truncate(titles[0], 24, cmd.Ellipsis) + "”") //nolint:mnd
+	}
+	return m.Styles.StatusBarReminder.Render(fmt.Sprintf("⏰ %d reminders unanswered", len(titles)))
+}
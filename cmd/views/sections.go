@@ -0,0 +1,131 @@
+package views
+
+import (
+	"fmt"
+	"slices"
+
+	"clitodo/pkg/domain"
+	"clitodo/pkg/state"
+)
+
+// SetCollapsedSections sets which sections of the list only show their
+// header, by the IDs of their headers. The done section has its own
+// setting, see SetDoneExpanded.
+func (m *ListScreen) SetCollapsedSections(ids []string) {
+	m.collapsedSections = slices.Clone(ids)
+	m.updatePagination()
+}
+
+// sectionCollapsed reports whether the section with the given header ID only
+// shows its header.
+func (m ListScreen) sectionCollapsed(id string) bool {
+	if id == doneHeaderID {
+		return !m.doneExpanded
+	}
+	return slices.Contains(m.collapsedSections, id)
+}
+
+// sectionHeader returns the header row of a section of count tasks. While the
+// section is collapsed it says they're hidden.
+func (m ListScreen) sectionHeader(id, name string, count int) filteredItem {
+	title := fmt.Sprintf("%s (%d)", name, count)
+	if m.sectionCollapsed(id) {
+		title = fmt.Sprintf("%s (%d hidden)", name, count)
+	}
+	return filteredItem{item: domain.Item{ID: id, ItemTitle: title}, index: -1}
+}
+
+// collapse drops the rows of the collapsed sections, keeping their headers,
+// so each of those takes up one row. A section's rows are the ones up to the
+// next header.
+func (m ListScreen) collapse(rows filteredItems) filteredItems {
+	kept := make(filteredItems, 0, len(rows))
+	collapsed := false
+	for _, row := range rows {
+		if row.index < 0 {
+			collapsed = m.sectionCollapsed(row.item.ID)
+			kept = append(kept, row)
+		} else if !collapsed {
+			kept = append(kept, row)
+		}
+	}
+	return kept
+}
+
+// expanded returns the list with every section expanded, for counting the
+// items the collapsed ones hide.
+func (m ListScreen) expanded() ListScreen {
+	m.collapsedSections = nil
+	m.doneExpanded = true
+	return m
+}
+
+// unfilteredRows returns the rows of the unfiltered list, or nil if they're
+// the stored items as they are.
+func (m ListScreen) unfilteredRows() filteredItems {
+	switch {
+	case m.sectioned():
+		return m.arranged()
+	case m.projected():
+		return m.rows()
+	}
+	return nil
+}
+
+// selectedHeader returns the ID of the section header under the cursor, or ""
+// if the cursor is on a task.
+func (m ListScreen) selectedHeader() string {
+	items := m.VisibleItems()
+	i := m.Index()
+	if m.filterState != Unfiltered || i < 0 || i >= len(items) || !isHeader(items[i]) {
+		return ""
+	}
+	return items[i].ID
+}
+
+// toggleSection collapses or expands the section with the given header ID and
+// remembers that for the next start. The cursor stays on the header.
+func (m *ListScreen) toggleSection(id string) {
+	if id == doneHeaderID {
+		m.toggleDoneSection()
+	} else {
+		if i := slices.Index(m.collapsedSections, id); i >= 0 {
+			m.collapsedSections = slices.Delete(m.collapsedSections, i, i+1)
+		} else {
+			m.collapsedSections = append(m.collapsedSections, id)
+		}
+		if st, err := state.Load(); err == nil {
+			st.CollapsedSections = m.collapsedSections
+			st.Save()
+		}
+		m.refreshRows()
+	}
+	m.selectHeader(id)
+}
+
+// selectHeader puts the cursor on the section header with the given ID.
+func (m *ListScreen) selectHeader(id string) {
+	for i, item := range m.VisibleItems() {
+		if item.ID == id {
+			m.Select(i)
+			return
+		}
+	}
+}
+
+// revealSection expands the collapsed section that the item at index i of
+// the unfiltered list is in, if any.
+func (m *ListScreen) revealSection(i int) {
+	header := ""
+	for _, row := range m.expanded().unfilteredRows() {
+		switch {
+		case row.index < 0:
+			header = row.item.ID
+		case row.index == i:
+			if header != "" && m.sectionCollapsed(header) {
+				m.toggleSection(header)
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package views
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"clitodo/pkg/domain"
+)
+
+// sinkWhenIdle moves the completed tasks below the open ones once the list
+// has been left alone for SinkCompletedAfter, unless something is going on
+// that moving rows would get in the way of.
+func (m *ListScreen) sinkWhenIdle() tea.Cmd {
+	if m.SinkCompletedAfter <= 0 || m.Clock.Now().Sub(m.lastInput) < m.SinkCompletedAfter || !m.canSink() {
+		return nil
+	}
+	return m.sinkCompleted()
+}
+
+// canSink reports whether the rows can be moved now: the list is shown in the
+// order it's stored in, unfiltered, and nothing is being typed, picked or
+// confirmed.
+func (m ListScreen) canSink() bool {
+	busy := m.filterState != Unfiltered || m.jump != nil || m.goTo != nil || m.palette != nil ||
+		m.confirm != nil || m.chipFocus != nil || m.pending != nil || m.Importing()
+	return !busy && !m.loading && m.loadErr == nil && !m.itemRepository.ReadOnly() &&
+		m.sortMode == SortManual && !m.showAgenda
+}
+
+// sinkCompleted moves the completed tasks below the open ones among their
+// siblings in one go and saves, as one change that can be undone. The same
+// task stays selected.
+func (m *ListScreen) sinkCompleted() tea.Cmd {
+	sunk := domain.SinkCompleted(m.items)
+	if sameOrder(sunk, m.items) {
+		return nil
+	}
+	step := m.snapshot("Moved the completed tasks back", "Moved the completed tasks down")
+	m.items = sunk
+	m.refreshRows()
+	m.selectID(step.selected)
+	m.remember(step)
+	if err := m.saveItems(); err != nil {
+		return m.saveFailed(err)
+	}
+	return m.NewStatusMessage(step.redone)
+}
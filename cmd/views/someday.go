@@ -0,0 +1,204 @@
+package views
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"clitodo/pkg/domain"
+	"clitodo/pkg/storage"
+)
+
+// somedayHeaderID is the ID of the row heading the someday section. Like the
+// done section's header it's not a real item.
+const somedayHeaderID = "someday"
+
+// SetShowSomeday sets whether the tasks put aside for some day are listed in
+// a section at the end of the list, where they can be pulled back.
+func (m *ListScreen) SetShowSomeday(v bool) {
+	m.showSomeday = v
+	m.refreshRows()
+}
+
+// ShowSomeday returns whether the someday section is shown.
+func (m ListScreen) ShowSomeday() bool {
+	return m.showSomeday
+}
+
+// hasSomeday reports whether any task is put aside for some day.
+func (m ListScreen) hasSomeday() bool {
+	for _, item := range m.items {
+		if item.Someday {
+			return true
+		}
+	}
+	return false
+}
+
+// somedayCount returns how many items, subtasks included, are put aside.
+func (m ListScreen) somedayCount() int {
+	n := 0
+	for _, item := range m.items {
+		if item.Someday {
+			n++
+		}
+	}
+	return n
+}
+
+// withSomeday moves the someday rows to a section of their own at the end.
+// The section's header's index is -1.
+func (m ListScreen) withSomeday(rows filteredItems) filteredItems {
+	var active, someday filteredItems
+	count := 0
+	for _, row := range rows {
+		if !row.item.Someday {
+			active = append(active, row)
+			continue
+		}
+		someday = append(someday, row)
+		if row.item.Depth == 0 {
+			count++
+		}
+	}
+	if len(someday) == 0 {
+		return rows
+	}
+
+	active = append(active, m.sectionHeader(somedayHeaderID, "Someday", count))
+	return append(active, someday...)
+}
+
+// moveSomeday puts the selected task, with its subtasks, aside for some day,
+// or pulls it back into the list if it already is. For a subtask that's the
+// task it belongs to.
+func (m *ListScreen) moveSomeday() tea.Cmd {
+	selected := m.SelectedItem()
+	if selected == nil {
+		return nil
+	}
+	i := m.indexOfID(selected.ID)
+	for i > 0 && m.items[i].Depth > 0 {
+		i--
+	}
+	if !m.items[i].Someday && m.items[i].Completed() {
+		return m.NewStatusMessage("Completed tasks can't be put aside")
+	}
+
+	title := m.items[i].Title()
+	if !m.items[i].Someday {
+		m.remember(m.snapshot(fmt.Sprintf("Pulled “%s” back into the list", title), fmt.Sprintf("Put “%s” aside for someday", title)))
+		return m.setSomeday(i, true)
+	}
+
+	// Pulled back, the task and its subtasks may be in progress again.
+	id, end := m.items[i].ID, m.subtreeEnd(i)
+	pullBack := func(items []domain.Item) []domain.Item {
+		for j := i; j < end; j++ {
+			items[j].Someday = false
+		}
+		return items
+	}
+	return m.guardWIP(pullBack, func() tea.Cmd {
+		i := m.indexOfID(id)
+		if i < 0 {
+			return nil
+		}
+		m.remember(m.snapshot(fmt.Sprintf("Put “%s” aside again", title), fmt.Sprintf("Pulled “%s” back into the list", title)))
+		return m.setSomeday(i, false)
+	})
+}
+
+// setSomeday moves the task at index i of the unfiltered list, with its
+// subtasks, to the someday list or back and saves both files.
+func (m *ListScreen) setSomeday(i int, someday bool) tea.Cmd {
+	end := m.subtreeEnd(i)
+	for j := i; j < end; j++ {
+		m.items[j].Someday = someday
+	}
+	m.refreshRows()
+
+	// The file the task moves to is written first, so if the second write
+	// fails the task is in both rather than in neither.
+	if err := m.storeItems(!someday); err != nil {
+		return m.saveFailed(err)
+	}
+	title := m.items[i].Title()
+	if someday {
+		return m.NewStatusMessage(fmt.Sprintf("Put “%s” aside for someday", title))
+	}
+	return m.NewStatusMessage(fmt.Sprintf("Pulled “%s” back into the list", title))
+}
+
+// storeItems saves the list to its file and the tasks put aside to the
+// someday file, the list first if listFirst is set. The someday file is only
+// created once something is put aside. Items added or moved get their
+// positions here, so the list keeps them from one save to the next.
+func (m *ListScreen) storeItems(listFirst bool) error {
+	if m.itemRepository.ReadOnly() {
+		return storage.ErrReadOnly
+	}
+	// Waits for a command changing the items from outside, and keeps the
+	// next one out until both files are written.
+	unlock, err := m.itemRepository.Lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	domain.AssignFlatPositions(m.items)
+	var active, someday []domain.Item
+	for _, item := range m.items {
+		if item.Someday {
+			someday = append(someday, item)
+		} else {
+			active = append(active, item)
+		}
+	}
+
+	store := []func() error{
+		func() error { return m.itemRepository.StoreItemsState(domain.Nest(active)) },
+	}
+	somedayRepository := m.itemRepository.Someday()
+	if _, err := os.Stat(somedayRepository.Path()); len(someday) > 0 || err == nil {
+		storeSomeday := func() error {
+			if len(someday) == 0 {
+				// An empty list rather than null.
+				return somedayRepository.StoreItemsState([]domain.Item{})
+			}
+			return somedayRepository.StoreItemsState(domain.Nest(someday))
+		}
+		if listFirst {
+			store = append(store, storeSomeday)
+		} else {
+			store = append([]func() error{storeSomeday}, store...)
+		}
+	}
+	for _, s := range store {
+		if err := s(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getSomedayTasks reads the tasks put aside next to itemRepository, marked as
+// such. A missing someday file means there are none.
+func getSomedayTasks(itemRepository storage.ItemRepository) ([]domain.Item, error) {
+	somedayRepository := itemRepository.Someday()
+	items, err := somedayRepository.GetItems()
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows := domain.Flatten(items)
+	for i := range rows {
+		rows[i].Someday = true
+	}
+	return rows, nil
+}
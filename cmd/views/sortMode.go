@@ -0,0 +1,127 @@
+package views
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"clitodo/pkg/domain"
+)
+
+// SortMode is the order the list shows its items in. Sorting only changes
+// what's shown: the stored order stays as it is until the sort is applied.
+type SortMode int
+
+const (
+	SortManual SortMode = iota
+	SortTitle
+	SortCompletedLast
+	SortNewest
+)
+
+// sortModes describes each mode but SortManual by the domain sort order it
+// uses, so the list sorts the same way as `clitodo list --sort`.
+var sortModes = map[SortMode]struct {
+	name    string
+	order   string
+	reverse bool
+}{
+	SortTitle:         {name: "by title", order: "title"},
+	SortCompletedLast: {name: "completed last", order: "completed"},
+	SortNewest:        {name: "newest first", order: "created", reverse: true},
+}
+
+func (s SortMode) String() string {
+	if mode, ok := sortModes[s]; ok {
+		return mode.name
+	}
+	return "manual"
+}
+
+// SetSortMode sets the order the list is shown in. The selection stays on
+// the same item.
+func (m *ListScreen) SetSortMode(s SortMode) {
+	var selected string
+	if item := m.SelectedItem(); item != nil {
+		selected = item.ID
+	}
+	m.sortMode = s
+	m.refreshRows()
+	m.selectID(selected)
+}
+
+// SortMode returns the order the list is shown in.
+func (m ListScreen) SortMode() SortMode {
+	return m.sortMode
+}
+
+// cycleSortMode switches to the next sort mode, back to manual after the last.
+func (m *ListScreen) cycleSortMode() {
+	m.SetSortMode((m.sortMode + 1) % SortMode(len(sortModes)+1))
+}
+
+// sortRows orders rows by the sort mode. A task's subtasks are listed right
+// after it, in stored order, so they move along with it.
+func (m ListScreen) sortRows(rows filteredItems) filteredItems {
+	mode, ok := sortModes[m.sortMode]
+	if !ok {
+		return rows
+	}
+
+	var groups []filteredItems
+	root, end := -1, -1
+	for _, row := range rows {
+		if len(groups) > 0 && row.index > root && row.index < end {
+			groups[len(groups)-1] = append(groups[len(groups)-1], row)
+			continue
+		}
+		groups = append(groups, filteredItems{row})
+		root, end = row.index, m.subtreeEnd(row.index)
+	}
+
+	roots := make([]domain.Item, len(groups))
+	for i, g := range groups {
+		roots[i] = g[0].item
+	}
+	sorted := make(filteredItems, 0, len(rows))
+	for _, i := range domain.Sorted(roots, domain.SortOrders[mode.order], mode.reverse) {
+		sorted = append(sorted, groups[i]...)
+	}
+	return sorted
+}
+
+// applySort stores the items in the order of the sort mode and switches back
+// to manual order, which now looks the same.
+func (m *ListScreen) applySort() tea.Cmd {
+	mode := m.sortMode
+	all := make(filteredItems, len(m.items))
+	for i, item := range m.items {
+		all[i] = filteredItem{item: item, index: i}
+	}
+	sorted := m.sortRows(all)
+
+	var selected string
+	if item := m.SelectedItem(); item != nil {
+		selected = item.ID
+	}
+	m.remember(m.snapshot("Put the list back in its saved order", fmt.Sprintf("Saved the list sorted %s", mode)))
+	m.items = sorted.items()
+	m.sortMode = SortManual
+	m.refreshRows()
+	m.selectID(selected)
+
+	if err := m.saveItems(); err != nil {
+		return m.saveFailed(err)
+	}
+	return m.NewStatusMessage(fmt.Sprintf("Saved the list sorted %s", mode))
+}
+
+// selectID selects the row of the item with the given ID, if it's shown.
+func (m *ListScreen) selectID(id string) {
+	for i, item := range m.VisibleItems() {
+		if item.ID == id {
+			m.Select(i)
+			return
+		}
+	}
+}
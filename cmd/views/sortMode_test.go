@@ -0,0 +1,91 @@
+package views
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"clitodo/cmd"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/storage"
+)
+
+// sortItems are tasks created a day apart in the order they're stored, some
+// completed, one with subtasks.
+func sortItems() []domain.Item {
+	created := time.Date(2026, time.March, 1, 9, 0, 0, 0, time.Local)
+	item := func(title string, day int, done bool) domain.Item {
+		item := domain.NewItem(title)
+		at := created.AddDate(0, 0, day)
+		item.CreatedAt = &at
+		item.ItemCompleted = done
+		return item
+	}
+	flights := item("book the flights", 2, false)
+	flights.Children = []domain.Item{item("pack the bags", 3, false), item("renew the passport", 3, true)}
+	return []domain.Item{
+		item("water the plants", 0, false),
+		item("call mum", 1, true),
+		flights,
+		item("pay the rent", 4, true),
+		item("answer the letter", 5, false),
+		item("fix my bike", 6, false),
+	}
+}
+
+func TestSortModesCompose(t *testing.T) {
+	// Every row in each order, subtasks after their task.
+	orders := map[SortMode][]string{
+		SortManual:        {"water the plants", "call mum", "book the flights", "pack the bags", "renew the passport", "pay the rent", "answer the letter", "fix my bike"},
+		SortTitle:         {"answer the letter", "book the flights", "pack the bags", "renew the passport", "call mum", "fix my bike", "pay the rent", "water the plants"},
+		SortCompletedLast: {"water the plants", "book the flights", "pack the bags", "renew the passport", "answer the letter", "fix my bike", "call mum", "pay the rent"},
+		SortNewest:        {"fix my bike", "answer the letter", "pay the rent", "book the flights", "pack the bags", "renew the passport", "call mum", "water the plants"},
+	}
+	done := map[string]bool{"call mum": true, "renew the passport": true, "pay the rent": true}
+	// The substring filter keeps the stored order, so the filtered rows are
+	// the ones in the sort order whose titles contain "the".
+	const filter, term = "'the", "the"
+
+	for mode, order := range orders {
+		for _, filtered := range []bool{false, true} {
+			for _, hide := range []bool{false, true} {
+				name := fmt.Sprintf("%s, filtered %t, completed hidden %t", mode, filtered, hide)
+				var want []string
+				for _, title := range order {
+					if (!filtered || strings.Contains(title, term)) && (!hide || !done[title]) {
+						want = append(want, title)
+					}
+				}
+
+				// Sorting before or after filtering and hiding comes out
+				// the same.
+				for _, sortFirst := range []bool{true, false} {
+					t.Run(fmt.Sprintf("%s, sorted first %t", name, sortFirst), func(t *testing.T) {
+						t.Setenv("XDG_STATE_HOME", t.TempDir())
+						repo := storage.NewMemoryItemRepository(sortItems())
+						m := NewListScreen(cmd.DefaultTheme(), repo)
+						if sortFirst {
+							m.SetSortMode(mode)
+						}
+						if filtered {
+							m.SetFilterText(filter)
+						}
+						m.SetShowCompleted(!hide)
+						if !sortFirst {
+							m.SetSortMode(mode)
+						}
+
+						if got := visibleTitles(m); !slices.Equal(got, want) {
+							t.Errorf("the list shows %q, want %q", got, want)
+						}
+						if got := stored(t, repo); !slices.Equal(got, []string{"water the plants", "call mum", "book the flights", "pay the rent", "answer the letter", "fix my bike"}) {
+							t.Errorf("sorting the view changed the stored order to %q", got)
+						}
+					})
+				}
+			}
+		}
+	}
+}
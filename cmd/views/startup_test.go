@@ -0,0 +1,49 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+
+	"clitodo/pkg/storage"
+)
+
+// BenchmarkFirstFrame measures the time from making the MainView to its
+// first frame with 5000 tasks stored. The items are read by a command Init
+// gives, which isn't run, so that frame is drawn while they load.
+func BenchmarkFirstFrame(b *testing.B) {
+	b.Setenv("XDG_STATE_HOME", b.TempDir())
+	titles := make([]string, 5000)
+	for i := range titles {
+		titles[i] = fmt.Sprintf("task %d", i+1)
+	}
+	items := titledItems(titles...)
+	repo := storage.NewMemoryItemRepository(items)
+
+	var h *harness
+	var frame string
+	b.ResetTimer()
+	for range b.N {
+		h = startHarness(b, repo, nil)
+		h.model.Init()
+		frame = h.view()
+	}
+	b.StopTimer()
+
+	if !h.list().loading {
+		b.Fatal("the items were read before the first frame")
+	}
+	for n, line := range strings.Split(frame, "\n") {
+		if w := ansi.StringWidth(line); w > 80 {
+			b.Fatalf("line %d of the first frame is %d wide: %q", n+1, w, line)
+		}
+	}
+
+	// Once they're read, the list shows them.
+	h.process(h.run(h.model.Init()))
+	if h.list().loading || len(h.list().VisibleItems()) != len(items) || !strings.Contains(h.view(), "task 1") {
+		b.Fatalf("after loading the list shows %d of %d tasks:\n%s", len(h.list().VisibleItems()), len(items), h.view())
+	}
+}
@@ -0,0 +1,202 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"clitodo/cmd"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/stats"
+)
+
+// heatmapWeeks is how far back the completion heatmap goes.
+const heatmapWeeks = 12
+
+type statsDoneMsg struct{}
+
+// statsScreen shows how many tasks were completed on each of the last weeks as
+// a heatmap. The cursor highlights a day to show its count and titles.
+type statsScreen struct {
+	total, done int
+	byDay       map[stats.Day][]domain.Item
+	weeks       [][7]stats.Day
+	today       stats.Day
+	busiest     int
+
+	// The WIP limit and how often it was gone over this week. Not shown
+	// without a limit.
+	wipLimit    domain.WIPLimit
+	wipExceeded int
+
+	// Highlighted cell.
+	week, weekday int
+
+	KeyMap cmd.KeyMap
+	help   help.Model
+	styles cmd.Styles
+}
+
+func newStatsScreen(items []domain.Item, now time.Time, styles cmd.Styles) statsScreen {
+	today := stats.DayOf(now, now.Location())
+	m := statsScreen{
+		total:  len(items),
+		byDay:  stats.CompletionsByDay(items, now.Location()),
+		weeks:  stats.Weeks(today, heatmapWeeks),
+		today:  today,
+		week:   heatmapWeeks - 1,
+		KeyMap: cmd.DefaultKeyMap(),
+		help:   help.New(),
+		styles: styles,
+	}
+	m.weekday = (int(today.Weekday()) + 6) % 7 //nolint:mnd
+
+	for _, item := range items {
+		if item.Completed() {
+			m.done++
+		}
+	}
+	for _, week := range m.weeks {
+		for _, day := range week {
+			m.busiest = max(m.busiest, len(m.byDay[day]))
+		}
+	}
+	return m
+}
+
+func (m statsScreen) Init() tea.Cmd {
+	return nil
+}
+
+func (m statsScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.KeyMap.CloseStats):
+		return m, func() tea.Msg { return statsDoneMsg{} }
+	case key.Matches(keyMsg, m.KeyMap.CursorUp):
+		m.move(-1)
+	case key.Matches(keyMsg, m.KeyMap.CursorDown):
+		m.move(1)
+	case key.Matches(keyMsg, m.KeyMap.PrevPage):
+		m.move(-7) //nolint:mnd
+	case key.Matches(keyMsg, m.KeyMap.NextPage):
+		m.move(7) //nolint:mnd
+	}
+	return m, nil
+}
+
+// move shifts the highlighted day by n days, staying within the heatmap and
+// never going past today.
+func (m *statsScreen) move(n int) {
+	i := m.week*7 + m.weekday + n
+	if i < 0 || i >= len(m.weeks)*7 {
+		return
+	}
+	if m.today.Before(m.weeks[i/7][i%7]) {
+		return
+	}
+	m.week, m.weekday = i/7, i%7
+}
+
+func (m statsScreen) View() string {
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render("Stats"))
+	fmt.Fprintf(&b, "  %d of %d tasks done\n", m.done, m.total)
+	if m.wipLimit.Limit > 0 {
+		fmt.Fprintf(&b, "WIP limit of %d #%s gone over %s this week\n", m.wipLimit.Limit, m.wipLimit.Tag, times(m.wipExceeded))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(m.heatmapView())
+	b.WriteString("\n\n")
+	b.WriteString(m.dayView())
+
+	// The list's movement keys move through days and weeks here.
+	prevDay, nextDay := m.KeyMap.CursorUp, m.KeyMap.CursorDown
+	prevDay.SetHelp("↑/k", "prev day")
+	nextDay.SetHelp("↓/j", "next day")
+	prevWeek, nextWeek := m.KeyMap.PrevPage, m.KeyMap.NextPage
+	prevWeek.SetHelp("←/h", "prev week")
+	nextWeek.SetHelp("→/l", "next week")
+
+	b.WriteString(m.styles.HelpStyle.Render(m.help.ShortHelpView([]key.Binding{
+		prevDay,
+		nextDay,
+		prevWeek,
+		nextWeek,
+		m.KeyMap.CloseStats,
+	})))
+	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
+}
+
+// times writes n as "never", "once" or "3 times".
+func times(n int) string {
+	switch n {
+	case 0:
+		return "never"
+	case 1:
+		return "once"
+	}
+	return fmt.Sprintf("%d times", n)
+}
+
+var weekdayLabels = [7]string{"Mon", "", "Wed", "", "Fri", "", "Sun"}
+
+func (m statsScreen) heatmapView() string {
+	highlight := lipgloss.NewStyle().Reverse(true)
+
+	var b strings.Builder
+	for d := range 7 {
+		fmt.Fprintf(&b, "%-4s", weekdayLabels[d])
+		for w, week := range m.weeks {
+			day := week[d]
+			cell := " "
+			if !m.today.Before(day) {
+				level := stats.Level(len(m.byDay[day]), m.busiest)
+				cell = m.styles.HeatCell[level].String()
+				if w == m.week && d == m.weekday {
+					cell = highlight.Render(cell)
+				}
+			}
+			b.WriteString(cell + " ")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n    less ")
+	for _, style := range m.styles.HeatCell {
+		b.WriteString(style.String() + " ")
+	}
+	b.WriteString("more")
+	return b.String()
+}
+
+func (m statsScreen) dayView() string {
+	day := m.weeks[m.week][m.weekday]
+	items := m.byDay[day]
+	date := time.Date(day.Year, day.Month, day.Day, 0, 0, 0, 0, time.UTC)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: ", date.Format("Mon 2006-01-02"))
+	switch len(items) {
+	case 0:
+		b.WriteString("nothing completed\n")
+	case 1:
+		b.WriteString("1 task completed\n")
+	default:
+		fmt.Fprintf(&b, "%d tasks completed\n", len(items))
+	}
+	for _, item := range items {
+		b.WriteString(m.styles.StatusBar.UnsetPadding().Render("  "+item.Title()) + "\n")
+	}
+	return b.String()
+}
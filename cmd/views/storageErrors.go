@@ -0,0 +1,121 @@
+package views
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"clitodo/pkg/storage"
+)
+
+// storageErrorMessage says in a few words what went wrong with the storage and
+// what can be done about it.
+func storageErrorMessage(err error) string {
+	var corrupt *storage.CorruptError
+	var locked *storage.LockError
+	var inUse *storage.InUseError
+	switch {
+	case errors.As(err, &corrupt) && corrupt.Line > 0:
+		return fmt.Sprintf("%s is damaged at line %d, column %d; fix it and press r", filepath.Base(corrupt.Path), corrupt.Line, corrupt.Column)
+	case errors.As(err, &corrupt):
+		return fmt.Sprintf("%s is damaged; fix it and press r", filepath.Base(corrupt.Path))
+	case errors.Is(err, os.ErrPermission):
+		return "no permission to read the storage; fix it and press r"
+	case errors.Is(err, storage.ErrReadOnly):
+		return "the storage is read-only"
+	case errors.As(err, &locked) && locked.PID != 0:
+		return fmt.Sprintf("%s is locked by PID %d since %s; press r to retry", lockedName(locked), locked.PID, locked.Since.Format("15:04"))
+	case errors.As(err, &locked):
+		return fmt.Sprintf("%s is locked by another clitodo; press r to retry", lockedName(locked))
+	case errors.As(err, &inUse) && inUse.PID != 0:
+		return fmt.Sprintf("%s is open in clitodo PID %d; press r to retry", filepath.Base(inUse.Path), inUse.PID)
+	case errors.As(err, &inUse):
+		return fmt.Sprintf("%s is open in another clitodo; press r to retry", filepath.Base(inUse.Path))
+	}
+	return err.Error()
+}
+
+// refuseReadOnly says the list can't be changed, its storage being
+// read-only.
+func (m *ListScreen) refuseReadOnly() tea.Cmd {
+	return m.NewStatusMessage("Can't change the list: " + storageErrorMessage(storage.ErrReadOnly))
+}
+
+// lockedName returns the name of the file e's lock is for.
+func lockedName(e *storage.LockError) string {
+	return strings.TrimSuffix(filepath.Base(e.Path), ".lock")
+}
+
+// retryable reports whether err is from a storage that's only busy for the
+// moment, so trying again later can work.
+func retryable(err error) bool {
+	var locked *storage.LockError
+	var inUse *storage.InUseError
+	return errors.As(err, &locked) || errors.As(err, &inUse)
+}
+
+// storageFailed says why what failed. If the storage was only busy, r runs
+// retry.
+func (m *ListScreen) storageFailed(what string, err error, retry func() tea.Cmd) tea.Cmd {
+	if retryable(err) {
+		m.retry = retry
+		m.updateKeybindings()
+	}
+	return m.NewStatusMessage(what + " failed: " + storageErrorMessage(err))
+}
+
+// saveFailed says why saving the items failed. If the storage was only
+// busy, r saves them again.
+func (m *ListScreen) saveFailed(err error) tea.Cmd {
+	return m.storageFailed("Saving", err, func() tea.Cmd {
+		if err := m.saveItems(); err != nil {
+			return m.saveFailed(err)
+		}
+		return m.NewStatusMessage("Saved")
+	})
+}
+
+// setLoadError remembers why the items couldn't be loaded. The storage is
+// read-only from then on, so the first change doesn't replace a damaged file
+// with the empty list that's shown instead.
+func (m *ListScreen) setLoadError(err error) {
+	m.loadErr = err
+	m.itemRepository = storage.ReadOnly(m.itemRepository)
+	m.updateKeybindings()
+}
+
+// reload runs again what failed on a busy storage, or reads the items again
+// after loading them failed.
+func (m *ListScreen) reload() tea.Cmd {
+	if retry := m.retry; retry != nil {
+		m.retry = nil
+		m.updateKeybindings()
+		return retry()
+	}
+
+	repository := storage.Writable(m.itemRepository)
+	items, err := getTasks(repository)
+	if err != nil {
+		m.loadErr = err
+		return m.NewStatusMessage("Still failing: " + storageErrorMessage(err))
+	}
+
+	m.loadErr = nil
+	if !m.SafeMode && !m.OpenElsewhere {
+		m.itemRepository = repository
+	}
+	return tea.Batch(m.SetItems(items), m.NewStatusMessage(fmt.Sprintf("Loaded %d items", len(items))))
+}
+
+// releaseStorage gives up the list's claim on its storage, so another
+// instance can open it for writing.
+func (m *ListScreen) releaseStorage() {
+	if m.release != nil {
+		m.release()
+		m.release = nil
+	}
+}
@@ -0,0 +1,65 @@
+package views
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"clitodo/cmd"
+	"clitodo/pkg/storage"
+)
+
+func TestStorageErrorMessage(t *testing.T) {
+	since := time.Date(2026, time.March, 10, 9, 5, 0, 0, time.Local)
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{&storage.LockError{Path: "/home/me/tasks.json.lock", PID: 4242, Since: since}, "tasks.json is locked by PID 4242 since 09:05; press r to retry"},
+		{&storage.LockError{Path: "/home/me/tasks.json.lock"}, "tasks.json is locked by another clitodo; press r to retry"},
+		{&storage.InUseError{Path: "/home/me/tasks.json", PID: 4242}, "tasks.json is open in clitodo PID 4242; press r to retry"},
+		{&storage.InUseError{Path: "/home/me/tasks.json"}, "tasks.json is open in another clitodo; press r to retry"},
+		{storage.ErrReadOnly, "the storage is read-only"},
+	}
+	for _, tt := range tests {
+		if got := storageErrorMessage(tt.err); got != tt.want {
+			t.Errorf("storageErrorMessage(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestSavingRetriesWhenLocked(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	repo := storage.NewFileItemRepository(path)
+	if err := repo.StoreItemsState(titledItems("water the plants")); err != nil {
+		t.Fatal(err)
+	}
+	m := NewListScreen(cmd.DefaultTheme(), repo)
+
+	// Another clitodo is changing the items.
+	if err := os.WriteFile(path+".lock", []byte("4242\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	m.toggleSelected()
+	if !strings.Contains(m.statusMessage, "locked by PID 4242") {
+		t.Fatalf("status %q, want it to say who holds the lock", m.statusMessage)
+	}
+	if !m.KeyMap.Reload.Enabled() {
+		t.Fatal("r is off after saving failed on a lock")
+	}
+
+	if err := os.Remove(path + ".lock"); err != nil {
+		t.Fatal(err)
+	}
+	m.reload()
+	if m.statusMessage != "Saved" || m.KeyMap.Reload.Enabled() {
+		t.Errorf("retrying said %q, r enabled %t", m.statusMessage, m.KeyMap.Reload.Enabled())
+	}
+	items, err := repo.GetItems()
+	if err != nil || len(items) != 1 || !items[0].Completed() {
+		t.Errorf("stored %v, %v, want the plants watered", items, err)
+	}
+}
@@ -0,0 +1,165 @@
+package views
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"clitodo/cmd"
+	"clitodo/pkg/domain"
+)
+
+// The layouts of the list's rows, see Options.Layout: the DefaultDelegate's,
+// the CompactDelegate's and the TableDelegate's.
+const (
+	LayoutDefault = "default"
+	LayoutCompact = "compact"
+	LayoutTable   = "table"
+)
+
+const (
+	// The gap between the columns of a TableDelegate.
+	tableGap = 2
+
+	// The tags column is never wider than this, and the title column gives
+	// up the tags and then the due dates to stay at least this wide.
+	tableTagsWidth  = 24
+	tableTitleWidth = 16
+)
+
+// TableDelegate draws each item on a single line laid out in columns: the
+// priority marker and check mark, the title, the due date and the tags, each
+// column as wide as its widest entry among the visible items, so the due dates
+// and tags line up from row to row. The title takes up what's left; when the
+// list is too narrow for it the tags go first, then the due dates. It's
+// styled by the same DefaultItemStyles as the DefaultDelegate.
+type TableDelegate struct {
+	PlainCompleted bool
+	Styles         DefaultItemStyles
+	UpdateFunc     func(tea.Msg, *ListScreen) tea.Cmd
+}
+
+// NewTableDelegate creates a table delegate styled with the given theme.
+func NewTableDelegate(t cmd.Theme) TableDelegate {
+	return TableDelegate{Styles: NewItemStyles(t)}
+}
+
+// Height returns 1, items being a row each.
+func (d TableDelegate) Height() int {
+	return 1
+}
+
+// Spacing returns 0, rows going one right under the other.
+func (d TableDelegate) Spacing() int {
+	return 0
+}
+
+// Update checks whether the delegate's UpdateFunc is set and calls it.
+func (d TableDelegate) Update(msg tea.Msg, m *ListScreen) tea.Cmd {
+	if d.UpdateFunc == nil {
+		return nil
+	}
+	return d.UpdateFunc(msg, m)
+}
+
+// tableColumns are the widths of a TableDelegate's columns past the check
+// mark, 0 for columns left out.
+type tableColumns struct {
+	title, due, tags int
+}
+
+// columns returns the widths of the columns for the visible items of m.
+func (d TableDelegate) columns(m ListScreen) tableColumns {
+	var c tableColumns
+	now := m.Clock.Now()
+	for _, item := range m.VisibleItems() {
+		if isHeader(item) {
+			continue
+		}
+		c.due = max(c.due, lipgloss.Width(d.Styles.Due(item, now)))
+		if chips := tagChips(item.Tags, math.MaxInt, nil); len(chips) > 0 {
+			c.tags = max(c.tags, chipsWidth(chips))
+		}
+	}
+	c.tags = min(c.tags, tableTagsWidth)
+
+	// What's left for the titles once the marks in front of them are in.
+	width := m.width - d.Styles.NormalTitle.GetHorizontalFrameSize() -
+		lipgloss.Width(d.base().priority(m, domain.Item{})) - lipgloss.Width(d.Styles.EmptyCheckMark.String()) - 1
+	c.title = width
+	for _, col := range []*int{&c.due, &c.tags} {
+		if *col > 0 {
+			c.title -= *col + tableGap
+		}
+	}
+	if c.title < tableTitleWidth && c.tags > 0 {
+		c.title += c.tags + tableGap
+		c.tags = 0
+	}
+	if c.title < tableTitleWidth && c.due > 0 {
+		c.title += c.due + tableGap
+		c.due = 0
+	}
+	c.title = max(0, c.title)
+	return c
+}
+
+// Render prints an item.
+func (d TableDelegate) Render(w io.Writer, m ListScreen, index int, item domain.Item) {
+	base := d.base()
+	if isHeader(item) {
+		base.renderSectionHeader(w, m, index, item)
+		return
+	}
+	if m.width <= 0 {
+		return
+	}
+
+	s := &d.Styles
+	c := d.columns(m)
+	mark, titleStyle := base.checkMark(item)
+
+	// Subtasks are indented within the title column.
+	indent := strings.Repeat(" ", min(item.Depth*subtaskIndent, c.title))
+	title := ansi.Truncate(item.Title(), c.title-len(indent), cmd.Ellipsis)
+	pad := func(cell string, width int) string {
+		return cell + strings.Repeat(" ", max(0, width-lipgloss.Width(cell)))
+	}
+	row := indent + base.styledTitle(m, index, item, title, titleStyle)
+	if c.due > 0 || c.tags > 0 {
+		row = pad(row, c.title)
+	}
+	row = base.priority(m, item) + mark + " " + row
+	if c.due > 0 {
+		row += strings.Repeat(" ", tableGap) + pad(s.Due(item, m.Clock.Now()), c.due)
+	}
+	if c.tags > 0 {
+		tagStyle := s.Tags
+		if index == m.Index() && m.FilterState() != Filtering {
+			tagStyle = tagStyle.Inherit(s.SelectedText)
+		}
+		var tags string
+		if chips := tagChips(item.Tags, c.tags, nil); len(chips) > 0 {
+			tags = base.renderTagChips(chips, tagStyle)
+		}
+		row += strings.Repeat(" ", tableGap) + tags
+	}
+
+	if index == m.Index() && m.FilterState() != Filtering {
+		row = s.SelectedTitle.Render(row)
+	} else {
+		row = s.NormalTitle.Render(row)
+	}
+	fmt.Fprint(w, cutLines(row, m.width)) //nolint: errcheck
+}
+
+// base returns the DefaultDelegate the table borrows its marks and section
+// headers from.
+func (d TableDelegate) base() DefaultDelegate {
+	return DefaultDelegate{Styles: d.Styles, PlainCompleted: d.PlainCompleted, height: 1}
+}
@@ -0,0 +1,254 @@
+package views
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"clitodo/cmd"
+	"clitodo/pkg/domain"
+)
+
+// chipsMinWidth is the narrowest list the tag chips are shown in. Below it the
+// row collapses, and the tags can still be filtered by with tag:NAME.
+const chipsMinWidth = 30
+
+// Scroll markers for chips that don't fit the row.
+const (
+	chipsMoreLeft  = "‹ "
+	chipsMoreRight = " ›"
+)
+
+// chipFocus is the tag chip picked with the keyboard while the chips have
+// the focus.
+type chipFocus struct {
+	cursor int
+}
+
+// chipSpan is where a chip is drawn in the chip row.
+type chipSpan struct {
+	tag      string
+	from, to int
+}
+
+// SetShowTagChips shows or hides the row of tags under the title that the
+// list can be filtered by.
+func (m *ListScreen) SetShowTagChips(v bool) {
+	m.showTagChips = v
+	if !v {
+		m.chipFocus = nil
+	}
+	m.updatePagination()
+	m.updateKeybindings()
+}
+
+// chipsShown reports whether the chip row takes up a line of the list view.
+func (m ListScreen) chipsShown() bool {
+	return m.showTagChips && m.filteringEnabled && m.width >= chipsMinWidth
+}
+
+// chipsTop returns the line of the list view the chip row is drawn on.
+func (m ListScreen) chipsTop() int {
+	if m.showTitle || (m.showFilter && m.filteringEnabled) {
+		return lipgloss.Height(m.titleView())
+	}
+	return 0
+}
+
+// chipTags returns the tags of the chips: those of the items, and those
+// filtered by that no item has anymore so they can be turned off, sorted and
+// without case duplicates.
+func (m ListScreen) chipTags() []string {
+	seen := map[string]bool{}
+	var tags []string
+	add := func(tag string) {
+		if !seen[strings.ToLower(tag)] {
+			seen[strings.ToLower(tag)] = true
+			tags = append(tags, tag)
+		}
+	}
+	for _, item := range m.items {
+		for _, tag := range item.Tags {
+			add(tag)
+		}
+	}
+	for _, tag := range m.activeTags() {
+		add(tag)
+	}
+	slices.SortFunc(tags, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	return tags
+}
+
+// activeTags returns the tags the list is filtered by, lowercased.
+func (m ListScreen) activeTags() []string {
+	if m.filterState == Unfiltered {
+		return nil
+	}
+	tags, _ := splitTagWords(m.FilterInput.Value())
+	return tags
+}
+
+// chipsLayout lays out the chips that fit the row, scrolled so the focused
+// one is among them, and returns the row with where each chip is on it.
+func (m ListScreen) chipsLayout() (string, []chipSpan) {
+	tags := m.chipTags()
+	if len(tags) == 0 {
+		return m.Styles.ChipBar.Render(m.Styles.Chip.Faint(true).Render("no tags yet")), nil
+	}
+
+	active := m.activeTags()
+	chips := make([]string, len(tags))
+	for i, tag := range tags {
+		style := m.Styles.Chip
+		if slices.Contains(active, strings.ToLower(tag)) {
+			style = m.Styles.ActiveChip
+		}
+		if m.chipFocus != nil && m.chipFocus.cursor == i {
+			style = style.Inherit(m.Styles.FocusedChip)
+		}
+		chips[i] = style.Render("#" + tag)
+	}
+
+	// The row needs room for the markers of chips scrolled out of view on
+	// either side.
+	availWidth := m.width - m.Styles.ChipBar.GetHorizontalFrameSize()
+	fits := func(from, to int) bool {
+		width := 0
+		if from > 0 {
+			width += lipgloss.Width(chipsMoreLeft)
+		}
+		if to < len(chips)-1 {
+			width += lipgloss.Width(chipsMoreRight)
+		}
+		for _, chip := range chips[from : to+1] {
+			width += lipgloss.Width(chip)
+		}
+		return width <= availWidth
+	}
+	start := 0
+	if m.chipFocus != nil {
+		for start < m.chipFocus.cursor && !fits(start, m.chipFocus.cursor) {
+			start++
+		}
+	}
+	end := start
+	for end+1 < len(chips) && fits(start, end+1) {
+		end++
+	}
+
+	var (
+		b     strings.Builder
+		spans []chipSpan
+		left  = m.Styles.ChipBar.GetPaddingLeft() + m.Styles.ChipBar.GetMarginLeft()
+	)
+	if start > 0 {
+		b.WriteString(m.Styles.Chip.UnsetPadding().Render(chipsMoreLeft))
+	}
+	for i := start; i <= end; i++ {
+		from := lipgloss.Width(b.String())
+		b.WriteString(chips[i])
+		spans = append(spans, chipSpan{tag: tags[i], from: left + from, to: left + lipgloss.Width(b.String())})
+	}
+	if end < len(chips)-1 {
+		b.WriteString(m.Styles.Chip.UnsetPadding().Render(chipsMoreRight))
+	}
+	return m.Styles.ChipBar.Render(b.String()), spans
+}
+
+// chipsView renders the chip row.
+func (m ListScreen) chipsView() string {
+	row, _ := m.chipsLayout()
+	return row
+}
+
+// chipAt returns the tag of the chip drawn at column x of the chip row.
+func (m ListScreen) chipAt(x int) (string, bool) {
+	_, spans := m.chipsLayout()
+	for _, span := range spans {
+		if x >= span.from && x < span.to {
+			return span.tag, true
+		}
+	}
+	return "", false
+}
+
+// toggleTag filters the list by tag as well, or no longer by it if it
+// already is. Chips add up: an item has to have every active tag, and match
+// whatever else is in the filter.
+func (m *ListScreen) toggleTag(tag string) {
+	var value string
+	if m.filterState != Unfiltered {
+		value = m.FilterInput.Value()
+	}
+
+	words := strings.Fields(value)
+	kept := slices.DeleteFunc(slices.Clone(words), func(word string) bool {
+		t, ok := tagWord(word)
+		return ok && t == strings.ToLower(tag)
+	})
+	if len(kept) == len(words) {
+		kept = append(kept, tagToken+tag)
+	}
+
+	if len(kept) == 0 {
+		m.resetFiltering()
+		return
+	}
+	m.SetFilterText(strings.Join(kept, " "))
+}
+
+// openChips gives the keyboard to the chip row.
+func (m *ListScreen) openChips() {
+	m.chipFocus = &chipFocus{}
+	m.updateKeybindings()
+}
+
+// handleChips moves between the chips and turns them on and off until the
+// chip row gives the keyboard back.
+func (m *ListScreen) handleChips(msg tea.KeyMsg) tea.Cmd {
+	tags := m.chipTags()
+	switch {
+	case key.Matches(msg, m.KeyMap.CloseChips):
+		m.chipFocus = nil
+		m.updateKeybindings()
+	case len(tags) == 0:
+	case key.Matches(msg, m.KeyMap.PrevChip):
+		m.chipFocus.cursor = max(0, m.chipFocus.cursor-1)
+	case key.Matches(msg, m.KeyMap.NextChip):
+		m.chipFocus.cursor = min(len(tags)-1, m.chipFocus.cursor+1)
+	case key.Matches(msg, m.KeyMap.ToggleChip):
+		tag := tags[min(m.chipFocus.cursor, len(tags)-1)]
+		m.toggleTag(tag)
+		// A tag no item has anymore goes away once it's turned off.
+		if i := slices.Index(m.chipTags(), tag); i >= 0 {
+			m.chipFocus.cursor = i
+		} else {
+			m.chipFocus.cursor = min(m.chipFocus.cursor, max(0, len(m.chipTags())-1))
+		}
+	}
+	return nil
+}
+
+func (m ListScreen) chipsHelp() []key.Binding {
+	return []key.Binding{m.KeyMap.PrevChip, m.KeyMap.NextChip, m.KeyMap.ToggleChip, m.KeyMap.CloseChips}
+}
+
+// filterDescription describes the filter for the status bar: the tags it
+// keeps, then the rest of it in quotes.
+func (m ListScreen) filterDescription() string {
+	tags, rest := splitTagWords(m.FilterInput.Value())
+	var parts []string
+	if len(tags) > 0 {
+		parts = append(parts, ansi.Truncate(domain.FormatTags(tags), 20, cmd.Ellipsis)) //nolint:mnd
+	}
+	if rest != "" {
+		parts = append(parts, "“"+ansi.Truncate(rest, 10, "…")+"”") //nolint:mnd
+	}
+	return strings.Join(parts, " ")
+}
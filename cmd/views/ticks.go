@@ -0,0 +1,125 @@
+package views
+
+import (
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// The list's periodic work runs off a single tick rather than a timer per
+// feature, since every message wakes the program up and redraws it. Each tick
+// fires due reminders and sinks completed tasks once the list is left alone;
+// the first tick of a minute also flushes notifications held back by quiet
+// hours, redraws the due labels, which are written relative to now, and
+// archives completed tasks after midnight. While the terminal doesn't have
+// focus the tick slows down to the reminder interval, and the due labels wait
+// until it's back.
+
+// How often the list checks for reminders that are due, and how often the tick
+// runs when nothing needs it sooner.
+const reminderInterval = 15 * time.Second
+
+// tickIntervals are the intervals the tick runs at, shortest first. Each
+// divides a minute, so with tea.Every one tick falls right on the minute and
+// the due labels change as the clock does.
+var tickIntervals = []time.Duration{
+	time.Second, 2 * time.Second, 3 * time.Second, 5 * time.Second, 10 * time.Second, reminderInterval,
+}
+
+type tickMsg struct{}
+
+// ticker is what the tick keeps from one tick to the next.
+type ticker struct {
+	// Whether the terminal lost focus.
+	blurred bool
+
+	// Whether a minute passed without redrawing the due labels.
+	labelsStale bool
+
+	// The minute the last tick was in, and the local day.
+	minute time.Time
+	day    time.Time
+
+	// Messages the list handled this minute, each one a redraw, for the
+	// debug log.
+	updates int
+}
+
+// startTicks starts the tick, counting minutes and days from now.
+func (m *ListScreen) startTicks(now time.Time) tea.Cmd {
+	m.ticks.minute = now.Truncate(time.Minute)
+	m.ticks.day = startOfDay(now)
+	return m.tick()
+}
+
+// tick waits for the next tick.
+func (m ListScreen) tick() tea.Cmd {
+	return tea.Every(m.tickInterval(), func(time.Time) tea.Msg {
+		return tickMsg{}
+	})
+}
+
+// tickInterval is how long the tick waits. Only what the list needs shortens
+// it: to sink completed tasks soon after SinkCompletedAfter, a quarter of it,
+// rounded down to one of tickIntervals, while the terminal has focus.
+func (m ListScreen) tickInterval() time.Duration {
+	if m.SinkCompletedAfter <= 0 || m.ticks.blurred {
+		return reminderInterval
+	}
+	want := m.SinkCompletedAfter / 4 //nolint:mnd
+	i, found := slices.BinarySearch(tickIntervals, want)
+	if !found {
+		i = max(i-1, 0)
+	}
+	return tickIntervals[i]
+}
+
+// onTick does the periodic work due at now and waits for the next tick.
+func (m *ListScreen) onTick(now time.Time) tea.Cmd {
+	cmds := []tea.Cmd{m.fireReminders(now), m.sinkWhenIdle()}
+
+	if minute := now.Truncate(time.Minute); !minute.Equal(m.ticks.minute) {
+		m.ticks.minute = minute
+		cmds = append(cmds, m.flushNotifications())
+		if m.ticks.blurred {
+			m.ticks.labelsStale = true
+		} else {
+			// Wrapped titles can take up another line with a longer
+			// label.
+			m.updatePagination()
+		}
+		if m.Debug != nil {
+			m.Debug.Printf("%d redraws in the last minute, focused: %t", m.ticks.updates, !m.ticks.blurred)
+		}
+		m.ticks.updates = 0
+	}
+
+	if day := startOfDay(now); !day.Equal(m.ticks.day) {
+		m.ticks.day = day
+		if m.showDoneSection {
+			cmds = append(cmds, m.archiveCompleted(now))
+		}
+	}
+
+	return tea.Batch(append(cmds, m.tick())...)
+}
+
+// focus resumes what waited while the terminal didn't have focus.
+func (m *ListScreen) focus() tea.Cmd {
+	m.ticks.blurred = false
+	if m.ticks.labelsStale {
+		m.ticks.labelsStale = false
+		m.updatePagination()
+	}
+	cmds := []tea.Cmd{m.flushNotifications()}
+	if m.showSpinner {
+		cmds = append(cmds, m.spinner.Tick)
+	}
+	return tea.Batch(cmds...)
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.Local()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
@@ -0,0 +1,90 @@
+package views
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"clitodo/cmd"
+	"clitodo/pkg/clock"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/storage"
+)
+
+func TestTickInterval(t *testing.T) {
+	tests := []struct {
+		sinkAfter time.Duration
+		blurred   bool
+		want      time.Duration
+	}{
+		{0, false, reminderInterval},
+		{time.Hour, false, reminderInterval},
+		{time.Minute, false, 15 * time.Second},
+		{30 * time.Second, false, 5 * time.Second},
+		{20 * time.Second, false, 5 * time.Second},
+		{8 * time.Second, false, 2 * time.Second},
+		{2 * time.Second, false, time.Second},
+		{20 * time.Second, true, reminderInterval},
+	}
+	for _, tt := range tests {
+		m := ListScreen{SinkCompletedAfter: tt.sinkAfter}
+		m.ticks.blurred = tt.blurred
+		if got := m.tickInterval(); got != tt.want {
+			t.Errorf("SinkCompletedAfter %s, blurred %t: tickInterval() = %s, want %s", tt.sinkAfter, tt.blurred, got, tt.want)
+		}
+		if time.Minute%m.tickInterval() != 0 {
+			t.Errorf("tickInterval() = %s doesn't divide a minute", m.tickInterval())
+		}
+	}
+}
+
+func TestTickFiresRemindersAndWaitsForFocus(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	start := time.Date(2026, 3, 10, 9, 0, 30, 0, time.Local)
+	item := domain.NewItem("call the dentist")
+	item.Remind = &domain.Reminder{At: start.Add(20 * time.Second)}
+	m := NewListScreen(cmd.DefaultTheme(), storage.NewMemoryItemRepository([]domain.Item{item}))
+	m.startTicks(start)
+
+	m.onTick(start.Add(15 * time.Second))
+	if m.items[0].Remind.Fired != 0 {
+		t.Fatal("reminder fired early")
+	}
+	m.Update(tea.BlurMsg{})
+	m.onTick(start.Add(30 * time.Second))
+	if m.items[0].Remind.Fired != 1 {
+		t.Error("reminder didn't fire while the terminal didn't have focus")
+	}
+	if !m.ticks.labelsStale {
+		t.Error("due labels were redrawn while the terminal didn't have focus")
+	}
+
+	m.Update(tea.FocusMsg{})
+	if m.ticks.labelsStale || m.ticks.blurred {
+		t.Error("focus didn't bring the due labels up to date")
+	}
+}
+
+func TestTickArchivesAfterMidnight(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	evening := time.Date(2026, 3, 10, 23, 59, 45, 0, time.Local)
+	done := domain.NewItem("pay rent")
+	done.ItemCompleted = true
+	done.CompletedAt = &evening
+	open := domain.NewItem("water the plants")
+
+	m := NewListScreen(cmd.DefaultTheme(), storage.NewMemoryItemRepository([]domain.Item{done, open}))
+	m.Clock = clock.Fixed(evening)
+	m.SetShowDoneSection(true)
+	m.startTicks(evening)
+
+	m.onTick(evening.Add(10 * time.Second))
+	if len(m.items) != 2 {
+		t.Fatalf("archived before midnight: %d items left", len(m.items))
+	}
+	m.onTick(evening.Add(20 * time.Second))
+	if len(m.items) != 1 || m.items[0].ID != open.ID {
+		t.Errorf("after midnight the list holds %d items, want the open one", len(m.items))
+	}
+}
@@ -0,0 +1,189 @@
+package views
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+
+	"clitodo/cmd"
+	"clitodo/pkg/state"
+)
+
+// tipLaunches is how many launches show tips.
+const tipLaunches = 3
+
+// tipAnchor is the part of the list a tip is drawn next to.
+type tipAnchor int
+
+const (
+	// The blank line under the title bar.
+	tipAnchorTitle tipAnchor = iota
+	// The line under the selected row, or above it at the bottom of the
+	// page.
+	tipAnchorSelection
+)
+
+// A tip suggests one thing to try while getting to know the list. It's shown
+// until it's done or dismissed.
+type tip struct {
+	// Name the tip is remembered by in the state file.
+	name   string
+	anchor tipAnchor
+
+	// text returns what the tip says, with the keys from keys.
+	text func(keys cmd.KeyMap) string
+
+	// applies reports whether the tip makes sense for the list as it is.
+	applies func(list *ListScreen) bool
+
+	// done reports whether msg, about to reach the list, does what the tip
+	// suggests.
+	done func(list *ListScreen, msg tea.Msg) bool
+}
+
+// allTips are shown one at a time, in this order.
+var allTips = []tip{
+	{
+		name:    "add",
+		anchor:  tipAnchorTitle,
+		text:    func(cmd.KeyMap) string { return "press ctrl+a to add your first task" },
+		applies: func(*ListScreen) bool { return true },
+		done: func(_ *ListScreen, msg tea.Msg) bool {
+			_, ok := msg.(cmd.TaskAdded)
+			return ok
+		},
+	},
+	{
+		name:   "complete",
+		anchor: tipAnchorSelection,
+		text:   func(keys cmd.KeyMap) string { return "press " + keys.ToggleDone.Help().Key + " to complete it" },
+		applies: func(list *ListScreen) bool {
+			selected := list.SelectedItem()
+			return selected != nil && !selected.Completed() && !selected.Someday
+		},
+		done: func(list *ListScreen, msg tea.Msg) bool {
+			if !listKey(list, msg, list.KeyMap.ToggleDone) || list.selectedHeader() != "" {
+				return false
+			}
+			selected := list.SelectedItem()
+			return selected != nil && !selected.Completed()
+		},
+	},
+	{
+		name:    "filter",
+		anchor:  tipAnchorTitle,
+		text:    func(keys cmd.KeyMap) string { return "press " + keys.Filter.Help().Key + " to filter" },
+		applies: func(list *ListScreen) bool { return list.KeyMap.Filter.Enabled() },
+		done: func(list *ListScreen, msg tea.Msg) bool {
+			return listKey(list, msg, list.KeyMap.Filter)
+		},
+	},
+}
+
+// listKey reports whether msg is a key press the list handles as binding.
+// Disabled bindings don't match, and neither does anything typed into the
+// jump prompt, the number prompt, the WIP confirmation or the command palette.
+func listKey(list *ListScreen, msg tea.Msg, binding key.Binding) bool {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	return ok && list.jump == nil && list.goTo == nil && list.confirm == nil && list.palette == nil && key.Matches(keyMsg, binding)
+}
+
+// tipEngine keeps track of which tips are left and watches the messages going
+// to the list for the ones that get done. A nil engine shows no tips.
+type tipEngine struct {
+	pending []tip
+}
+
+// newTipEngine counts this launch and returns the engine with the tips that
+// weren't done yet, or nil after the first few launches.
+func newTipEngine() *tipEngine {
+	st, err := state.Load()
+	if err != nil || st.Launches >= tipLaunches {
+		return nil
+	}
+	st.Launches++
+	st.Save()
+
+	t := &tipEngine{}
+	for _, tip := range allTips {
+		if !slices.Contains(st.TipsDone, tip.name) {
+			t.pending = append(t.pending, tip)
+		}
+	}
+	return t
+}
+
+// current returns the tip to show for list, if any. Only the first tip left is
+// ever shown, so they come up in order.
+func (t *tipEngine) current(list *ListScreen) *tip {
+	if t == nil || len(t.pending) == 0 || list.loading || list.FilterState() == Filtering || list.jump != nil || list.goTo != nil || list.palette != nil {
+		return nil
+	}
+	if !t.pending[0].applies(list) {
+		return nil
+	}
+	return &t.pending[0]
+}
+
+// observe marks the tips done that msg does, whether they're shown yet or
+// not.
+func (t *tipEngine) observe(list *ListScreen, msg tea.Msg) {
+	var done []string
+	for _, tip := range t.pending {
+		if tip.done(list, msg) {
+			done = append(done, tip.name)
+		}
+	}
+	for _, name := range done {
+		t.finish(name)
+	}
+}
+
+// finish drops the named tip and remembers never to show it again.
+func (t *tipEngine) finish(name string) {
+	t.pending = slices.DeleteFunc(t.pending, func(tip tip) bool { return tip.name == name })
+	if st, err := state.Load(); err == nil && !slices.Contains(st.TipsDone, name) {
+		st.TipsDone = append(st.TipsDone, name)
+		st.Save()
+	}
+}
+
+// withTip draws the current tip, if there is one, over the line of view it's
+// anchored to. view is the list without the details pane.
+func (m ListScreen) withTip(view string) string {
+	tip := m.tips.current(&m)
+	if tip == nil {
+		return view
+	}
+	lines := strings.Split(view, "\n")
+
+	line := -1
+	if m.showTitle || (m.showFilter && m.filteringEnabled) {
+		line = lipgloss.Height(m.titleView()) - 1
+	}
+	if tip.anchor == tipAnchorSelection {
+		top := m.itemsTop()
+		row := top + m.rowTop(m.cursor)
+		line = row + m.delegate.Height()
+		if item := m.SelectedItem(); item != nil {
+			line = row + m.itemHeight(m.Index(), *item)
+		}
+		if line >= top+m.pageHeight {
+			line = row - 1
+		}
+	}
+	if line < 0 || line >= len(lines) {
+		return view
+	}
+
+	dismiss := m.KeyMap.DismissTip.Help()
+	text := "Tip: " + tip.text(m.KeyMap) + " · " + dismiss.Key + " " + dismiss.Desc
+	indent := m.Styles.StatusBar.GetPaddingLeft()
+	width := max(1, m.width-indent-m.Styles.Tip.GetHorizontalFrameSize())
+	lines[line] = strings.Repeat(" ", indent) + m.Styles.Tip.Render(ansi.Truncate(text, width, cmd.Ellipsis))
+	return strings.Join(lines, "\n")
+}
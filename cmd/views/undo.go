@@ -0,0 +1,139 @@
+package views
+
+import (
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"clitodo/pkg/domain"
+)
+
+// undoStep is the list as it was before or after a change, with what to say
+// when going back or forth to it.
+type undoStep struct {
+	items []domain.Item
+	// ID of the item selected at the time.
+	selected string
+	// Status messages for undoing and redoing the change.
+	undone, redone string
+	// Another list the change touched too, as it was then. Nil if none was.
+	other *otherList
+}
+
+// otherList is what a list other than the one shown held, to put it back when
+// a change that reached into it, like moving a task there, is undone or
+// redone.
+type otherList struct {
+	path    string
+	someday bool
+	// The stored items, nil if the list didn't exist yet.
+	items []domain.Item
+}
+
+// restore puts the items of o back into its list and returns what the list
+// held until then.
+func (o otherList) restore() (otherList, error) {
+	current, err := changeOtherList(o.path, o.someday, func([]domain.Item) []domain.Item { return o.items })
+	if err != nil {
+		return otherList{}, err
+	}
+	o.items = current
+	return o, nil
+}
+
+// snapshot returns the current state of the list to go back to, described
+// by the status messages for undoing and redoing the change about to be made.
+func (m ListScreen) snapshot(undone, redone string) undoStep {
+	step := undoStep{items: slices.Clone(m.items), undone: undone, redone: redone}
+	if item := m.SelectedItem(); item != nil {
+		step.selected = item.ID
+	}
+	return step
+}
+
+// remember records a change so it can be undone, dropping the oldest one past
+// UndoDepth. Whatever was undone before can't be redone anymore.
+func (m *ListScreen) remember(step undoStep) {
+	if m.UndoDepth <= 0 {
+		return
+	}
+	m.undo = append(m.undo, step)
+	if len(m.undo) > m.UndoDepth {
+		m.undo = slices.Delete(m.undo, 0, len(m.undo)-m.UndoDepth)
+	}
+	m.redo = nil
+	m.updateKeybindings()
+}
+
+// undoChange puts the list back the way it was before the last change.
+func (m *ListScreen) undoChange() tea.Cmd {
+	if len(m.undo) == 0 {
+		return nil
+	}
+	step := m.undo[len(m.undo)-1]
+	redo, err := m.counterpart(step)
+	if err != nil {
+		return m.storageFailed("Undoing", err, m.undoChange)
+	}
+	m.undo = m.undo[:len(m.undo)-1]
+	m.redo = append(m.redo, redo)
+	return m.restore(step, step.undone)
+}
+
+// redoChange makes the last undone change again.
+func (m *ListScreen) redoChange() tea.Cmd {
+	if len(m.redo) == 0 {
+		return nil
+	}
+	step := m.redo[len(m.redo)-1]
+	undo, err := m.counterpart(step)
+	if err != nil {
+		return m.storageFailed("Redoing", err, m.redoChange)
+	}
+	m.redo = m.redo[:len(m.redo)-1]
+	m.undo = append(m.undo, undo)
+	return m.restore(step, step.redone)
+}
+
+// counterpart returns the state to go back to once step is restored. The
+// other list step touched, if any, is put back right away, as only the list
+// shown is saved by restore.
+func (m ListScreen) counterpart(step undoStep) (undoStep, error) {
+	back := m.snapshot(step.undone, step.redone)
+	if step.other != nil {
+		other, err := step.other.restore()
+		if err != nil {
+			return undoStep{}, err
+		}
+		back.other = &other
+	}
+	return back, nil
+}
+
+// restore replaces the items with the ones of step and saves them. The item
+// selected back then is selected again if the filter shows it; otherwise the
+// cursor stays where it is, within the list.
+func (m *ListScreen) restore(step undoStep, message string) tea.Cmd {
+	m.items = step.items
+	m.refreshRows()
+	m.selectID(step.selected)
+
+	if err := m.saveItems(); err != nil {
+		return m.saveFailed(err)
+	}
+	return m.NewStatusMessage(message)
+}
+
+// movedMessage describes moving the selected item in direction.
+func (m ListScreen) movedMessage(direction string) string {
+	if item := m.SelectedItem(); item != nil {
+		return fmt.Sprintf("Moved “%s” %s", item.Title(), direction)
+	}
+	return "Moved the item " + direction
+}
+
+// sameOrder reports whether a and b hold the same items in the same order.
+func sameOrder(a, b []domain.Item) bool {
+	return slices.EqualFunc(a, b, func(x, y domain.Item) bool { return x.ID == y.ID })
+}
@@ -0,0 +1,180 @@
+package views
+
+import (
+	"fmt"
+	"testing"
+
+	"clitodo/cmd"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/storage"
+)
+
+// undoList returns a list of n tasks, "task 1" to "task n", with every third
+// one about a bill, that can undo ten changes, and the storage it saves to.
+func undoList(t *testing.T, n int) (*ListScreen, storage.ItemRepository) {
+	t.Helper()
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	items := make([]domain.Item, n)
+	for i := range items {
+		title := fmt.Sprintf("task %d", i+1)
+		if i%3 == 0 {
+			title = fmt.Sprintf("pay bill %d", i+1)
+		}
+		items[i] = domain.NewItem(title)
+	}
+	repo := storage.NewMemoryItemRepository(items)
+	m := NewListScreen(cmd.DefaultTheme(), repo)
+	m.UndoDepth = 10
+	m.SetSize(80, 40)
+	return m, repo
+}
+
+// stored returns the titles of the items saved in repo.
+func stored(t *testing.T, repo storage.ItemRepository) []string {
+	t.Helper()
+	items, err := repo.GetItems()
+	if err != nil {
+		t.Fatal(err)
+	}
+	titles := make([]string, len(items))
+	for i, item := range items {
+		titles[i] = item.Title()
+	}
+	return titles
+}
+
+func visibleTitles(m *ListScreen) []string {
+	var titles []string
+	for _, item := range m.VisibleItems() {
+		titles = append(titles, item.Title())
+	}
+	return titles
+}
+
+func selectedTitle(m *ListScreen) string {
+	if item := m.SelectedItem(); item != nil {
+		return item.Title()
+	}
+	return ""
+}
+
+func TestUndoRedoWithFilter(t *testing.T) {
+	m, repo := undoList(t, 9)
+	m.SetFilterText("bill")
+	if got := visibleTitles(m); len(got) != 3 {
+		t.Fatalf("filter shows %q, want the three bills", got)
+	}
+
+	m.Select(1)
+	if selectedTitle(m) != "pay bill 4" {
+		t.Fatalf("selected %q, want pay bill 4", selectedTitle(m))
+	}
+	m.deleteSelected()
+	if got := visibleTitles(m); len(got) != 2 {
+		t.Fatalf("after deleting the filter shows %q", got)
+	}
+
+	m.undoChange()
+	if m.FilterState() != FilterApplied {
+		t.Errorf("undo left the filter %s, want it applied", m.FilterState())
+	}
+	if got := visibleTitles(m); len(got) != 3 || got[1] != "pay bill 4" {
+		t.Errorf("after undo the filter shows %q, want pay bill 4 back in its place", got)
+	}
+	if selectedTitle(m) != "pay bill 4" {
+		t.Errorf("after undo %q is selected, want pay bill 4", selectedTitle(m))
+	}
+	if got := stored(t, repo); len(got) != 9 || got[3] != "pay bill 4" {
+		t.Errorf("after undo the storage holds %q", got)
+	}
+
+	// Redoing with the cursor on the last match leaves it on the list.
+	m.Select(2)
+	m.redoChange()
+	if got := visibleTitles(m); len(got) != 2 || got[0] != "pay bill 1" || got[1] != "pay bill 7" {
+		t.Errorf("after redo the filter shows %q", got)
+	}
+	if m.Index() >= len(m.VisibleItems()) {
+		t.Errorf("after redo the cursor is on row %d of %d", m.Index(), len(m.VisibleItems()))
+	}
+	if got := stored(t, repo); len(got) != 8 {
+		t.Errorf("after redo the storage holds %q", got)
+	}
+}
+
+func TestUndoChangeHiddenByFilter(t *testing.T) {
+	m, _ := undoList(t, 9)
+
+	// Complete a task, then filter it out of sight before undoing.
+	m.Select(1)
+	m.toggleDone(*m.SelectedItem())
+	m.SetFilterText("bill")
+	m.Select(2)
+
+	m.undoChange()
+	if m.items[1].Completed() {
+		t.Error("undo didn't reopen the task hidden by the filter")
+	}
+	if selectedTitle(m) != "pay bill 7" {
+		t.Errorf("selected %q, want the cursor to stay on pay bill 7", selectedTitle(m))
+	}
+
+	m.redoChange()
+	if !m.items[1].Completed() {
+		t.Error("redo didn't complete the task hidden by the filter again")
+	}
+	if got := visibleTitles(m); len(got) != 3 {
+		t.Errorf("after redo the filter shows %q", got)
+	}
+}
+
+func TestUndoRedoAcrossPages(t *testing.T) {
+	m, repo := undoList(t, 30)
+	m.SetSize(80, 12)
+	if m.Paginator.TotalPages < 3 {
+		t.Fatalf("%d pages, want at least 3", m.Paginator.TotalPages)
+	}
+
+	// Delete the last task on the last page, then go back to the first.
+	last := len(m.VisibleItems()) - 1
+	m.Select(last)
+	lastPage := m.Paginator.Page
+	m.deleteSelected()
+	m.Select(0)
+
+	m.undoChange()
+	if selectedTitle(m) != "task 30" {
+		t.Errorf("after undo %q is selected, want task 30", selectedTitle(m))
+	}
+	if m.Paginator.Page != lastPage {
+		t.Errorf("after undo page %d is shown, want %d with task 30 on it", m.Paginator.Page, lastPage)
+	}
+	if got := stored(t, repo); len(got) != 30 {
+		t.Errorf("after undo the storage holds %d items, want 30", len(got))
+	}
+
+	// Redo with the restored task selected: it goes again and the cursor
+	// stays on a page that exists.
+	m.redoChange()
+	if n := len(m.VisibleItems()); n != 29 || m.Index() >= n {
+		t.Errorf("after redo the cursor is on row %d of %d", m.Index(), n)
+	}
+	if m.Paginator.Page >= m.Paginator.TotalPages {
+		t.Errorf("after redo page %d is shown of %d", m.Paginator.Page, m.Paginator.TotalPages)
+	}
+
+	// Undoing twice in a row and redoing twice goes through the same
+	// states.
+	m.Select(0)
+	m.deleteSelected()
+	m.undoChange()
+	m.undoChange()
+	if got := stored(t, repo); len(got) != 30 || got[0] != "pay bill 1" {
+		t.Errorf("after undoing both deletes the storage holds %d items starting with %q", len(got), got[0])
+	}
+	m.redoChange()
+	m.redoChange()
+	if got := stored(t, repo); len(got) != 28 || got[0] != "task 2" {
+		t.Errorf("after redoing both deletes the storage holds %d items starting with %q", len(got), got[0])
+	}
+}
@@ -0,0 +1,132 @@
+package views
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"clitodo/cmd"
+	"clitodo/pkg/domain"
+)
+
+// waitDoneMsg carries the answer from the waiting prompt for the item with
+// the given ID. waiting is nil when the prompt was cancelled.
+type waitDoneMsg struct {
+	id      string
+	waiting *domain.Waiting
+}
+
+// waitScreen asks what an item is waiting on and when to follow up.
+type waitScreen struct {
+	id       string
+	title    string
+	on       textinput.Model
+	followUp textinput.Model
+	now      time.Time
+	err      string
+
+	KeyMap cmd.KeyMap
+	help   help.Model
+	styles cmd.Styles
+}
+
+func newWaitScreen(item domain.Item, now time.Time, styles cmd.Styles) waitScreen {
+	on := textinput.New()
+	on.Placeholder = "who or what (optional)"
+	on.CharLimit = 80
+	on.Width = 30
+	on.Focus()
+
+	followUp := textinput.New()
+	followUp.Placeholder = "in 1 week, fri 9am or " + now.AddDate(0, 0, 7).Format(domain.DueLayout) //nolint:mnd
+	followUp.CharLimit = 40
+	followUp.Width = 40
+
+	return waitScreen{
+		id:       item.ID,
+		title:    item.Title(),
+		on:       on,
+		followUp: followUp,
+		now:      now,
+		KeyMap:   cmd.DefaultKeyMap(),
+		help:     help.New(),
+		styles:   styles,
+	}
+}
+
+func (m waitScreen) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m waitScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(keyMsg, m.KeyMap.CancelWait):
+			id := m.id
+			return m, func() tea.Msg { return waitDoneMsg{id: id} }
+		case key.Matches(keyMsg, m.KeyMap.AcceptWait):
+			return m.accept()
+		case key.Matches(keyMsg, m.KeyMap.NextWaitField):
+			if m.on.Focused() {
+				m.on.Blur()
+				return m, m.followUp.Focus()
+			}
+			m.followUp.Blur()
+			return m, m.on.Focus()
+		}
+	}
+
+	var cmd tea.Cmd
+	if m.on.Focused() {
+		m.on, cmd = m.on.Update(msg)
+	} else {
+		m.followUp, cmd = m.followUp.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m waitScreen) accept() (tea.Model, tea.Cmd) {
+	waiting := &domain.Waiting{On: strings.TrimSpace(m.on.Value())}
+	if v := strings.TrimSpace(m.followUp.Value()); v != "" {
+		followUp, err := domain.ParseDue(v, m.now)
+		if err != nil {
+			m.err = err.Error()
+			return m, nil
+		}
+		waiting.FollowUp = &followUp
+	}
+
+	id := m.id
+	return m, func() tea.Msg { return waitDoneMsg{id: id, waiting: waiting} }
+}
+
+func (m waitScreen) View() string {
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render("Waiting"))
+	b.WriteString("  " + m.title + "\n\n")
+	b.WriteString("Waiting on\n" + m.on.View() + "\n\n")
+	b.WriteString("Follow up on\n" + m.followUp.View() + "\n")
+	if v := strings.TrimSpace(m.followUp.Value()); v != "" {
+		// Show what the date resolves to, so the rules of ParseDate can be
+		// learned by typing.
+		preview := "not a date yet"
+		if d, err := domain.ParseDue(v, m.now); err == nil {
+			preview = "→ " + d.Format("Mon ") + domain.FormatDue(d)
+		}
+		b.WriteString(m.styles.StatusBar.UnsetPadding().Render(preview) + "\n")
+	}
+	if m.err != "" {
+		b.WriteString("\n" + m.styles.StatusBar.UnsetPadding().Render(m.err) + "\n")
+	}
+	b.WriteString(m.styles.HelpStyle.Render(m.help.ShortHelpView([]key.Binding{
+		m.KeyMap.AcceptWait,
+		m.KeyMap.NextWaitField,
+		m.KeyMap.CancelWait,
+	})))
+	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
+}
@@ -0,0 +1,78 @@
+package views
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"clitodo/cmd"
+	"clitodo/pkg/state"
+	"clitodo/pkg/version"
+)
+
+type whatsNewDoneMsg struct{}
+
+// whatsNewScreen lists what changed in some releases, from the changelog
+// built into clitodo. Any key closes it.
+type whatsNewScreen struct {
+	title    string
+	releases []version.Release
+	help     help.Model
+	styles   cmd.Styles
+}
+
+// newWhatsNew returns the screen of what changed since the clitodo that ran
+// last, if this one is a newer release, and remembers that this one ran.
+func newWhatsNew(styles cmd.Styles) *whatsNewScreen {
+	current := version.Current()
+	if current == version.Dev {
+		return nil
+	}
+	st, err := state.Load()
+	if err != nil || st.LastVersion == current {
+		return nil
+	}
+	last := st.LastVersion
+	st.LastVersion = current
+	st.Save()
+
+	releases := version.Since(last)
+	if len(releases) == 0 {
+		return nil
+	}
+	return &whatsNewScreen{title: "What's new in clitodo " + current, releases: releases, help: help.New(), styles: styles}
+}
+
+// newVersionScreen returns the screen with this clitodo's version and the
+// whole changelog.
+func newVersionScreen(styles cmd.Styles) whatsNewScreen {
+	return whatsNewScreen{title: version.String(), releases: version.Changelog, help: help.New(), styles: styles}
+}
+
+func (m whatsNewScreen) Init() tea.Cmd {
+	return nil
+}
+
+func (m whatsNewScreen) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(tea.KeyMsg); ok {
+		return m, func() tea.Msg { return whatsNewDoneMsg{} }
+	}
+	return m, nil
+}
+
+func (m whatsNewScreen) View() string {
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render(m.title))
+	b.WriteString("\n")
+	for _, r := range m.releases {
+		b.WriteString("\n  " + lipgloss.NewStyle().Bold(true).Render(r.Version) + "\n")
+		for _, change := range r.Changes {
+			b.WriteString("  • " + change + "\n")
+		}
+	}
+	// Any key closes it, so there's no binding to show.
+	b.WriteString(m.styles.HelpStyle.Render(m.help.Styles.ShortKey.Render("any key") + " " + m.help.Styles.ShortDesc.Render("close")))
+	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
+}
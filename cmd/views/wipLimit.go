@@ -0,0 +1,72 @@
+package views
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"clitodo/pkg/domain"
+	"clitodo/pkg/state"
+)
+
+// wipHistory is how long going over the WIP limit is remembered for.
+const wipHistory = 8 * 7 * 24 * time.Hour
+
+// guardWIP is what every change that can put more tasks in progress goes
+// through. change returns the items as they'd be after it. If that's over the
+// WIP limit, and more over it than now, the user is asked first and apply
+// only runs once they agree. Otherwise apply runs right away.
+func (m *ListScreen) guardWIP(change func([]domain.Item) []domain.Item, apply func() tea.Cmd) tea.Cmd {
+	after := change(slices.Clone(m.items))
+	count := m.WIPLimit.Count(after)
+	if !m.WIPLimit.Exceeded(after) || count <= m.WIPLimit.Count(m.items) {
+		return apply()
+	}
+
+	m.confirm = &confirmation{
+		question: fmt.Sprintf("That makes %d tasks tagged #%s, over the limit of %d. Go ahead?", count, m.WIPLimit.Tag, m.WIPLimit.Limit),
+		apply: func() tea.Cmd {
+			recordWIPExceeded(m.Clock.Now())
+			return apply()
+		},
+		cancelled: "Left as it was",
+	}
+	m.updateKeybindings()
+	return nil
+}
+
+// changingItem returns a change for guardWIP that applies change to the item
+// with the given ID.
+func changingItem(id string, change func(*domain.Item)) func([]domain.Item) []domain.Item {
+	return func(items []domain.Item) []domain.Item {
+		if i := slices.IndexFunc(items, func(item domain.Item) bool { return item.ID == id }); i >= 0 {
+			change(&items[i])
+		}
+		return items
+	}
+}
+
+// wipView returns the status bar's warning while more tasks are in progress
+// than the limit allows, or "".
+func (m ListScreen) wipView() string {
+	if !m.WIPLimit.Exceeded(m.items) {
+		return ""
+	}
+	return m.Styles.StatusBarWIP.Render(fmt.Sprintf("⚠ %d/%d #%s", m.WIPLimit.Count(m.items), m.WIPLimit.Limit, m.WIPLimit.Tag))
+}
+
+// recordWIPExceeded remembers that the WIP limit was knowingly exceeded at
+// now, for the stats screen.
+func recordWIPExceeded(now time.Time) {
+	st, err := state.Load()
+	if err != nil {
+		return
+	}
+	st.WIPExceeded = slices.DeleteFunc(st.WIPExceeded, func(t time.Time) bool {
+		return now.Sub(t) > wipHistory
+	})
+	st.WIPExceeded = append(st.WIPExceeded, now)
+	st.Save()
+}
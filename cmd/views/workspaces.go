@@ -0,0 +1,273 @@
+package views
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"clitodo/cmd"
+	"clitodo/pkg/state"
+)
+
+// Workspace is a storage file with its own theme and default tags that the
+// list can be switched to without restarting. The workspace named "" is the
+// list used without one.
+type Workspace struct {
+	Name        string
+	StoragePath string
+	Theme       cmd.Theme
+	DefaultTags []string
+}
+
+// label returns how the workspace is shown in the picker.
+func (w Workspace) label() string {
+	if w.Name == "" {
+		return "(no workspace)"
+	}
+	return w.Name
+}
+
+func (m ListScreen) openWorkspaces() tea.Cmd {
+	if m.Importing() {
+		return m.NewStatusMessage("Wait for the import to finish before switching workspaces")
+	}
+	return func() tea.Msg { return cmd.WorkspaceTrigger{} }
+}
+
+// cycleWorkspace switches to the workspace step places after the active one
+// in the picker, wrapping around at either end.
+func (m ListScreen) cycleWorkspace(step int) tea.Cmd {
+	if m.Importing() {
+		return m.NewStatusMessage("Wait for the import to finish before switching workspaces")
+	}
+	return func() tea.Msg { return cmd.CycleWorkspaceTrigger{Step: step} }
+}
+
+func (m ListScreen) newList() tea.Cmd {
+	if m.Importing() {
+		return m.NewStatusMessage("Wait for the import to finish before switching workspaces")
+	}
+	return func() tea.Msg { return cmd.NewListTrigger{} }
+}
+
+// withDefaultTags adds the default tags that tags doesn't have yet.
+func withDefaultTags(tags, defaults []string) []string {
+	for _, tag := range defaults {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// workspaceState returns where the list was left, to be restored the next time
+// its workspace is opened.
+func (m ListScreen) workspaceState() state.Workspace {
+	var ws state.Workspace
+	if m.filterState == FilterApplied {
+		ws.Filter = m.FilterValue()
+	}
+	if item := m.SelectedItem(); item != nil {
+		ws.Selected = item.ID
+	}
+	return ws
+}
+
+// restoreWorkspaceState brings back the filter and selection of ws. The list
+// must already be sized, or the selection lands on the wrong page.
+func (m *ListScreen) restoreWorkspaceState(ws state.Workspace) {
+	if ws.Filter != "" {
+		m.SetFilterText(ws.Filter)
+	}
+	for i, item := range m.VisibleItems() {
+		if item.ID == ws.Selected {
+			m.Select(i)
+			return
+		}
+	}
+}
+
+type workspaceDoneMsg struct {
+	name      string
+	cancelled bool
+}
+
+// workspacePicker lists the configured workspaces to switch to, or to move a
+// task to.
+type workspacePicker struct {
+	title      string
+	workspaces []Workspace
+	current    string
+	cursor     int
+
+	// done makes the message sent once a workspace is picked, or cancelled
+	// if none was.
+	done func(name string, cancelled bool) tea.Msg
+
+	KeyMap cmd.KeyMap
+	help   help.Model
+	styles cmd.Styles
+}
+
+func newWorkspacePicker(workspaces []Workspace, current string, styles cmd.Styles) workspacePicker {
+	m := workspacePicker{
+		title:      "Workspaces",
+		workspaces: workspaces,
+		current:    current,
+		done: func(name string, cancelled bool) tea.Msg {
+			return workspaceDoneMsg{name: name, cancelled: cancelled}
+		},
+		KeyMap: cmd.DefaultKeyMap(),
+		help:   help.New(),
+		styles: styles,
+	}
+	for i, w := range workspaces {
+		if w.Name == current {
+			m.cursor = i
+		}
+	}
+	return m
+}
+
+func (m workspacePicker) Init() tea.Cmd {
+	return nil
+}
+
+func (m workspacePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(keyMsg, m.KeyMap.CancelWorkspace):
+		return m, func() tea.Msg { return m.done("", true) }
+	case key.Matches(keyMsg, m.KeyMap.AcceptWorkspace):
+		done := m.done(m.workspaces[m.cursor].Name, false)
+		return m, func() tea.Msg { return done }
+	case key.Matches(keyMsg, m.KeyMap.CursorUp):
+		m.cursor = max(0, m.cursor-1)
+	case key.Matches(keyMsg, m.KeyMap.CursorDown):
+		m.cursor = min(len(m.workspaces)-1, m.cursor+1)
+	}
+	return m, nil
+}
+
+func (m workspacePicker) View() string {
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render(m.title))
+	b.WriteString("\n\n")
+
+	for i, w := range m.workspaces {
+		line := w.label()
+		if w.Name == m.current {
+			line += " (current)"
+		}
+		if i == m.cursor {
+			line = lipgloss.NewStyle().Reverse(true).Render(line)
+		}
+		fmt.Fprintf(&b, "  %s\n", line)
+	}
+
+	b.WriteString(m.styles.HelpStyle.Render(m.help.ShortHelpView([]key.Binding{
+		m.KeyMap.CursorUp,
+		m.KeyMap.CursorDown,
+		m.KeyMap.AcceptWorkspace,
+		m.KeyMap.CancelWorkspace,
+	})))
+	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
+}
+
+// newListDoneMsg closes the new list prompt. workspace is the list to switch
+// to, nil if the prompt was cancelled.
+type newListDoneMsg struct {
+	workspace *Workspace
+}
+
+// newListPrompt asks for the name of a new list. create sets the list up, or
+// returns an error that's shown until the name is changed.
+type newListPrompt struct {
+	textInput textinput.Model
+	create    func(name string) (Workspace, error)
+	err       string
+
+	KeyMap cmd.KeyMap
+	help   help.Model
+	styles cmd.Styles
+}
+
+func newNewListPrompt(create func(name string) (Workspace, error), styles cmd.Styles) newListPrompt {
+	ti := textinput.New()
+	ti.Placeholder = "inbox"
+	ti.CharLimit = 64
+	ti.Width = 40
+	ti.Focus()
+
+	return newListPrompt{
+		textInput: ti,
+		create:    create,
+		KeyMap:    cmd.DefaultKeyMap(),
+		help:      help.New(),
+		styles:    styles,
+	}
+}
+
+func (m newListPrompt) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m newListPrompt) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(keyMsg, m.KeyMap.CancelListName):
+			return m, func() tea.Msg { return newListDoneMsg{} }
+		case key.Matches(keyMsg, m.KeyMap.AcceptListName):
+			name := strings.TrimSpace(m.textInput.Value())
+			if name == "" {
+				m.err = "The name can't be empty."
+				return m, nil
+			}
+			w, err := m.create(name)
+			if err != nil {
+				m.err = err.Error()
+				return m, nil
+			}
+			return m, func() tea.Msg { return newListDoneMsg{workspace: &w} }
+		}
+	}
+
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+func (m newListPrompt) View() string {
+	var b strings.Builder
+	b.WriteString(m.styles.Title.Render("New List"))
+	b.WriteString("\n\n" + m.textInput.View() + "\n")
+	if m.err != "" {
+		fmt.Fprintf(&b, "\n%s\n", m.styles.StatusBar.UnsetPadding().Render(m.err))
+	}
+	b.WriteString(m.styles.HelpStyle.Render(m.help.ShortHelpView([]key.Binding{
+		m.KeyMap.AcceptListName,
+		m.KeyMap.CancelListName,
+	})))
+	return lipgloss.NewStyle().Margin(1, 2).Render(b.String())
+}
+
+// rememberList records the list of the given name in the state file, so the
+// picker offers it on the next start too.
+func rememberList(name string) {
+	st, err := state.Load()
+	if err != nil || slices.Contains(st.Lists, name) {
+		return
+	}
+	st.Lists = append(st.Lists, name)
+	st.Save()
+}
@@ -0,0 +1,101 @@
+package views
+
+import "clitodo/pkg/state"
+
+// chrome is which parts of the list view around the rows are shown, as set
+// one by one with the SetShow* toggles.
+type chrome struct {
+	title, filter, statusBar, pagination, help, pageSummary, tagChips bool
+}
+
+func (m ListScreen) chrome() chrome {
+	return chrome{
+		title:       m.showTitle,
+		filter:      m.showFilter,
+		statusBar:   m.showStatusBar,
+		pagination:  m.showPagination,
+		help:        m.showHelp,
+		pageSummary: m.showPageSummary,
+		tagChips:    m.showTagChips,
+	}
+}
+
+// setChrome shows and hides the parts of the list view in c at once, so the
+// rows are fitted to the space left only once.
+func (m *ListScreen) setChrome(c chrome) {
+	if c == m.chrome() {
+		return
+	}
+	m.showTitle = c.title
+	m.showFilter = c.filter
+	m.showStatusBar = c.statusBar
+	m.showPagination = c.pagination
+	m.showHelp = c.help
+	m.showPageSummary = c.pageSummary
+	m.showTagChips = c.tagChips
+	if !c.tagChips {
+		m.chipFocus = nil
+	}
+	m.updatePagination()
+	m.updateKeybindings()
+}
+
+// SetZen turns zen mode on or off. In zen mode the list shows nothing but
+// the tasks, centered while they don't fill the page, until something needs
+// the rest of the view for a while; see syncZen. Turning it off shows what
+// was shown before.
+func (m *ListScreen) SetZen(v bool) {
+	if v == m.zen {
+		return
+	}
+	if v {
+		m.beforeZen = m.chrome()
+	} else {
+		m.setChrome(m.beforeZen)
+	}
+	m.zen = v
+	m.syncZen()
+}
+
+// Zen reports whether zen mode is on.
+func (m ListScreen) Zen() bool {
+	return m.zen
+}
+
+// toggleZen turns zen mode on or off and remembers that for the next start.
+func (m *ListScreen) toggleZen() {
+	m.SetZen(!m.zen)
+	if st, err := state.Load(); err == nil {
+		st.Zen = m.zen
+		st.Save()
+	}
+}
+
+// syncZen shows in zen mode the parts of the view the list needs right now,
+// and hides them again once it doesn't: the prompt while filtering, jumping,
+// going to a number or picking a command, the title bar while it has a status message, the
+// status bar while it asks a question, reports an import or why the storage
+// couldn't be read, and the full help while it's open.
+func (m *ListScreen) syncZen() {
+	if !m.zen {
+		return
+	}
+	m.setChrome(chrome{
+		title:     m.statusMessage != "",
+		filter:    m.filterState == Filtering || m.jump != nil || m.goTo != nil || m.palette != nil,
+		statusBar: m.confirm != nil || m.importJob != nil || m.loadErr != nil,
+		help:      m.Help.ShowAll,
+	})
+}
+
+// zenPadding returns how many lines zen mode leaves above the rows to center
+// them on a page they don't fill.
+func (m ListScreen) zenPadding() int {
+	if !m.zen || m.Paginator.TotalPages > 1 || m.jump != nil || m.palette != nil {
+		return 0
+	}
+	if m.itemsOnPage() == 0 {
+		return 0
+	}
+	return max(0, m.pageHeight-m.pageLines()) / 2
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"clitodo/pkg/config"
+	"clitodo/pkg/state"
+	"clitodo/pkg/storage"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// legacyStorage returns the storage.json in the working directory, as an
+// absolute path, when older versions may have kept the tasks there: a storage
+// path is configured at the top level and isn't that file. It also returns
+// what was decided about it in this directory before, if anything.
+func legacyStorage(cfg config.Config) (string, state.LegacyDecision, bool) {
+	if cfg.Storage == "" || cfg.StorageTemplate() != cfg.Storage {
+		return "", "", false
+	}
+	configured, err := cfg.StoragePath()
+	if err != nil {
+		return "", "", false
+	}
+	path, err := filepath.Abs(storage.DefaultFilePath)
+	if err != nil || filepath.Clean(configured) == path {
+		return "", "", false
+	}
+	if info, err := os.Stat(path); err != nil || !info.Mode().IsRegular() {
+		return "", "", false
+	}
+
+	st, err := state.Load()
+	if err != nil {
+		return "", "", false
+	}
+	return path, st.LegacyStorage[filepath.Dir(path)], true
+}
+
+// legacyPending reports whether the tasks in the storage.json at legacy should
+// be offered to be moved to the configured storage at configured: there are
+// some, and the configured storage is still empty.
+func legacyPending(legacy, configured string) bool {
+	source := storage.NewFileItemRepository(legacy)
+	if items, err := source.GetItems(); err != nil || len(items) == 0 {
+		return false
+	}
+	target := storage.NewFileItemRepository(configured)
+	items, err := target.GetItems()
+	return (err == nil || errors.Is(err, storage.ErrNotFound)) && len(items) == 0
+}
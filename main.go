@@ -2,17 +2,200 @@ package main
 
 import (
 	"clitodo/cmd/views"
+	"clitodo/pkg/cli"
+	"clitodo/pkg/config"
+	"clitodo/pkg/startup"
+	"clitodo/pkg/state"
+	"clitodo/pkg/storage"
+	"clitodo/pkg/version"
+	"flag"
 	"fmt"
+	"log"
 	"os"
+	"runtime"
+	"time"
+	// Timezones named in the config work without the system's database.
+	_ "time/tzdata"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-isatty"
 )
 
 func main() {
-	p := tea.NewProgram(views.NewMainView(), tea.WithAltScreen())
+	var options views.Options
+	flag.StringVar(&options.ImportPath, "import", "", "import a todo.txt or Taskwarrior export on startup")
+	flag.BoolVar(&options.SafeMode, "safe-mode", false, "start without hooks, notifications or themes and open the storage read-only")
+	add := flag.Bool("add", false, "open the add screen right away")
+	flag.BoolVar(&options.Quick, "quick", false, "with --add, quit after adding one task")
+	flag.BoolVar(&options.Inline, "no-altscreen", false, "draw below the prompt instead of using the whole screen")
+	workspace := flag.String("workspace", "", "use the storage, theme and tags of this workspace from the config")
+	list := flag.String("list", "", "open this list: a workspace from the config, or NAME.json next to the storage")
+	noTips := flag.Bool("no-tips", false, "don't show tips for getting started")
+	keymap := flag.String("keymap", "", "start from these keybindings instead of the configured ones: default or vim")
+	traceStartup := flag.Bool("trace-startup", false, "print how long each phase of startup took on exit")
+	debugLog := flag.String("debug-log", "", "append how often the list is redrawn, once a minute, to this file")
+	showVersion := flag.Bool("version", false, "print the version and exit")
+	flag.Parse()
+	if *showVersion {
+		fmt.Println(version.String())
+		return
+	}
+	if *traceStartup {
+		options.Trace = startup.New()
+	}
+	if *debugLog != "" {
+		f, err := os.OpenFile(*debugLog, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		options.Debug = log.New(f, "", log.LstdFlags)
+	}
+
+	args := flag.Args()
+	if *add || isBareAdd(args) {
+		options.InitialView = views.AddTaskView
+		args = nil
+	} else {
+		options.Quick = false
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		if !options.SafeMode {
+			fmt.Fprintln(os.Stderr, "Error loading config:", err)
+			os.Exit(1)
+		}
+		fmt.Fprintln(os.Stderr, "Ignoring config in safe mode:", err)
+		cfg = config.Default()
+	}
+	options.Trace.Mark("config")
+	loc, err := cfg.Location()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error in config:", err)
+		os.Exit(1)
+	}
+	// Dates are shown and typed in local time everywhere, and stored in UTC.
+	time.Local = loc
+	backend, err := storage.ParseBackend(cfg.Backend)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error in config:", err)
+		os.Exit(1)
+	}
+	storage.SetBackend(backend)
+	if *workspace != "" && *list != "" {
+		fmt.Fprintln(os.Stderr, "Error: --list and --workspace can't be used together")
+		os.Exit(1)
+	}
+	if *workspace != "" {
+		cfg.Workspace = *workspace
+	}
+	if *keymap != "" {
+		cfg.Keymap = *keymap
+	}
+	if *list != "" {
+		if err := cfg.UseList(*list); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+	}
+	if err := cfg.CheckWorkspace(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	// Older versions kept the tasks in the working directory.
+	legacy, decision, found := legacyStorage(cfg)
+	if found && decision == state.LegacyKept {
+		cfg.Storage = legacy
+	}
+
+	if len(args) > 0 {
+		if err := cli.Run(cfg, args); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if !options.SafeMode {
+		if err := setupSubsystems(cfg, &options); err != nil {
+			fmt.Fprintln(os.Stderr, "Error in config:", err)
+			fmt.Fprintln(os.Stderr, "Run with --safe-mode to start without it.")
+			os.Exit(1)
+		}
+		options.Trace.Mark("subsystems")
+	}
+	if *noTips {
+		options.Tips = false
+	}
 
-	if _, err := p.Run(); err != nil {
+	options.StoragePath, err = cfg.StoragePath()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error in config:", err)
+		os.Exit(1)
+	}
+
+	if found && decision == "" && !options.SafeMode && options.InitialView != views.AddTaskView && legacyPending(legacy, options.StoragePath) {
+		options.LegacyStorage = legacy
+	}
+	// Bundles go next to the storage file, where recover finds them. Safe
+	// mode keeps the command: it's for when something is broken.
+	options.Backup = func() (string, error) {
+		path := cli.BackupPath(options.StoragePath, time.Now())
+		_, err := cli.CreateBackup(cfg, path)
+		return path, err
+	}
+
+	if os.Getenv("CLITODO_NO_ALTSCREEN") != "" || !altScreenSupported() {
+		options.Inline = true
+	}
+	programOptions := []tea.ProgramOption{tea.WithReportFocus()}
+	if options.Inline {
+		options.InlineHeight = cfg.InlineHeight
+	} else {
+		// Mouse events carry screen coordinates, which only line up with
+		// the view when it fills the alt screen.
+		programOptions = append(programOptions, tea.WithAltScreen(), tea.WithMouseCellMotion())
+	}
+
+	view := views.NewMainView(options)
+	options.Trace.Mark("main view")
+	p := tea.NewProgram(view, programOptions...)
+
+	final, err := p.Run()
+	if err != nil {
 		fmt.Println("Error running program:", err)
 		os.Exit(1)
 	}
+	if view, ok := final.(views.MainView); ok {
+		view.SaveWorkspaceState()
+		view.Close()
+		if options.Inline {
+			fmt.Println(view.Summary())
+		}
+	}
+	options.Trace.Print(os.Stderr)
+}
+
+// altScreenSupported reports whether the terminal can be expected to handle
+// the alt screen. Dumb terminals and output that isn't a terminal at all, as
+// in many CI logs, get garbled by it.
+func altScreenSupported() bool {
+	if !isatty.IsTerminal(os.Stdout.Fd()) {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		// Windows consoles don't set TERM.
+		return true
+	}
+	term := os.Getenv("TERM")
+	return term != "" && term != "dumb"
+}
+
+// isBareAdd reports whether args are the add command without a title, which
+// opens the add screen instead of adding from the command line.
+func isBareAdd(args []string) bool {
+	return len(args) == 1 && (args[0] == "a" || args[0] == "add")
 }
@@ -0,0 +1,120 @@
+// Package activity keeps a log of the changes made to items, for the activity
+// screen and `clitodo log`.
+package activity
+
+import (
+	"bufio"
+	"clitodo/pkg/hooks"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// MaxSize is how large the log file grows before it's rotated. The previous
+// file is kept, so the log holds between one and two of these.
+const MaxSize = 256 << 10
+
+// Entry is one change to an item.
+type Entry struct {
+	Time   time.Time   `json:"time"`
+	Event  hooks.Event `json:"event"`
+	ItemID string      `json:"id"`
+	Title  string      `json:"title"`
+}
+
+// Verb describes the entry's event in the past tense: "added", "completed"
+// or "deleted".
+func (e Entry) Verb() string {
+	switch e.Event {
+	case hooks.EventAdd:
+		return "added"
+	case hooks.EventComplete:
+		return "completed"
+	case hooks.EventDelete:
+		return "deleted"
+	}
+	return string(e.Event)
+}
+
+// Log is a file of entries, one JSON object per line, oldest first. Once it
+// grows past MaxSize it's moved aside to path.1, replacing the previous one.
+// A nil Log records nothing and has no entries.
+type Log struct {
+	path string
+}
+
+// PathFor returns where the log for the storage at storagePath is kept, next
+// to it: tasks.json logs to tasks.activity.jsonl.
+func PathFor(storagePath string) string {
+	return strings.TrimSuffix(storagePath, filepath.Ext(storagePath)) + ".activity.jsonl"
+}
+
+// New returns the log kept at path.
+func New(path string) *Log {
+	return &Log{path: path}
+}
+
+// Append adds an entry at the end of the log, rotating it first if it's full.
+func (l *Log) Append(e Entry) error {
+	if l == nil {
+		return nil
+	}
+	if info, err := os.Stat(l.path); err == nil && info.Size() >= MaxSize {
+		if err := os.Rename(l.path, l.path+".1"); err != nil {
+			return err
+		}
+	}
+
+	e.Time = e.Time.UTC()
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Entries returns the logged entries from since on, oldest first. Lines that
+// can't be read, such as one cut short by a crash, are skipped.
+func (l *Log) Entries(since time.Time) ([]Entry, error) {
+	if l == nil {
+		return nil, nil
+	}
+	var entries []Entry
+	for _, path := range []string{l.path + ".1", l.path} {
+		f, err := os.Open(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var e Entry
+			if json.Unmarshal(scanner.Bytes(), &e) != nil || e.Time.Before(since) {
+				continue
+			}
+			entries = append(entries, e)
+		}
+		f.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
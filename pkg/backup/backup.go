@@ -0,0 +1,183 @@
+// Package backup packs what clitodo keeps, the config, the state and the
+// files of each list, into one .tar.gz bundle and reads such bundles back, so
+// everything can be moved to another machine at once.
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+)
+
+// FormatVersion is the version of the bundle layout Create writes. Bundles
+// of a later version, from a newer clitodo, aren't read.
+const FormatVersion = 1
+
+// manifestName is the bundle entry that describes the others. It comes first.
+const manifestName = "manifest.json"
+
+// Manifest describes a bundle.
+type Manifest struct {
+	Version int       `json:"version"`
+	Created time.Time `json:"created"`
+	Files   []File    `json:"files"`
+}
+
+// File is one file in a bundle.
+type File struct {
+	// Name of the entry, such as "config.toml" or "lists/inbox/items.json".
+	Name string `json:"name"`
+	// When the file was last changed before it was packed.
+	Modified time.Time `json:"modified"`
+}
+
+// Source is a file to pack into a bundle under Name.
+type Source struct {
+	Name string
+	Path string
+
+	// Contents to pack instead of reading Path, for files put together
+	// from several others, as they were at Modified.
+	Data     []byte
+	Modified time.Time
+}
+
+// Create writes a bundle of the given files to w. Files that don't exist are
+// left out.
+func Create(w io.Writer, sources []Source, now time.Time) (Manifest, error) {
+	manifest := Manifest{Version: FormatVersion, Created: now.UTC()}
+	var contents [][]byte
+	for _, src := range sources {
+		if src.Data != nil {
+			manifest.Files = append(manifest.Files, File{Name: src.Name, Modified: src.Modified.UTC()})
+			contents = append(contents, src.Data)
+			continue
+		}
+		info, err := os.Stat(src.Path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return manifest, err
+		}
+		data, err := os.ReadFile(src.Path)
+		if err != nil {
+			return manifest, err
+		}
+		manifest.Files = append(manifest.Files, File{Name: src.Name, Modified: info.ModTime().UTC()})
+		contents = append(contents, data)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return manifest, err
+	}
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	if err := writeEntry(tw, manifestName, data, manifest.Created); err != nil {
+		return manifest, err
+	}
+	for i, f := range manifest.Files {
+		if err := writeEntry(tw, f.Name, contents[i], f.Modified); err != nil {
+			return manifest, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return manifest, err
+	}
+	return manifest, gz.Close()
+}
+
+func writeEntry(tw *tar.Writer, name string, data []byte, modified time.Time) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: modified,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// Bundle is a bundle read into memory.
+type Bundle struct {
+	Manifest Manifest
+	contents map[string][]byte
+}
+
+// Read reads a bundle written by Create. It fails for bundles of a later
+// FormatVersion and for bundles missing a file their manifest lists.
+func Read(r io.Reader) (*Bundle, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a backup bundle: %w", err)
+	}
+	defer gz.Close()
+
+	b := &Bundle{contents: map[string][]byte{}}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("not a backup bundle: %w", err)
+		}
+		var buf bytes.Buffer
+		if _, err := io.Copy(&buf, tr); err != nil {
+			return nil, err
+		}
+		b.contents[path.Clean(header.Name)] = buf.Bytes()
+	}
+
+	data, ok := b.contents[manifestName]
+	if !ok {
+		return nil, errors.New("not a backup bundle: no manifest")
+	}
+	if err := json.Unmarshal(data, &b.Manifest); err != nil {
+		return nil, fmt.Errorf("not a backup bundle: manifest: %w", err)
+	}
+	if b.Manifest.Version > FormatVersion {
+		return nil, fmt.Errorf("the bundle is of version %d, this clitodo only reads up to version %d; restore it with a newer clitodo", b.Manifest.Version, FormatVersion)
+	}
+	for _, f := range b.Manifest.Files {
+		if _, ok := b.contents[f.Name]; !ok {
+			return nil, fmt.Errorf("the bundle is incomplete: %s is missing", f.Name)
+		}
+	}
+	return b, nil
+}
+
+// Content returns the packed contents of the named file, if the bundle has it.
+func (b *Bundle) Content(name string) ([]byte, bool) {
+	for _, f := range b.Manifest.Files {
+		if f.Name == name {
+			return b.contents[name], true
+		}
+	}
+	return nil, false
+}
+
+// Newer reports whether the file at path was changed after the copy of it in
+// the bundle was packed. A missing file isn't newer.
+func Newer(path string, f File) (bool, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return info.ModTime().After(f.Modified), nil
+}
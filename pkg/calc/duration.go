@@ -0,0 +1,121 @@
+// Package calc evaluates the small arithmetic expressions accepted in time
+// fields, such as "3*25m" or "1h+20m".
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxDuration is the longest duration there is, about 292 years.
+const maxDuration = time.Duration(math.MaxInt64)
+
+// ParseDuration evaluates expr to a duration. An expression is a sum of
+// terms; each term is a duration like "1h", "25m" or "1h30m", optionally
+// multiplied by whole numbers on either side ("3*25m", "25m*3"). Spaces are
+// ignored. Anything else is rejected with an error saying what's wrong.
+func ParseDuration(expr string) (time.Duration, error) {
+	expr = strings.Join(strings.Fields(expr), "")
+	if expr == "" {
+		return 0, errors.New("empty duration")
+	}
+
+	var total time.Duration
+	for _, term := range strings.Split(expr, "+") {
+		if term == "" {
+			return 0, fmt.Errorf("%q: missing a term around +", expr)
+		}
+		d, err := parseTerm(term)
+		if err != nil {
+			return 0, err
+		}
+		if total > maxDuration-d {
+			return 0, fmt.Errorf("%q: too long", expr)
+		}
+		total += d
+	}
+	return total, nil
+}
+
+// parseTerm evaluates a product of whole numbers and exactly one duration.
+func parseTerm(term string) (time.Duration, error) {
+	var d time.Duration
+	hasDuration := false
+	factor := int64(1)
+	for _, f := range strings.Split(term, "*") {
+		if f == "" {
+			return 0, fmt.Errorf("%q: missing a factor around *", term)
+		}
+		if n, err := strconv.ParseInt(f, 10, 64); err == nil {
+			if n != 0 && factor > math.MaxInt64/n {
+				return 0, fmt.Errorf("%q: too long", term)
+			}
+			factor *= n
+			continue
+		}
+		v, err := parseUnits(f)
+		if err != nil {
+			return 0, err
+		}
+		if hasDuration {
+			return 0, fmt.Errorf("%q: can't multiply two durations, only a duration by a whole number", term)
+		}
+		d, hasDuration = v, true
+	}
+	if !hasDuration {
+		return 0, fmt.Errorf("%q: missing a unit (h, m or s)", term)
+	}
+	if factor != 0 && d > maxDuration/time.Duration(factor) {
+		return 0, fmt.Errorf("%q: too long", term)
+	}
+	return d * time.Duration(factor), nil
+}
+
+// parseUnits parses a duration made of whole numbers with h, m or s units,
+// like "1h30m". Each unit may appear once, largest first.
+func parseUnits(s string) (time.Duration, error) {
+	units := map[byte]time.Duration{'h': time.Hour, 'm': time.Minute, 's': time.Second}
+	order := "hms"
+
+	var d time.Duration
+	rest := s
+	last := -1
+	for rest != "" {
+		i := 0
+		for i < len(rest) && rest[i] >= '0' && rest[i] <= '9' {
+			i++
+		}
+		if i == 0 {
+			return 0, fmt.Errorf("%q: expected a whole number before %q", s, rest)
+		}
+		if i == len(rest) {
+			return 0, fmt.Errorf("%q: %s is missing a unit (h, m or s)", s, rest)
+		}
+		unit := rest[i]
+		if unit == '.' {
+			return 0, fmt.Errorf("%q: fractions aren't supported, write 1h30m instead of 1.5h", s)
+		}
+		pos := strings.IndexByte(order, unit)
+		if pos < 0 {
+			return 0, fmt.Errorf("%q: unknown unit %q, use h, m or s", s, rest[i:i+1])
+		}
+		if pos <= last {
+			return 0, fmt.Errorf("%q: units must go from hours to seconds, each at most once", s)
+		}
+		n, err := strconv.ParseInt(rest[:i], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q: %w", s, err)
+		}
+		if n > int64((maxDuration-d)/units[unit]) {
+			return 0, fmt.Errorf("%q: too long", s)
+		}
+		d += time.Duration(n) * units[unit]
+		last = pos
+		rest = rest[i+1:]
+	}
+	return d, nil
+}
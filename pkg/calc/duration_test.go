@@ -0,0 +1,70 @@
+package calc
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		expr string
+		want time.Duration
+	}{
+		{"25m", 25 * time.Minute},
+		{"1h30m", 90 * time.Minute},
+		{"1h30m15s", time.Hour + 30*time.Minute + 15*time.Second},
+		{"90m", 90 * time.Minute},
+		{"0m", 0},
+		{"1h+20m", 80 * time.Minute},
+		{"3*25m", 75 * time.Minute},
+		{"25m*3", 75 * time.Minute},
+		{"2*3*10m", time.Hour},
+		{"2*25m+2*5m+15m", 75 * time.Minute},
+		{" 3 * 25m + 1h ", 135 * time.Minute},
+		{"0*25m", 0},
+	}
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.expr)
+		if err != nil {
+			t.Errorf("ParseDuration(%q) error = %v", tt.expr, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDuration(%q) = %s, want %s", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestParseDurationRejects(t *testing.T) {
+	tests := []struct {
+		expr string
+		err  string
+	}{
+		{"", "empty duration"},
+		{"   ", "empty duration"},
+		{"1h+", "missing a term around +"},
+		{"+1h", "missing a term around +"},
+		{"3*", "missing a factor around *"},
+		{"3", "missing a unit"},
+		{"3*4", "missing a unit"},
+		{"25", "missing a unit"},
+		{"1.5h", "fractions aren't supported"},
+		{"2d", "unknown unit"},
+		{"h", "expected a whole number"},
+		{"30m1h", "units must go from hours to seconds"},
+		{"1h1h", "units must go from hours to seconds"},
+		{"1h*30m", "can't multiply two durations"},
+		{"1h-20m", "expected a whole number"},
+		{"99999999999999999999h", "value out of range"},
+		{"3000000h", "too long"},
+		{"3000000*1h", "too long"},
+		{"2000000h+2000000h", "too long"},
+	}
+	for _, tt := range tests {
+		_, err := ParseDuration(tt.expr)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("ParseDuration(%q) error = %v, want one containing %q", tt.expr, err, tt.err)
+		}
+	}
+}
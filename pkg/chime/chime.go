@@ -0,0 +1,110 @@
+package chime
+
+import (
+	"clitodo/pkg/clock"
+	"clitodo/pkg/config"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Runner runs the done command. Tests replace ShellRunner with a fake so no
+// real processes are spawned.
+type Runner interface {
+	Run(ctx context.Context, command string) error
+}
+
+// ShellRunner runs the done command through the system shell, detached from
+// the terminal so it can't draw over the TUI or read keystrokes.
+type ShellRunner struct{}
+
+func (ShellRunner) Run(ctx context.Context, command string) error {
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		c = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	return c.Run()
+}
+
+// Chime gives local feedback when a task is completed: it rings the terminal
+// bell and/or runs a user command such as "paplay ding.ogg". Chimes closer
+// together than the configured cooldown are dropped, so completing a batch
+// of tasks makes one sound instead of a cacophony.
+type Chime struct {
+	config config.Done
+	runner Runner
+	clock  clock.Clock
+	bell   io.Writer
+
+	mu   sync.Mutex
+	last time.Time
+}
+
+// New returns a Chime for the given configuration that rings the bell on
+// terminal. A nil Chime is valid and never makes a sound.
+func New(cfg config.Done, runner Runner, clk clock.Clock, terminal io.Writer) *Chime {
+	return &Chime{config: cfg, runner: runner, clock: clk, bell: terminal}
+}
+
+// Enabled returns whether completing a task makes any sound at all.
+func (c *Chime) Enabled() bool {
+	return c != nil && (c.config.Command != "" || c.config.Bell)
+}
+
+// Allow reports whether a chime may sound now, and if so counts it towards
+// the cooldown. Call it when the task is completed, not when the chime is
+// played, so the cooldown follows what the user did.
+func (c *Chime) Allow() bool {
+	if !c.Enabled() {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	if !c.last.IsZero() && now.Sub(c.last) < c.config.Cooldown {
+		return false
+	}
+	c.last = now
+	return true
+}
+
+// Play rings the bell and runs the done command, waiting at most the
+// configured timeout for it.
+func (c *Chime) Play() error {
+	if !c.Enabled() {
+		return nil
+	}
+
+	if c.config.Bell && c.bell != nil {
+		if _, err := io.WriteString(c.bell, "\a"); err != nil {
+			return err
+		}
+	}
+	if c.config.Command == "" {
+		return nil
+	}
+
+	ctx := context.Background()
+	if c.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.Timeout)
+		defer cancel()
+	}
+
+	err := c.runner.Run(ctx, c.config.Command)
+	if ctx.Err() != nil {
+		return fmt.Errorf("done command timed out after %s", c.config.Timeout)
+	}
+	if err != nil {
+		return fmt.Errorf("done command failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package chime
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"clitodo/pkg/config"
+)
+
+// fakeRunner records the commands it's asked to run. If block is set it waits
+// for the context to end instead.
+type fakeRunner struct {
+	err      error
+	block    bool
+	commands []string
+}
+
+func (r *fakeRunner) Run(ctx context.Context, command string) error {
+	r.commands = append(r.commands, command)
+	if r.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return r.err
+}
+
+// stepClock is a clock that only moves when told to.
+type stepClock struct {
+	now time.Time
+}
+
+func (c *stepClock) Now() time.Time { return c.now }
+
+func TestAllowCooldown(t *testing.T) {
+	clk := &stepClock{now: time.Date(2026, time.March, 10, 9, 30, 0, 0, time.UTC)}
+	c := New(config.Done{Bell: true, Cooldown: 2 * time.Second}, &fakeRunner{}, clk, nil)
+
+	steps := []struct {
+		after time.Duration
+		want  bool
+	}{
+		{0, true},
+		// Dropped chimes don't push the cooldown further out.
+		{time.Second, false},
+		{900 * time.Millisecond, false},
+		{100 * time.Millisecond, true},
+		{time.Second, false},
+		{3 * time.Second, true},
+	}
+	for i, step := range steps {
+		clk.now = clk.now.Add(step.after)
+		if got := c.Allow(); got != step.want {
+			t.Errorf("step %d: Allow() = %t, want %t", i, got, step.want)
+		}
+	}
+
+	// Without a cooldown every chime sounds.
+	c = New(config.Done{Bell: true}, &fakeRunner{}, clk, nil)
+	if !c.Allow() || !c.Allow() {
+		t.Error("without a cooldown a chime was dropped")
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	var bell strings.Builder
+	runner := &fakeRunner{}
+	for _, c := range []*Chime{nil, New(config.Done{Cooldown: time.Second}, runner, &stepClock{}, &bell)} {
+		if c.Enabled() || c.Allow() {
+			t.Error("a chime without a bell or command is enabled")
+		}
+		if err := c.Play(); err != nil {
+			t.Errorf("Play() error = %v", err)
+		}
+	}
+	if bell.Len() != 0 || len(runner.commands) != 0 {
+		t.Errorf("a disabled chime rang %q and ran %q", bell.String(), runner.commands)
+	}
+}
+
+func TestPlay(t *testing.T) {
+	var bell strings.Builder
+	runner := &fakeRunner{}
+	c := New(config.Done{Command: "paplay ding.ogg", Bell: true}, runner, &stepClock{}, &bell)
+	if err := c.Play(); err != nil {
+		t.Fatalf("Play() error = %v", err)
+	}
+	if bell.String() != "\a" {
+		t.Errorf("rang %q, want the bell", bell.String())
+	}
+	if len(runner.commands) != 1 || runner.commands[0] != "paplay ding.ogg" {
+		t.Errorf("ran %q, want the done command", runner.commands)
+	}
+
+	// The bell alone runs nothing.
+	runner = &fakeRunner{}
+	if err := New(config.Done{Bell: true}, runner, &stepClock{}, &bell).Play(); err != nil || len(runner.commands) != 0 {
+		t.Errorf("the bell alone ran %q, error %v", runner.commands, err)
+	}
+}
+
+func TestPlayFailures(t *testing.T) {
+	failed := errors.New("exit status 1")
+	err := New(config.Done{Command: "paplay"}, &fakeRunner{err: failed}, &stepClock{}, nil).Play()
+	if !errors.Is(err, failed) || !strings.HasPrefix(err.Error(), "done command failed") {
+		t.Errorf("a failing command gave %v", err)
+	}
+
+	start := time.Now()
+	cfg := config.Done{Command: "paplay", Timeout: 10 * time.Millisecond}
+	err = New(cfg, &fakeRunner{block: true}, &stepClock{}, nil).Play()
+	if err == nil || err.Error() != "done command timed out after 10ms" {
+		t.Errorf("a command that hangs gave %v, want it to time out", err)
+	}
+	if waited := time.Since(start); waited > time.Second {
+		t.Errorf("Play() waited %s for a command that times out after 10ms", waited)
+	}
+}
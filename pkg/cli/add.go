@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"clitodo/pkg/domain"
+	"clitodo/pkg/fold"
+	"clitodo/pkg/hooks"
+	"clitodo/pkg/match"
+	"clitodo/pkg/storage"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Add creates a new task. By default it is appended to the end of the list;
+// --top, --after and --before place it elsewhere in stored order.
+func (c *commandContext) Add(args []string) error {
+	fs := flag.NewFlagSet("add", flag.ContinueOnError)
+	top := fs.Bool("top", false, "insert the task at the top of the list")
+	after := fs.String("after", "", "insert after the task matching this partial title")
+	before := fs.Int("before", 0, "insert before the task at this 1-based position")
+	due := fs.String("due", "", `due date: YYYY-MM-DD, "tomorrow 5pm", "next fri", "in 3 days", "eom"`)
+	every := fs.String("every", "", `repeat when completed: daily, weekly, monthly or "every N days"`)
+	remind := fs.String("remind", "", `remind at a date as for --due, optionally repeating: "5pm, every 30m, max 5"`)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	title := strings.TrimSpace(strings.Join(fs.Args(), " "))
+	if title == "" {
+		return errors.New("usage: clitodo add [--top | --after TITLE | --before N] [--due DATE] [--every SPEC] [--remind SPEC] <title>")
+	}
+
+	rules, err := c.config.DefaultRules()
+	if err != nil {
+		return err
+	}
+
+	item := domain.NewItem(title)
+	item.Tags = c.config.DefaultTags()
+	if *due != "" {
+		d, err := domain.ParseDue(*due, time.Now())
+		if err != nil {
+			return fmt.Errorf("--due %w", err)
+		}
+		item.Due = &d
+	}
+	if *every != "" {
+		r, err := domain.ParseRecurrence(*every)
+		if err != nil {
+			return fmt.Errorf("--every: %w", err)
+		}
+		item.Recurrence = &r
+	}
+	if *remind != "" {
+		r, err := domain.ParseReminder(*remind, time.Now())
+		if err != nil {
+			return fmt.Errorf("--remind: %w", err)
+		}
+		item.Remind = &r
+	}
+	item, _ = rules.Apply(item, c.config.Workspace, domain.Given{Due: *due != ""}, time.Now())
+
+	itemRepository, err := c.repository()
+	if err != nil {
+		return err
+	}
+	items, err := itemRepository.GetItems()
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+
+	index, err := insertIndex(items, *top, *after, *before, c.config.Filter.IgnoreAccents)
+	if err != nil {
+		return err
+	}
+
+	items = append(items[:index], append([]domain.Item{item}, items[index:]...)...)
+	if err := itemRepository.StoreItemsState(items); err != nil {
+		return err
+	}
+
+	c.record(itemRepository, hooks.EventAdd, item)
+	return nil
+}
+
+// insertIndex returns where in items, in stored order, a new task goes: at
+// the end, at the top, after the task matching the title after, or before
+// the task at the 1-based position before.
+func insertIndex(items []domain.Item, top bool, after string, before int, ignoreAccents bool) (int, error) {
+	index := len(items)
+	switch {
+	case after != "" && before != 0, top && (after != "" || before != 0):
+		return 0, errors.New("--top, --after and --before are mutually exclusive")
+	case top:
+		index = 0
+	case after != "":
+		found, err := resolveTitle(items, after, ignoreAccents)
+		if err != nil {
+			return 0, err
+		}
+		index = found + 1
+	case before != 0:
+		if before < 1 || before > len(items) {
+			return 0, fmt.Errorf("--before %d is out of range (list has %d items)", before, len(items))
+		}
+		index = before - 1
+	}
+	return index, nil
+}
+
+// resolveTitle finds the index of the item whose title matches query using the
+// same fuzzy matcher as the TUI filter. A case-insensitive exact title match
+// always wins; otherwise the query must match exactly one item. An empty
+// query matches none.
+func resolveTitle(items []domain.Item, query string, ignoreAccents bool) (int, error) {
+	if query == "" {
+		return 0, fmt.Errorf("no task matches %q", query)
+	}
+	targets := make([]string, len(items))
+	for i, item := range items {
+		if strings.EqualFold(item.Title(), query) {
+			return i, nil
+		}
+		targets[i] = item.FilterValue()
+	}
+
+	ranks := match.NewFilter(fold.Folder{StripDiacritics: ignoreAccents})(query, targets)
+	switch len(ranks) {
+	case 0:
+		return 0, fmt.Errorf("no task matches %q", query)
+	case 1:
+		return ranks[0].Index, nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%q matches several tasks:", query)
+	for _, r := range ranks {
+		fmt.Fprintf(&b, "\n  %d. %s", r.Index+1, items[r.Index].Title())
+	}
+	return 0, errors.New(b.String())
+}
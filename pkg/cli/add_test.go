@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"clitodo/pkg/domain"
+)
+
+func titledItems(titles ...string) []domain.Item {
+	items := make([]domain.Item, len(titles))
+	for i, title := range titles {
+		items[i] = domain.NewItem(title)
+	}
+	return items
+}
+
+func TestInsertIndex(t *testing.T) {
+	list := titledItems("buy milk", "buy bread", "call Bob", "Café visit")
+
+	tests := []struct {
+		name   string
+		items  []domain.Item
+		top    bool
+		after  string
+		before int
+		accent bool
+		want   int
+		err    string
+	}{
+		{name: "empty list appends", items: nil, want: 0},
+		{name: "empty list top", items: nil, top: true, want: 0},
+		{name: "empty list after", items: nil, after: "milk", err: `no task matches "milk"`},
+		{name: "empty list before", items: nil, before: 1, err: "out of range (list has 0 items)"},
+		{name: "default appends", items: list, want: 4},
+		{name: "top", items: list, top: true, want: 0},
+		{name: "after unique match", items: list, after: "bob", want: 3},
+		{name: "after exact title wins over fuzzy", items: list, after: "BUY MILK", want: 1},
+		{name: "after ambiguous match", items: list, after: "buy", err: `"buy" matches several tasks:`},
+		{name: "after no match", items: list, after: "zzz", err: `no task matches "zzz"`},
+		{name: "after folds accents when asked", items: list, after: "cafe", accent: true, want: 4},
+		{name: "after keeps accents otherwise", items: list, after: "cafe", err: `no task matches "cafe"`},
+		{name: "before first", items: list, before: 1, want: 0},
+		{name: "before exact index", items: list, before: 3, want: 2},
+		{name: "before last", items: list, before: 4, want: 3},
+		{name: "before past the end", items: list, before: 5, err: "--before 5 is out of range (list has 4 items)"},
+		{name: "before negative", items: list, before: -1, err: "out of range"},
+		{name: "top and after", items: list, top: true, after: "bob", err: "mutually exclusive"},
+		{name: "after and before", items: list, after: "bob", before: 1, err: "mutually exclusive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := insertIndex(tt.items, tt.top, tt.after, tt.before, tt.accent)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("insertIndex() error = %v, want one containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("insertIndex() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("insertIndex() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveTitleListsCandidates(t *testing.T) {
+	items := titledItems("buy milk", "call Bob", "buy bread")
+	_, err := resolveTitle(items, "buy", false)
+	if err == nil {
+		t.Fatal("resolveTitle() succeeded on an ambiguous title")
+	}
+	for _, want := range []string{"1. buy milk", "3. buy bread"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q doesn't list %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "call Bob") {
+		t.Errorf("error %q lists a task that doesn't match", err)
+	}
+}
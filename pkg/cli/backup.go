@@ -0,0 +1,323 @@
+package cli
+
+import (
+	"bytes"
+	"clitodo/pkg/activity"
+	"clitodo/pkg/backup"
+	"clitodo/pkg/config"
+	"clitodo/pkg/state"
+	"clitodo/pkg/storage"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const backupUsage = "usage: clitodo backup create <file> | clitodo backup restore [--force] <file>"
+
+// Backup packs the config, the state and the files of every list into one
+// bundle, or restores them from one, possibly on another machine.
+func (c *commandContext) Backup(args []string) error {
+	if len(args) == 0 {
+		return errors.New(backupUsage)
+	}
+	switch args[0] {
+	case "create":
+		return c.backupCreate(args[1:])
+	case "restore":
+		return c.backupRestore(args[1:])
+	}
+	return errors.New(backupUsage)
+}
+
+func (c *commandContext) backupCreate(args []string) error {
+	if len(args) != 1 {
+		return errors.New(backupUsage)
+	}
+	n, err := CreateBackup(c.config, args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Backed up %d files to %s\n", n, args[0])
+	return nil
+}
+
+// BackupPath returns where a bundle made at t goes when none is named: next
+// to the storage file at storagePath, where recover looks for bundles.
+func BackupPath(storagePath string, t time.Time) string {
+	return filepath.Join(filepath.Dir(storagePath), "clitodo-"+t.Format("2006-01-02-150405")+".tar.gz")
+}
+
+// CreateBackup packs the config, the state and the files of every list into
+// a bundle at path. It returns how many files it packed.
+func CreateBackup(cfg config.Config, path string) (int, error) {
+	st, err := state.Load()
+	if err != nil {
+		return 0, err
+	}
+	cfg.Workspace = ""
+	files, err := bundleFiles(cfg, st.Lists)
+	if err != nil {
+		return 0, err
+	}
+
+	sources := make([]backup.Source, len(files))
+	for i, f := range files {
+		sources[i] = backup.Source{Name: f.name, Path: f.path}
+		// A list kept one file per item is packed as one file of items,
+		// so it can be restored into either backend.
+		if info, err := os.Stat(f.path); err == nil && info.IsDir() && f.items {
+			repository := storage.NewFileItemRepository(f.path)
+			items, err := repository.GetItems()
+			if err != nil {
+				return 0, err
+			}
+			if sources[i].Data, err = json.MarshalIndent(items, "", "  "); err != nil {
+				return 0, err
+			}
+			sources[i].Modified = info.ModTime()
+		}
+	}
+	var buf bytes.Buffer
+	manifest, err := backup.Create(&buf, sources, time.Now())
+	if err != nil {
+		return 0, err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return 0, err
+	}
+	return len(manifest.Files), nil
+}
+
+func (c *commandContext) backupRestore(args []string) error {
+	fs := flag.NewFlagSet("backup restore", flag.ContinueOnError)
+	force := fs.Bool("force", false, "overwrite files changed since the backup was made")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New(backupUsage)
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	bundle, err := backup.Read(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fs.Arg(0), err)
+	}
+
+	// The lists go where the restored config and state put them on this
+	// machine, which may be elsewhere than where they were packed.
+	cfg := c.config
+	if data, ok := bundle.Content(configEntry); ok {
+		if cfg, err = config.Read(bytes.NewReader(data)); err != nil {
+			return fmt.Errorf("config in the bundle: %w", err)
+		}
+	}
+	cfg.Workspace = ""
+	// Lists that aren't there yet are created with the restored backend.
+	backend, err := storage.ParseBackend(cfg.Backend)
+	if err != nil {
+		return fmt.Errorf("config in the bundle: %w", err)
+	}
+	storage.SetBackend(backend)
+	st, err := state.Load()
+	if err != nil {
+		return err
+	}
+	if data, ok := bundle.Content(stateEntry); ok {
+		st = state.State{}
+		if err := json.Unmarshal(data, &st); err != nil {
+			return fmt.Errorf("state in the bundle: %w", err)
+		}
+	}
+	files, err := bundleFiles(cfg, st.Lists)
+	if err != nil {
+		return err
+	}
+
+	// Check everything before writing anything: where each file goes,
+	// that the items can be read, and that nothing newer is overwritten.
+	var newer []string
+	for _, entry := range bundle.Manifest.Files {
+		i := slices.IndexFunc(files, func(f bundleFile) bool { return f.name == entry.Name })
+		if i < 0 {
+			return fmt.Errorf("the bundle has %s, which the restored config doesn't say where to put", entry.Name)
+		}
+		if files[i].items {
+			data, _ := bundle.Content(entry.Name)
+			if _, err := storage.DecodeItems(data); err != nil {
+				return fmt.Errorf("%s in the bundle: %w", entry.Name, err)
+			}
+		}
+		isNewer, err := backup.Newer(files[i].path, entry)
+		if err != nil {
+			return err
+		}
+		if isNewer {
+			newer = append(newer, files[i].path)
+		}
+	}
+	if len(newer) > 0 && !*force {
+		return fmt.Errorf("these files changed after the backup was made, --force overwrites them:\n  %s", strings.Join(newer, "\n  "))
+	}
+
+	// A running clitodo would save its own items over the restored ones.
+	for _, f := range files {
+		if f.items && strings.HasSuffix(f.name, "/"+itemsEntry) {
+			repository := storage.NewFileItemRepository(f.path)
+			if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+				return err
+			}
+			release, err := repository.Claim()
+			if err != nil {
+				return err
+			}
+			defer func() { warn(release()) }()
+		}
+	}
+
+	for _, entry := range bundle.Manifest.Files {
+		i := slices.IndexFunc(files, func(f bundleFile) bool { return f.name == entry.Name })
+		data, _ := bundle.Content(entry.Name)
+		if err := restoreFile(files[i], data, entry.Modified); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "restored %s\n", files[i].path)
+	}
+	fmt.Printf("Restored %d files from %s\n", len(bundle.Manifest.Files), fs.Arg(0))
+	return nil
+}
+
+// Names of the files in a bundle. Each list's files are kept in a directory
+// of their own: default/ for the list used without a workspace and
+// lists/NAME/ for the others.
+const (
+	configEntry   = "config.toml"
+	stateEntry    = "state.json"
+	itemsEntry    = "items.json"
+	archiveEntry  = "archive.json"
+	somedayEntry  = "someday.json"
+	activityEntry = "activity.jsonl"
+)
+
+// bundleFile is a file of a bundle and where it's kept on this machine.
+type bundleFile struct {
+	name string
+	path string
+	// Whether it holds items, which are restored through the storage so
+	// ones from older versions are brought up to date.
+	items bool
+}
+
+// bundleFiles returns the files a bundle holds for the given config: the
+// config and state files, and the items, archive, someday list and activity
+// log of the top-level list, each configured workspace and the given lists
+// created in the TUI. Lists sharing a storage file are only packed once.
+func bundleFiles(cfg config.Config, lists []string) ([]bundleFile, error) {
+	configPath, err := config.Path()
+	if err != nil {
+		return nil, err
+	}
+	statePath, err := state.Path()
+	if err != nil {
+		return nil, err
+	}
+	files := []bundleFile{
+		{name: configEntry, path: configPath},
+		{name: stateEntry, path: statePath},
+	}
+
+	names := append([]string{""}, cfg.WorkspaceNames()...)
+	for _, name := range lists {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	seen := map[string]bool{}
+	for _, name := range names {
+		list := cfg
+		dir := "default/"
+		if name != "" {
+			if err := list.UseList(name); err != nil {
+				return nil, fmt.Errorf("list %s: %w", name, err)
+			}
+			dir = "lists/" + name + "/"
+		}
+		path, err := list.StoragePath()
+		if err != nil {
+			return nil, err
+		}
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+
+		repository := storage.NewFileItemRepository(path)
+		archive, someday := repository.Archive(), repository.Someday()
+		files = append(files,
+			bundleFile{name: dir + itemsEntry, path: path, items: true},
+			bundleFile{name: dir + archiveEntry, path: archive.Path(), items: true},
+			bundleFile{name: dir + somedayEntry, path: someday.Path(), items: true},
+			bundleFile{name: dir + activityEntry, path: activity.PathFor(path)},
+		)
+	}
+	return files, nil
+}
+
+// restoreFile writes data where f is kept and dates it back to when it was
+// packed, so restoring the same bundle again doesn't count it as newer. Items
+// are read and stored again, which brings older ones up to date.
+func restoreFile(f bundleFile, data []byte, modified time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return err
+	}
+	if f.items {
+		items, err := storage.DecodeItems(data)
+		if err != nil {
+			return err
+		}
+		repository := storage.NewFileItemRepository(f.path)
+		unlock, err := repository.Lock()
+		if err != nil {
+			return err
+		}
+		err = repository.StoreItemsState(items)
+		warn(unlock())
+		if err != nil {
+			return err
+		}
+	} else if err := writeFileAtomic(f.path, data); err != nil {
+		return err
+	}
+	return os.Chtimes(f.path, modified, modified)
+}
+
+// writeFileAtomic replaces the file at path with data, so a failure leaves
+// either the old or the new contents.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
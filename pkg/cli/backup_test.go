@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"clitodo/pkg/config"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/storage"
+)
+
+func TestBackupNextToTheStorageIsRecovered(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	repository := storage.NewFileItemRepository(path)
+	if err := repository.StoreItemsState([]domain.Item{domain.NewItem("pay the rent"), domain.NewItem("water the plants")}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Default()
+	cfg.Storage = path
+	bundle := BackupPath(path, time.Date(2026, time.March, 10, 9, 30, 5, 0, time.Local))
+	if want := filepath.Join(filepath.Dir(path), "clitodo-2026-03-10-093005.tar.gz"); bundle != want {
+		t.Errorf("BackupPath() = %s, want %s", bundle, want)
+	}
+	if _, err := CreateBackup(cfg, bundle); err != nil {
+		t.Fatalf("CreateBackup() error = %v", err)
+	}
+
+	for _, src := range recoverSources(repository, "default/", nil) {
+		if src.kind == sourceList && src.name != path && len(src.items) == 2 {
+			return
+		}
+	}
+	t.Errorf("recover didn't find the tasks in %s", bundle)
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"clitodo/pkg/activity"
+	"clitodo/pkg/config"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/hooks"
+	"clitodo/pkg/storage"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Run executes the subcommand named by args[0] with the remaining arguments.
+func Run(cfg config.Config, args []string) error {
+	c := &commandContext{
+		config: cfg,
+		hooks:  hooks.New(cfg.Hooks, hooks.ShellExecutor{}),
+	}
+
+	switch args[0] {
+	case "add", "a":
+		return c.Add(args[1:])
+	case "list":
+		return c.List(args[1:])
+	case "import":
+		return c.Import(args[1:])
+	case "rm":
+		return c.Remove(args[1:])
+	case "prune":
+		return c.Prune(args[1:])
+	case "edit":
+		return c.Edit(args[1:])
+	case "shift":
+		return c.Shift(args[1:])
+	case "doctor":
+		return c.Doctor(args[1:])
+	case "log":
+		return c.Log(args[1:])
+	case "dedupe":
+		return c.Dedupe(args[1:])
+	case "backup":
+		return c.Backup(args[1:])
+	case "convert":
+		return c.Convert(args[1:])
+	case "schema":
+		return c.Schema(args[1:])
+	case "recover":
+		return c.Recover(args[1:])
+	case "template":
+		return c.Template(args[1:])
+	case "remind":
+		return c.Remind(args[1:])
+	case "notify":
+		return c.Notify(args[1:])
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// commandContext carries what subcommands share: the loaded configuration
+// and the hook runner.
+type commandContext struct {
+	config config.Config
+	hooks  *hooks.Runner
+}
+
+// repository returns the storage configured for this invocation. A broken
+// storage path template ends the command with an error naming the template.
+func (c *commandContext) repository() (storage.FileItemStorage, error) {
+	path, err := c.config.StoragePath()
+	if err != nil {
+		return storage.FileItemStorage{}, err
+	}
+	return storage.NewFileItemRepository(path), nil
+}
+
+// record notes a change to item in the activity log next to the storage and
+// runs its hook. Neither failing fails the command.
+func (c *commandContext) record(itemRepository storage.FileItemStorage, event hooks.Event, item domain.Item) {
+	log := activity.New(activity.PathFor(itemRepository.Path()))
+	warn(log.Append(activity.Entry{Time: time.Now(), Event: event, ItemID: item.ID, Title: item.Title()}))
+	warn(c.hooks.Run(event, item))
+}
+
+// warn reports a problem that doesn't fail the command, such as a hook that
+// exited with an error after the change was already saved.
+func warn(err error) {
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Warning:", err)
+	}
+}
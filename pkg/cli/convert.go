@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"clitodo/pkg/storage"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Convert moves the items of the current list to the backend given as the
+// only argument, "file" or "dir", keeping the storage path. The old layout
+// is kept next to it with a .bak suffix.
+func (c *commandContext) Convert(args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: clitodo convert file|dir")
+	}
+	to, err := storage.ParseBackend(args[0])
+	if err != nil {
+		return err
+	}
+
+	itemRepository, err := c.repository()
+	if err != nil {
+		return err
+	}
+	// A running clitodo would save its items in the old layout again.
+	release, err := itemRepository.Claim()
+	if err != nil {
+		return err
+	}
+	defer func() { warn(release()) }()
+	unlock, err := itemRepository.Lock()
+	if err != nil {
+		return err
+	}
+	defer func() { warn(unlock()) }()
+
+	n, err := storage.Convert(itemRepository.Path(), to)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "previous layout kept at %s.bak\n", itemRepository.Path())
+	fmt.Printf("Converted %d items in %s to the %s backend\n", n, itemRepository.Path(), to)
+	if c.config.Backend != string(to) {
+		fmt.Printf("Set backend = %q in the config to create new lists this way too\n", to)
+	}
+	return nil
+}
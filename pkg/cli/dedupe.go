@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"bufio"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/hooks"
+	"clitodo/pkg/state"
+	"clitodo/pkg/storage"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-isatty"
+)
+
+// Dedupe lists the open tasks whose titles look like duplicates of each
+// other, a cluster at a time, and asks whether to merge each cluster into its
+// first task or dismiss it. Dismissed clusters aren't suggested again. With
+// --report, or when not running in a terminal, the clusters are only listed.
+func (c *commandContext) Dedupe(args []string) error {
+	fs := flag.NewFlagSet("dedupe", flag.ContinueOnError)
+	report := fs.Bool("report", false, "only list the clusters of duplicates")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return errors.New("usage: clitodo dedupe [--report]")
+	}
+
+	itemRepository, err := c.repository()
+	if err != nil {
+		return err
+	}
+
+	unlock, err := itemRepository.Lock()
+	if err != nil {
+		return err
+	}
+	defer func() { warn(unlock()) }()
+
+	items, err := itemRepository.GetItems()
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+	st, err := state.Load()
+	if err != nil {
+		return err
+	}
+
+	clusters := domain.DuplicateClusters(items, st.DuplicateDismissed)
+	if len(clusters) == 0 {
+		fmt.Fprintln(os.Stderr, "No duplicates found.")
+		return nil
+	}
+	if *report || !isatty.IsTerminal(os.Stdin.Fd()) {
+		for n, cluster := range clusters {
+			if n > 0 {
+				fmt.Println()
+			}
+			for _, i := range cluster {
+				fmt.Println(items[i].Title())
+			}
+		}
+		return nil
+	}
+
+	// Clusters are collected as IDs, since merging shifts the indices.
+	var merges, dismissals [][]string
+	in := bufio.NewReader(os.Stdin)
+ask:
+	for n, cluster := range clusters {
+		ids := make([]string, len(cluster))
+		fmt.Fprintf(os.Stderr, "Possible duplicates %d/%d:\n", n+1, len(clusters))
+		for k, i := range cluster {
+			ids[k] = items[i].ID
+			fmt.Fprintf(os.Stderr, "  %s\n", items[i].Title())
+		}
+		for {
+			fmt.Fprint(os.Stderr, "[m]erge into the first, [d]ismiss, [s]kip or [q]uit? ")
+			answer, err := in.ReadString('\n')
+			if err != nil && answer == "" {
+				break ask
+			}
+			switch strings.ToLower(strings.TrimSpace(answer)) {
+			case "m", "merge":
+				merges = append(merges, ids)
+			case "d", "dismiss":
+				dismissals = append(dismissals, ids)
+			case "s", "skip", "":
+			case "q", "quit":
+				break ask
+			default:
+				continue
+			}
+			break
+		}
+	}
+
+	if len(dismissals) > 0 {
+		for _, ids := range dismissals {
+			st.DismissDuplicates(ids)
+		}
+		if err := st.Save(); err != nil {
+			return err
+		}
+	}
+	if len(merges) == 0 {
+		return nil
+	}
+
+	var removed []domain.Item
+	for _, ids := range merges {
+		var gone []domain.Item
+		items, gone = domain.MergeItems(items, ids)
+		removed = append(removed, gone...)
+	}
+	now := time.Now()
+	for i := range items {
+		for _, ids := range merges {
+			if items[i].ID == ids[0] {
+				items[i].Touch(now)
+			}
+		}
+	}
+	if err := itemRepository.StoreItemsState(items); err != nil {
+		return err
+	}
+	for _, item := range removed {
+		c.record(itemRepository, hooks.EventDelete, item)
+	}
+	fmt.Printf("Merged %d tasks into %d\n", len(removed), len(merges))
+	return nil
+}
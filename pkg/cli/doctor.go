@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"clitodo/pkg/config"
+	"clitodo/pkg/storage"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Doctor prints where clitodo reads its configuration and items from, to help
+// diagnose setups that don't behave as expected.
+func (c *commandContext) Doctor(args []string) error {
+	if len(args) != 0 {
+		return errors.New("usage: clitodo doctor")
+	}
+
+	configPath, err := config.Path()
+	switch {
+	case err != nil:
+		fmt.Printf("config:   unknown (%v)\n", err)
+	case fileExists(configPath):
+		fmt.Printf("config:   %s\n", configPath)
+	default:
+		fmt.Printf("config:   %s (not found, using defaults)\n", configPath)
+	}
+
+	if c.config.Workspace != "" {
+		fmt.Printf("workspace: %s\n", c.config.Workspace)
+	}
+	template := c.config.StorageTemplate()
+	if template == "" {
+		template = "(default)"
+	}
+	fmt.Printf("storage:  %s\n", template)
+
+	itemRepository, err := c.repository()
+	if err != nil {
+		fmt.Printf("expanded: error: %v\n", err)
+		return err
+	}
+	fmt.Printf("expanded: %s\n", itemRepository.Path())
+	if backend := itemRepository.Backend(); string(backend) != c.config.Backend {
+		fmt.Printf("backend:  %s (the config asks for %s; `clitodo convert %s` converts it)\n", backend, c.config.Backend, c.config.Backend)
+	} else {
+		fmt.Printf("backend:  %s\n", backend)
+	}
+
+	items, err := itemRepository.GetItems()
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		fmt.Println("items:    none yet (file will be created on first save)")
+	case err != nil:
+		fmt.Printf("items:    error: %v\n", err)
+		return err
+	default:
+		fmt.Printf("items:    %d\n", len(items))
+	}
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"clitodo/pkg/calc"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/storage"
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Edit changes fields of the task given by its 1-based position or a partial
+// title: the estimate, which takes expressions like "2h+15m", how often the
+// task repeats and when to remind about it.
+func (c *commandContext) Edit(args []string) error {
+	fs := flag.NewFlagSet("edit", flag.ContinueOnError)
+	estimate := fs.String("estimate", "", `estimate, e.g. "2h+15m" or "3*25m"; "0" removes it`)
+	every := fs.String("every", "", `repeat when completed: daily, weekly, monthly or "every N days"; "never" stops it`)
+	remind := fs.String("remind", "", `remind at a date as for add --due, optionally repeating: "5pm, every 30m, max 5"; "never" stops it`)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	query := strings.TrimSpace(strings.Join(fs.Args(), " "))
+	if query == "" || (*estimate == "" && *every == "" && *remind == "") {
+		return errors.New(`usage: clitodo edit [--estimate EXPR] [--every SPEC] [--remind SPEC] <N | title>`)
+	}
+
+	var d time.Duration
+	if *estimate != "" && *estimate != "0" {
+		var err error
+		if d, err = calc.ParseDuration(*estimate); err != nil {
+			return fmt.Errorf("--estimate: %w", err)
+		}
+	}
+
+	var recurrence *domain.Recurrence
+	if *every != "" && *every != "never" {
+		r, err := domain.ParseRecurrence(*every)
+		if err != nil {
+			return fmt.Errorf("--every: %w", err)
+		}
+		recurrence = &r
+	}
+
+	var reminder *domain.Reminder
+	if *remind != "" && *remind != "never" {
+		r, err := domain.ParseReminder(*remind, time.Now())
+		if err != nil {
+			return fmt.Errorf("--remind: %w", err)
+		}
+		reminder = &r
+	}
+
+	itemRepository, err := c.repository()
+	if err != nil {
+		return err
+	}
+
+	unlock, err := itemRepository.Lock()
+	if err != nil {
+		return err
+	}
+	defer func() { warn(unlock()) }()
+
+	items, err := itemRepository.GetItems()
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+
+	index, err := c.resolveItem(items, query)
+	if err != nil {
+		return err
+	}
+	item := &items[index]
+	if *estimate != "" {
+		item.Estimate = domain.Duration(d)
+	}
+	if *every != "" {
+		item.Recurrence = recurrence
+	}
+	if *remind != "" {
+		item.Remind = reminder
+	}
+	item.Touch(time.Now())
+	if err := itemRepository.StoreItemsState(items); err != nil {
+		return err
+	}
+
+	if *estimate != "" {
+		if d == 0 {
+			fmt.Printf("%s: estimate removed\n", item.Title())
+		} else {
+			fmt.Printf("%s: estimate %s\n", item.Title(), item.Estimate)
+		}
+	}
+	if *every != "" {
+		if recurrence == nil {
+			fmt.Printf("%s: no longer repeats\n", item.Title())
+		} else {
+			fmt.Printf("%s: repeats %s\n", item.Title(), recurrence)
+		}
+	}
+	if *remind != "" {
+		if reminder == nil {
+			fmt.Printf("%s: no reminder\n", item.Title())
+		} else {
+			fmt.Printf("%s: remind %s\n", item.Title(), reminder)
+		}
+	}
+	return nil
+}
+
+// resolveItem finds the item given on the command line, either by its 1-based
+// position as printed by list or by a partial title.
+func (c *commandContext) resolveItem(items []domain.Item, query string) (int, error) {
+	if n, err := strconv.Atoi(query); err == nil {
+		if n < 1 || n > len(items) {
+			return 0, fmt.Errorf("task %d is out of range (list has %d items)", n, len(items))
+		}
+		return n - 1, nil
+	}
+	return resolveTitle(items, query, c.config.Filter.IgnoreAccents)
+}
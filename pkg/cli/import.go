@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"clitodo/pkg/domain"
+	"clitodo/pkg/importer"
+	"clitodo/pkg/storage"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mattn/go-isatty"
+)
+
+const importBatchSize = 500
+
+// Import reads the file given in args and appends its items to storage. The
+// whole file is staged before anything is written, so a failed import leaves
+// storage untouched.
+func (c *commandContext) Import(args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: clitodo import <file>")
+	}
+
+	rules, err := c.config.DefaultRules()
+	if err != nil {
+		return err
+	}
+	src, err := importer.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	itemRepository, err := c.repository()
+	if err != nil {
+		return err
+	}
+	items, err := itemRepository.GetItems()
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		seen[item.Title()] = true
+	}
+
+	showProgress := isatty.IsTerminal(os.Stderr.Fd())
+	var (
+		progress importer.Progress
+		staged   []domain.Item
+	)
+	for {
+		batch, _, done, err := importer.Batch(src, importBatchSize, seen, &progress)
+		if err != nil {
+			return fmt.Errorf("import failed after %d records: %w", progress.Parsed, err)
+		}
+		now := time.Now()
+		for i, item := range batch {
+			batch[i], _ = rules.Apply(item, c.config.Workspace, domain.GivenIn(item), now)
+		}
+		staged = append(staged, batch...)
+		if showProgress {
+			fmt.Fprintf(os.Stderr, "\r%3.0f%%  %d parsed, %d created, %d skipped",
+				src.Fraction()*100, progress.Parsed, progress.Created, progress.Skipped)
+		}
+		if done {
+			break
+		}
+	}
+	if showProgress {
+		fmt.Fprintln(os.Stderr)
+	}
+
+	if err := itemRepository.StoreItemsState(append(items, staged...)); err != nil {
+		return err
+	}
+	fmt.Printf("Imported %d items (%d skipped)\n", progress.Created, progress.Skipped)
+	return nil
+}
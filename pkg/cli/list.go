@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"bytes"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/storage"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// ItemView is the data a list template is executed with, once per item.
+type ItemView struct {
+	// Index is the 1-based position of the item in the list, as accepted by
+	// the other subcommands. It stays the stored position when the output is
+	// sorted, so it can be passed on as printed.
+	Index     int
+	ID        string
+	Title     string
+	Completed bool
+}
+
+// Templates are the built-in list templates, selectable by name.
+var Templates = map[string]string{
+	"plain":    `{{.Index}}. [{{if .Completed}}x{{else}} {{end}}] {{.Title}}`,
+	"markdown": `- [{{if .Completed}}x{{else}} {{end}}] {{.Title}}`,
+	"csv-row":  `{{csv .Index .Title .Completed}}`,
+}
+
+var templateFuncs = template.FuncMap{
+	// csv formats its arguments as one CSV record, quoting where needed.
+	"csv": func(fields ...any) (string, error) {
+		record := make([]string, len(fields))
+		for i, f := range fields {
+			record[i] = fmt.Sprint(f)
+		}
+		var b bytes.Buffer
+		w := csv.NewWriter(&b)
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+		w.Flush()
+		return strings.TrimSuffix(b.String(), "\n"), w.Error()
+	},
+}
+
+// TemplateError reports where a list template failed to parse.
+type TemplateError struct {
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (e *TemplateError) Error() string {
+	return fmt.Sprintf("template:%d:%d: %s", e.Line, e.Column, e.Msg)
+}
+
+// List prints the items, one per line, formatted with --template which is
+// either the name of a built-in template or a text/template string.
+func (c *commandContext) List(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	tmplText := fs.String("template", "plain", "built-in template name or text/template over ItemView")
+	sortBy := fs.String("sort", "", "sort by due, priority, created, title or completed instead of the stored order")
+	reverse := fs.Bool("reverse", false, "reverse the order; with --sort, items without the sort key stay last")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var order *domain.SortOrder
+	if *sortBy != "" {
+		o, err := domain.SortOrderByName(*sortBy)
+		if err != nil {
+			return err
+		}
+		order = &o
+	}
+
+	tmpl, err := parseListTemplate(*tmplText)
+	if err != nil {
+		return err
+	}
+
+	itemRepository, err := c.repository()
+	if err != nil {
+		return err
+	}
+	items, err := itemRepository.GetItems()
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+
+	indices := make([]int, len(items))
+	for i := range indices {
+		indices[i] = i
+	}
+	if order != nil {
+		indices = domain.Sorted(items, *order, *reverse)
+	} else if *reverse {
+		slices.Reverse(indices)
+	}
+	return printItems(tmpl, items, indices)
+}
+
+// printItems prints the items at indices, in that order.
+func printItems(tmpl *template.Template, items []domain.Item, indices []int) error {
+	var b bytes.Buffer
+	for _, i := range indices {
+		item := items[i]
+		view := ItemView{Index: i + 1, ID: item.ID, Title: item.Title(), Completed: item.Completed()}
+		if err := tmpl.Execute(&b, view); err != nil {
+			return err
+		}
+		if !bytes.HasSuffix(b.Bytes(), []byte("\n")) {
+			b.WriteByte('\n')
+		}
+	}
+	_, err := os.Stdout.Write(b.Bytes())
+	return err
+}
+
+func parseListTemplate(text string) (*template.Template, error) {
+	if builtin, ok := Templates[text]; ok {
+		text = builtin
+	}
+
+	tmpl, err := template.New("list").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, locateTemplateError(text, err)
+	}
+	return tmpl, nil
+}
+
+var (
+	templateErrLine = regexp.MustCompile(`^template: list:(\d+): (.*)$`)
+	templateAction  = regexp.MustCompile(`{{-?\s*(\w*)[^}]*}}`)
+)
+
+// locateTemplateError turns a text/template parse error, which only carries a
+// line number, into a TemplateError with a column. The column is found by
+// parsing each action on the failing line on its own; block actions get a
+// matching {{end}} so only their own expression is checked.
+func locateTemplateError(text string, err error) error {
+	match := templateErrLine.FindStringSubmatch(err.Error())
+	if match == nil {
+		return err
+	}
+	line, _ := strconv.Atoi(match[1])
+	tErr := &TemplateError{Line: line, Column: 1, Msg: match[2]}
+
+	lines := strings.Split(text, "\n")
+	if line < 1 || line > len(lines) {
+		return tErr
+	}
+
+	src := lines[line-1]
+
+	// An unterminated action is reported at its opening braces.
+	if open := strings.LastIndex(src, "{{"); open >= 0 && !strings.Contains(src[open:], "}}") {
+		tErr.Column = len([]rune(src[:open])) + 1
+		return tErr
+	}
+
+	for _, loc := range templateAction.FindAllStringSubmatchIndex(src, -1) {
+		action := src[loc[0]:loc[1]]
+		switch src[loc[2]:loc[3]] {
+		case "else", "end":
+			continue
+		case "if", "range", "with", "block", "define":
+			action += "{{end}}"
+		}
+		if _, err := template.New("").Funcs(templateFuncs).Parse(action); err != nil {
+			tErr.Column = len([]rune(src[:loc[0]])) + 1
+			break
+		}
+	}
+	return tErr
+}
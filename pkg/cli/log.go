@@ -0,0 +1,44 @@
+package cli
+
+import (
+	"clitodo/pkg/activity"
+	"clitodo/pkg/domain"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+)
+
+// Log prints the activity log, oldest first, one tab-separated line per
+// change: time, what happened and the task's title.
+func (c *commandContext) Log(args []string) error {
+	fs := flag.NewFlagSet("log", flag.ContinueOnError)
+	since := fs.String("since", "", `only changes from this date on, e.g. "yesterday" or "mon"`)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return errors.New("usage: clitodo log [--since DATE]")
+	}
+
+	var from time.Time
+	if *since != "" {
+		var err error
+		if from, err = domain.ParseDate(*since, time.Now()); err != nil {
+			return fmt.Errorf("--since: %w", err)
+		}
+	}
+
+	itemRepository, err := c.repository()
+	if err != nil {
+		return err
+	}
+	entries, err := activity.New(activity.PathFor(itemRepository.Path())).Entries(from)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		fmt.Printf("%s\t%s\t%s\n", e.Time.Local().Format(domain.DueTimeLayout), e.Verb(), e.Title)
+	}
+	return nil
+}
@@ -0,0 +1,443 @@
+package cli
+
+import (
+	"bytes"
+	"clitodo/pkg/activity"
+	"clitodo/pkg/backup"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/hooks"
+	"clitodo/pkg/storage"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-isatty"
+)
+
+// sourceKind is what a recovery source holds items for.
+type sourceKind int
+
+const (
+	sourceList sourceKind = iota
+	sourceArchive
+	sourceSomeday
+)
+
+// recoverSource is a copy of the items found where recover looks: the storage
+// file itself, copies of it such as tasks.json.bak, the archive and someday
+// list, and the entries of backup bundles.
+type recoverSource struct {
+	name     string
+	kind     sourceKind
+	modified time.Time
+	items    []domain.Item
+	err      error
+}
+
+// recovered is what recoverItems put together from the sources.
+type recovered struct {
+	items []domain.Item
+	// Items with differing copies, and the source of the copy kept.
+	conflicts []recoverConflict
+	// Items left out since their newest copy is in the archive or the
+	// someday list, or since they were deleted after it was saved.
+	archived, someday, deleted int
+	// Items known only from the activity log, with nothing but a title.
+	fromLog int
+}
+
+type recoverConflict struct {
+	title  string
+	source string
+}
+
+// Recover rebuilds the items of the current list from whatever is left of
+// them: the storage file, copies of it next to it, the archive and someday
+// list, backup bundles in the same directory or given as arguments, and the
+// activity log. Copies of an item are told apart by its ID and the one
+// changed last is kept. It shows what it found and asks before replacing the
+// stored items, which are kept next to them; --auto doesn't ask.
+func (c *commandContext) Recover(args []string) error {
+	fs := flag.NewFlagSet("recover", flag.ContinueOnError)
+	auto := fs.Bool("auto", false, "store the recovered items without asking")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	itemRepository, err := c.repository()
+	if err != nil {
+		return err
+	}
+	// A running clitodo would save its own items over the recovered ones.
+	release, err := itemRepository.Claim()
+	if err != nil {
+		return err
+	}
+	defer func() { warn(release()) }()
+	unlock, err := itemRepository.Lock()
+	if err != nil {
+		return err
+	}
+	defer func() { warn(unlock()) }()
+
+	entryDir := "default/"
+	if c.config.Workspace != "" {
+		entryDir = "lists/" + c.config.Workspace + "/"
+	}
+	sources := recoverSources(itemRepository, entryDir, fs.Args())
+	entries, err := activity.New(activity.PathFor(itemRepository.Path())).Entries(time.Time{})
+	warn(err)
+	r := recoverItems(sources, entries)
+
+	for _, src := range sources {
+		switch {
+		case src.err != nil && len(src.items) > 0:
+			fmt.Printf("%s: %d items read before the damage, from %s: %v\n", src.name, len(src.items), src.modified.Format(time.DateTime), src.err)
+		case src.err != nil:
+			fmt.Printf("%s: unreadable: %v\n", src.name, src.err)
+		case src.modified.IsZero():
+			fmt.Printf("%s: not found\n", src.name)
+		case src.kind == sourceArchive:
+			fmt.Printf("%s: %d archived items, from %s\n", src.name, len(src.items), src.modified.Format(time.DateTime))
+		case src.kind == sourceSomeday:
+			fmt.Printf("%s: %d items for some day, from %s\n", src.name, len(src.items), src.modified.Format(time.DateTime))
+		default:
+			fmt.Printf("%s: %d items, from %s\n", src.name, len(src.items), src.modified.Format(time.DateTime))
+		}
+	}
+	if len(entries) > 0 {
+		fmt.Printf("%s: %d entries\n", activity.PathFor(itemRepository.Path()), len(entries))
+	}
+	for _, conflict := range r.conflicts {
+		fmt.Printf("  %s: kept the newest copy, from %s\n", conflict.title, conflict.source)
+	}
+	fmt.Printf("Recovered %d items: %d conflicts resolved by the newest change, %d only in the activity log\n", len(r.items), len(r.conflicts), r.fromLog)
+	if r.archived+r.someday+r.deleted > 0 {
+		fmt.Printf("Left out %d archived, %d for some day and %d deleted\n", r.archived, r.someday, r.deleted)
+	}
+	if len(r.items) == 0 {
+		return errors.New("nothing to recover")
+	}
+
+	if !*auto {
+		if !isatty.IsTerminal(os.Stdin.Fd()) {
+			return errors.New("refusing to replace the items without confirmation; pass --auto when not running in a terminal")
+		}
+		if err := confirm(nil, fmt.Sprintf("Replace %s with the %d recovered items?", itemRepository.Path(), len(r.items))); err != nil {
+			return err
+		}
+	}
+
+	// The recovered items replace the stored ones the usual way, so a
+	// failure leaves them as they were. Whatever was stored before is then
+	// kept next to them, in case it's needed again; recover finds it there
+	// next time.
+	previous, err := readStored(itemRepository.Path())
+	if err != nil {
+		return err
+	}
+	if err := itemRepository.StoreItemsState(r.items); err != nil {
+		return err
+	}
+	fmt.Printf("Stored %d recovered items in %s\n", len(r.items), itemRepository.Path())
+	if previous == nil {
+		return nil
+	}
+	kept := itemRepository.Path() + "." + time.Now().Format("20060102-150405") + ".bak"
+	if err := keepStored(kept, previous); err != nil {
+		return fmt.Errorf("keeping the previous items: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "previous items kept at %s\n", kept)
+	return nil
+}
+
+// readStored returns the contents of what's stored at path by name: "" for a
+// storage file, and the name of each file in it for a BackendDir directory.
+// It returns nil if nothing is stored there.
+func readStored(path string) (map[string][]byte, error) {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return map[string][]byte{"": data}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	files := map[string][]byte{}
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(path, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		files[e.Name()] = data
+	}
+	return files, nil
+}
+
+// keepStored writes what readStored read to path, as a file or a directory
+// like it was.
+func keepStored(path string, files map[string][]byte) error {
+	if data, ok := files[""]; ok {
+		return os.WriteFile(path, data, 0o644)
+	}
+	if err := os.Mkdir(path, 0o755); err != nil {
+		return err
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(path, name), data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recoverSources reads the copies of the items of the list stored by
+// itemRepository: the storage itself, files next to it named after it, its
+// archive and someday list, and the entries under entryDir of the backup
+// bundles in the same directory. Files in extra are read too, as bundles or
+// item files. The storage comes first, then the others by name.
+func recoverSources(itemRepository storage.FileItemStorage, entryDir string, extra []string) []recoverSource {
+	path := itemRepository.Path()
+	archive, someday := itemRepository.Archive(), itemRepository.Someday()
+	base := filepath.Base(path)
+	stem := strings.TrimSuffix(base, filepath.Ext(base))
+
+	sources := []recoverSource{readRecoverSource(path, sourceList)}
+	var paths []string
+	if dirEntries, err := os.ReadDir(filepath.Dir(path)); err == nil {
+		for _, e := range dirEntries {
+			name := e.Name()
+			switch {
+			case name == base, strings.HasPrefix(name, "."):
+			case strings.HasSuffix(name, ".lock"), strings.HasSuffix(name, ".open"):
+			case strings.HasPrefix(name, stem+".activity.jsonl"):
+			case isBundleName(name):
+				paths = append(paths, filepath.Join(filepath.Dir(path), name))
+			case strings.HasPrefix(name, stem+"."), strings.HasPrefix(name, stem+"-"), strings.HasPrefix(name, stem+"_"):
+				paths = append(paths, filepath.Join(filepath.Dir(path), name))
+			}
+		}
+	}
+	for _, p := range extra {
+		if !slices.Contains(paths, p) {
+			paths = append(paths, p)
+		}
+	}
+
+	for _, p := range paths {
+		switch {
+		case isBundleName(p):
+			sources = append(sources, readBundleSources(p, entryDir)...)
+		case p == archive.Path():
+			sources = append(sources, readRecoverSource(p, sourceArchive))
+		case p == someday.Path():
+			sources = append(sources, readRecoverSource(p, sourceSomeday))
+		default:
+			sources = append(sources, readRecoverSource(p, sourceList))
+		}
+	}
+	return sources
+}
+
+func isBundleName(name string) bool {
+	return strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz")
+}
+
+// readRecoverSource reads the items stored at path, in either backend. A
+// missing storage file is a source without items, and one that's damaged
+// gives the items before the damage, along with its *storage.CorruptError.
+func readRecoverSource(path string, kind sourceKind) recoverSource {
+	src := recoverSource{name: path, kind: kind}
+	if info, err := os.Stat(path); err == nil {
+		src.modified = info.ModTime()
+	}
+	repository := storage.NewFileItemRepository(path)
+	src.items, src.err = repository.GetItems()
+	var corrupt *storage.CorruptError
+	switch {
+	case errors.Is(src.err, storage.ErrNotFound):
+		src.err = nil
+	case errors.As(src.err, &corrupt):
+		if data, err := os.ReadFile(path); err == nil {
+			src.items = salvageItems(data)
+		}
+	}
+	return src
+}
+
+// salvageItems returns the items of a damaged item list up to the first one
+// that can't be read, such as the one a truncated file is cut off in.
+func salvageItems(data []byte) []domain.Item {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if t, err := decoder.Token(); err != nil || t != json.Delim('[') {
+		return nil
+	}
+	var items []domain.Item
+	for decoder.More() {
+		var item domain.Item
+		if decoder.Decode(&item) != nil {
+			break
+		}
+		items = append(items, item)
+	}
+	// Brought up to date the way the storage reads them.
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil
+	}
+	items, _ = storage.DecodeItems(data)
+	return items
+}
+
+// readBundleSources reads the items, archive and someday list under entryDir
+// in the bundle at path.
+func readBundleSources(path, entryDir string) []recoverSource {
+	f, err := os.Open(path)
+	if err != nil {
+		return []recoverSource{{name: path, err: err}}
+	}
+	defer f.Close()
+	bundle, err := backup.Read(f)
+	if err != nil {
+		return []recoverSource{{name: path, err: err}}
+	}
+
+	var sources []recoverSource
+	for _, entry := range bundle.Manifest.Files {
+		var kind sourceKind
+		switch entry.Name {
+		case entryDir + itemsEntry:
+			kind = sourceList
+		case entryDir + archiveEntry:
+			kind = sourceArchive
+		case entryDir + somedayEntry:
+			kind = sourceSomeday
+		default:
+			continue
+		}
+		src := recoverSource{name: path + ": " + entry.Name, kind: kind, modified: entry.Modified}
+		data, _ := bundle.Content(entry.Name)
+		if src.items, err = storage.DecodeItems(data); err != nil {
+			src.err = &storage.CorruptError{Path: src.name, Err: err}
+		}
+		sources = append(sources, src)
+	}
+	return sources
+}
+
+// recoverItems puts the items of the list together from sources, keeping
+// for each ID the copy changed last: when it was touched, or for items that
+// don't say, when its source was saved. Items whose newest copy is archived
+// or put aside for some day stay there, and the activity log leaves out the
+// ones deleted after that copy was saved. Items the log has but no source
+// does are recovered from it, with their title and whether they were
+// completed.
+func recoverItems(sources []recoverSource, entries []activity.Entry) recovered {
+	type candidate struct {
+		item    domain.Item
+		source  int
+		changed time.Time
+		differs bool
+	}
+	var ids []string
+	newest := map[string]*candidate{}
+	known := map[string]bool{}
+	for s, src := range sources {
+		for _, item := range src.items {
+			markKnown(known, item)
+			changed := src.modified
+			if item.TouchedAt != nil {
+				changed = *item.TouchedAt
+			}
+			c, ok := newest[item.ID]
+			if !ok {
+				ids = append(ids, item.ID)
+				newest[item.ID] = &candidate{item: item, source: s, changed: changed}
+				continue
+			}
+			if !reflect.DeepEqual(c.item, item) {
+				c.differs = true
+			}
+			if changed.After(c.changed) {
+				c.item, c.source, c.changed = item, s, changed
+			}
+		}
+	}
+
+	// The log is oldest first, so the last entry of an item is its latest.
+	last := map[string]activity.Entry{}
+	var logged []string
+	for _, e := range entries {
+		if _, ok := last[e.ItemID]; !ok {
+			logged = append(logged, e.ItemID)
+		}
+		last[e.ItemID] = e
+	}
+
+	var r recovered
+	for _, id := range ids {
+		c := newest[id]
+		if e, ok := last[id]; ok && e.Event == hooks.EventDelete && e.Time.After(c.changed) {
+			r.deleted++
+			continue
+		}
+		switch sources[c.source].kind {
+		case sourceArchive:
+			r.archived++
+			continue
+		case sourceSomeday:
+			r.someday++
+			continue
+		}
+		if c.differs {
+			r.conflicts = append(r.conflicts, recoverConflict{title: c.item.Title(), source: sources[c.source].name})
+		}
+		r.items = append(r.items, c.item)
+	}
+	// Items from the log have no position and go last.
+	domain.SortByPosition(r.items)
+	for _, id := range logged {
+		e := last[id]
+		if known[id] || e.Event == hooks.EventDelete {
+			continue
+		}
+		t := e.Time
+		item := domain.Item{ID: id, ItemTitle: e.Title, TouchedAt: &t}
+		if e.Event == hooks.EventComplete {
+			item.ItemCompleted, item.CompletedAt = true, &t
+		}
+		r.items = append(r.items, item)
+		r.fromLog++
+	}
+	return r
+}
+
+// markKnown notes the IDs of item and its subtasks, which the activity log
+// has entries for too.
+func markKnown(known map[string]bool, item domain.Item) {
+	known[item.ID] = true
+	for _, child := range item.Children {
+		markKnown(known, child)
+	}
+}
@@ -0,0 +1,201 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"clitodo/pkg/activity"
+	"clitodo/pkg/config"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/hooks"
+	"clitodo/pkg/storage"
+)
+
+// writeItems writes items to path as the storage would, last modified at
+// modified, and returns what it wrote.
+func writeItems(t *testing.T, path string, modified time.Time, items ...domain.Item) []byte {
+	t.Helper()
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, path, modified, data)
+	return data
+}
+
+func writeFile(t *testing.T, path string, modified time.Time, data []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func recoveredTitles(r recovered) []string {
+	titles := make([]string, len(r.items))
+	for i, item := range r.items {
+		titles[i] = item.Title()
+	}
+	return titles
+}
+
+// touched returns item as changed at t.
+func touched(item domain.Item, t time.Time) domain.Item {
+	item.TouchedAt = &t
+	return item
+}
+
+func TestRecoverFromDisaster(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tasks.json")
+
+	rent := domain.NewItem("pay the rent")
+	plants := domain.NewItem("water the plants")
+	mum := domain.NewItem("call mum")
+	// Stored before items had IDs, in every copy.
+	legacy := domain.Item{ItemTitle: "fix the bike"}
+	legacySub := domain.Item{ItemTitle: "buy a tube"}
+	legacy.Children = []domain.Item{legacySub}
+
+	// A backup from two days ago, with the plants still to water.
+	writeItems(t, path+".bak", now.Add(-48*time.Hour),
+		touched(rent, now.Add(-72*time.Hour)), touched(plants, now.Add(-72*time.Hour)), legacy)
+
+	// The storage file, cut off in the middle of the last item.
+	plantsDone := touched(plants, now.Add(-2*time.Hour))
+	plantsDone.ItemCompleted = true
+	whole := writeItems(t, path, now.Add(-time.Hour),
+		touched(rent, now.Add(-72*time.Hour)), plantsDone, legacy, touched(mum, now.Add(-2*time.Hour)))
+	writeFile(t, path, now.Add(-time.Hour), whole[:len(whole)-40])
+
+	// An export from elsewhere, newer than both, where mum was renamed.
+	export := filepath.Join(t.TempDir(), "export.json")
+	mumRenamed := touched(mum, now.Add(-time.Minute))
+	mumRenamed.ItemTitle = "call mum back"
+	writeItems(t, export, now, mumRenamed, legacy)
+
+	sources := recoverSources(storage.NewFileItemRepository(path), "default/", []string{export})
+	if sources[0].name != path || sources[0].err == nil || len(sources[0].items) != 3 {
+		t.Fatalf("storage file read as %d items, error %v, want 3 items salvaged", len(sources[0].items), sources[0].err)
+	}
+
+	r := recoverItems(sources, nil)
+	want := []string{"pay the rent", "water the plants", "fix the bike", "call mum back"}
+	if got := recoveredTitles(r); !slices.Equal(got, want) {
+		t.Fatalf("recovered %q, want %q", got, want)
+	}
+	if !r.items[1].Completed() {
+		t.Error("the plants were recovered from the older backup, still to water")
+	}
+	if len(r.items[2].Children) != 1 || r.items[2].Children[0].Title() != "buy a tube" {
+		t.Errorf("the item without an ID was recovered with subtasks %v", r.items[2].Children)
+	}
+	var conflicts []string
+	for _, c := range r.conflicts {
+		conflicts = append(conflicts, c.title+" from "+c.source)
+	}
+	if want := []string{"water the plants from " + path}; !slices.Equal(conflicts, want) {
+		t.Errorf("conflicts %q, want %q", conflicts, want)
+	}
+}
+
+func TestRecoverKeepsThePreviousItems(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	writeItems(t, path+".bak", now.Add(-time.Hour), domain.NewItem("pay the rent"), domain.NewItem("water the plants"))
+	damaged := []byte(`[{"title": "pay the`)
+	writeFile(t, path, now, damaged)
+
+	c := &commandContext{config: config.Config{Storage: path}}
+	if err := c.Recover([]string{"--auto"}); err != nil {
+		t.Fatalf("Recover() error = %v", err)
+	}
+
+	items, err := storage.NewFileItemRepository(path).GetItems()
+	if err != nil || len(items) != 2 {
+		t.Fatalf("stored %v, %v, want the two items of the backup", items, err)
+	}
+	kept, err := filepath.Glob(path + ".*-*.bak")
+	if err != nil || len(kept) != 1 {
+		t.Fatalf("kept %q, want the damaged file kept once", kept)
+	}
+	if data, err := os.ReadFile(kept[0]); err != nil || !bytes.Equal(data, damaged) {
+		t.Errorf("kept %q, want %q", data, damaged)
+	}
+}
+
+func TestRecoverItemsWithLog(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	rent := touched(domain.NewItem("pay the rent"), now.Add(-3*time.Hour))
+	plants := touched(domain.NewItem("water the plants"), now.Add(-3*time.Hour))
+	old := touched(domain.NewItem("sell the sofa"), now.Add(-3*time.Hour))
+	sources := []recoverSource{
+		{name: "tasks.json", kind: sourceList, modified: now.Add(-2 * time.Hour), items: []domain.Item{rent, plants}},
+		{name: "tasks.archive.json", kind: sourceArchive, modified: now.Add(-time.Hour), items: []domain.Item{touched(old, now.Add(-time.Hour))}},
+		{name: "tasks.json.bak", kind: sourceList, modified: now.Add(-4 * time.Hour), items: []domain.Item{old}},
+	}
+	entries := []activity.Entry{
+		{Time: now.Add(-time.Hour), Event: hooks.EventDelete, ItemID: plants.ID, Title: plants.Title()},
+		{Time: now.Add(-30 * time.Minute), Event: hooks.EventAdd, ItemID: "new", Title: "book the flights"},
+		{Time: now.Add(-20 * time.Minute), Event: hooks.EventComplete, ItemID: "new", Title: "book the flights"},
+		{Time: now.Add(-10 * time.Minute), Event: hooks.EventAdd, ItemID: "gone", Title: "buy milk"},
+		{Time: now.Add(-5 * time.Minute), Event: hooks.EventDelete, ItemID: "gone", Title: "buy milk"},
+	}
+
+	r := recoverItems(sources, entries)
+	if got, want := recoveredTitles(r), []string{"pay the rent", "book the flights"}; !slices.Equal(got, want) {
+		t.Fatalf("recovered %q, want %q", got, want)
+	}
+	if !r.items[1].Completed() || r.fromLog != 1 {
+		t.Errorf("the item from the log completed %t, %d from the log", r.items[1].Completed(), r.fromLog)
+	}
+	if r.archived != 1 || r.deleted != 1 || r.someday != 0 {
+		t.Errorf("left out %d archived, %d deleted and %d for some day, want 1, 1 and 0", r.archived, r.deleted, r.someday)
+	}
+}
+
+func TestSalvageItems(t *testing.T) {
+	items := []domain.Item{domain.NewItem("pay the rent"), domain.NewItem("water the plants"), {ItemTitle: "fix the bike"}}
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := storage.DecodeItems(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{"whole", data, 3},
+		{"cut off in the last item", data[:len(data)-20], 2},
+		{"cut off after an item", data[:bytes.LastIndexByte(data, '{')], 2},
+		{"only the bracket", []byte("["), 0},
+		{"empty", nil, 0},
+		{"not a list", []byte(`{"title": "pay the rent"}`), 0},
+		{"garbage in the middle", append(append([]byte{}, data[:len(data)/2]...), "}}}xx"...), 1},
+	}
+	for _, tt := range tests {
+		got := salvageItems(tt.data)
+		if len(got) != tt.want {
+			t.Errorf("%s: salvaged %d items, want %d", tt.name, len(got), tt.want)
+			continue
+		}
+		for i, item := range got {
+			if item.ID != decoded[i].ID || item.Title() != decoded[i].Title() {
+				t.Errorf("%s: item %d is %s %q, want %s %q as the storage reads it", tt.name, i, item.ID, item.Title(), decoded[i].ID, decoded[i].Title())
+			}
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package cli
+
+import (
+	"clitodo/pkg/domain"
+	"clitodo/pkg/notify"
+	"clitodo/pkg/remind"
+	"clitodo/pkg/state"
+	"clitodo/pkg/storage"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Remind schedules the reminders of the open tasks with the operating system's
+// scheduler, so they fire while the list isn't open, or removes them again.
+func (c *commandContext) Remind(args []string) error {
+	fs := flag.NewFlagSet("remind", flag.ContinueOnError)
+	install := fs.Bool("install", false, "schedule the reminders of the open tasks")
+	uninstall := fs.Bool("uninstall", false, "remove the scheduled reminders")
+	sync := fs.Bool("sync", false, "bring the scheduled reminders up to date after tasks changed")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 || countTrue(*install, *uninstall, *sync) != 1 {
+		return errors.New("usage: clitodo remind --install | --uninstall | --sync")
+	}
+
+	var jobs []remind.Job
+	if !*uninstall {
+		itemRepository, err := c.repository()
+		if err != nil {
+			return err
+		}
+		items, err := itemRepository.GetItems()
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+		jobs = remind.Jobs(items, c.config.Workspace)
+	}
+
+	installed, err := c.syncReminders(jobs)
+	if err != nil {
+		return err
+	}
+	if *uninstall {
+		fmt.Println("Removed the scheduled reminders")
+		return nil
+	}
+	switch installed {
+	case 0:
+		fmt.Println("No reminders to schedule")
+	case 1:
+		fmt.Println("1 reminder scheduled")
+	default:
+		fmt.Printf("%d reminders scheduled\n", installed)
+	}
+	return nil
+}
+
+// Notify fires the reminder of the task with the given ID if it's due, as the
+// jobs `remind` schedules do, and schedules the next one of a repeating
+// reminder. During quiet hours it's put off until they end.
+func (c *commandContext) Notify(args []string) error {
+	fs := flag.NewFlagSet("notify", flag.ContinueOnError)
+	id := fs.String("item", "", "ID of the task to remind of")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 || *id == "" {
+		return errors.New("usage: clitodo notify --item ID")
+	}
+	quiet, err := notify.ParseQuietHours(c.config.Notifications.QuietStart, c.config.Notifications.QuietEnd)
+	if err != nil {
+		return err
+	}
+
+	itemRepository, err := c.repository()
+	if err != nil {
+		return err
+	}
+	unlock, err := itemRepository.Lock()
+	if err != nil {
+		return err
+	}
+	defer func() { warn(unlock()) }()
+
+	items, err := itemRepository.GetItems()
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+
+	now := time.Now()
+	jobs := remind.Jobs(items, c.config.Workspace)
+	var fired []remind.Firing
+	if siblings, i, ok := findItem(items, *id); ok && siblings[i].Remind != nil {
+		// Schedulers only go by the minute and may run the job up to one
+		// early.
+		at := now
+		if next, ok := siblings[i].Remind.Next(); ok && next.After(now) && next.Sub(now) < time.Minute {
+			at = next
+		}
+		if quiet.Active(now) {
+			for j := range jobs {
+				if jobs[j].ItemID == *id {
+					jobs[j].When = quiet.End(now)
+				}
+			}
+		} else {
+			fired = remind.Fire(siblings[i:i+1], at)
+		}
+	}
+
+	if len(fired) != 0 {
+		if err := itemRepository.StoreItemsState(items); err != nil {
+			return err
+		}
+		jobs = remind.Jobs(items, c.config.Workspace)
+		f := fired[0]
+		n := notify.Notification{Title: "Reminder", Body: f.Item.Title()}
+		if f.Final {
+			n.Title = "Last reminder"
+		}
+		warn(notify.DesktopSender{}.Send(n))
+	}
+	_, err = c.syncReminders(jobs)
+	return err
+}
+
+// syncReminders reconciles the jobs installed for this list with jobs and
+// records the result in the state file. It returns how many are installed.
+func (c *commandContext) syncReminders(jobs []remind.Job) (int, error) {
+	scheduler, err := remind.ForOS(remind.ExecRunner{})
+	if err != nil {
+		return 0, err
+	}
+	executable, err := os.Executable()
+	if err != nil {
+		return 0, err
+	}
+	st, err := state.Load()
+	if err != nil {
+		return 0, err
+	}
+
+	list := c.config.Workspace
+	ours := map[string]state.Reminder{}
+	for id, r := range st.Reminders {
+		if r.List == list {
+			ours[id] = r
+			delete(st.Reminders, id)
+		}
+	}
+	installed, syncErr := remind.Sync(scheduler, executable, jobs, ours, time.Now())
+	if st.Reminders == nil {
+		st.Reminders = map[string]state.Reminder{}
+	}
+	for id, r := range installed {
+		st.Reminders[id] = r
+	}
+	return len(installed), errors.Join(syncErr, st.Save())
+}
+
+// findItem finds the item with the given ID among items and their subtasks,
+// returning the slice it's in and its index there.
+func findItem(items []domain.Item, id string) ([]domain.Item, int, bool) {
+	for i := range items {
+		if items[i].ID == id {
+			return items, i, true
+		}
+		if siblings, j, ok := findItem(items[i].Children, id); ok {
+			return siblings, j, true
+		}
+	}
+	return nil, 0, false
+}
+
+func countTrue(flags ...bool) int {
+	n := 0
+	for _, f := range flags {
+		if f {
+			n++
+		}
+	}
+	return n
+}
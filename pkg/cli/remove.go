@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"bufio"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/hooks"
+	"clitodo/pkg/storage"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+)
+
+// Remove deletes the task matching a partial title.
+func (c *commandContext) Remove(args []string) error {
+	fs := flag.NewFlagSet("rm", flag.ContinueOnError)
+	guard := addGuardFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	query := strings.TrimSpace(strings.Join(fs.Args(), " "))
+	if query == "" {
+		return errors.New("usage: clitodo rm [--yes] [--expect-count N] <title>")
+	}
+
+	return c.destructive(guard, func(items []domain.Item) ([]domain.Item, []domain.Item, error) {
+		index, err := resolveTitle(items, query, c.config.Filter.IgnoreAccents)
+		if err != nil {
+			return nil, nil, err
+		}
+		removed := items[index]
+		return append(items[:index:index], items[index+1:]...), []domain.Item{removed}, nil
+	})
+}
+
+// Prune deletes all completed tasks.
+func (c *commandContext) Prune(args []string) error {
+	fs := flag.NewFlagSet("prune", flag.ContinueOnError)
+	guard := addGuardFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return errors.New("usage: clitodo prune [--yes] [--expect-count N]")
+	}
+
+	return c.destructive(guard, func(items []domain.Item) ([]domain.Item, []domain.Item, error) {
+		var kept, removed []domain.Item
+		for _, item := range items {
+			if item.Completed() {
+				removed = append(removed, item)
+			} else {
+				kept = append(kept, item)
+			}
+		}
+		return kept, removed, nil
+	})
+}
+
+// guardFlags are the safety options every destructive subcommand takes.
+type guardFlags struct {
+	yes         *bool
+	expectCount *int
+}
+
+func addGuardFlags(fs *flag.FlagSet) guardFlags {
+	return guardFlags{
+		yes:         fs.Bool("yes", false, "don't ask for confirmation"),
+		expectCount: fs.Int("expect-count", -1, "abort unless the storage holds exactly this many items"),
+	}
+}
+
+// destructive runs a change that deletes items. Since the storage path can
+// come from several places, it first says which file it's about to change and
+// how many items that file holds, then checks --expect-count and asks for
+// confirmation unless --yes is given. All of that happens while the storage is
+// locked, so the count can't change between the check and the write.
+//
+// change returns the items to keep and the ones it removes.
+func (c *commandContext) destructive(guard guardFlags, change func([]domain.Item) (kept, removed []domain.Item, err error)) error {
+	itemRepository, err := c.repository()
+	if err != nil {
+		return err
+	}
+
+	unlock, err := itemRepository.Lock()
+	if err != nil {
+		return err
+	}
+	defer func() { warn(unlock()) }()
+
+	items, err := itemRepository.GetItems()
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Storage: %s (%d items)\n", itemRepository.Path(), len(items))
+	if *guard.expectCount >= 0 && len(items) != *guard.expectCount {
+		return fmt.Errorf("expected %d items but %s holds %d; nothing was changed", *guard.expectCount, itemRepository.Path(), len(items))
+	}
+
+	kept, removed, err := change(items)
+	if err != nil {
+		return err
+	}
+	if len(removed) == 0 {
+		fmt.Fprintln(os.Stderr, "Nothing to remove.")
+		return nil
+	}
+
+	if !*guard.yes {
+		lines := make([]string, len(removed))
+		for i, item := range removed {
+			lines[i] = item.Title()
+		}
+		if err := confirm(lines, fmt.Sprintf("Remove %d items?", len(removed))); err != nil {
+			return err
+		}
+	}
+
+	if err := itemRepository.StoreItemsState(kept); err != nil {
+		return err
+	}
+	for _, item := range removed {
+		c.record(itemRepository, hooks.EventDelete, item)
+	}
+	fmt.Printf("Removed %d items\n", len(removed))
+	return nil
+}
+
+// errNotConfirmed is returned when the user declines a destructive change.
+var errNotConfirmed = errors.New("aborted, nothing was changed")
+
+// confirm lists what is about to change and asks question on the terminal.
+func confirm(lines []string, question string) error {
+	if !isatty.IsTerminal(os.Stdin.Fd()) {
+		return errors.New("refusing to change items without confirmation; pass --yes when not running in a terminal")
+	}
+
+	for _, line := range lines {
+		fmt.Fprintf(os.Stderr, "  %s\n", line)
+	}
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", question)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return errNotConfirmed
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return errNotConfirmed
+}
@@ -0,0 +1,22 @@
+package cli
+
+import (
+	"clitodo/pkg/domain"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Schema prints the JSON Schema of the storage file, for tools that read or
+// write it.
+func (c *commandContext) Schema(args []string) error {
+	if len(args) != 0 {
+		return errors.New("usage: clitodo schema")
+	}
+	data, err := json.MarshalIndent(domain.Schema(), "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"clitodo/pkg/domain"
+	"clitodo/pkg/fold"
+	"clitodo/pkg/match"
+	"clitodo/pkg/storage"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Shift moves the due dates of all open tasks matching --where (or of all open
+// tasks) by a number of days and months. Tasks without a due date are skipped
+// and counted. The new dates are previewed and, once confirmed, saved in a
+// single write.
+func (c *commandContext) Shift(args []string) error {
+	fs := flag.NewFlagSet("shift", flag.ContinueOnError)
+	days := fs.Int("days", 0, "move due dates by this many days (negative moves them earlier)")
+	months := fs.Int("months", 0, "move due dates by this many months, clamping to the end of shorter months")
+	where := fs.String("where", "", "only shift tasks whose title matches this filter")
+	guard := addGuardFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 || (*days == 0 && *months == 0) {
+		return errors.New("usage: clitodo shift --days N [--months N] [--where FILTER] [--yes] [--expect-count N]")
+	}
+
+	itemRepository, err := c.repository()
+	if err != nil {
+		return err
+	}
+
+	unlock, err := itemRepository.Lock()
+	if err != nil {
+		return err
+	}
+	defer func() { warn(unlock()) }()
+
+	items, err := itemRepository.GetItems()
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Storage: %s (%d items)\n", itemRepository.Path(), len(items))
+	if *guard.expectCount >= 0 && len(items) != *guard.expectCount {
+		return fmt.Errorf("expected %d items but %s holds %d; nothing was changed", *guard.expectCount, itemRepository.Path(), len(items))
+	}
+
+	var shifted []int
+	var preview []string
+	skipped := 0
+	for _, i := range c.matching(items, *where) {
+		item := &items[i]
+		if item.Completed() {
+			continue
+		}
+		if item.Due == nil {
+			skipped++
+			continue
+		}
+		// Decoded times carry a fixed offset; shift in the local zone so
+		// crossing a DST change keeps the time of day.
+		due := *item.Due
+		due.Time = domain.ShiftDate(item.Due.Local(), *months, *days)
+		preview = append(preview, fmt.Sprintf("%s: %s → %s", item.Title(), domain.FormatDue(*item.Due), domain.FormatDue(due)))
+		item.Due = &due
+		shifted = append(shifted, i)
+	}
+
+	if skipped > 0 {
+		fmt.Fprintf(os.Stderr, "Skipping %d tasks without a due date.\n", skipped)
+	}
+	if len(shifted) == 0 {
+		fmt.Fprintln(os.Stderr, "Nothing to shift.")
+		return nil
+	}
+
+	if !*guard.yes {
+		if err := confirm(preview, fmt.Sprintf("Shift %d due dates?", len(shifted))); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	for _, i := range shifted {
+		items[i].Touch(now)
+	}
+	if err := itemRepository.StoreItemsState(items); err != nil {
+		return err
+	}
+	fmt.Printf("Shifted %d tasks\n", len(shifted))
+	return nil
+}
+
+// matching returns the indices of the items whose title matches filter using
+// the TUI's fuzzy filter, or of all items when filter is empty.
+func (c *commandContext) matching(items []domain.Item, filter string) []int {
+	indices := make([]int, 0, len(items))
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		for i := range items {
+			indices = append(indices, i)
+		}
+		return indices
+	}
+
+	targets := make([]string, len(items))
+	for i, item := range items {
+		targets[i] = item.FilterValue()
+	}
+	for _, r := range match.NewFilter(fold.Folder{StripDiacritics: c.config.Filter.IgnoreAccents})(filter, targets) {
+		indices = append(indices, r.Index)
+	}
+	slices.Sort(indices)
+	return indices
+}
@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"clitodo/pkg/config"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/storage"
+)
+
+// inBerlin sets time.Local to Berlin for the test, where 2026 has a 23-hour
+// day on March 29 and a 25-hour one on October 25.
+func inBerlin(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("no timezone data for Europe/Berlin: %v", err)
+	}
+	saved := time.Local
+	time.Local = loc
+	t.Cleanup(func() { time.Local = saved })
+	return loc
+}
+
+func TestShift(t *testing.T) {
+	loc := inBerlin(t)
+	day := func(y int, m time.Month, d int) domain.Date {
+		return domain.Date{Time: time.Date(y, m, d, 0, 0, 0, 0, loc), AllDay: true}
+	}
+	at := func(y int, m time.Month, d, hour int) domain.Date {
+		return domain.Date{Time: time.Date(y, m, d, hour, 0, 0, 0, loc)}
+	}
+	tests := []struct {
+		name         string
+		due          domain.Date
+		months, days int
+		want         domain.Date
+	}{
+		{"end of January plus a month", day(2026, time.January, 31), 1, 0, day(2026, time.February, 28)},
+		{"end of January plus a month in a leap year", day(2028, time.January, 31), 1, 0, day(2028, time.February, 29)},
+		{"end of March less a month", day(2026, time.March, 31), -1, 0, day(2026, time.February, 28)},
+		{"end of January plus a month and a day", day(2026, time.January, 31), 1, 1, day(2026, time.March, 1)},
+		{"end of January at ten plus a month", at(2026, time.January, 31, 10), 1, 0, at(2026, time.February, 28, 10)},
+		{"a whole day into summer time", day(2026, time.March, 28), 0, 1, day(2026, time.March, 29)},
+		{"a whole day out of summer time", day(2026, time.October, 24), 0, 2, day(2026, time.October, 26)},
+		// The time of day stays, though the day is 23 or 25 hours long.
+		{"a moment into summer time", at(2026, time.March, 28, 9), 0, 1, at(2026, time.March, 29, 9)},
+		{"a moment out of summer time", at(2026, time.October, 25, 9), 0, -1, at(2026, time.October, 24, 9)},
+		{"a moment a month into summer time", at(2026, time.March, 15, 23), 1, 0, at(2026, time.April, 15, 23)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("XDG_STATE_HOME", t.TempDir())
+			path := filepath.Join(t.TempDir(), "tasks.json")
+			due := domain.NewItem("renew the passport")
+			due.Due = &tt.due
+			done := domain.NewItem("book the flights")
+			done.Due = &tt.due
+			done.ItemCompleted = true
+			undated := domain.NewItem("pay the rent")
+			repository := storage.NewFileItemRepository(path)
+			if err := repository.StoreItemsState([]domain.Item{due, done, undated}); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg := config.Default()
+			cfg.Storage = path
+			c := &commandContext{config: cfg}
+			args := []string{"--months", strconv.Itoa(tt.months), "--days", strconv.Itoa(tt.days), "--yes"}
+			if err := c.Shift(args); err != nil {
+				t.Fatalf("Shift(%q) error = %v", args, err)
+			}
+
+			items, err := repository.GetItems()
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := items[0].Due
+			if got == nil || got.AllDay != tt.want.AllDay || !got.Equal(tt.want.Time) {
+				t.Errorf("shifted %s to %v, want %s", domain.FormatDue(tt.due), got, domain.FormatDue(tt.want))
+			}
+			if !items[1].Due.Equal(tt.due.Time) || items[2].Due != nil {
+				t.Errorf("shifted the completed task to %v or dated the undated one %v", items[1].Due, items[2].Due)
+			}
+		})
+	}
+}
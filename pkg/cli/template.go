@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"clitodo/pkg/config"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/hooks"
+	"clitodo/pkg/placeholder"
+	"clitodo/pkg/storage"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+const templateUsage = "usage: clitodo template list | clitodo template apply [--var NAME=VALUE]... <name>"
+
+// Template adds the tasks of a template kept in the templates directory next
+// to the config file, or lists the templates there.
+func (c *commandContext) Template(args []string) error {
+	if len(args) == 0 {
+		return errors.New(templateUsage)
+	}
+	switch args[0] {
+	case "list":
+		return c.templateList(args[1:])
+	case "apply":
+		return c.templateApply(args[1:])
+	}
+	return errors.New(templateUsage)
+}
+
+func (c *commandContext) templateList(args []string) error {
+	if len(args) != 0 {
+		return errors.New(templateUsage)
+	}
+	dir, err := config.TemplatesDir()
+	if err != nil {
+		return err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".txt")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if names := placeholder.Names(string(data)); len(names) > 0 {
+			fmt.Printf("%s (%s)\n", name, strings.Join(names, ", "))
+		} else {
+			fmt.Println(name)
+		}
+	}
+	return nil
+}
+
+// templateVars collects the --var flags of template apply.
+type templateVars map[string]string
+
+func (v templateVars) String() string {
+	return ""
+}
+
+func (v templateVars) Set(s string) error {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok || strings.TrimSpace(name) == "" {
+		return fmt.Errorf("%q is not NAME=VALUE", s)
+	}
+	v[strings.TrimSpace(name)] = value
+	return nil
+}
+
+func (c *commandContext) templateApply(args []string) error {
+	fs := flag.NewFlagSet("template apply", flag.ContinueOnError)
+	vars := templateVars{}
+	fs.Var(vars, "var", "value of a {{NAME}} placeholder as NAME=VALUE; may be repeated")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New(templateUsage)
+	}
+	name := fs.Arg(0)
+
+	dir, err := config.TemplatesDir()
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name+".txt"))
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("no template %q in %s", name, dir)
+	}
+	if err != nil {
+		return err
+	}
+	text, err := placeholder.Expand(string(data), vars)
+	var missing *placeholder.MissingError
+	if errors.As(err, &missing) {
+		return fmt.Errorf("template %q: %w; give them with --var NAME=VALUE", name, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	rules, err := c.config.DefaultRules()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	var added []domain.Item
+	for _, task := range domain.ParseTemplate(text) {
+		item, err := domain.ParseQuickEntry(task.Entry, now)
+		if err != nil {
+			return fmt.Errorf("template %q: %s: %w", name, task.Entry, err)
+		}
+		item.Notes = task.Notes
+		for _, tag := range c.config.DefaultTags() {
+			if !slices.Contains(item.Tags, tag) {
+				item.Tags = append(item.Tags, tag)
+			}
+		}
+		item, _ = rules.Apply(item, c.config.Workspace, domain.QuickEntryGiven(task.Entry), now)
+		added = append(added, item)
+	}
+	if len(added) == 0 {
+		return fmt.Errorf("template %q has no tasks", name)
+	}
+
+	itemRepository, err := c.repository()
+	if err != nil {
+		return err
+	}
+	unlock, err := itemRepository.Lock()
+	if err != nil {
+		return err
+	}
+	defer func() { warn(unlock()) }()
+
+	items, err := itemRepository.GetItems()
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+	if err := itemRepository.StoreItemsState(append(items, added...)); err != nil {
+		return err
+	}
+	for _, item := range added {
+		c.record(itemRepository, hooks.EventAdd, item)
+	}
+	fmt.Printf("Added %d tasks from %s\n", len(added), name)
+	return nil
+}
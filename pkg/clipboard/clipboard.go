@@ -0,0 +1,32 @@
+// Package clipboard puts text on the system clipboard, also when clitodo runs
+// on another machine over SSH.
+package clipboard
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-isatty"
+)
+
+// ErrUnavailable is returned when there's neither a terminal to ask nor a
+// clipboard tool such as xclip or pbcopy.
+var ErrUnavailable = errors.New("no clipboard available")
+
+// Copy puts text on the clipboard. If terminal is a terminal it's asked to do
+// it with an OSC 52 escape sequence, which works over SSH too; otherwise, or
+// if that can't be written, the platform's clipboard tool is used.
+func Copy(terminal *os.File, text string) error {
+	if terminal != nil && isatty.IsTerminal(terminal.Fd()) {
+		if _, err := io.WriteString(terminal, ansi.SetSystemClipboard(text)); err == nil {
+			return nil
+		}
+	}
+	if clipboard.Unsupported {
+		return ErrUnavailable
+	}
+	return clipboard.WriteAll(text)
+}
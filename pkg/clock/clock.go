@@ -0,0 +1,19 @@
+package clock
+
+import "time"
+
+// Clock tells the time. Code that depends on the current time takes a Clock so
+// tests can substitute a fixed one.
+type Clock interface {
+	Now() time.Time
+}
+
+// Real is the system clock.
+type Real struct{}
+
+func (Real) Now() time.Time { return time.Now() }
+
+// Fixed always returns the same time.
+type Fixed time.Time
+
+func (f Fixed) Now() time.Time { return time.Time(f) }
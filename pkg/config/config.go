@@ -0,0 +1,323 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Config holds the user settings read from config.toml. The zero value is a
+// valid configuration; every setting falls back to the built-in default.
+type Config struct {
+	// Path of the storage file. May contain ~, environment variables and a
+	// {hostname} token, see ExpandPath.
+	Storage string `toml:"storage"`
+
+	// How lists that don't exist yet keep their items: "file" in one JSON
+	// file at the storage path, "dir" in a directory there with a file per
+	// item. Existing lists keep theirs until converted with `clitodo
+	// convert`.
+	Backend string `toml:"backend"`
+
+	// Name of the color theme, see cmd.Themes.
+	Theme string `toml:"theme"`
+
+	// Whether the terminal has a dark or light background: "dark", "light"
+	// or "auto" to detect it at startup.
+	Background string `toml:"background"`
+
+	// How the selected task is marked: "border", "glyph", "reverse" or
+	// "underline". Empty uses the theme's own.
+	Cursor string `toml:"cursor"`
+
+	// IANA name of the timezone dates are shown and typed in, such as
+	// "Europe/Madrid". Empty uses the system's.
+	Timezone string `toml:"timezone"`
+
+	// Keybindings to start from, "default" or "vim". keys.toml changes
+	// them further.
+	Keymap string `toml:"keymap"`
+
+	// Actions whose keys the short help under the list shows too, by the
+	// names keys.toml remaps them by, such as "sort_mode".
+	PinnedKeys []string `toml:"pinned_keys"`
+
+	Hooks Hooks `toml:"hooks"`
+
+	Notifications Notifications `toml:"notifications"`
+
+	Nag Nag `toml:"nag"`
+
+	Done Done `toml:"done"`
+
+	// Whether to show "items 11–20 of 54 · 3 done on this page" above the
+	// list when it spans more than one page.
+	PageSummary bool `toml:"page_summary"`
+
+	// Whether to show hints like "enter toggle · ctrl+d delete" for the
+	// current state at the end of the status bar.
+	StatusHints bool `toml:"status_hints"`
+
+	// Whether to point out the first few things to try, such as adding a
+	// task, during the first launches.
+	Tips bool `toml:"tips"`
+
+	// Whether to show the first line of each task's notes under its title.
+	ShowNotes bool `toml:"show_notes"`
+
+	// Whether to number the rows, so typing a number and enter goes to
+	// that row.
+	ShowNumbers bool `toml:"show_numbers"`
+
+	// Whether long titles wrap onto more lines instead of being cut off.
+	WrapTitles bool `toml:"wrap_titles"`
+
+	// Whether completed tasks look like open ones apart from the check
+	// mark, instead of being struck through and dimmed.
+	PlainCompleted bool `toml:"plain_completed"`
+
+	// How the rows are laid out: "default", "compact", a line per task
+	// with no gap between them, or "table", lined up in columns.
+	Layout string `toml:"layout"`
+
+	// Whether checking off the last open subtask also checks off its
+	// parent.
+	CompleteParents bool `toml:"complete_parents"`
+
+	// Which row is selected after the selected task is deleted: the
+	// "previous" one or the "next" one.
+	AfterDelete string `toml:"after_delete"`
+
+	// Quitting while an open task is due within this long asks first,
+	// naming the task. Waiting tasks don't count. 0 quits right away.
+	QuitWarning time.Duration `toml:"quit_warning"`
+
+	// Whether tasks completed today move into a collapsible "Done today"
+	// section at the bottom of the list. Tasks completed on earlier days
+	// are moved to the archive file then.
+	DoneSection bool `toml:"done_section"`
+
+	// Once the list is left alone this long, completed tasks move below the
+	// open ones among their siblings. 0 leaves them where they are.
+	SinkCompletedAfter time.Duration `toml:"sink_completed_after"`
+
+	// Terminal width from which the selected task's details are shown next
+	// to the list. 0 never splits.
+	SplitWidth int `toml:"split_width"`
+
+	// Rows used when running without the alt screen (--no-altscreen).
+	InlineHeight int `toml:"inline_height"`
+
+	// How many changes to the list u can undo.
+	UndoDepth int `toml:"undo_depth"`
+
+	Titles Titles `toml:"titles"`
+
+	WIP WIP `toml:"wip"`
+
+	Filter Filter `toml:"filter"`
+
+	// Name of the active workspace, empty for none. --workspace overrides
+	// it.
+	Workspace string `toml:"workspace"`
+
+	// Workspaces by name, each defined in a [workspaces.NAME] table.
+	Workspaces map[string]Workspace `toml:"workspaces"`
+
+	// Rules for the due date and priority new tasks start with, by name,
+	// each defined in a [defaults.NAME] table.
+	Defaults map[string]DefaultRule `toml:"defaults"`
+}
+
+// Workspace bundles a storage file with its own theme and the tags every new
+// task in it starts with. Settings left out fall back to the top-level ones.
+type Workspace struct {
+	Storage string   `toml:"storage"`
+	Theme   string   `toml:"theme"`
+	Tags    []string `toml:"tags"`
+}
+
+// DefaultRule gives new tasks tagged Tag, created in the workspace List, or
+// both, a due date such as "in 7 days" and a priority, unless they're added
+// with one. See domain.DefaultRule.
+type DefaultRule struct {
+	Tag      string `toml:"tag"`
+	List     string `toml:"list"`
+	Due      string `toml:"due"`
+	Priority string `toml:"priority"`
+}
+
+// Filter configures how the filter and the jump prompt match tasks. Case is
+// always ignored; IgnoreAccents also lets "cafe" match "Café".
+type Filter struct {
+	IgnoreAccents bool `toml:"ignore_accents"`
+
+	// How the filter matches tasks: fuzzy, substring, regex or tags.
+	Match string `toml:"match"`
+
+	// Whether to match the titles only, not the tags, notes and
+	// subtasks.
+	TitlesOnly bool `toml:"titles_only"`
+
+	// Whether to show the tags in use as chips under the title, which
+	// filter the list by tag when clicked or picked with #.
+	TagChips bool `toml:"tag_chips"`
+}
+
+// WIP configures the work-in-progress limit: at most Limit open tasks tagged
+// Tag. Going over it takes a confirmation and shows a warning until it's
+// back within. A Limit of 0 turns it off.
+type WIP struct {
+	Tag   string `toml:"tag"`
+	Limit int    `toml:"limit"`
+}
+
+// Titles configures the soft limit on task titles. Longer titles are still
+// accepted, but the add screen offers to move the overflow into the notes.
+// A SoftLimit of 0 turns the suggestion off.
+type Titles struct {
+	SoftLimit int `toml:"soft_limit"`
+}
+
+// Nag configures the daily "still relevant?" prompt, which shows up to Count
+// incomplete tasks that weren't touched for more than AfterDays days.
+type Nag struct {
+	Enabled   bool `toml:"enabled"`
+	Count     int  `toml:"count"`
+	AfterDays int  `toml:"after_days"`
+}
+
+// Done configures local feedback when a task is completed: the terminal bell
+// and/or a command such as "paplay ding.ogg". The command runs in the
+// background for at most Timeout. Completions within Cooldown of the last
+// chime stay silent. Un-completing a task never makes a sound.
+type Done struct {
+	Command  string        `toml:"command"`
+	Bell     bool          `toml:"bell"`
+	Timeout  time.Duration `toml:"timeout"`
+	Cooldown time.Duration `toml:"cooldown"`
+}
+
+// Notifications configures desktop notifications and the terminal bell.
+// Between QuietStart and QuietEnd ("HH:MM", local time) notifications are held
+// back and delivered as one digest afterwards.
+type Notifications struct {
+	Enabled    bool   `toml:"enabled"`
+	QuietStart string `toml:"quiet_start"`
+	QuietEnd   string `toml:"quiet_end"`
+}
+
+// Hooks configures shell commands run after items are added, completed or
+// deleted. The affected item is passed as JSON on stdin. Hooks never run
+// unless Enabled is set.
+type Hooks struct {
+	Enabled    bool          `toml:"enabled"`
+	Timeout    time.Duration `toml:"timeout"`
+	OnAdd      string        `toml:"on_add"`
+	OnComplete string        `toml:"on_complete"`
+	OnDelete   string        `toml:"on_delete"`
+}
+
+// Default returns the configuration used when no config file exists.
+func Default() Config {
+	return Config{
+		Theme:        "default",
+		Backend:      "file",
+		Background:   "auto",
+		Keymap:       "default",
+		PageSummary:  true,
+		StatusHints:  true,
+		Tips:         true,
+		AfterDelete:  "previous",
+		Layout:       "default",
+		QuitWarning:  time.Hour,
+		SplitWidth:   120,
+		InlineHeight: 15,
+		UndoDepth:    100,
+		Hooks: Hooks{
+			Timeout: 10 * time.Second,
+		},
+		Nag: Nag{
+			Count:     3,
+			AfterDays: 30,
+		},
+		Done: Done{
+			Timeout:  3 * time.Second,
+			Cooldown: time.Second,
+		},
+		Titles: Titles{
+			SoftLimit: 80,
+		},
+		WIP: WIP{
+			Tag: "doing",
+		},
+		Filter: Filter{
+			IgnoreAccents: true,
+			Match:         "fuzzy",
+		},
+	}
+}
+
+// Location returns the timezone dates are shown and typed in: the one named by
+// Timezone, or time.Local.
+func (c Config) Location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("timezone: %w", err)
+	}
+	return loc, nil
+}
+
+// Path returns the location of the config file, honoring XDG_CONFIG_HOME.
+func Path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "clitodo", "config.toml"), nil
+}
+
+// Load reads the config file. A missing file is not an error and yields
+// Default().
+func Load() (Config, error) {
+	path, err := Path()
+	if err != nil {
+		return Default(), nil
+	}
+	return LoadFile(path)
+}
+
+// LoadFile reads the config file at path on top of the defaults.
+func LoadFile(path string) (Config, error) {
+	cfg := Default()
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+	defer file.Close()
+
+	cfg, err = Read(file)
+	if err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Read reads the contents of a config file from r on top of the defaults.
+func Read(r io.Reader) (Config, error) {
+	cfg := Default()
+	if err := decode(r, &cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
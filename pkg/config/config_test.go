@@ -0,0 +1,141 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReadSettings(t *testing.T) {
+	cfg, err := Read(strings.NewReader(`
+theme = "light"   # trailing comment
+pinned_keys = [
+  "sort_mode",
+  "agenda",
+]
+quit_warning = "30m"
+split_width = 100
+
+[hooks]
+enabled = true
+timeout = "5s"
+
+[workspaces.work]
+storage = "~/work.json"
+tags = ["work", "office"]
+
+[defaults.bills]
+tag = "bills"
+due = "in 7 days"
+`))
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if cfg.Theme != "light" || cfg.SplitWidth != 100 || cfg.QuitWarning != 30*time.Minute {
+		t.Errorf("top-level settings = %q, %d, %v", cfg.Theme, cfg.SplitWidth, cfg.QuitWarning)
+	}
+	if got := strings.Join(cfg.PinnedKeys, ","); got != "sort_mode,agenda" {
+		t.Errorf("PinnedKeys = %q", got)
+	}
+	if !cfg.Hooks.Enabled || cfg.Hooks.Timeout != 5*time.Second {
+		t.Errorf("Hooks = %+v", cfg.Hooks)
+	}
+	if w := cfg.Workspaces["work"]; w.Storage != "~/work.json" || len(w.Tags) != 2 {
+		t.Errorf("Workspaces[work] = %+v", w)
+	}
+	if d := cfg.Defaults["bills"]; d.Tag != "bills" || d.Due != "in 7 days" {
+		t.Errorf("Defaults[bills] = %+v", d)
+	}
+	// Settings left out keep their defaults.
+	if cfg.UndoDepth != Default().UndoDepth || cfg.Nag.Count != Default().Nag.Count {
+		t.Errorf("defaults lost: UndoDepth = %d, Nag.Count = %d", cfg.UndoDepth, cfg.Nag.Count)
+	}
+}
+
+func TestReadRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		toml  string
+		want  string
+		exact bool
+		line  int
+	}{
+		{name: "unknown key", toml: "theme = \"light\"\nthem = \"dark\"\n", want: "unknown setting them"},
+		{name: "unknown key in table", toml: "[hooks]\nenabeld = true\n", want: "unknown setting hooks.enabeld"},
+		{name: "unknown table", toml: "[colours]\nbg = \"red\"\n", want: "unknown setting colours", exact: true},
+		{name: "float for an integer", toml: "split_width = 1.5\n", want: "split_width"},
+		{name: "string for a boolean", toml: "tips = \"yes\"\n", want: "tips"},
+		{name: "bad duration", toml: "quit_warning = \"soon\"\n", want: "soon"},
+		{name: "missing value", toml: "\n\ntheme =\n", line: 3},
+		{name: "unterminated string", toml: "theme = \"light\nkeymap = \"vim\"\n", line: 1},
+		{name: "unterminated array", toml: "pinned_keys = [\"a\",\n", line: 1},
+		{name: "duplicate key", toml: "tips = true\ntips = false\n", line: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tt.toml))
+			if err == nil {
+				t.Fatal("Read() succeeded")
+			}
+			if tt.exact && err.Error() != tt.want {
+				t.Errorf("Read() error = %q, want %q", err, tt.want)
+			}
+			if tt.want != "" && !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Read() error = %q, want one containing %q", err, tt.want)
+			}
+			if tt.line != 0 {
+				var perr *ParseError
+				if !errors.As(err, &perr) {
+					t.Fatalf("Read() error = %v, want a ParseError", err)
+				}
+				if perr.Line != tt.line {
+					t.Errorf("ParseError.Line = %d, want %d", perr.Line, tt.line)
+				}
+			}
+		})
+	}
+}
+
+// The example config in the README has to stay readable.
+func TestReadmeConfig(t *testing.T) {
+	readme, err := os.ReadFile("../../README.md")
+	if err != nil {
+		t.Skip(err)
+	}
+	_, rest, ok := strings.Cut(string(readme), "## Configuration")
+	if !ok {
+		t.Fatal("README has no Configuration section")
+	}
+	_, rest, _ = strings.Cut(rest, "```toml\n")
+	example, _, _ := strings.Cut(rest, "```")
+	if _, err := Read(strings.NewReader(example)); err != nil {
+		t.Errorf("README config: %v", err)
+	}
+}
+
+func TestKeyList(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+		err   bool
+	}{
+		{value: "w", want: "w"},
+		{value: []any{"up", "p"}, want: "up,p"},
+		{value: []any{}, want: ""},
+		{value: []any{"up", int64(1)}, err: true},
+		{value: int64(1), err: true},
+		{value: map[string]any{"up": "w"}, err: true},
+	}
+	for _, tt := range tests {
+		got, err := keyList(tt.value)
+		if (err != nil) != tt.err {
+			t.Errorf("keyList(%v) error = %v, want error %t", tt.value, err, tt.err)
+			continue
+		}
+		if s := strings.Join(got, ","); !tt.err && s != tt.want {
+			t.Errorf("keyList(%v) = %q, want %q", tt.value, s, tt.want)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"clitodo/pkg/domain"
+	"fmt"
+	"strings"
+)
+
+// DefaultRules returns the [defaults.NAME] rules in the order they apply, or
+// an error naming the first one that doesn't make sense.
+func (c Config) DefaultRules() (domain.DefaultRules, error) {
+	rules := make([]domain.DefaultRule, 0, len(c.Defaults))
+	for name, d := range c.Defaults {
+		rule := domain.DefaultRule{
+			Name: name,
+			Tag:  strings.TrimPrefix(d.Tag, "#"),
+			List: d.List,
+			Due:  d.Due,
+		}
+		if d.Priority != "" {
+			var p domain.Priority
+			if err := p.UnmarshalText([]byte(d.Priority)); err != nil {
+				return nil, fmt.Errorf("defaults: rule %s: %w", name, err)
+			}
+			rule.Priority = &p
+		}
+		rules = append(rules, rule)
+	}
+	sorted, err := domain.NewDefaultRules(rules)
+	if err != nil {
+		return nil, fmt.Errorf("defaults: %w", err)
+	}
+	return sorted, nil
+}
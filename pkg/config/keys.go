@@ -0,0 +1,80 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/BurntSushi/toml"
+)
+
+// KeysPath returns the location of the keys file, next to the config file.
+func KeysPath() (string, error) {
+	path, err := Path()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "keys.toml"), nil
+}
+
+// LoadKeys reads the keys file, which binds actions to other keys than the
+// built-in ones: `cursor_up = ["w"]`, or `cursor_up = "w"` for a single key.
+// A missing file binds nothing.
+func LoadKeys() (map[string][]string, error) {
+	path, err := KeysPath()
+	if err != nil {
+		return nil, nil
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var t map[string]any
+	if _, err := toml.NewDecoder(file).Decode(&t); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, parseError(err))
+	}
+
+	actions := make([]string, 0, len(t))
+	for action := range t {
+		actions = append(actions, action)
+	}
+	slices.Sort(actions)
+	keys := make(map[string][]string, len(actions))
+	for _, action := range actions {
+		list, err := keyList(t[action])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", path, action, err)
+		}
+		keys[action] = list
+	}
+	return keys, nil
+}
+
+// keyList returns the keys bound to an action, given as a list of strings or
+// a single one.
+func keyList(value any) ([]string, error) {
+	switch value := value.(type) {
+	case string:
+		return []string{value}, nil
+	case []any:
+		list := make([]string, len(value))
+		for i, v := range value {
+			s, ok := v.(string)
+			if !ok {
+				return nil, errors.New("expected a list of strings")
+			}
+			list[i] = s
+		}
+		return list, nil
+	case map[string]any:
+		return nil, errors.New("unexpected table; bind actions at the top level")
+	}
+	return nil, errors.New("expected a list of strings")
+}
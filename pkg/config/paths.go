@@ -0,0 +1,80 @@
+package config
+
+import (
+	"clitodo/pkg/storage"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PathError reports a storage path template that couldn't be turned into a
+// usable path.
+type PathError struct {
+	Template string
+	Expanded string
+	Err      error
+}
+
+func (e *PathError) Error() string {
+	if e.Expanded == "" {
+		return fmt.Sprintf("storage path %q: %v", e.Template, e.Err)
+	}
+	return fmt.Sprintf("storage path %q (expanded to %q): %v", e.Template, e.Expanded, e.Err)
+}
+
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// StoragePath returns the file items are stored in, expanding the configured
+// template of the active workspace. Without a configured path items live in
+// ./storage.json.
+func (c Config) StoragePath() (string, error) {
+	template := c.StorageTemplate()
+	if template == "" {
+		return storage.DefaultFilePath, nil
+	}
+	return ExpandPath(template)
+}
+
+// ExpandPath expands a leading ~, environment variables ($VAR or ${VAR}) and
+// the {hostname} token in template. The result must be absolute. Nothing is
+// created: the storage makes the directories it needs when it first writes.
+func ExpandPath(template string) (string, error) {
+	path := template
+
+	if strings.Contains(path, "{hostname}") {
+		host, err := os.Hostname()
+		if err != nil {
+			return "", &PathError{Template: template, Err: fmt.Errorf("resolving {hostname}: %w", err)}
+		}
+		path = strings.ReplaceAll(path, "{hostname}", host)
+	}
+
+	var missing []string
+	path = os.Expand(path, func(name string) string {
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+		return v
+	})
+	if len(missing) > 0 {
+		return "", &PathError{Template: template, Err: fmt.Errorf("environment variable %s is not set", strings.Join(missing, ", "))}
+	}
+
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", &PathError{Template: template, Err: fmt.Errorf("resolving ~: %w", err)}
+		}
+		path = filepath.Join(home, path[1:])
+	}
+
+	if !filepath.IsAbs(path) {
+		return "", &PathError{Template: template, Expanded: path, Err: errors.New("path must be absolute")}
+	}
+	return filepath.Clean(path), nil
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExpandPath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("TODO_DIR", filepath.Join(home, "sync"))
+	host, err := os.Hostname()
+	if err != nil {
+		t.Skip(err)
+	}
+
+	tests := []struct {
+		template string
+		want     string
+		err      bool
+	}{
+		{template: "~/todo.json", want: filepath.Join(home, "todo.json")},
+		{template: "$TODO_DIR/todo.json", want: filepath.Join(home, "sync", "todo.json")},
+		{template: "${TODO_DIR}/a/../todo.json", want: filepath.Join(home, "sync", "todo.json")},
+		{template: "~/todo-{hostname}.json", want: filepath.Join(home, "todo-"+host+".json")},
+		{template: "$CLITODO_UNSET_FOR_TEST/todo.json", err: true},
+		{template: "todo.json", err: true},
+	}
+	for _, tt := range tests {
+		got, err := ExpandPath(tt.template)
+		if (err != nil) != tt.err {
+			t.Errorf("ExpandPath(%q) error = %v, want error %t", tt.template, err, tt.err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ExpandPath(%q) = %q, want %q", tt.template, got, tt.want)
+		}
+	}
+}
+
+func TestExpandPathCreatesNothing(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	path, err := ExpandPath("~/deep/er/todo.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Dir(path)); !os.IsNotExist(err) {
+		t.Errorf("ExpandPath made %s: %v", filepath.Dir(path), err)
+	}
+}
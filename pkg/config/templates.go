@@ -0,0 +1,13 @@
+package config
+
+import "path/filepath"
+
+// TemplatesDir returns the directory task templates are kept in, next to the
+// config file. The template NAME is the file NAME.txt in it.
+func TemplatesDir() (string, error) {
+	path, err := Path()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "templates"), nil
+}
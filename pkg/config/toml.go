@@ -0,0 +1,55 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ParseError reports a malformed line in a config file.
+type ParseError struct {
+	Line int
+	Msg  string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+// decode reads the TOML in r into the struct v points to, matching keys
+// against `toml` struct tags. Keys that v has no field for are an error, so
+// a misspelled setting doesn't go unnoticed.
+func decode(r io.Reader, v any) error {
+	md, err := toml.NewDecoder(r).Decode(v)
+	if err != nil {
+		return parseError(err)
+	}
+	var unknown []string
+	for _, key := range md.Undecoded() {
+		// An unknown table's keys are unknown too; naming the table is
+		// enough.
+		if !slices.ContainsFunc(unknown, func(table string) bool {
+			return strings.HasPrefix(key.String(), table+".")
+		}) {
+			unknown = append(unknown, key.String())
+		}
+	}
+	if len(unknown) != 0 {
+		return fmt.Errorf("unknown setting %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// parseError turns the TOML library's syntax errors into a ParseError naming
+// the line.
+func parseError(err error) error {
+	var perr toml.ParseError
+	if errors.As(err, &perr) {
+		return &ParseError{Line: perr.Position.Line, Msg: perr.Message}
+	}
+	return err
+}
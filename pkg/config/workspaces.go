@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// WorkspaceNames returns the names of the configured workspaces, sorted.
+func (c Config) WorkspaceNames() []string {
+	names := make([]string, 0, len(c.Workspaces))
+	for name := range c.Workspaces {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// CheckWorkspace returns an error if the active workspace isn't defined.
+func (c Config) CheckWorkspace() error {
+	if c.Workspace == "" {
+		return nil
+	}
+	if _, ok := c.Workspaces[c.Workspace]; ok {
+		return nil
+	}
+	if len(c.Workspaces) == 0 {
+		return fmt.Errorf("unknown workspace %q; none are configured", c.Workspace)
+	}
+	return fmt.Errorf("unknown workspace %q; configured are %s", c.Workspace, strings.Join(c.WorkspaceNames(), ", "))
+}
+
+// UseList makes the named list the active workspace. A name that isn't a
+// configured workspace is a list of its own, kept in NAME.json next to the
+// top-level storage with the top-level theme and tags.
+func (c *Config) UseList(name string) error {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return fmt.Errorf("invalid list name %q", name)
+	}
+	if _, ok := c.Workspaces[name]; ok {
+		c.Workspace = name
+		return nil
+	}
+
+	top := *c
+	top.Workspace = ""
+	path, err := top.StoragePath()
+	if err != nil {
+		return err
+	}
+	path, err = filepath.Abs(filepath.Join(filepath.Dir(path), name+".json"))
+	if err != nil {
+		return err
+	}
+	if c.Workspaces == nil {
+		c.Workspaces = map[string]Workspace{}
+	}
+	c.Workspaces[name] = Workspace{Storage: path}
+	c.Workspace = name
+	return nil
+}
+
+// StorageTemplate returns the storage path template of the active workspace,
+// or the top-level one.
+func (c Config) StorageTemplate() string {
+	if w := c.Workspaces[c.Workspace]; w.Storage != "" {
+		return w.Storage
+	}
+	return c.Storage
+}
+
+// ThemeName returns the theme of the active workspace, or the top-level one.
+func (c Config) ThemeName() string {
+	if w := c.Workspaces[c.Workspace]; w.Theme != "" {
+		return w.Theme
+	}
+	return c.Theme
+}
+
+// DefaultTags returns the tags new tasks in the active workspace start with.
+func (c Config) DefaultTags() []string {
+	return slices.Clone(c.Workspaces[c.Workspace].Tags)
+}
@@ -0,0 +1,231 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DueTimeLayout is how due dates with a time of day are shown.
+const DueTimeLayout = "2006-01-02 15:04"
+
+// FormatDue writes a due date, with its time of day unless it's a whole day.
+func FormatDue(d Date) string {
+	if d.AllDay {
+		return d.Format(DueLayout)
+	}
+	return d.Local().Format(DueTimeLayout)
+}
+
+// relativeDueDays is how many days ahead a due date is still written
+// relative to today; later ones are written as dates.
+const relativeDueDays = 6
+
+// RelativeDue writes a due date relative to now, the way it's shown next to
+// a task: "today", "tomorrow", "in 3d" or, once it has passed, "2d overdue".
+// Due dates with a time of day keep it for today and tomorrow, "today 17:00",
+// and count the hours or minutes once they've passed the same day, "2h
+// overdue"; within a minute either way they're "now". Dates more than a week
+// ahead are written as FormatDue does.
+func RelativeDue(d Date, now time.Time) string {
+	due, now := d.Local(), now.Local()
+	days := calendarDays(now, due)
+	timed := !d.AllDay
+
+	if timed {
+		if left := due.Sub(now); left > -time.Minute && left < time.Minute {
+			return "now"
+		}
+		if passed := now.Sub(due); passed > 0 && days == 0 {
+			if passed < time.Hour {
+				return fmt.Sprintf("%dm overdue", int(passed/time.Minute))
+			}
+			return fmt.Sprintf("%dh overdue", int(passed/time.Hour))
+		}
+	}
+
+	clock := ""
+	if timed {
+		clock = " " + due.Format("15:04")
+	}
+	switch {
+	case days < 0:
+		return fmt.Sprintf("%dd overdue", -days)
+	case days == 0:
+		return "today" + clock
+	case days == 1:
+		return "tomorrow" + clock
+	case days <= relativeDueDays:
+		return fmt.Sprintf("in %dd", days)
+	}
+	return FormatDue(d)
+}
+
+// calendarDays returns how many days on the calendar there are from a's day
+// to b's, in the local timezone, whatever the time of day and DST changes in
+// between.
+func calendarDays(a, b time.Time) int {
+	ay, am, ad := a.Local().Date()
+	by, bm, bd := b.Local().Date()
+	from := time.Date(ay, am, ad, 0, 0, 0, 0, time.UTC)
+	to := time.Date(by, bm, bd, 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from) / (24 * time.Hour))
+}
+
+// ParseDate reads a date typed by the user, relative to now, optionally
+// followed by a time of day ("tomorrow 5pm", "fri at 9:30"). Without a time
+// the result is midnight. It understands:
+//
+//   - 2006-01-02
+//   - yesterday, today, tomorrow
+//   - a weekday, full or abbreviated ("friday", "fri"): the next day with
+//     that weekday, today included, so "friday" on a Friday is today
+//   - next <weekday>: the next day with that weekday after today, so on a
+//     Friday "next friday" is a week later and on a Wednesday it's in two
+//     days, same as "friday"
+//   - in N days, in N weeks, in N months (singular units too)
+//   - eom, end of month: the last day of the current month, today included
+//
+// A time on its own means today at that time, even if it has passed.
+func ParseDate(s string, now time.Time) (time.Time, error) {
+	t, _, err := parseDate(s, now)
+	return t, err
+}
+
+// ParseDue reads a due date as ParseDate does. Without a time of day it's the
+// whole day; with one it's that moment, even at midnight ("fri 12am").
+func ParseDue(s string, now time.Time) (Date, error) {
+	t, timed, err := parseDate(s, now)
+	if err != nil {
+		return Date{}, err
+	}
+	return Date{Time: t, AllDay: !timed}, nil
+}
+
+// parseDate reads a date as ParseDate does and reports whether it came with a
+// time of day.
+func parseDate(s string, now time.Time) (t time.Time, timed bool, err error) {
+	now = now.Local()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) == 0 {
+		return time.Time{}, false, fmt.Errorf("no date given")
+	}
+
+	// A trailing time of day, optionally introduced by "at".
+	hour, minute, ok := parseTimeOfDay(fields[len(fields)-1])
+	if ok {
+		fields = fields[:len(fields)-1]
+		if len(fields) > 0 && fields[len(fields)-1] == "at" {
+			fields = fields[:len(fields)-1]
+		}
+	}
+
+	day := today
+	if len(fields) > 0 {
+		if day, err = parseDay(fields, today); err != nil {
+			return time.Time{}, false, fmt.Errorf("%q: %w", s, err)
+		}
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, time.Local), ok, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// parseDay reads the date part of ParseDate.
+func parseDay(fields []string, today time.Time) (time.Time, error) {
+	phrase := strings.Join(fields, " ")
+	switch phrase {
+	case "yesterday":
+		return ShiftDate(today, 0, -1), nil
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return ShiftDate(today, 0, 1), nil
+	case "eom", "end of month":
+		return time.Date(today.Year(), today.Month()+1, 0, 0, 0, 0, 0, time.Local), nil
+	}
+
+	if d, ok := weekdays[phrase]; ok {
+		return ShiftDate(today, 0, (int(d)-int(today.Weekday())+7)%7), nil //nolint:mnd
+	}
+	if len(fields) == 2 && fields[0] == "next" {
+		if d, ok := weekdays[fields[1]]; ok {
+			return ShiftDate(today, 0, (int(d)-int(today.Weekday())+6)%7+1), nil //nolint:mnd
+		}
+	}
+
+	if len(fields) == 3 && fields[0] == "in" {
+		n, err := strconv.Atoi(fields[1])
+		if err != nil || n < 0 {
+			return time.Time{}, fmt.Errorf("%q is not a number of days, weeks or months", fields[1])
+		}
+		switch fields[2] {
+		case "day", "days":
+			return ShiftDate(today, 0, n), nil
+		case "week", "weeks":
+			return ShiftDate(today, 0, 7*n), nil //nolint:mnd
+		case "month", "months":
+			return ShiftDate(today, n, 0), nil
+		}
+		return time.Time{}, fmt.Errorf("unknown unit %q, use days, weeks or months", fields[2])
+	}
+
+	if t, err := time.ParseInLocation(DueLayout, phrase, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("not a date; try 2006-01-02, tomorrow, fri, next fri, in 3 days or eom")
+}
+
+// parseTimeOfDay reads "17:00", "5pm", "5:30pm" or "noon".
+func parseTimeOfDay(s string) (hour, minute int, ok bool) {
+	if s == "noon" {
+		return 12, 0, true //nolint:mnd
+	}
+
+	pm := strings.HasSuffix(s, "pm")
+	am := strings.HasSuffix(s, "am")
+	if am || pm {
+		s = s[:len(s)-2]
+	} else if !strings.Contains(s, ":") {
+		// A bare number is more likely part of the date than an hour.
+		return 0, 0, false
+	}
+
+	hs, ms := s, "0"
+	if h, m, found := strings.Cut(s, ":"); found {
+		hs, ms = h, m
+	}
+	h, err := strconv.Atoi(hs)
+	if err != nil {
+		return 0, 0, false
+	}
+	m, err := strconv.Atoi(ms)
+	if err != nil || m < 0 || m > 59 {
+		return 0, 0, false
+	}
+
+	switch {
+	case am || pm:
+		if h < 1 || h > 12 {
+			return 0, 0, false
+		}
+		h %= 12
+		if pm {
+			h += 12
+		}
+	case h < 0 || h > 23:
+		return 0, 0, false
+	}
+	return h, m, true
+}
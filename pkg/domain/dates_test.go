@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	loc := inZone(t, "Europe/Berlin")
+	at := func(y int, m time.Month, d, hour, minute int) time.Time {
+		return time.Date(y, m, d, hour, minute, 0, 0, loc)
+	}
+	// A Wednesday afternoon.
+	wed := at(2025, time.March, 12, 15, 30)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		s    string
+		want time.Time
+	}{
+		{"today", wed, "today", at(2025, time.March, 12, 0, 0)},
+		{"yesterday", wed, "yesterday", at(2025, time.March, 11, 0, 0)},
+		{"tomorrow 5pm", wed, "tomorrow 5pm", at(2025, time.March, 13, 17, 0)},
+		{"tomorrow at 9:30am", wed, "Tomorrow at 9:30am", at(2025, time.March, 13, 9, 30)},
+		{"24-hour time", wed, "tomorrow 17:45", at(2025, time.March, 13, 17, 45)},
+		{"noon", wed, "fri noon", at(2025, time.March, 14, 12, 0)},
+		{"12am is midnight", wed, "tomorrow 12am", at(2025, time.March, 13, 0, 0)},
+		{"12pm is noon", wed, "tomorrow 12pm", at(2025, time.March, 13, 12, 0)},
+		{"time alone is today even when past", wed, "9am", at(2025, time.March, 12, 9, 0)},
+		{"weekday", wed, "fri", at(2025, time.March, 14, 0, 0)},
+		{"weekday today is today", wed, "wednesday", at(2025, time.March, 12, 0, 0)},
+		{"next weekday", wed, "next fri", at(2025, time.March, 14, 0, 0)},
+		{"next same weekday is a week later", wed, "next wed", at(2025, time.March, 19, 0, 0)},
+		{"next earlier weekday", wed, "next mon", at(2025, time.March, 17, 0, 0)},
+		{"in days", wed, "in 3 days", at(2025, time.March, 15, 0, 0)},
+		{"in a week", wed, "in 1 week", at(2025, time.March, 19, 0, 0)},
+		{"in months", wed, "in 2 months", at(2025, time.May, 12, 0, 0)},
+		{"eom", wed, "eom", at(2025, time.March, 31, 0, 0)},
+		{"end of month with a time", wed, "end of month 6pm", at(2025, time.March, 31, 18, 0)},
+		{"date", wed, "2025-07-04", at(2025, time.July, 4, 0, 0)},
+		{"date with a time", wed, "2025-07-04 at 8pm", at(2025, time.July, 4, 20, 0)},
+
+		// Month and year boundaries.
+		{"eom on the last day", at(2025, time.January, 31, 10, 0), "eom", at(2025, time.January, 31, 0, 0)},
+		{"eom in a leap February", at(2024, time.February, 3, 10, 0), "eom", at(2024, time.February, 29, 0, 0)},
+		{"eom in February", at(2025, time.February, 3, 10, 0), "eom", at(2025, time.February, 28, 0, 0)},
+		{"in a month from the 31st", at(2025, time.January, 31, 10, 0), "in 1 month", at(2025, time.February, 28, 0, 0)},
+		{"tomorrow on new year's eve", at(2025, time.December, 31, 22, 0), "tomorrow 5pm", at(2026, time.January, 1, 17, 0)},
+		{"next fri into the new year", at(2025, time.December, 30, 9, 0), "next fri", at(2026, time.January, 2, 0, 0)},
+		{"in months into the new year", at(2025, time.November, 30, 9, 0), "in 3 months", at(2026, time.February, 28, 0, 0)},
+		{"eom in December", at(2025, time.December, 2, 9, 0), "eom", at(2025, time.December, 31, 0, 0)},
+
+		// Clocks go forward on 2025-03-30 at 2:00 and back on 2025-10-26
+		// at 3:00.
+		{"tomorrow over spring forward", at(2025, time.March, 29, 23, 30), "tomorrow", at(2025, time.March, 30, 0, 0)},
+		{"in a day over spring forward", at(2025, time.March, 29, 12, 0), "in 1 day 5pm", at(2025, time.March, 30, 17, 0)},
+		{"skipped hour moves on", at(2025, time.March, 29, 12, 0), "tomorrow 2:30am", at(2025, time.March, 30, 3, 30)},
+		{"tomorrow over fall back", at(2025, time.October, 25, 23, 30), "tomorrow 5pm", at(2025, time.October, 26, 17, 0)},
+		{"next sun over fall back", at(2025, time.October, 20, 8, 0), "next sun", at(2025, time.October, 26, 0, 0)},
+		{"in a week over fall back", at(2025, time.October, 20, 8, 0), "in 1 week", at(2025, time.October, 27, 0, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDate(tt.s, tt.now)
+			if err != nil {
+				t.Fatalf("ParseDate(%q) error = %v", tt.s, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDate(%q) = %s, want %s", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDateRejects(t *testing.T) {
+	inZone(t, "UTC")
+	now := time.Date(2025, time.March, 12, 15, 30, 0, 0, time.UTC)
+	tests := []struct {
+		s   string
+		err string
+	}{
+		{"", "no date given"},
+		{"someday", "not a date"},
+		{"next", "not a date"},
+		{"next week", "not a date"},
+		{"in 3 fortnights", "unknown unit"},
+		{"in three days", "not a number"},
+		{"in -2 days", "not a number"},
+		{"2025-02-30", "not a date"},
+		{"tomorrow 13pm", "not a date"},
+		{"tomorrow 25:00", "not a date"},
+		{"tomorrow 9:75", "not a date"},
+	}
+	for _, tt := range tests {
+		_, err := ParseDate(tt.s, now)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("ParseDate(%q) error = %v, want one containing %q", tt.s, err, tt.err)
+		}
+	}
+}
+
+func TestRelativeDue(t *testing.T) {
+	loc := inZone(t, "Europe/Berlin")
+	at := func(y int, m time.Month, d, hour, minute, second int) time.Time {
+		return time.Date(y, m, d, hour, minute, second, 0, loc)
+	}
+	moment := func(t time.Time) Date { return Date{Time: t} }
+	// A Tuesday morning.
+	now := at(2026, time.March, 10, 9, 30, 0)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		due  Date
+		want string
+	}{
+		{"now", now, moment(now), "now"},
+		{"in under a minute", now, moment(now.Add(59 * time.Second)), "now"},
+		{"under a minute ago", now, moment(now.Add(-59 * time.Second)), "now"},
+		{"a minute ago", now, moment(now.Add(-time.Minute)), "1m overdue"},
+		{"minutes overdue", now, moment(now.Add(-59*time.Minute - 59*time.Second)), "59m overdue"},
+		{"hours overdue", now, moment(now.Add(-2*time.Hour - 59*time.Minute)), "2h overdue"},
+		{"since midnight", now, moment(at(2026, time.March, 10, 0, 0, 0)), "9h overdue"},
+		{"yesterday late", now, moment(at(2026, time.March, 9, 23, 59, 0)), "1d overdue"},
+		{"a minute from now", now, moment(now.Add(time.Minute)), "today 09:31"},
+		{"later today", now, moment(at(2026, time.March, 10, 17, 0, 0)), "today 17:00"},
+
+		{"today all day", now, Day(now), "today"},
+		{"today all day, late", at(2026, time.March, 10, 23, 59, 59), Day(now), "today"},
+		{"yesterday all day", now, Day(now.AddDate(0, 0, -1)), "1d overdue"},
+		{"last week all day", now, Day(now.AddDate(0, 0, -7)), "7d overdue"},
+
+		// Tomorrow lasts right up to its midnight, and starts right after
+		// today's.
+		{"tomorrow all day", now, Day(now.AddDate(0, 0, 1)), "tomorrow"},
+		{"tomorrow just before midnight", now, moment(at(2026, time.March, 11, 23, 59, 0)), "tomorrow 23:59"},
+		{"tomorrow at its midnight", at(2026, time.March, 10, 0, 0, 0), moment(at(2026, time.March, 11, 0, 0, 0)), "tomorrow 00:00"},
+		{"tomorrow from just before midnight", at(2026, time.March, 10, 23, 58, 0), moment(at(2026, time.March, 11, 23, 59, 0)), "tomorrow 23:59"},
+		{"the day after from just before midnight", at(2026, time.March, 10, 23, 59, 0), moment(at(2026, time.March, 12, 0, 0, 0)), "in 2d"},
+		{"just after midnight", at(2026, time.March, 10, 23, 59, 0), moment(at(2026, time.March, 11, 0, 5, 0)), "tomorrow 00:05"},
+
+		// A week ahead is the cutoff.
+		{"in two days", now, Day(now.AddDate(0, 0, 2)), "in 2d"},
+		{"in six days", now, Day(now.AddDate(0, 0, relativeDueDays)), "in 6d"},
+		{"in six days, late", now, moment(at(2026, time.March, 16, 23, 59, 0)), "in 6d"},
+		{"in a week", now, Day(now.AddDate(0, 0, relativeDueDays+1)), "2026-03-17"},
+		{"in a week, early", now, moment(at(2026, time.March, 17, 0, 0, 0)), "2026-03-17 00:00"},
+
+		// Days are counted on the calendar, over the change to summer time
+		// on March 29.
+		{"over the change to summer time", at(2026, time.March, 28, 23, 0, 0), Day(at(2026, time.March, 30, 12, 0, 0)), "in 2d"},
+		{"overdue over the change", at(2026, time.March, 30, 0, 30, 0), Day(at(2026, time.March, 28, 12, 0, 0)), "2d overdue"},
+	}
+	for _, tt := range tests {
+		if got := RelativeDue(tt.due, tt.now); got != tt.want {
+			t.Errorf("%s: RelativeDue(%s) at %s = %q, want %q", tt.name, FormatDue(tt.due), tt.now.Format(DueTimeLayout), got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultRule gives a new item a due date, a priority or both, if the item
+// has Tag, is created in List, or both. Rules only ever fill in what an item
+// is created without; existing items are never changed by them.
+type DefaultRule struct {
+	// Name of the rule, shown where it gave an item something.
+	Name string
+
+	// Tag the item must have, without the "#", and the list it must be
+	// created in, by workspace name. Empty matches any.
+	Tag  string
+	List string
+
+	// When the item is due, as ParseDate reads it relative to when the item
+	// is created, such as "in 7 days" or "fri". Empty sets none.
+	Due string
+
+	// Priority to give the item. Nil sets none.
+	Priority *Priority
+}
+
+// specificity is how many conditions the rule has.
+func (r DefaultRule) specificity() int {
+	n := 0
+	if r.Tag != "" {
+		n++
+	}
+	if r.List != "" {
+		n++
+	}
+	return n
+}
+
+func (r DefaultRule) matches(item Item, list string) bool {
+	if r.List != "" && r.List != list {
+		return false
+	}
+	return r.Tag == "" || slices.ContainsFunc(item.Tags, func(tag string) bool { return strings.EqualFold(tag, r.Tag) })
+}
+
+// DefaultRules are the rules new items are created with, in the order they
+// apply, see NewDefaultRules.
+type DefaultRules []DefaultRule
+
+// NewDefaultRules checks rules and puts them in the order they apply: rules
+// with both a tag and a list first, then those with one of them, then those
+// with neither, and rules alike in that by name.
+func NewDefaultRules(rules []DefaultRule) (DefaultRules, error) {
+	for _, r := range rules {
+		if r.Due == "" && r.Priority == nil {
+			return nil, fmt.Errorf("rule %s sets neither due nor priority", r.Name)
+		}
+		if r.Due != "" {
+			if _, err := ParseDate(r.Due, time.Now()); err != nil {
+				return nil, fmt.Errorf("rule %s: due %w", r.Name, err)
+			}
+		}
+	}
+	sorted := slices.Clone(rules)
+	slices.SortStableFunc(sorted, func(a, b DefaultRule) int {
+		if c := cmp.Compare(b.specificity(), a.specificity()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return sorted, nil
+}
+
+// Given says which of the fields rules set the user gave a new item
+// themselves. Those are left alone, even where what was given is the zero
+// value, like "!none" typed in the add screen.
+type Given struct {
+	Due      bool
+	Priority bool
+}
+
+// GivenIn returns what item has already, for items made without the user
+// saying what they left out, as by an import.
+func GivenIn(item Item) Given {
+	return Given{Due: item.Due != nil, Priority: item.Priority != PriorityNone}
+}
+
+// Defaulted names the rules that gave an item its due date and priority, ""
+// where none did.
+type Defaulted struct {
+	Due      string
+	Priority string
+}
+
+// Any reports whether a rule set anything.
+func (d Defaulted) Any() bool {
+	return d.Due != "" || d.Priority != ""
+}
+
+// Apply returns item, about to be created in list at now, with what the
+// rules give it, and which rules did. Each field not given is set by the
+// first rule that matches and sets it, in the order of NewDefaultRules.
+func (rules DefaultRules) Apply(item Item, list string, given Given, now time.Time) (Item, Defaulted) {
+	var d Defaulted
+	for _, r := range rules {
+		if !r.matches(item, list) {
+			continue
+		}
+		if r.Due != "" && !given.Due && d.Due == "" {
+			if due, err := ParseDue(r.Due, now); err == nil {
+				item.Due = &due
+				d.Due = r.Name
+			}
+		}
+		if r.Priority != nil && !given.Priority && d.Priority == "" {
+			item.Priority = *r.Priority
+			d.Priority = r.Name
+		}
+	}
+	return item, d
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func priority(p Priority) *Priority { return &p }
+
+func TestNewDefaultRules(t *testing.T) {
+	rules, err := NewDefaultRules([]DefaultRule{
+		{Name: "everything", Priority: priority(PriorityLow)},
+		{Name: "work", Tag: "work", Due: "in 7 days"},
+		{Name: "office", List: "office", Due: "fri"},
+		{Name: "urgent work", Tag: "work", List: "office", Priority: priority(PriorityHigh)},
+		{Name: "errands", Tag: "errands", Due: "sat"},
+		{Name: "anything", Priority: priority(PriorityMedium)},
+	})
+	if err != nil {
+		t.Fatalf("NewDefaultRules() error = %v", err)
+	}
+	var names []string
+	for _, r := range rules {
+		names = append(names, r.Name)
+	}
+	// Both conditions, then one of them, then none; by name among equals.
+	want := []string{"urgent work", "errands", "office", "work", "anything", "everything"}
+	if !slices.Equal(names, want) {
+		t.Errorf("rules apply in the order %q, want %q", names, want)
+	}
+
+	for _, tt := range []struct {
+		rule DefaultRule
+		err  string
+	}{
+		{DefaultRule{Name: "empty", Tag: "work"}, "rule empty sets neither due nor priority"},
+		{DefaultRule{Name: "typo", Due: "tomorow"}, "rule typo: due"},
+	} {
+		if _, err := NewDefaultRules([]DefaultRule{tt.rule}); err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("NewDefaultRules(%s) error = %v, want %q", tt.rule.Name, err, tt.err)
+		}
+	}
+}
+
+func TestDefaultRulesApply(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 9, 30, 0, 0, time.Local) // a Tuesday
+	rules, err := NewDefaultRules([]DefaultRule{
+		{Name: "everything", Priority: priority(PriorityLow)},
+		{Name: "work", Tag: "work", Due: "in 7 days", Priority: priority(PriorityMedium)},
+		{Name: "office", List: "office", Due: "fri"},
+		{Name: "urgent work", Tag: "work", List: "office", Due: "tomorrow", Priority: priority(PriorityHigh)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := func(d int) *Date {
+		return &Date{Time: time.Date(2026, time.March, d, 0, 0, 0, 0, time.Local), AllDay: true}
+	}
+	given := day(20)
+
+	tests := []struct {
+		name     string
+		tags     []string
+		list     string
+		item     Item
+		given    Given
+		due      *Date
+		priority Priority
+		by       Defaulted
+	}{
+		{"tag and list", []string{"work"}, "office", Item{}, Given{}, day(11), PriorityHigh, Defaulted{"urgent work", "urgent work"}},
+		{"tag in another list", []string{"work"}, "home", Item{}, Given{}, day(17), PriorityMedium, Defaulted{"work", "work"}},
+		{"tag in another case", []string{"Work"}, "home", Item{}, Given{}, day(17), PriorityMedium, Defaulted{"work", "work"}},
+		{"list without the tag", []string{"errands"}, "office", Item{}, Given{}, day(13), PriorityLow, Defaulted{"office", "everything"}},
+		{"neither", nil, "home", Item{}, Given{}, nil, PriorityLow, Defaulted{"", "everything"}},
+		{"due given", []string{"work"}, "office", Item{Due: given}, Given{Due: true}, given, PriorityHigh, Defaulted{"", "urgent work"}},
+		// "!none" typed in the add screen is no priority, given.
+		{"no priority given", []string{"work"}, "office", Item{}, Given{Priority: true}, day(11), PriorityNone, Defaulted{"urgent work", ""}},
+		{"no due given", nil, "office", Item{}, Given{Due: true}, nil, PriorityLow, Defaulted{"", "everything"}},
+		{"both given", []string{"work"}, "office", Item{Due: given, Priority: PriorityLow}, Given{Due: true, Priority: true}, given, PriorityLow, Defaulted{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := tt.item
+			item.ItemTitle = "file the report"
+			item.Tags = tt.tags
+			got, by := rules.Apply(item, tt.list, tt.given, now)
+			if (got.Due == nil) != (tt.due == nil) || got.Due != nil && (!got.Due.Equal(tt.due.Time) || got.Due.AllDay != tt.due.AllDay) {
+				t.Errorf("Apply() is due %v, want %v", got.Due, tt.due)
+			}
+			if got.Priority != tt.priority {
+				t.Errorf("Apply() has priority %v, want %v", got.Priority, tt.priority)
+			}
+			if by != tt.by {
+				t.Errorf("Apply() was defaulted by %+v, want %+v", by, tt.by)
+			}
+			if by.Any() != (tt.by != Defaulted{}) {
+				t.Errorf("Any() = %t for %+v", by.Any(), by)
+			}
+			if got.Title() != item.Title() || !slices.Equal(got.Tags, item.Tags) {
+				t.Errorf("Apply() changed the item to %q %v", got.Title(), got.Tags)
+			}
+		})
+	}
+}
+
+func TestGivenIn(t *testing.T) {
+	due := Day(time.Date(2026, time.March, 20, 0, 0, 0, 0, time.Local))
+	tests := []struct {
+		item Item
+		want Given
+	}{
+		{Item{}, Given{}},
+		{Item{Due: &due}, Given{Due: true}},
+		{Item{Priority: PriorityLow}, Given{Priority: true}},
+		{Item{Due: &due, Priority: PriorityHigh}, Given{Due: true, Priority: true}},
+	}
+	for _, tt := range tests {
+		if got := GivenIn(tt.item); got != tt.want {
+			t.Errorf("GivenIn(%v, %v) = %+v, want %+v", tt.item.Due, tt.item.Priority, got, tt.want)
+		}
+	}
+}
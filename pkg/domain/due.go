@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// DueLayout is how due dates are written on the command line and shown.
+const DueLayout = "2006-01-02"
+
+// ShiftDate moves t by months and then days on the calendar, keeping the time
+// of day in t's location across DST changes. Moving by months clamps to the
+// end of shorter months, so Jan 31 plus one month is Feb 28 (or 29), not
+// Mar 3.
+func ShiftDate(t time.Time, months, days int) time.Time {
+	if months != 0 {
+		y, m, d := t.Date()
+		lastDay := time.Date(y, m+time.Month(months)+1, 0, 0, 0, 0, 0, t.Location()).Day()
+		t = time.Date(y, m+time.Month(months), min(d, lastDay), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	}
+	return t.AddDate(0, 0, days)
+}
+
+// Date is when an item is due or to be followed up on: a moment, or with
+// AllDay a whole day. A whole day is kept as midnight and only its year, month
+// and day count, so it's the same day in every timezone. It's stored as a
+// civil date, "2006-01-02", and read as midnight local time; a moment is
+// stored in UTC, and one at midnight stays a moment.
+type Date struct {
+	time.Time
+
+	// Whether the date has no time of day and stands for the whole day.
+	AllDay bool
+}
+
+// Day returns the whole day that t falls on in the local timezone.
+func Day(t time.Time) Date {
+	y, m, d := t.Local().Date()
+	return Date{Time: time.Date(y, m, d, 0, 0, 0, 0, time.Local), AllDay: true}
+}
+
+// Local returns the date in the local timezone. Unlike time.Time's, for a
+// whole day that's its midnight there, whichever timezone the day was set in.
+func (d Date) Local() time.Time {
+	if d.AllDay {
+		y, m, day := d.Date()
+		return time.Date(y, m, day, 0, 0, 0, 0, time.Local)
+	}
+	return d.Time.Local()
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.AllDay {
+		return json.Marshal(d.Format(DueLayout))
+	}
+	return d.UTC().MarshalJSON()
+}
+
+// UnmarshalJSON reads a civil date as a whole day and a moment as it is.
+// Lists saved before dates were stored as civil dates have whole days as
+// midnight with the offset of the timezone they were set in, which is read as
+// that day too.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if t, err := time.ParseInLocation(DueLayout, s, time.Local); err == nil {
+		*d = Date{Time: t, AllDay: true}
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return err
+	}
+	if _, offset := t.Zone(); offset != 0 && t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		*d = Date{Time: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local), AllDay: true}
+		return nil
+	}
+	*d = Date{Time: t}
+	return nil
+}
+
+// DueBy returns the moment a due date passes, in the local timezone. A whole
+// day lasts until the end of that day.
+func DueBy(due Date) time.Time {
+	t := due.Local()
+	if due.AllDay {
+		return ShiftDate(t, 0, 1)
+	}
+	return t
+}
+
+// Overdue reports whether the item is still open and its due date has passed
+// at now.
+func (i Item) Overdue(now time.Time) bool {
+	return i.Due != nil && !i.Completed() && !now.Before(DueBy(*i.Due))
+}
+
+// DueToday reports whether the item is still open and due later on now's
+// day, in the local timezone.
+func (i Item) DueToday(now time.Time) bool {
+	return i.Due != nil && !i.Completed() && !i.Overdue(now) && sameLocalDay(i.Due.Local(), now)
+}
+
+func sameLocalDay(a, b time.Time) bool {
+	ay, am, ad := a.Local().Date()
+	by, bm, bd := b.Local().Date()
+	return ay == by && am == bm && ad == bd
+}
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDateJSON(t *testing.T) {
+	loc := inZone(t, "Europe/Berlin")
+	tests := []struct {
+		name string
+		date Date
+		json string
+	}{
+		{"whole day", Day(time.Date(2025, time.March, 13, 15, 0, 0, 0, loc)), `"2025-03-13"`},
+		{"moment", Date{Time: time.Date(2025, time.March, 13, 17, 30, 0, 0, loc)}, `"2025-03-13T16:30:00Z"`},
+		{"moment at midnight", Date{Time: time.Date(2025, time.March, 13, 0, 0, 0, 0, loc)}, `"2025-03-12T23:00:00Z"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.date)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != tt.json {
+				t.Errorf("Marshal() = %s, want %s", data, tt.json)
+			}
+			var back Date
+			if err := json.Unmarshal(data, &back); err != nil {
+				t.Fatal(err)
+			}
+			if back.AllDay != tt.date.AllDay || !back.Equal(tt.date.Time) {
+				t.Errorf("read back as %s, all day %t; want %s, all day %t", back.Time, back.AllDay, tt.date.Time, tt.date.AllDay)
+			}
+		})
+	}
+
+	// Lists saved before whole days were stored as civil dates have them as
+	// midnight in the timezone they were set in.
+	var legacy Date
+	if err := json.Unmarshal([]byte(`"2025-03-13T00:00:00-05:00"`), &legacy); err != nil {
+		t.Fatal(err)
+	}
+	if !legacy.AllDay || FormatDue(legacy) != "2025-03-13" {
+		t.Errorf("legacy date read as %s, all day %t; want the whole of 2025-03-13", legacy.Time, legacy.AllDay)
+	}
+}
+
+func TestDateAcrossZones(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		// How the moment at midnight in from is shown in to.
+		midnight string
+	}{
+		{"west to east", "America/New_York", "Asia/Tokyo", "2025-03-13 13:00"},
+		{"east to west", "Asia/Tokyo", "America/New_York", "2025-03-12 11:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from := inZone(t, tt.from)
+			day := Day(time.Date(2025, time.March, 13, 9, 0, 0, 0, from))
+			moment := Date{Time: time.Date(2025, time.March, 13, 0, 0, 0, 0, from)}
+			dayJSON, _ := json.Marshal(day)
+			momentJSON, _ := json.Marshal(moment)
+
+			to := inZone(t, tt.to)
+
+			// Held in memory while the zone changes, the day stays the
+			// same and is still saved as that day.
+			if got := FormatDue(day); got != "2025-03-13" {
+				t.Errorf("day in memory shown as %s", got)
+			}
+			if data, _ := json.Marshal(day); string(data) != string(dayJSON) {
+				t.Errorf("day in memory saved as %s, want %s", data, dayJSON)
+			}
+
+			var readDay, readMoment Date
+			if err := json.Unmarshal(dayJSON, &readDay); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(momentJSON, &readMoment); err != nil {
+				t.Fatal(err)
+			}
+			for _, d := range []Date{day, readDay} {
+				if want := time.Date(2025, time.March, 14, 0, 0, 0, 0, to); !DueBy(d).Equal(want) {
+					t.Errorf("day passes at %s, want the end of 2025-03-13 here, %s", DueBy(d), want)
+				}
+			}
+
+			// The moment is the same moment, at the time it is here.
+			if readMoment.AllDay || !readMoment.Equal(moment.Time) {
+				t.Errorf("moment read as %s, all day %t; want %s", readMoment.Time, readMoment.AllDay, moment.Time)
+			}
+			if got := FormatDue(readMoment); got != tt.midnight {
+				t.Errorf("moment shown as %s, want %s", got, tt.midnight)
+			}
+			if !DueBy(readMoment).Equal(moment.Time) {
+				t.Errorf("moment passes at %s, want %s", DueBy(readMoment), moment.Time)
+			}
+		})
+	}
+}
+
+func TestParseDue(t *testing.T) {
+	loc := inZone(t, "Europe/Berlin")
+	now := time.Date(2025, time.March, 12, 15, 30, 0, 0, loc)
+	tests := []struct {
+		s         string
+		allDay    bool
+		formatted string
+		relative  string
+	}{
+		{"tomorrow", true, "2025-03-13", "tomorrow"},
+		{"tomorrow 12am", false, "2025-03-13 00:00", "tomorrow 00:00"},
+		{"tomorrow 00:00", false, "2025-03-13 00:00", "tomorrow 00:00"},
+		{"2025-03-20", true, "2025-03-20", "2025-03-20"},
+		{"fri 9:30am", false, "2025-03-14 09:30", "in 2d"},
+	}
+	for _, tt := range tests {
+		d, err := ParseDue(tt.s, now)
+		if err != nil {
+			t.Fatalf("ParseDue(%q) error = %v", tt.s, err)
+		}
+		if d.AllDay != tt.allDay || FormatDue(d) != tt.formatted {
+			t.Errorf("ParseDue(%q) = %s, all day %t; want %s, all day %t", tt.s, FormatDue(d), d.AllDay, tt.formatted, tt.allDay)
+		}
+		if got := RelativeDue(d, now); got != tt.relative {
+			t.Errorf("RelativeDue(%q) = %q, want %q", tt.s, got, tt.relative)
+		}
+	}
+}
+
+func TestDueAtMidnight(t *testing.T) {
+	loc := inZone(t, "Europe/Berlin")
+	midnight := time.Date(2025, time.March, 13, 0, 0, 0, 0, loc)
+	now := midnight.Add(30 * time.Minute)
+
+	timed := Item{Due: &Date{Time: midnight}}
+	if !timed.Overdue(now) {
+		t.Error("a task due at midnight isn't overdue half an hour later")
+	}
+	if got := RelativeDue(*timed.Due, now); got != "30m overdue" {
+		t.Errorf("RelativeDue() = %q, want 30m overdue", got)
+	}
+
+	allDay := Item{Due: &Date{Time: midnight, AllDay: true}}
+	if allDay.Overdue(now) || !allDay.DueToday(now) {
+		t.Error("a task due today is overdue, or not due today, half an hour into the day")
+	}
+	if got := RelativeDue(*allDay.Due, now); got != "today" {
+		t.Errorf("RelativeDue() = %q, want today", got)
+	}
+}
@@ -0,0 +1,256 @@
+package domain
+
+import (
+	"clitodo/pkg/fold"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeTitle folds case and collapses runs of whitespace, so titles that
+// only differ in those, or in how their accents are encoded, compare equal.
+func NormalizeTitle(title string) string {
+	return strings.Join(strings.Fields(fold.Folder{}.String(norm.NFC.String(title))), " ")
+}
+
+// SimilarTitles reports whether a and b probably name the same task: equal
+// once normalized, or a few typos apart. Short titles have to match exactly,
+// since one letter makes a different word there, and titles whose numbers
+// differ never match, so "chapter 3" and "chapter 4" stay apart.
+func SimilarTitles(a, b string) bool {
+	return newTitleKey(a).similar(newTitleKey(b))
+}
+
+// titleKey is a title prepared for comparing.
+type titleKey struct {
+	runes []rune
+	// The title's numbers, which have to be the same.
+	numbers string
+}
+
+func newTitleKey(title string) titleKey {
+	runes := []rune(NormalizeTitle(title))
+	return titleKey{runes: runes, numbers: strings.Join(numbers(runes), " ")}
+}
+
+func (a titleKey) similar(b titleKey) bool {
+	if a.numbers != b.numbers {
+		return false
+	}
+	if slices.Equal(a.runes, b.runes) {
+		return true
+	}
+	limit := allowedEdits(min(len(a.runes), len(b.runes)))
+	if limit == 0 || abs(len(a.runes)-len(b.runes)) > limit {
+		return false
+	}
+	return editDistance(a.runes, b.runes, limit) <= limit
+}
+
+// allowedEdits is how many typos a title of n runes may hold and still match.
+func allowedEdits(n int) int {
+	switch {
+	case n < 6: //nolint:mnd
+		return 0
+	case n < 16: //nolint:mnd
+		return 1
+	}
+	return maxEdits
+}
+
+// maxEdits is the most typos any two similar titles are apart.
+const maxEdits = 2
+
+// numbers returns the runs of digits in s.
+func numbers(s []rune) []string {
+	var runs []string
+	start := -1
+	for i := 0; i <= len(s); i++ {
+		digit := i < len(s) && unicode.IsDigit(s[i])
+		switch {
+		case digit && start < 0:
+			start = i
+		case !digit && start >= 0:
+			runs = append(runs, string(s[start:i]))
+			start = -1
+		}
+	}
+	return runs
+}
+
+// editDistance returns the edit distance between a and b, counting two
+// swapped neighbours as one typo, or limit+1 as soon as it's clear the
+// distance is larger than limit.
+func editDistance(a, b []rune, limit int) int {
+	before := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		best := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				cur[j] = min(cur[j], before[j-2]+1)
+			}
+			best = min(best, cur[j])
+		}
+		if best > limit {
+			return limit + 1
+		}
+		before, prev, cur = prev, cur, before
+	}
+	return prev[len(b)]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// DuplicateClusters groups the open top-level items of items whose titles are
+// similar, see SimilarTitles. Titles similar to a similar title end up in the
+// same cluster. Pairs for which dismissed returns true aren't linked. Each
+// cluster holds indices into items in list order, and clusters are ordered by
+// their first item. Items put aside for some day are left out.
+func DuplicateClusters(items []Item, dismissed func(a, b string) bool) [][]int {
+	type candidate struct {
+		index int
+		key   titleKey
+	}
+	var candidates []candidate
+	for i, item := range items {
+		if item.Depth == 0 && !item.Completed() && !item.Someday {
+			candidates = append(candidates, candidate{i, newTitleKey(item.Title())})
+		}
+	}
+
+	// Union-find over the candidates, the root being the earliest item.
+	parent := make(map[int]int, len(candidates))
+	var root func(i int) int
+	root = func(i int) int {
+		if parent[i] != i {
+			parent[i] = root(parent[i])
+		}
+		return parent[i]
+	}
+	for _, c := range candidates {
+		parent[c.index] = c.index
+	}
+
+	// Titles of very different lengths can't be similar, so sorted by
+	// length each one is only compared to its neighbours.
+	byLength := slices.Clone(candidates)
+	slices.SortStableFunc(byLength, func(a, b candidate) int { return len(a.key.runes) - len(b.key.runes) })
+	for x, a := range byLength {
+		for _, b := range byLength[x+1:] {
+			if len(b.key.runes)-len(a.key.runes) > maxEdits {
+				break
+			}
+			if !a.key.similar(b.key) || dismissed != nil && dismissed(items[a.index].ID, items[b.index].ID) {
+				continue
+			}
+			ra, rb := root(a.index), root(b.index)
+			if ra != rb {
+				parent[max(ra, rb)] = min(ra, rb)
+			}
+		}
+	}
+
+	members := make(map[int][]int)
+	for _, c := range candidates {
+		r := root(c.index)
+		members[r] = append(members[r], c.index)
+	}
+	var clusters [][]int
+	for _, c := range candidates {
+		if cluster := members[c.index]; len(cluster) > 1 {
+			clusters = append(clusters, cluster)
+		}
+	}
+	return clusters
+}
+
+// Merge combines two items that turned out to be the same task into one. The
+// result is into with what from adds:
+//
+//   - notes are joined, unless from's are empty or already part of into's
+//   - tags are combined, into's first
+//   - the earlier due date, the higher priority and the larger estimate win
+//   - it was created when the earlier one was and touched when the later one
+//     was
+//   - waiting and recurrence are taken from from if into has none
+//   - from's subtasks follow into's
+//
+// The ID, title and completion stay into's.
+func Merge(into, from Item) Item {
+	if from.Notes != "" && !strings.Contains(into.Notes, from.Notes) {
+		if into.Notes == "" {
+			into.Notes = from.Notes
+		} else {
+			into.Notes += "\n\n" + from.Notes
+		}
+	}
+
+	into.Tags = slices.Clone(into.Tags)
+	for _, tag := range from.Tags {
+		if !slices.Contains(into.Tags, tag) {
+			into.Tags = append(into.Tags, tag)
+		}
+	}
+
+	if from.Due != nil && (into.Due == nil || from.Due.Before(into.Due.Time)) {
+		into.Due = from.Due
+	}
+	into.Priority = max(into.Priority, from.Priority)
+	into.Estimate = max(into.Estimate, from.Estimate)
+	if from.CreatedAt != nil && (into.CreatedAt == nil || from.CreatedAt.Before(*into.CreatedAt)) {
+		into.CreatedAt = from.CreatedAt
+	}
+	if from.TouchedAt != nil && (into.TouchedAt == nil || from.TouchedAt.After(*into.TouchedAt)) {
+		into.TouchedAt = from.TouchedAt
+	}
+	if into.Waiting == nil {
+		into.Waiting = from.Waiting
+	}
+	if into.Recurrence == nil {
+		into.Recurrence = from.Recurrence
+	}
+	into.Children = append(slices.Clone(into.Children), from.Children...)
+	return into
+}
+
+// MergeItems merges the top-level items with the given IDs into the first of
+// them, which keeps its place, and returns the list without the others. items
+// is a tree as returned by Nest; the merged-away items are returned too.
+func MergeItems(items []Item, ids []string) (merged []Item, removed []Item) {
+	items = slices.Clone(items)
+	keep := slices.IndexFunc(items, func(item Item) bool { return item.ID == ids[0] })
+	if keep < 0 {
+		return items, nil
+	}
+	merged = make([]Item, 0, len(items))
+	for i, item := range items {
+		if i != keep && slices.Contains(ids[1:], item.ID) {
+			items[keep] = Merge(items[keep], item)
+			removed = append(removed, item)
+		}
+	}
+	for i, item := range items {
+		if i == keep || !slices.Contains(ids[1:], item.ID) {
+			merged = append(merged, item)
+		}
+	}
+	return merged, removed
+}
@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSimilarTitles(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"pay the rent", "pay the rent", true},
+		{"Pay  the rent ", "pay the rent", true},
+		{"café order", "cafe\u0301 order", true},
+
+		// Under six runes only exact matches count.
+		{"milk", "silk", false},
+		{"apple", "appel", false},
+		{"apple", "Apple", true},
+
+		// From six to fifteen runes one typo is allowed.
+		{"banana", "banans", true},
+		{"banana", "bonans", false},
+		{"buy groceries", "buy grocereis", true},
+		{"water the plant", "watr the plant", true},
+		{"water the plant", "watr the plnt", false},
+
+		// From sixteen runes on, two.
+		{"water the plants", "wtaer the plantz", true},
+		{"water the plants", "wtaer the plnatz", false},
+		{"renew the passport", "renew passport", false},
+
+		// The shorter title sets the limit.
+		{"call the bank", "call the bank now", false},
+
+		// Numbers have to be the same.
+		{"read chapter 3", "read chapter 4", false},
+		{"read chapter 12", "read chapter 1", false},
+		{"read chapter 12", "raed chapter 12", true},
+		{"2024 taxes", "taxes", false},
+	}
+	for _, tt := range tests {
+		if got := SimilarTitles(tt.a, tt.b); got != tt.want {
+			t.Errorf("SimilarTitles(%q, %q) = %t, want %t", tt.a, tt.b, got, tt.want)
+		}
+		if got := SimilarTitles(tt.b, tt.a); got != tt.want {
+			t.Errorf("SimilarTitles(%q, %q) = %t, want %t", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestDuplicateClusters(t *testing.T) {
+	titles := []string{
+		"renew passport",
+		"water the plants",
+		"renew pasport",
+		"renew passport",
+		"water the plant",
+		"renew passport",
+		"renew passpor",
+		"call mum",
+	}
+	items := make([]Item, len(titles))
+	for i, title := range titles {
+		items[i] = NewItem(title)
+	}
+	items[3].ItemCompleted = true
+	items[5].Depth = 1
+
+	// "renew pasport" and "renew passpor" are two typos apart, but both
+	// only one from "renew passport", so all three end up together.
+	want := [][]int{{0, 2, 6}, {1, 4}}
+	if got := DuplicateClusters(items, nil); !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("DuplicateClusters() = %v, want %v", got, want)
+	}
+
+	dismissed := func(a, b string) bool {
+		pair := []string{a, b}
+		return slices.Contains(pair, items[1].ID) && slices.Contains(pair, items[4].ID)
+	}
+	want = [][]int{{0, 2, 6}}
+	if got := DuplicateClusters(items, dismissed); !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("DuplicateClusters() with a dismissed pair = %v, want %v", got, want)
+	}
+
+	items[4].Someday = true
+	items[6].ItemCompleted = true
+	want = [][]int{{0, 2}}
+	if got := DuplicateClusters(items, nil); !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("DuplicateClusters() = %v, want %v", got, want)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	earlier := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+	later := earlier.Add(48 * time.Hour)
+
+	into := NewItem("renew passport")
+	into.Notes = "bring photos"
+	into.Tags = []string{"errands"}
+	into.Due = &Date{Time: later}
+	into.Priority = PriorityLow
+	into.Estimate = Duration(time.Hour)
+	into.CreatedAt = &later
+	into.TouchedAt = &earlier
+	into.Children = []Item{NewItem("find the old one")}
+
+	from := NewItem("renew pasport")
+	from.ItemCompleted = true
+	from.Notes = "book an appointment"
+	from.Tags = []string{"travel", "errands"}
+	from.Due = &Date{Time: earlier}
+	from.Priority = PriorityHigh
+	from.Estimate = Duration(30 * time.Minute)
+	from.CreatedAt = &earlier
+	from.TouchedAt = &later
+	from.Waiting = &Waiting{On: "the embassy"}
+	from.Recurrence = &Recurrence{Every: 10, Unit: Months}
+	from.Children = []Item{NewItem("take photos")}
+
+	got := Merge(into, from)
+	if got.ID != into.ID || got.Title() != "renew passport" || got.Completed() {
+		t.Errorf("Merge() = %s %q completed %t, want into's ID, title and completion", got.ID, got.Title(), got.Completed())
+	}
+	if got.Notes != "bring photos\n\nbook an appointment" {
+		t.Errorf("notes = %q", got.Notes)
+	}
+	if !slices.Equal(got.Tags, []string{"errands", "travel"}) {
+		t.Errorf("tags = %q, want errands and travel", got.Tags)
+	}
+	if !got.Due.Equal(earlier) {
+		t.Errorf("due %s, want the earlier %s", got.Due, earlier)
+	}
+	if got.Priority != PriorityHigh || got.Estimate != Duration(time.Hour) {
+		t.Errorf("priority %v and estimate %s, want high and 1h", got.Priority, time.Duration(got.Estimate))
+	}
+	if !got.CreatedAt.Equal(earlier) || !got.TouchedAt.Equal(later) {
+		t.Errorf("created %s and touched %s, want created earlier and touched later", got.CreatedAt, got.TouchedAt)
+	}
+	if got.Waiting != from.Waiting || got.Recurrence != from.Recurrence {
+		t.Error("waiting and recurrence weren't taken from the merged item")
+	}
+	if len(got.Children) != 2 || got.Children[0].Title() != "find the old one" || got.Children[1].Title() != "take photos" {
+		t.Errorf("subtasks = %v, want into's then from's", got.Children)
+	}
+	if len(into.Tags) != 1 || len(into.Children) != 1 {
+		t.Error("Merge() changed into's tags or subtasks")
+	}
+
+	// Notes already there aren't added twice, and into's own waiting and
+	// recurrence stay.
+	into.Notes = "bring photos and book an appointment"
+	into.Waiting = &Waiting{On: "the photographer"}
+	into.Recurrence = &Recurrence{Every: 1, Unit: Weeks}
+	got = Merge(into, from)
+	if got.Notes != into.Notes {
+		t.Errorf("notes = %q, want them unchanged", got.Notes)
+	}
+	if got.Waiting != into.Waiting || got.Recurrence != into.Recurrence {
+		t.Error("into's waiting or recurrence was replaced")
+	}
+
+	// Into without notes takes from's.
+	into.Notes = ""
+	if got = Merge(into, from); got.Notes != from.Notes {
+		t.Errorf("notes = %q, want %q", got.Notes, from.Notes)
+	}
+}
+
+func TestMergeItems(t *testing.T) {
+	a := NewItem("call the bank")
+	b := NewItem("water the plants")
+	b.Children = []Item{NewItem("the ones upstairs")}
+	c := NewItem("call the bnak")
+	c.Tags = []string{"money"}
+	c.Children = []Item{NewItem("ask about the fee")}
+	d := NewItem("call teh bank")
+	items := []Item{a, b, c, d}
+
+	// Merging into a later item keeps it in its place.
+	merged, removed := MergeItems(items, []string{c.ID, a.ID, d.ID})
+	if len(merged) != 2 || merged[0].ID != b.ID || merged[1].ID != c.ID {
+		t.Fatalf("MergeItems() = %v, want b and the merged c", merged)
+	}
+	if len(removed) != 2 || removed[0].ID != a.ID || removed[1].ID != d.ID {
+		t.Errorf("removed %v, want a and d", removed)
+	}
+	if got := merged[1]; got.Title() != "call the bnak" || len(got.Children) != 1 || !slices.Equal(got.Tags, []string{"money"}) {
+		t.Errorf("merged item %q with %d subtasks and tags %q", got.Title(), len(got.Children), got.Tags)
+	}
+	if len(items) != 4 || items[2].ID != c.ID {
+		t.Error("MergeItems() changed the items passed in")
+	}
+
+	if merged, removed := MergeItems(items, []string{"gone", a.ID}); len(merged) != 4 || removed != nil {
+		t.Errorf("MergeItems() into a missing item = %d items and %v removed, want the list unchanged", len(merged), removed)
+	}
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDurationText(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{25 * time.Minute, "25m"},
+		{2*time.Hour + 15*time.Minute, "2h15m"},
+		{3 * time.Hour, "3h"},
+		{time.Hour + 30*time.Second, "1h0m30s"},
+		{45 * time.Second, "45s"},
+	}
+	for _, tt := range tests {
+		text, err := Duration(tt.d).MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(text) != tt.want {
+			t.Errorf("Duration(%s) = %q, want %q", tt.d, text, tt.want)
+		}
+		var back Duration
+		if err := back.UnmarshalText(text); err != nil || time.Duration(back) != tt.d {
+			t.Errorf("UnmarshalText(%q) = %s, %v; want %s", text, time.Duration(back), err, tt.d)
+		}
+	}
+}
+
+func TestDurationUnmarshalRejects(t *testing.T) {
+	for _, text := range []string{"", "soon", "3*25m", "1d"} {
+		var d Duration
+		if err := d.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("UnmarshalText(%q) = %s, want an error", text, time.Duration(d))
+		}
+	}
+}
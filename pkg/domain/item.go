@@ -1,11 +1,168 @@
 package domain
 
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Item is a task. Its JSON form is the storage format; the desc tags
+// document each key for the schema `clitodo schema` prints, see Schema.
 type Item struct {
-	ItemTitle     string `json:"name"`
-	ItemCompleted bool   `json:"completed"`
+	// Stable identifier, so an item can be found again after the list was
+	// filtered, reordered or reloaded.
+	ID string `json:"id,omitempty" desc:"Stable identifier, a UUID for items created by clitodo."`
+
+	// Stored as "title". Files from older versions have "name" instead,
+	// which is still read, see UnmarshalJSON.
+	ItemTitle     string `json:"title" desc:"What the task is."`
+	ItemCompleted bool   `json:"completed" desc:"Whether the task is checked off."`
+
+	// The board column the item was last moved to, empty for todo. See
+	// Status for how it combines with ItemCompleted.
+	ItemStatus Status `json:"status,omitempty" desc:"Board column the task was last moved to. Completed tasks are done whatever it says; missing means todo."`
+
+	// Free-form text that doesn't belong in the title.
+	Notes string `json:"notes,omitempty" desc:"Free-form text, may span several lines."`
+
+	// When the user last did something with the item. Nil for items stored
+	// before this was recorded.
+	TouchedAt *time.Time `json:"touched_at,omitempty" desc:"When the task was last acted on, in UTC."`
+
+	// When the item was created. Nil for items stored before this was
+	// recorded.
+	CreatedAt *time.Time `json:"created_at,omitempty" desc:"When the task was created, in UTC."`
+
+	// When the item was checked off. Nil while it's open.
+	CompletedAt *time.Time `json:"completed_at,omitempty" desc:"When the task was checked off, in UTC. Missing while it's open."`
+
+	// When the item is due, if it has a due date.
+	Due *Date `json:"due,omitempty" desc:"When the task is due: a date, 2006-01-02, for the whole day, or a moment in UTC."`
+
+	Priority Priority `json:"priority,omitempty" desc:"How urgent the task is. Missing means none."`
+
+	// How long the item is expected to take, 0 for no estimate.
+	Estimate Duration `json:"estimate,omitempty" desc:"How long the task is expected to take, such as 2h15m."`
+
+	// Tags without the leading "#".
+	Tags []string `json:"tags,omitempty" desc:"Tags without the leading #."`
+
+	// Set while the item is blocked on someone else.
+	Waiting *Waiting `json:"waiting,omitempty" desc:"Set while the task is blocked on someone or something else."`
+
+	// How often the item comes back once it's completed. Nil for one-off
+	// items.
+	Recurrence *Recurrence `json:"recurrence,omitempty" desc:"How often the task comes back once it's completed. Missing for one-off tasks."`
+
+	// When to remind about the item, and how often to repeat it until it's
+	// done. Completing the item ends the reminders.
+	Remind *Reminder `json:"remind,omitempty" desc:"When to remind about the task, and how often to repeat it until it's done."`
+
+	// Where the item goes among its siblings, compared as a string; see
+	// package position. Empty for items stored before positions existed.
+	Position string `json:"position,omitempty" desc:"Where the task goes among its siblings, compared as a string, so copies of the list merged together keep one order. Missing on tasks saved before positions existed."`
+
+	// Subtasks, saved nested in their parent.
+	Children []Item `json:"children,omitempty" desc:"Subtasks, in order."`
+
+	// Where the item sits while the tree is shown as a flat list, see
+	// Flatten. Neither is saved.
+	ParentID string `json:"-"`
+	Depth    int    `json:"-"`
+
+	// Set for items put aside in the someday file instead of the list. It
+	// isn't saved: the file the item was read from says it.
+	Someday bool `json:"-"`
+}
+
+func NewItem(title string) Item {
+	now := time.Now()
+	return Item{ID: NewID(), ItemTitle: title, TouchedAt: &now, CreatedAt: &now}
+}
+
+// NewID returns a new random item ID.
+func NewID() string {
+	return uuid.NewString()
+}
+
+func (i Item) Completed() bool { return i.ItemCompleted }
+func (i Item) Title() string   { return i.ItemTitle }
+
+// FilterValue is the title followed by the tags, the notes and the titles of
+// the subtasks, so filtering for "#work" finds the items tagged work and a
+// parent is found by what its subtasks are called.
+func (i Item) FilterValue() string {
+	v := i.ItemTitle
+	if len(i.Tags) != 0 {
+		v += " " + FormatTags(i.Tags)
+	}
+	if i.Notes != "" {
+		v += " " + i.Notes
+	}
+	for _, child := range i.Children {
+		v += " " + child.FilterValue()
+	}
+	return v
 }
 
-func NewItem(title string) Item    { return Item{ItemTitle: title} }
-func (i Item) Completed() bool     { return i.ItemCompleted }
-func (i Item) Title() string       { return i.ItemTitle }
-func (i Item) FilterValue() string { return i.ItemTitle }
+// MarshalJSON stores the item with its timestamps in UTC, so the file reads
+// the same whichever timezone it was saved in. Dates are stored as described
+// at Date.
+//
+// The title is also written under the legacy key "name", which is all the
+// previous release reads, so a list saved by this one can still be opened by
+// it. Stop writing it in the release after this one.
+func (i Item) MarshalJSON() ([]byte, error) {
+	type item Item
+	i.TouchedAt, i.CreatedAt, i.CompletedAt = utc(i.TouchedAt), utc(i.CreatedAt), utc(i.CompletedAt)
+	return json.Marshal(struct {
+		item
+		LegacyName string `json:"name"`
+	}{item(i), i.ItemTitle})
+}
+
+// UnmarshalJSON reads items in the current format and in that of older
+// versions, which stored the title as "name". If both keys are there,
+// "title" wins.
+func (i *Item) UnmarshalJSON(data []byte) error {
+	type item Item
+	var v struct {
+		item
+		Title      *string `json:"title"`
+		LegacyName *string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*i = Item(v.item)
+	switch {
+	case v.Title != nil:
+		i.ItemTitle = *v.Title
+	case v.LegacyName != nil:
+		i.ItemTitle = *v.LegacyName
+	}
+	return nil
+}
+
+func utc(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
+
+// Touch records that the user acted on the item at t.
+func (i *Item) Touch(t time.Time) { i.TouchedAt = &t }
+
+// SetCompleted checks the item off at t, or reopens it when done is false.
+// Checking it off ends its reminders.
+func (i *Item) SetCompleted(done bool, t time.Time) {
+	i.ItemCompleted = done
+	i.CompletedAt = nil
+	if done {
+		i.CompletedAt = &t
+		i.Remind = nil
+	}
+}
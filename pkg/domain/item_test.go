@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestItemUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{"current format", `{"id": "a", "title": "pay the rent", "completed": true}`, "pay the rent"},
+		{"older versions", `{"name": "pay the rent", "completed": true}`, "pay the rent"},
+		{"saved by this version", `{"id": "a", "title": "pay the rent", "completed": true, "name": "pay the rent"}`, "pay the rent"},
+		// Renamed by a version that only knows "title", which kept the
+		// stale "name" it didn't understand.
+		{"both keys differ", `{"name": "pay the bills", "title": "pay the rent", "completed": true}`, "pay the rent"},
+		{"empty title wins", `{"title": "", "name": "pay the rent", "completed": true}`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var item Item
+			if err := json.Unmarshal([]byte(tt.json), &item); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if item.Title() != tt.want || !item.Completed() {
+				t.Errorf("Unmarshal() = %q, completed %t; want %q, completed", item.Title(), item.Completed(), tt.want)
+			}
+		})
+	}
+}
+
+func TestItemMarshalJSON(t *testing.T) {
+	loc := inZone(t, "Europe/Berlin")
+	created := time.Date(2026, time.March, 10, 9, 30, 0, 0, loc)
+	tests := []struct {
+		name string
+		item Item
+		want map[string]any
+	}{
+		{
+			name: "title",
+			item: Item{ID: "a", ItemTitle: "pay the rent"},
+			want: map[string]any{"id": "a", "title": "pay the rent", "name": "pay the rent", "completed": false},
+		},
+		{
+			name: "empty title",
+			item: Item{ID: "a"},
+			want: map[string]any{"id": "a", "title": "", "name": "", "completed": false},
+		},
+		{
+			name: "timestamps in UTC",
+			item: Item{ID: "a", ItemTitle: "pay the rent", ItemCompleted: true, CreatedAt: &created},
+			want: map[string]any{"id": "a", "title": "pay the rent", "name": "pay the rent", "completed": true, "created_at": "2026-03-10T08:30:00Z"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.item)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got map[string]any
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("Marshal() = %s, want the keys of %v", data, tt.want)
+			}
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Errorf("Marshal() has %s = %v, want %v", key, got[key], want)
+				}
+			}
+
+			var back Item
+			if err := json.Unmarshal(data, &back); err != nil {
+				t.Fatal(err)
+			}
+			if back.Title() != tt.item.Title() || back.ID != tt.item.ID {
+				t.Errorf("read back as %q, %q; want %q, %q", back.ID, back.Title(), tt.item.ID, tt.item.Title())
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package domain
+
+import "regexp"
+
+var taskLinkPattern = regexp.MustCompile(`\[\[task:([^\]\s]+)\]\]`)
+
+// TaskLink is a [[task:ID]] reference to another item found in free text.
+// Start and End are byte offsets of the whole reference.
+type TaskLink struct {
+	ID    string
+	Start int
+	End   int
+}
+
+// FindTaskLinks returns the task references in text in order of appearance.
+func FindTaskLinks(text string) []TaskLink {
+	var links []TaskLink
+	for _, loc := range taskLinkPattern.FindAllStringSubmatchIndex(text, -1) {
+		links = append(links, TaskLink{
+			ID:    text[loc[2]:loc[3]],
+			Start: loc[0],
+			End:   loc[1],
+		})
+	}
+	return links
+}
+
+// TaskLinkTo returns the reference syntax for the item with the given ID.
+func TaskLinkTo(id string) string {
+	return "[[task:" + id + "]]"
+}
+
+// ReplaceTaskLinks returns text with every task reference replaced by the
+// result of render for its ID.
+func ReplaceTaskLinks(text string, render func(id string) string) string {
+	return taskLinkPattern.ReplaceAllStringFunc(text, func(ref string) string {
+		return render(taskLinkPattern.FindStringSubmatch(ref)[1])
+	})
+}
+
+// RemoveTaskLinks drops every reference to id from text, for when the linked
+// item is deleted and the user doesn't want to keep a dangling link.
+func RemoveTaskLinks(text, id string) string {
+	return ReplaceTaskLinks(text, func(ref string) string {
+		if ref == id {
+			return ""
+		}
+		return TaskLinkTo(ref)
+	})
+}
+
+// LinksTo reports whether text references any of the items with the given
+// IDs.
+func LinksTo(text string, ids map[string]bool) bool {
+	for _, link := range FindTaskLinks(text) {
+		if ids[link.ID] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFindTaskLinks(t *testing.T) {
+	text := "see [[task:a1]] and [[task:b2]], not [[task:]] or [[task:c 3]]"
+	want := []TaskLink{
+		{ID: "a1", Start: 4, End: 15},
+		{ID: "b2", Start: 20, End: 31},
+	}
+	if got := FindTaskLinks(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("FindTaskLinks() = %+v, want %+v", got, want)
+	}
+	if got := FindTaskLinks("no links"); got != nil {
+		t.Errorf("FindTaskLinks() = %+v, want none", got)
+	}
+}
+
+func TestRemoveTaskLinks(t *testing.T) {
+	tests := []struct {
+		text, id, want string
+	}{
+		{"after [[task:a1]] is done", "a1", "after  is done"},
+		{"[[task:a1]] [[task:b2]] [[task:a1]]", "a1", " [[task:b2]] "},
+		{"after [[task:b2]]", "a1", "after [[task:b2]]"},
+		{"", "a1", ""},
+	}
+	for _, tt := range tests {
+		if got := RemoveTaskLinks(tt.text, tt.id); got != tt.want {
+			t.Errorf("RemoveTaskLinks(%q, %q) = %q, want %q", tt.text, tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestLinksTo(t *testing.T) {
+	ids := map[string]bool{"a1": true, "b2": true}
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"needs [[task:b2]] first", true},
+		{"needs [[task:c3]] first", false},
+		{"mentions a1 without linking", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := LinksTo(tt.text, ids); got != tt.want {
+			t.Errorf("LinksTo(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"clitodo/pkg/position"
+	"slices"
+	"strings"
+)
+
+// SortByPosition puts items, and the subtasks of each, in the order of their
+// Position, ties broken by ID, so two copies of a list merged together come
+// out in the same order. Siblings are only sorted if all of them have a
+// position: lists saved before positions existed keep the order they were
+// saved in.
+func SortByPosition(items []Item) {
+	all := !slices.ContainsFunc(items, func(item Item) bool { return item.Position == "" })
+	if all {
+		slices.SortStableFunc(items, func(a, b Item) int {
+			if c := strings.Compare(a.Position, b.Position); c != 0 {
+				return c
+			}
+			return strings.Compare(a.ID, b.ID)
+		})
+	}
+	for i := range items {
+		SortByPosition(items[i].Children)
+	}
+}
+
+// AssignPositions gives items, and the subtasks of each, positions in the
+// order they're in. Positions already in order are kept as far as they can
+// be, so only the items added or moved get new ones; see position.Assign.
+func AssignPositions(items []Item) {
+	assignPositions(items, func(item Item) string { return "" })
+}
+
+// AssignFlatPositions does what AssignPositions does for rows from Flatten,
+// where siblings are the rows with the same ParentID.
+func AssignFlatPositions(rows []Item) {
+	assignPositions(rows, func(item Item) string { return item.ParentID })
+}
+
+// assignPositions assigns positions among the items parent puts together, and
+// then among the subtasks of each.
+func assignPositions(items []Item, parent func(Item) string) {
+	siblings := map[string][]int{}
+	var parents []string
+	for i, item := range items {
+		p := parent(item)
+		if _, ok := siblings[p]; !ok {
+			parents = append(parents, p)
+		}
+		siblings[p] = append(siblings[p], i)
+	}
+	for _, p := range parents {
+		indexes := siblings[p]
+		current := make([]string, len(indexes))
+		for j, i := range indexes {
+			current[j] = items[i].Position
+		}
+		for j, key := range position.Assign(current) {
+			items[indexes[j]].Position = key
+		}
+	}
+	for i := range items {
+		AssignPositions(items[i].Children)
+	}
+}
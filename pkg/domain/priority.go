@@ -0,0 +1,47 @@
+package domain
+
+import "fmt"
+
+// Priority says how urgent an item is. The zero value is no priority, so
+// items stored before priorities existed load unchanged.
+type Priority int
+
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+)
+
+var priorityNames = [...]string{"none", "low", "medium", "high"}
+
+func (p Priority) String() string {
+	if p < PriorityNone || p > PriorityHigh {
+		return fmt.Sprintf("Priority(%d)", int(p))
+	}
+	return priorityNames[p]
+}
+
+// Raise returns the next higher priority, staying at PriorityHigh.
+func (p Priority) Raise() Priority { return min(p+1, PriorityHigh) }
+
+// Lower returns the next lower priority, staying at PriorityNone.
+func (p Priority) Lower() Priority { return max(p-1, PriorityNone) }
+
+// MarshalText stores priorities by name so the storage file stays readable.
+func (p Priority) MarshalText() ([]byte, error) {
+	if p < PriorityNone || p > PriorityHigh {
+		return nil, fmt.Errorf("invalid priority %d", int(p))
+	}
+	return []byte(priorityNames[p]), nil
+}
+
+func (p *Priority) UnmarshalText(text []byte) error {
+	for i, name := range priorityNames {
+		if string(text) == name {
+			*p = Priority(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown priority %q", text)
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// SplitQuickEntry strips the words at the end of entry that ParseQuickEntry
+// reads, "due:friday", "!high" and "#tag", and returns them in the order they
+// were typed. Like with SplitTags, words in the middle of the title are left
+// alone and the first word is never taken.
+func SplitQuickEntry(entry string) (title string, tokens []string) {
+	words := strings.FieldsFunc(entry, unicode.IsSpace)
+	n := len(words)
+	for n > 1 && isQuickEntryToken(words[n-1]) {
+		n--
+	}
+	if n == len(words) {
+		return strings.TrimSpace(entry), nil
+	}
+
+	// Cut the entry itself rather than joining the words, so the spacing
+	// within the title is kept.
+	title = strings.TrimSpace(entry)
+	for range len(words) - n {
+		title = strings.TrimRightFunc(title[:strings.LastIndexFunc(title, unicode.IsSpace)], unicode.IsSpace)
+	}
+	return title, slices.Clone(words[n:])
+}
+
+// isQuickEntryToken reports whether word is one ParseQuickEntry reads. A
+// "!" word only counts if it names a priority, so "done!" or "!urgent" stay in
+// the title, while any due: word does and has to be a date.
+func isQuickEntryToken(word string) bool {
+	if len(word) > 1 && word[0] == '#' {
+		return true
+	}
+	if when, ok := strings.CutPrefix(strings.ToLower(word), "due:"); ok {
+		return when != ""
+	}
+	_, ok := quickEntryPriority(word)
+	return ok
+}
+
+func quickEntryPriority(word string) (Priority, bool) {
+	name, ok := strings.CutPrefix(strings.ToLower(word), "!")
+	if !ok {
+		return PriorityNone, false
+	}
+	var p Priority
+	if err := p.UnmarshalText([]byte(name)); err != nil {
+		return PriorityNone, false
+	}
+	return p, true
+}
+
+// ParseQuickEntry reads a task typed on one line, like
+// "pay rent due:friday !high #finance", into an item titled "pay rent" with
+// the due date, priority and tags the words at the end stand for:
+//
+//   - due:DATE, where DATE is one word ParseDate reads relative to now, such
+//     as today, tomorrow, fri or 2006-01-02
+//   - !high, !medium, !low or !none
+//   - #tag, any number of them
+//
+// Words that don't look like these stay in the title, as do those followed
+// by one that doesn't. A due: word that isn't a date is an error rather than
+// part of the title, so a typo doesn't go unnoticed. The last due: and
+// priority typed win.
+func ParseQuickEntry(entry string, now time.Time) (Item, error) {
+	title, tokens := SplitQuickEntry(entry)
+	item := NewItem(title)
+	for _, token := range tokens {
+		if tag, ok := strings.CutPrefix(token, "#"); ok {
+			if !slices.Contains(item.Tags, tag) {
+				item.Tags = append(item.Tags, tag)
+			}
+			continue
+		}
+		if p, ok := quickEntryPriority(token); ok {
+			item.Priority = p
+			continue
+		}
+		when := token[len("due:"):]
+		due, err := ParseDue(when, now)
+		if err != nil {
+			return Item{}, fmt.Errorf("due:%s is not a date; try today, tomorrow, fri or 2006-01-02", when)
+		}
+		item.Due = &due
+	}
+	return item, nil
+}
+
+// QuickEntryGiven reports which of the fields default rules set the words at
+// the end of entry give, so the rules leave them alone, "!none" included.
+func QuickEntryGiven(entry string) Given {
+	_, tokens := SplitQuickEntry(entry)
+	var given Given
+	for _, token := range tokens {
+		if _, ok := quickEntryPriority(token); ok {
+			given.Priority = true
+		} else if !strings.HasPrefix(token, "#") {
+			given.Due = true
+		}
+	}
+	return given
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSplitQuickEntry(t *testing.T) {
+	tests := []struct {
+		entry  string
+		title  string
+		tokens []string
+	}{
+		{"pay rent", "pay rent", nil},
+		{"pay rent due:fri !high #money", "pay rent", []string{"due:fri", "!high", "#money"}},
+		{"pay  the   rent #money", "pay  the   rent", []string{"#money"}},
+		{"  pay rent #money  ", "pay rent", []string{"#money"}},
+
+		// Only the words at the end, and never the first.
+		{"#money matters", "#money matters", nil},
+		{"#money", "#money", nil},
+		{"pay #money rent", "pay #money rent", nil},
+		{"pay rent #money now #later", "pay rent #money now", []string{"#later"}},
+
+		// Words that only look like tokens stay.
+		{"done!", "done!", nil},
+		{"ship it !urgent", "ship it !urgent", nil},
+		{"ship it !", "ship it !", nil},
+		{"call the # line", "call the # line", nil},
+		{"read about due:", "read about due:", nil},
+		{"ship it !HIGH", "ship it", []string{"!HIGH"}},
+		{"ship it due:someday", "ship it", []string{"due:someday"}},
+	}
+	for _, tt := range tests {
+		title, tokens := SplitQuickEntry(tt.entry)
+		if title != tt.title || !slices.Equal(tokens, tt.tokens) {
+			t.Errorf("SplitQuickEntry(%q) = %q, %q, want %q, %q", tt.entry, title, tokens, tt.title, tt.tokens)
+		}
+	}
+}
+
+func TestParseQuickEntry(t *testing.T) {
+	loc := inZone(t, "Europe/Berlin")
+	// A Wednesday afternoon.
+	now := time.Date(2025, time.March, 12, 15, 30, 0, 0, loc)
+
+	item, err := ParseQuickEntry("pay rent due:fri !high #money #home #money", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Title() != "pay rent" || item.ID == "" {
+		t.Errorf("title %q and ID %q", item.Title(), item.ID)
+	}
+	if item.Due == nil || !item.Due.AllDay || !item.Due.Local().Equal(time.Date(2025, time.March, 14, 0, 0, 0, 0, loc)) {
+		t.Errorf("due %v, want the whole of Friday", item.Due)
+	}
+	if item.Priority != PriorityHigh {
+		t.Errorf("priority %v, want high", item.Priority)
+	}
+	if !slices.Equal(item.Tags, []string{"money", "home"}) {
+		t.Errorf("tags %q, want money and home once each", item.Tags)
+	}
+
+	// The last due: and priority win, and a time of day makes a moment.
+	item, err = ParseQuickEntry("call Bob due:today !low due:tomorrow !none", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Due == nil || !item.Due.Local().Equal(time.Date(2025, time.March, 13, 0, 0, 0, 0, loc)) || item.Priority != PriorityNone {
+		t.Errorf("due %v and priority %v, want tomorrow and none", item.Due, item.Priority)
+	}
+	item, err = ParseQuickEntry("call Bob due:17:00", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.Due == nil || item.Due.AllDay || !item.Due.Local().Equal(time.Date(2025, time.March, 12, 17, 0, 0, 0, loc)) {
+		t.Errorf("due %v, want today at 17:00", item.Due)
+	}
+
+	if _, err := ParseQuickEntry("call Bob due:someday", now); err == nil {
+		t.Error("due:someday was read as a date")
+	}
+	if item, err := ParseQuickEntry("call Bob", now); err != nil || item.Due != nil || item.Priority != PriorityNone || item.Tags != nil {
+		t.Errorf("ParseQuickEntry() of a plain title = %+v, %v", item, err)
+	}
+}
+
+func TestQuickEntryGiven(t *testing.T) {
+	tests := []struct {
+		entry string
+		want  Given
+	}{
+		{"pay rent", Given{}},
+		{"pay rent #money", Given{}},
+		{"pay rent !none", Given{Priority: true}},
+		{"pay rent due:fri #money", Given{Due: true}},
+		{"pay rent due:fri !high", Given{Due: true, Priority: true}},
+	}
+	for _, tt := range tests {
+		if got := QuickEntryGiven(tt.entry); got != tt.want {
+			t.Errorf("QuickEntryGiven(%q) = %+v, want %+v", tt.entry, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecurrenceUnit is the calendar unit a recurrence counts in.
+type RecurrenceUnit string
+
+const (
+	Days   RecurrenceUnit = "days"
+	Weeks  RecurrenceUnit = "weeks"
+	Months RecurrenceUnit = "months"
+)
+
+// Recurrence says how often a task comes back once it's completed.
+type Recurrence struct {
+	Every int            `json:"every" desc:"How many units apart the task comes back."`
+	Unit  RecurrenceUnit `json:"unit" desc:"Unit of every."`
+
+	// Day of the month monthly recurrences fall on. It's kept so a task due
+	// on the 31st comes back on the 30th in short months and on the 31st
+	// again afterwards, instead of drifting to the 30th for good. 0 means
+	// the day of the current due date.
+	Day int `json:"day,omitempty" desc:"Day of the month monthly recurrences fall on, clamped in short months. Missing means that of the due date."`
+}
+
+var recurrenceAliases = map[string]Recurrence{
+	"daily":   {Every: 1, Unit: Days},
+	"weekly":  {Every: 1, Unit: Weeks},
+	"monthly": {Every: 1, Unit: Months},
+}
+
+// ParseRecurrence reads "daily", "weekly", "monthly" or "every N days",
+// "every N weeks" or "every N months". N may be left out for 1, and the unit
+// may be singular.
+func ParseRecurrence(spec string) (Recurrence, error) {
+	s := strings.ToLower(strings.TrimSpace(spec))
+	if r, ok := recurrenceAliases[s]; ok {
+		return r, nil
+	}
+
+	fields := strings.Fields(s)
+	if len(fields) < 2 || len(fields) > 3 || fields[0] != "every" {
+		return Recurrence{}, fmt.Errorf(`unknown recurrence %q; use daily, weekly, monthly or "every N days|weeks|months"`, spec)
+	}
+
+	r := Recurrence{Every: 1}
+	if len(fields) == 3 {
+		n, err := strconv.Atoi(fields[1])
+		if err != nil || n < 1 {
+			return Recurrence{}, fmt.Errorf("recurrence %q: %q is not a positive whole number", spec, fields[1])
+		}
+		r.Every = n
+	}
+
+	switch unit := fields[len(fields)-1]; unit {
+	case "day", "days":
+		r.Unit = Days
+	case "week", "weeks":
+		r.Unit = Weeks
+	case "month", "months":
+		r.Unit = Months
+	default:
+		return Recurrence{}, fmt.Errorf("recurrence %q: unknown unit %q, use days, weeks or months", spec, unit)
+	}
+	return r, nil
+}
+
+// String returns the recurrence the way ParseRecurrence reads it.
+func (r Recurrence) String() string {
+	if r.Every == 1 {
+		for name, alias := range recurrenceAliases {
+			if alias.Unit == r.Unit {
+				return name
+			}
+		}
+	}
+	return fmt.Sprintf("every %d %s", r.Every, r.Unit)
+}
+
+// Next returns the date one recurrence after t, keeping the time of day.
+func (r Recurrence) Next(t time.Time) time.Time {
+	switch r.Unit {
+	case Weeks:
+		return ShiftDate(t, 0, 7*r.Every) //nolint:mnd
+	case Months:
+		day := r.Day
+		if day == 0 {
+			day = t.Day()
+		}
+		y, m, _ := t.Date()
+		m += time.Month(r.Every)
+		lastDay := time.Date(y, m+1, 0, 0, 0, 0, 0, t.Location()).Day()
+		return time.Date(y, m, min(day, lastDay), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	}
+	return ShiftDate(t, 0, r.Every)
+}
+
+// NextOccurrence returns the open copy of a recurring item that replaces it
+// once it's completed at now. It's due one recurrence after the item's due
+// date, or after today for items without one, skipping occurrences that are
+// already past so an overdue task comes back only once.
+func (i Item) NextOccurrence(now time.Time) Item {
+	now = now.Local()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	base, allDay := today, true
+	if i.Due != nil {
+		base, allDay = i.Due.Local(), i.Due.AllDay
+	}
+
+	r := *i.Recurrence
+	if r.Unit == Months && r.Day == 0 {
+		r.Day = base.Day()
+	}
+	due := r.Next(base)
+	for due.Before(today) {
+		due = r.Next(due)
+	}
+
+	next := i
+	next.ID = NewID()
+	next.ItemCompleted = false
+	next.CompletedAt = nil
+	next.ItemStatus = ""
+	next.CreatedAt = &now
+	next.TouchedAt = &now
+	next.Due = &Date{Time: due, AllDay: allDay}
+	next.Recurrence = &r
+	next.Tags = slices.Clone(i.Tags)
+	next.Waiting = nil
+	next.Children = nil
+	return next
+}
@@ -0,0 +1,212 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+// inZone runs the test with time.Local set to the named zone.
+func inZone(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("no timezone data for %s: %v", name, err)
+	}
+	saved := time.Local
+	time.Local = loc
+	t.Cleanup(func() { time.Local = saved })
+	return loc
+}
+
+func day(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		spec string
+		want Recurrence
+	}{
+		{"daily", Recurrence{Every: 1, Unit: Days}},
+		{" Weekly ", Recurrence{Every: 1, Unit: Weeks}},
+		{"monthly", Recurrence{Every: 1, Unit: Months}},
+		{"every day", Recurrence{Every: 1, Unit: Days}},
+		{"every 3 days", Recurrence{Every: 3, Unit: Days}},
+		{"every 2 week", Recurrence{Every: 2, Unit: Weeks}},
+		{"every 12 months", Recurrence{Every: 12, Unit: Months}},
+	}
+	for _, tt := range tests {
+		got, err := ParseRecurrence(tt.spec)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseRecurrence(%q) = %+v, %v; want %+v", tt.spec, got, err, tt.want)
+		}
+	}
+	for _, spec := range []string{"", "yearly", "every", "every 0 days", "every -1 weeks", "every 2 fortnights", "every 2 3 days"} {
+		if _, err := ParseRecurrence(spec); err == nil {
+			t.Errorf("ParseRecurrence(%q) succeeded, want an error", spec)
+		}
+	}
+}
+
+func TestRecurrenceNextClampsMonths(t *testing.T) {
+	inZone(t, "UTC")
+	monthly := Recurrence{Every: 1, Unit: Months, Day: 31}
+
+	// A task due on the 31st comes back on the last day of shorter months
+	// and returns to the 31st afterwards.
+	want := []time.Time{
+		day(2025, time.February, 28),
+		day(2025, time.March, 31),
+		day(2025, time.April, 30),
+		day(2025, time.May, 31),
+	}
+	due := day(2025, time.January, 31)
+	for _, w := range want {
+		due = monthly.Next(due)
+		if !due.Equal(w) {
+			t.Fatalf("Next() = %s, want %s", due.Format(time.DateOnly), w.Format(time.DateOnly))
+		}
+	}
+
+	// Without a day to return to, the clamped day sticks.
+	if got := (Recurrence{Every: 1, Unit: Months}).Next(day(2025, time.January, 31)); !got.Equal(day(2025, time.February, 28)) {
+		t.Errorf("Next() = %s, want 2025-02-28", got.Format(time.DateOnly))
+	}
+
+	// Months past December roll over into the next year.
+	quarterly := Recurrence{Every: 3, Unit: Months, Day: 30}
+	if got := quarterly.Next(day(2025, time.November, 30)); !got.Equal(day(2026, time.February, 28)) {
+		t.Errorf("Next() = %s, want 2026-02-28", got.Format(time.DateOnly))
+	}
+}
+
+func TestRecurrenceNextLeapDays(t *testing.T) {
+	inZone(t, "UTC")
+	yearly := Recurrence{Every: 12, Unit: Months, Day: 29}
+	want := []time.Time{
+		day(2025, time.February, 28),
+		day(2026, time.February, 28),
+		day(2027, time.February, 28),
+		day(2028, time.February, 29),
+	}
+	due := day(2024, time.February, 29)
+	for _, w := range want {
+		due = yearly.Next(due)
+		if !due.Equal(w) {
+			t.Fatalf("Next() = %s, want %s", due.Format(time.DateOnly), w.Format(time.DateOnly))
+		}
+	}
+
+	daily := Recurrence{Every: 1, Unit: Days}
+	if got := daily.Next(day(2024, time.February, 28)); !got.Equal(day(2024, time.February, 29)) {
+		t.Errorf("Next() = %s, want 2024-02-29", got.Format(time.DateOnly))
+	}
+	if got := daily.Next(day(2025, time.February, 28)); !got.Equal(day(2025, time.March, 1)) {
+		t.Errorf("Next() = %s, want 2025-03-01", got.Format(time.DateOnly))
+	}
+}
+
+func TestRecurrenceNextKeepsTimeAcrossDST(t *testing.T) {
+	inZone(t, "Europe/Berlin")
+	// Clocks go forward on 2025-03-30.
+	due := time.Date(2025, time.March, 29, 9, 0, 0, 0, time.Local)
+	for _, r := range []Recurrence{{Every: 1, Unit: Days}, {Every: 1, Unit: Weeks}, {Every: 1, Unit: Months}} {
+		next := r.Next(due)
+		if next.Hour() != 9 || next.Minute() != 0 {
+			t.Errorf("%s: Next() = %s, want 09:00", r, next)
+		}
+	}
+}
+
+func TestNextOccurrence(t *testing.T) {
+	inZone(t, "UTC")
+	at := func(y int, m time.Month, d, hour int) time.Time {
+		return time.Date(y, m, d, hour, 0, 0, 0, time.Local)
+	}
+	tests := []struct {
+		name       string
+		due        *time.Time
+		recurrence Recurrence
+		completed  time.Time
+		want       time.Time
+	}{
+		{
+			name:       "on time",
+			due:        ptr(day(2025, time.March, 10)),
+			recurrence: Recurrence{Every: 1, Unit: Weeks},
+			completed:  at(2025, time.March, 10, 15),
+			want:       day(2025, time.March, 17),
+		},
+		{
+			name:       "early",
+			due:        ptr(day(2025, time.March, 10)),
+			recurrence: Recurrence{Every: 1, Unit: Weeks},
+			completed:  at(2025, time.March, 8, 9),
+			want:       day(2025, time.March, 17),
+		},
+		{
+			name:       "late daily comes back today",
+			due:        ptr(day(2025, time.March, 1)),
+			recurrence: Recurrence{Every: 1, Unit: Days},
+			completed:  at(2025, time.March, 5, 20),
+			want:       day(2025, time.March, 5),
+		},
+		{
+			name:       "late weekly skips missed weeks",
+			due:        ptr(day(2025, time.March, 3)),
+			recurrence: Recurrence{Every: 1, Unit: Weeks},
+			completed:  at(2025, time.March, 19, 8),
+			want:       day(2025, time.March, 24),
+		},
+		{
+			name:       "late monthly keeps its day",
+			due:        ptr(day(2025, time.January, 31)),
+			recurrence: Recurrence{Every: 1, Unit: Months},
+			completed:  at(2025, time.April, 2, 8),
+			want:       day(2025, time.April, 30),
+		},
+		{
+			name:       "timed due keeps its time",
+			due:        ptr(at(2025, time.March, 10, 17)),
+			recurrence: Recurrence{Every: 2, Unit: Days},
+			completed:  at(2025, time.March, 10, 18),
+			want:       at(2025, time.March, 12, 17),
+		},
+		{
+			name:       "no due date counts from today",
+			recurrence: Recurrence{Every: 3, Unit: Days},
+			completed:  at(2025, time.March, 10, 18),
+			want:       day(2025, time.March, 13),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := NewItem("water the plants")
+			item.Recurrence = &tt.recurrence
+			if tt.due != nil {
+				item.Due = &Date{Time: *tt.due, AllDay: tt.due.Hour() == 0}
+			}
+			item.ItemCompleted = true
+			item.Tags = []string{"home"}
+
+			next := item.NextOccurrence(tt.completed)
+			if !next.Due.Equal(tt.want) {
+				t.Errorf("due %s, want %s", next.Due.Format(time.DateTime), tt.want.Format(time.DateTime))
+			}
+			if allDay := tt.due == nil || item.Due.AllDay; next.Due.AllDay != allDay {
+				t.Errorf("next due all day %t, want %t", next.Due.AllDay, allDay)
+			}
+			if next.Completed() || next.ID == item.ID {
+				t.Errorf("next occurrence is completed (%v) or has the same ID", next.Completed())
+			}
+			next.Tags[0] = "changed"
+			if item.Tags[0] != "home" {
+				t.Error("next occurrence shares its tags with the completed item")
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Reminder says when to remind about an item and whether to keep reminding
+// until it's done. How often it fired is kept with it, so the next reminder
+// can be worked out again after a restart.
+type Reminder struct {
+	At time.Time `json:"at" desc:"When the first reminder fires, in UTC."`
+
+	// How long after a reminder the next one fires while the item is open.
+	// 0 reminds only once.
+	Every Duration `json:"every,omitempty" desc:"How long after a reminder the next one fires while the task is open, such as 30m. Missing reminds once."`
+
+	// How many times to remind at most, the first one included. 0 keeps
+	// reminding until the item is done.
+	Max int `json:"max,omitempty" desc:"How many times to remind at most, the first one included. Missing keeps reminding until the task is done."`
+
+	Fired     int        `json:"fired,omitempty" desc:"How many times the reminder fired."`
+	LastFired *time.Time `json:"last_fired,omitempty" desc:"When the reminder last fired, in UTC."`
+}
+
+// Next returns when the reminder fires next, or false if it won't anymore.
+// Repeats are counted from when the last one actually fired, so reminders
+// missed while clitodo wasn't running fire once rather than all at once.
+func (r Reminder) Next() (time.Time, bool) {
+	switch {
+	case r.Fired == 0:
+		return r.At, true
+	case r.Every == 0, r.Max > 0 && r.Fired >= r.Max:
+		return time.Time{}, false
+	case r.LastFired == nil:
+		return r.At.Add(time.Duration(r.Every) * time.Duration(r.Fired)), true
+	}
+	return r.LastFired.Add(time.Duration(r.Every)), true
+}
+
+// Due reports whether the reminder should fire at now.
+func (r Reminder) Due(now time.Time) bool {
+	next, ok := r.Next()
+	return ok && !next.After(now)
+}
+
+// Final reports whether the reminder firing next is the last of a repeating
+// series, which is made harder to miss.
+func (r Reminder) Final() bool {
+	return r.Every > 0 && r.Max > 1 && r.Fired == r.Max-1
+}
+
+// Escalated reports whether a repeating reminder has fired for the last time.
+func (r Reminder) Escalated() bool {
+	return r.Every > 0 && r.Max > 1 && r.Fired >= r.Max
+}
+
+// Fire records that the reminder fired at now.
+func (r *Reminder) Fire(now time.Time) {
+	r.Fired++
+	r.LastFired = &now
+}
+
+// MarshalJSON stores the times in UTC, like those of Item.
+func (r Reminder) MarshalJSON() ([]byte, error) {
+	type reminder Reminder
+	r.At = r.At.UTC()
+	r.LastFired = utc(r.LastFired)
+	return json.Marshal(reminder(r))
+}
+
+// ParseReminder reads when to remind, relative to now, as ParseDate does,
+// optionally followed by how to repeat: "tomorrow 9am, every 30m, max 5".
+// "N times" may stand for "max N".
+func ParseReminder(spec string, now time.Time) (Reminder, error) {
+	parts := strings.Split(spec, ",")
+	at, err := ParseDate(parts[0], now)
+	if err != nil {
+		return Reminder{}, err
+	}
+
+	r := Reminder{At: at}
+	for _, part := range parts[1:] {
+		fields := strings.Fields(strings.ToLower(part))
+		switch {
+		case len(fields) == 2 && fields[0] == "every":
+			d, err := time.ParseDuration(fields[1])
+			if err != nil || d <= 0 {
+				return Reminder{}, fmt.Errorf("reminder %q: %q is not a duration like 30m", spec, fields[1])
+			}
+			r.Every = Duration(d)
+		case len(fields) == 2 && (fields[0] == "max" || fields[1] == "times"):
+			count := fields[1]
+			if fields[1] == "times" {
+				count = fields[0]
+			}
+			n, err := strconv.Atoi(count)
+			if err != nil || n < 1 {
+				return Reminder{}, fmt.Errorf("reminder %q: %q is not a positive whole number", spec, part)
+			}
+			r.Max = n
+		default:
+			return Reminder{}, fmt.Errorf(`reminder %q: unknown %q; use "every 30m" or "max 5"`, spec, strings.TrimSpace(part))
+		}
+	}
+	if r.Max > 1 && r.Every == 0 {
+		return Reminder{}, fmt.Errorf(`reminder %q: max needs "every" to repeat`, spec)
+	}
+	return r, nil
+}
+
+// String returns the reminder the way ParseReminder reads it.
+func (r Reminder) String() string {
+	s := r.At.Local().Format(DueTimeLayout)
+	if r.Every > 0 {
+		s += ", every " + r.Every.String()
+	}
+	if r.Max > 0 {
+		s += ", max " + strconv.Itoa(r.Max)
+	}
+	return s
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Schema returns the JSON Schema of a storage file: an array of items. A
+// file of the one-file-per-item layout holds a single item, $defs/item. The
+// keys are described from the json and desc tags of Item and the types it's
+// made of, so the schema follows the struct.
+func Schema() map[string]any {
+	item := objectSchema(reflect.TypeFor[Item]())
+	item["properties"].(map[string]any)["name"] = map[string]any{
+		"type":        "string",
+		"deprecated":  true,
+		"description": "The title as older versions stored it. Read when title is missing; still written next to title for now.",
+	}
+	// Items written before the title was stored as "title" only have "name".
+	item["required"] = slices.DeleteFunc(item["required"].([]string), func(key string) bool { return key == "title" })
+	item["anyOf"] = []any{
+		map[string]any{"required": []string{"title"}},
+		map[string]any{"required": []string{"name"}},
+	}
+
+	return map[string]any{
+		"$schema":     "https://json-schema.org/draft/2020-12/schema",
+		"title":       "clitodo items",
+		"description": "The tasks of a clitodo list, in order.",
+		"type":        "array",
+		"items":       map[string]any{"$ref": "#/$defs/item"},
+		"$defs":       map[string]any{"item": item},
+	}
+}
+
+// typeSchemas are the schemas of the types stored other than their Go kind
+// suggests.
+var typeSchemas = map[reflect.Type]func() map[string]any{
+	reflect.TypeFor[time.Time](): func() map[string]any {
+		return map[string]any{"type": "string", "format": "date-time"}
+	},
+	reflect.TypeFor[Date](): func() map[string]any {
+		return map[string]any{"type": "string", "anyOf": []any{
+			map[string]any{"format": "date"},
+			map[string]any{"format": "date-time"},
+		}}
+	},
+	reflect.TypeFor[Duration](): func() map[string]any {
+		return map[string]any{"type": "string"}
+	},
+	reflect.TypeFor[Priority](): func() map[string]any {
+		return map[string]any{"type": "string", "enum": priorityNames[:]}
+	},
+	reflect.TypeFor[Status](): func() map[string]any {
+		return map[string]any{"type": "string", "enum": Statuses}
+	},
+	reflect.TypeFor[RecurrenceUnit](): func() map[string]any {
+		return map[string]any{"type": "string", "enum": []RecurrenceUnit{Days, Weeks, Months}}
+	},
+	reflect.TypeFor[Item](): func() map[string]any {
+		return map[string]any{"$ref": "#/$defs/item"}
+	},
+}
+
+func typeSchema(t reflect.Type) map[string]any {
+	if s, ok := typeSchemas[t]; ok {
+		return s()
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return typeSchema(t.Elem())
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Struct:
+		return objectSchema(t)
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	}
+	panic("no schema for " + t.String())
+}
+
+// objectSchema describes the exported fields of struct t that are stored.
+// Those not left out when empty are required.
+func objectSchema(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		s := typeSchema(f.Type)
+		if desc := f.Tag.Get("desc"); desc != "" {
+			s["description"] = desc
+		}
+		properties[name] = s
+		if !strings.Contains(opts, "omitempty") {
+			required = append(required, name)
+		}
+	}
+	s := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// SortOrder is a way to order items. The CLI and the TUI look orders up in
+// SortOrders, so they always agree on what "by due date" means.
+type SortOrder struct {
+	// Has reports whether item has the sort key at all. Items without it
+	// go last, also when the order is reversed.
+	Has func(item Item) bool
+
+	// Compare orders two items that both have the key.
+	Compare func(a, b Item) int
+}
+
+func always(Item) bool { return true }
+
+// SortOrders lists the orders by name.
+var SortOrders = map[string]SortOrder{
+	"due": {
+		Has:     func(i Item) bool { return i.Due != nil },
+		Compare: func(a, b Item) int { return a.Due.Compare(b.Due.Time) },
+	},
+	"priority": {
+		Has: func(i Item) bool { return i.Priority != PriorityNone },
+		// Highest first.
+		Compare: func(a, b Item) int { return cmp.Compare(b.Priority, a.Priority) },
+	},
+	"created": {
+		Has:     func(i Item) bool { return i.CreatedAt != nil },
+		Compare: func(a, b Item) int { return a.CreatedAt.Compare(*b.CreatedAt) },
+	},
+	"title": {
+		Has: always,
+		Compare: func(a, b Item) int {
+			return cmp.Compare(strings.ToLower(a.ItemTitle), strings.ToLower(b.ItemTitle))
+		},
+	},
+	"completed": {
+		Has: always,
+		// Open items first.
+		Compare: func(a, b Item) int {
+			switch {
+			case a.Completed() == b.Completed():
+				return 0
+			case a.Completed():
+				return 1
+			}
+			return -1
+		},
+	},
+}
+
+// SortOrderByName returns the order called name, or an error listing the
+// known ones.
+func SortOrderByName(name string) (SortOrder, error) {
+	order, ok := SortOrders[name]
+	if !ok {
+		names := make([]string, 0, len(SortOrders))
+		for n := range SortOrders {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return SortOrder{}, fmt.Errorf("unknown sort order %q (available: %s)", name, strings.Join(names, ", "))
+	}
+	return order, nil
+}
+
+// Sorted returns the indices of items in the given order. Ties keep their
+// stored order, and reverse doesn't move items without the key to the front.
+func Sorted(items []Item, order SortOrder, reverse bool) []int {
+	indices := make([]int, len(items))
+	for i := range indices {
+		indices[i] = i
+	}
+	slices.SortStableFunc(indices, func(i, j int) int {
+		a, b := items[i], items[j]
+		hasA, hasB := order.Has(a), order.Has(b)
+		switch {
+		case !hasA && !hasB:
+			return 0
+		case !hasA:
+			return 1
+		case !hasB:
+			return -1
+		}
+		if reverse {
+			return order.Compare(b, a)
+		}
+		return order.Compare(a, b)
+	})
+	return indices
+}
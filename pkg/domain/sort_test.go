@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSorted(t *testing.T) {
+	at := func(d int) *time.Time {
+		t := time.Date(2025, time.March, d, 9, 0, 0, 0, time.UTC)
+		return &t
+	}
+	wholeDay := func(d int) *Date {
+		return &Date{Time: time.Date(2025, time.March, d, 0, 0, 0, 0, time.UTC), AllDay: true}
+	}
+	items := []Item{
+		{ItemTitle: "b water the plants", Due: wholeDay(14), Priority: PriorityLow, CreatedAt: at(3)},
+		{ItemTitle: "a pay the rent", Due: &Date{Time: *at(12)}, ItemCompleted: true},
+		{ItemTitle: "C call mum", Priority: PriorityHigh, CreatedAt: at(1)},
+		{ItemTitle: "d book flights", Due: wholeDay(12), Priority: PriorityHigh, CreatedAt: at(2)},
+		{ItemTitle: "e fix the bike"},
+	}
+	titles := func(indices []int) string {
+		var s []string
+		for _, i := range indices {
+			s = append(s, items[i].Title()[:1])
+		}
+		return strings.Join(s, "")
+	}
+
+	tests := []struct {
+		order   string
+		reverse bool
+		want    string
+	}{
+		// Ties keep their stored order, and items without the key go last
+		// either way.
+		{"due", false, "dabCe"},
+		{"due", true, "badCe"},
+		{"priority", false, "Cdbae"},
+		{"priority", true, "bCdae"},
+		{"created", false, "Cdbae"},
+		{"created", true, "bdCae"},
+		{"title", false, "abCde"},
+		{"title", true, "edCba"},
+		{"completed", false, "bCdea"},
+		{"completed", true, "abCde"},
+	}
+	for _, tt := range tests {
+		order, err := SortOrderByName(tt.order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := titles(Sorted(items, order, tt.reverse)); got != tt.want {
+			t.Errorf("Sorted() by %s, reverse %t = %s, want %s", tt.order, tt.reverse, got, tt.want)
+		}
+	}
+
+	if got := Sorted(nil, SortOrders["due"], false); len(got) != 0 {
+		t.Errorf("Sorted(nil) = %v", got)
+	}
+}
+
+func TestSortOrderByName(t *testing.T) {
+	_, err := SortOrderByName("urgency")
+	if err == nil {
+		t.Fatal("SortOrderByName() of an unknown order didn't fail")
+	}
+	names := make([]string, 0, len(SortOrders))
+	for name := range SortOrders {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	if want := "(available: " + strings.Join(names, ", ") + ")"; !strings.Contains(err.Error(), want) {
+		t.Errorf("error %q doesn't list the orders as %q", err, want)
+	}
+}
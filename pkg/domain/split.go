@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SplitTitle splits title so that head is at most limit runes long. The cut
+// is made at the last word break within the limit; a single word longer than
+// the limit is cut at the limit itself. The whitespace at the cut is dropped.
+// If title already fits, or limit isn't positive, rest is empty.
+func SplitTitle(title string, limit int) (head, rest string) {
+	runes := []rune(strings.TrimSpace(title))
+	if limit <= 0 || len(runes) <= limit {
+		return string(runes), ""
+	}
+
+	cut := -1
+	for i := limit; i > 0; i-- {
+		if unicode.IsSpace(runes[i]) {
+			cut = i
+			break
+		}
+	}
+	if cut < 0 {
+		cut = limit
+	}
+
+	head = strings.TrimRightFunc(string(runes[:cut]), unicode.IsSpace)
+	rest = strings.TrimLeftFunc(string(runes[cut:]), unicode.IsSpace)
+	return head, rest
+}
+
+// TitleLength is the length of title as SplitTitle counts it.
+func TitleLength(title string) int {
+	return len([]rune(strings.TrimSpace(title)))
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "testing"
+
+func TestSplitTitle(t *testing.T) {
+	tests := []struct {
+		title      string
+		limit      int
+		head, rest string
+	}{
+		{"pay the rent", 20, "pay the rent", ""},
+		{"pay the rent", 12, "pay the rent", ""},
+		{"pay the rent", 11, "pay the", "rent"},
+		{"pay the rent", 7, "pay the", "rent"},
+		{"pay the rent", 6, "pay", "the rent"},
+		{"pay   the rent", 5, "pay", "the rent"},
+		{"  pay the rent  ", 12, "pay the rent", ""},
+		{"supercalifragilistic", 5, "super", "califragilistic"},
+		{"東京へ行く 予定を立てる", 6, "東京へ行く", "予定を立てる"},
+		{"pay the rent", 0, "pay the rent", ""},
+		{"pay the rent", -1, "pay the rent", ""},
+		{"", 5, "", ""},
+	}
+	for _, tt := range tests {
+		head, rest := SplitTitle(tt.title, tt.limit)
+		if head != tt.head || rest != tt.rest {
+			t.Errorf("SplitTitle(%q, %d) = %q, %q, want %q, %q", tt.title, tt.limit, head, rest, tt.head, tt.rest)
+		}
+		if tt.limit > 0 && TitleLength(head) > tt.limit {
+			t.Errorf("SplitTitle(%q, %d) left %q, longer than the limit", tt.title, tt.limit, head)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// StaleItems returns the indices of at most n incomplete items that haven't
+// been touched for longer than after, oldest first. Items that were never
+// touched are left out, since their age is unknown.
+func StaleItems(items []Item, now time.Time, after time.Duration, n int) []int {
+	var stale []int
+	for i, item := range items {
+		if item.Completed() || item.Someday || item.TouchedAt == nil {
+			continue
+		}
+		if now.Sub(*item.TouchedAt) > after {
+			stale = append(stale, i)
+		}
+	}
+
+	sort.SliceStable(stale, func(a, b int) bool {
+		return items[stale[a]].TouchedAt.Before(*items[stale[b]].TouchedAt)
+	})
+	if len(stale) > n {
+		stale = stale[:n]
+	}
+	return stale
+}
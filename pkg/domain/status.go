@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+// Status is the column of the board an item is in.
+type Status string
+
+const (
+	StatusTodo  Status = "todo"
+	StatusDoing Status = "doing"
+	StatusDone  Status = "done"
+)
+
+// Statuses are the board's columns, left to right.
+var Statuses = []Status{StatusTodo, StatusDoing, StatusDone}
+
+// String returns the status as the board's column heads it.
+func (s Status) String() string {
+	switch s {
+	case StatusDoing:
+		return "Doing"
+	case StatusDone:
+		return "Done"
+	}
+	return "Todo"
+}
+
+// Status returns where the item stands. Completed items are done whatever
+// their saved status, so checking one off in the list moves it on the board
+// too; open items without a status are still to do.
+func (i Item) Status() Status {
+	switch {
+	case i.ItemCompleted:
+		return StatusDone
+	case i.ItemStatus == StatusDoing:
+		return StatusDoing
+	}
+	return StatusTodo
+}
+
+// SetStatus moves the item to status at t, checking it off when that's done
+// and reopening it when it was.
+func (i *Item) SetStatus(status Status, t time.Time) {
+	if done := status == StatusDone; done != i.ItemCompleted {
+		i.SetCompleted(done, t)
+	}
+	i.ItemStatus = status
+	if status == StatusTodo {
+		i.ItemStatus = ""
+	}
+}
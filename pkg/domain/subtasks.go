@@ -0,0 +1,83 @@
+package domain
+
+import "slices"
+
+// Flatten lists items depth first, each followed by its subtasks. The
+// returned items have no Children; their ParentID and Depth say where they
+// were instead. Nest puts them back together.
+func Flatten(items []Item) []Item {
+	var rows []Item
+	var walk func(items []Item, parentID string, depth int)
+	walk = func(items []Item, parentID string, depth int) {
+		for _, item := range items {
+			children := item.Children
+			item.Children = nil
+			item.ParentID = parentID
+			item.Depth = depth
+			rows = append(rows, item)
+			walk(children, item.ID, depth+1)
+		}
+	}
+	walk(items, "", 0)
+	return rows
+}
+
+// Nest turns rows from Flatten back into a tree. Subtasks keep their order
+// under their parent; ones whose parent is gone become top-level items.
+func Nest(rows []Item) []Item {
+	present := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		present[row.ID] = true
+	}
+
+	var roots []Item
+	children := make(map[string][]Item)
+	for _, row := range rows {
+		if row.ParentID != "" && present[row.ParentID] {
+			children[row.ParentID] = append(children[row.ParentID], row)
+		} else {
+			roots = append(roots, row)
+		}
+	}
+
+	var build func(items []Item) []Item
+	build = func(items []Item) []Item {
+		for i := range items {
+			items[i].Children = build(children[items[i].ID])
+			items[i].ParentID = ""
+			items[i].Depth = 0
+		}
+		return items
+	}
+	return build(roots)
+}
+
+// SinkCompleted returns rows from Flatten with the completed items moved
+// below the open ones among their siblings, each with its subtasks. Open and
+// completed items keep their order among themselves, and items put aside for
+// some day stay after the others.
+func SinkCompleted(rows []Item) []Item {
+	var sink func(items []Item)
+	sink = func(items []Item) {
+		slices.SortStableFunc(items, func(a, b Item) int {
+			return sinkRank(a) - sinkRank(b)
+		})
+		for i := range items {
+			sink(items[i].Children)
+		}
+	}
+	tree := Nest(slices.Clone(rows))
+	sink(tree)
+	return Flatten(tree)
+}
+
+func sinkRank(item Item) int {
+	rank := 0
+	if item.Someday {
+		rank += 2
+	}
+	if item.Completed() {
+		rank++
+	}
+	return rank
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SplitTags strips the "#tag" words at the end of title and returns them
+// without the "#", in the order they were typed. Tags in the middle of the
+// title are left alone, and the first word is never taken, so the title can't
+// end up empty.
+func SplitTags(title string) (rest string, tags []string) {
+	rest = strings.TrimSpace(title)
+	for {
+		space := strings.LastIndexFunc(rest, unicode.IsSpace)
+		if space < 0 {
+			break
+		}
+		_, size := utf8.DecodeRuneInString(rest[space:])
+		start := space + size
+		word := rest[start:]
+		if len(word) < 2 || word[0] != '#' {
+			break
+		}
+		if tag := word[1:]; !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+		rest = strings.TrimRightFunc(rest[:start], unicode.IsSpace)
+	}
+	slices.Reverse(tags)
+	return rest, tags
+}
+
+// FormatTags writes tags the way they are typed, as "#work #home".
+func FormatTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return "#" + strings.Join(tags, " #")
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// TemplateTask is a task of a template: the line it's typed on, read like
+// a quick entry, and its notes.
+type TemplateTask struct {
+	Entry string
+	Notes string
+}
+
+// ParseTemplate reads the tasks of a template, one per line:
+//
+//	Book flights to Oslo due:fri !high #trip
+//	    Window seat if there's one.
+//	Pack
+//
+// Indented lines under a task are its notes, with the indentation of the
+// first one taken off all of them. Blank lines between tasks are skipped.
+func ParseTemplate(text string) []TemplateTask {
+	var (
+		tasks  []TemplateTask
+		notes  []string
+		indent string
+	)
+	flush := func() {
+		if len(tasks) > 0 {
+			tasks[len(tasks)-1].Notes = strings.TrimRightFunc(strings.Join(notes, "\n"), unicode.IsSpace)
+		}
+		notes, indent = nil, ""
+	}
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(notes) > 0 {
+				notes = append(notes, "")
+			}
+			continue
+		}
+		if len(tasks) > 0 && unicode.IsSpace(rune(line[0])) {
+			if len(notes) == 0 {
+				indent = line[:len(line)-len(strings.TrimLeftFunc(line, unicode.IsSpace))]
+			}
+			notes = append(notes, strings.TrimPrefix(line, indent))
+			continue
+		}
+		flush()
+		tasks = append(tasks, TemplateTask{Entry: strings.TrimSpace(line)})
+	}
+	flush()
+	return tasks
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// Waiting marks an item as blocked on someone or something else.
+type Waiting struct {
+	// Who or what the item is waiting on, free-form.
+	On string `json:"on,omitempty" desc:"Who or what the task is waiting on."`
+
+	// When to check on it again. Nil for no follow-up date.
+	FollowUp *Date `json:"follow_up,omitempty" desc:"When to check on it again, a date or a moment like due."`
+}
+
+// IsWaiting reports whether the item is open and waiting on something.
+func (i Item) IsWaiting() bool {
+	return i.Waiting != nil && !i.ItemCompleted
+}
+
+// FollowUpDue reports whether the item is waiting and its follow-up date has
+// arrived at now.
+func (i Item) FollowUpDue(now time.Time) bool {
+	return i.IsWaiting() && i.Waiting.FollowUp != nil && !now.Before(i.Waiting.FollowUp.Local())
+}
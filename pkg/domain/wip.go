@@ -0,0 +1,34 @@
+package domain
+
+import "strings"
+
+// WIPLimit caps how many tasks are in progress at once: open tasks tagged
+// Tag, without regard to case. A Limit of 0 means there's no cap.
+type WIPLimit struct {
+	Tag   string
+	Limit int
+}
+
+// Count returns how many of items are in progress. Subtasks count on their
+// own; tasks put aside for some day don't count.
+func (l WIPLimit) Count(items []Item) int {
+	n := 0
+	for _, item := range items {
+		if item.Completed() || item.Someday {
+			continue
+		}
+		for _, tag := range item.Tags {
+			if strings.EqualFold(tag, l.Tag) {
+				n++
+				break
+			}
+		}
+	}
+	return n
+}
+
+// Exceeded reports whether more of items are in progress than the limit
+// allows.
+func (l WIPLimit) Exceeded(items []Item) bool {
+	return l.Limit > 0 && l.Count(items) > l.Limit
+}
@@ -0,0 +1,94 @@
+// Package fold normalizes text for matching, so that "cafe" finds "Café" and
+// "STRASSE" finds "straße", while remembering where each folded character came
+// from so matches can still be highlighted in the original text.
+package fold
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Folder folds text for matching. Case is always folded; diacritics are only
+// stripped when StripDiacritics is set.
+type Folder struct {
+	StripDiacritics bool
+}
+
+// Folded is text folded by a Folder, together with the mapping back to the
+// text it was folded from.
+type Folded struct {
+	Text string
+
+	// origin[i] is the rune index in the original text that byte i of Text
+	// came from.
+	origin []int
+
+	// starts[r] is the offset in Text where original rune r begins; runes
+	// that folded to nothing start where the next one does.
+	starts []int
+}
+
+// String folds s.
+func (f Folder) String(s string) string {
+	return f.Fold(s).Text
+}
+
+// Fold folds s rune by rune. One original rune can fold to several ("ß" to
+// "ss") or to none at all (a combining accent when stripping diacritics).
+func (f Folder) Fold(s string) Folded {
+	caser := cases.Fold()
+
+	var b strings.Builder
+	origin := make([]int, 0, len(s))
+	starts := make([]int, 0, len(s)+1)
+	i := 0
+	for _, r := range s {
+		starts = append(starts, b.Len())
+		folded := caser.String(f.strip(string(r)))
+		b.WriteString(folded)
+		for range len(folded) {
+			origin = append(origin, i)
+		}
+		i++
+	}
+	starts = append(starts, b.Len())
+	return Folded{Text: b.String(), origin: origin, starts: starts}
+}
+
+func (f Folder) strip(s string) string {
+	if !f.StripDiacritics {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		return r
+	}, norm.NFD.String(s))
+}
+
+// Runes maps byte offsets into Text to the rune indices of the original text
+// they came from, in order and without duplicates. Combining marks that were
+// stripped go along with the rune they belong to, so a highlighted "é" written
+// as "e" plus an accent is highlighted whole. Offsets out of range are dropped.
+func (f Folded) Runes(offsets []int) []int {
+	runes := make([]int, 0, len(offsets))
+	for _, o := range offsets {
+		if o < 0 || o >= len(f.origin) {
+			continue
+		}
+		r := f.origin[o]
+		if n := len(runes); n > 0 && runes[n-1] >= r {
+			continue
+		}
+		runes = append(runes, r)
+		for r+1 < len(f.starts)-1 && f.starts[r+1] == f.starts[r+2] {
+			r++
+			runes = append(runes, r)
+		}
+	}
+	return runes
+}
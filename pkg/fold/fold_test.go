@@ -0,0 +1,56 @@
+package fold
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFolderString(t *testing.T) {
+	tests := []struct {
+		s           string
+		keep, strip string
+	}{
+		{"Café", "café", "cafe"},
+		{"cafe\u0301", "cafe\u0301", "cafe"},
+		{"STRASSE", "strasse", "strasse"},
+		{"Straße", "strasse", "strasse"},
+		{"ΣΊΣΥΦΟΣ", "σίσυφοσ", "σισυφοσ"},
+		{"Ångström", "ångström", "angstrom"},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		if got := (Folder{}).String(tt.s); got != tt.keep {
+			t.Errorf("String(%q) = %q, want %q", tt.s, got, tt.keep)
+		}
+		if got := (Folder{StripDiacritics: true}).String(tt.s); got != tt.strip {
+			t.Errorf("String(%q) stripping diacritics = %q, want %q", tt.s, got, tt.strip)
+		}
+	}
+}
+
+func TestFoldedRunes(t *testing.T) {
+	strip := Folder{StripDiacritics: true}
+	tests := []struct {
+		name    string
+		folder  Folder
+		s       string
+		offsets []int
+		want    []int
+	}{
+		{"one to one", Folder{}, "Milk", []int{1, 2}, []int{1, 2}},
+		{"ß folds to two", Folder{}, "Maße", []int{2, 3, 4}, []int{2, 3}},
+		{"both halves of ß are the one rune", Folder{}, "Maße", []int{3}, []int{2}},
+		{"multi-byte rune", Folder{}, "Café", []int{3, 4}, []int{3}},
+		{"stripped accent goes along", strip, "cafe\u0301s", []int{3}, []int{3, 4}},
+		{"after a stripped accent", strip, "cafe\u0301s", []int{4}, []int{5}},
+		{"precomposed accent", strip, "cafés", []int{3, 4}, []int{3, 4}},
+		{"out of range", Folder{}, "tea", []int{-1, 1, 3, 9}, []int{1}},
+		{"out of order", Folder{}, "tea", []int{2, 0}, []int{2}},
+		{"none", Folder{}, "tea", nil, []int{}},
+	}
+	for _, tt := range tests {
+		if got := tt.folder.Fold(tt.s).Runes(tt.offsets); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: Fold(%q).Runes(%v) = %v, want %v", tt.name, tt.s, tt.offsets, got, tt.want)
+		}
+	}
+}
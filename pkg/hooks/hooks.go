@@ -0,0 +1,135 @@
+package hooks
+
+import (
+	"bytes"
+	"clitodo/pkg/config"
+	"clitodo/pkg/domain"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Event names a mutation hooks can be attached to.
+type Event string
+
+const (
+	EventAdd      Event = "add"
+	EventComplete Event = "complete"
+	EventDelete   Event = "delete"
+)
+
+// Executor runs a hook command with the given stdin and returns its exit code.
+// Tests replace ShellExecutor with a fake so no real shells are spawned.
+type Executor interface {
+	Execute(ctx context.Context, command string, stdin []byte) (exitCode int, err error)
+}
+
+// ShellExecutor runs hook commands through the system shell.
+type ShellExecutor struct{}
+
+func (ShellExecutor) Execute(ctx context.Context, command string, stdin []byte) (int, error) {
+	var c *exec.Cmd
+	if runtime.GOOS == "windows" {
+		c = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		c = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	c.Stdin = bytes.NewReader(stdin)
+
+	err := c.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	return 0, err
+}
+
+// Runner fires the hooks configured for each event.
+type Runner struct {
+	config   config.Hooks
+	executor Executor
+}
+
+// New returns a Runner for the given configuration. A nil Runner is valid and
+// never runs anything.
+func New(cfg config.Hooks, executor Executor) *Runner {
+	return &Runner{config: cfg, executor: executor}
+}
+
+// Error describes a hook that failed. It never means the mutation itself failed.
+type Error struct {
+	Event    Event
+	ExitCode int
+	Err      error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("on_%s hook failed: %v", e.Event, e.Err)
+	}
+	return fmt.Sprintf("on_%s hook failed with exit code %d", e.Event, e.ExitCode)
+}
+
+// Enabled returns whether a hook would run for event.
+func (r *Runner) Enabled(event Event) bool {
+	return r != nil && r.config.Enabled && r.command(event) != ""
+}
+
+// Run executes the hook for event with item as JSON on stdin, waiting at most
+// the configured timeout. It returns nil when no hook is configured or the hook
+// succeeded.
+func (r *Runner) Run(event Event, item domain.Item) error {
+	if !r.Enabled(event) {
+		return nil
+	}
+
+	payload, err := json.Marshal(item)
+	if err != nil {
+		return &Error{Event: event, Err: err}
+	}
+
+	ctx := context.Background()
+	if r.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.config.Timeout)
+		defer cancel()
+	}
+
+	code, err := r.executor.Execute(ctx, expandHome(r.command(event)), payload)
+	if ctx.Err() != nil {
+		return &Error{Event: event, Err: fmt.Errorf("timed out after %s", r.config.Timeout)}
+	}
+	if err != nil || code != 0 {
+		return &Error{Event: event, ExitCode: code, Err: err}
+	}
+	return nil
+}
+
+func (r *Runner) command(event Event) string {
+	switch event {
+	case EventAdd:
+		return r.config.OnAdd
+	case EventComplete:
+		return r.config.OnComplete
+	case EventDelete:
+		return r.config.OnDelete
+	}
+	return ""
+}
+
+func expandHome(command string) string {
+	if !strings.HasPrefix(command, "~/") {
+		return command
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return command
+	}
+	return filepath.Join(home, command[2:])
+}
@@ -0,0 +1,127 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"clitodo/pkg/config"
+	"clitodo/pkg/domain"
+)
+
+// fakeExecutor records what it's asked to run and exits with code. If block
+// is set it waits for the context to end instead.
+type fakeExecutor struct {
+	code  int
+	err   error
+	block bool
+
+	commands []string
+	stdin    [][]byte
+}
+
+func (e *fakeExecutor) Execute(ctx context.Context, command string, stdin []byte) (int, error) {
+	e.commands = append(e.commands, command)
+	e.stdin = append(e.stdin, stdin)
+	if e.block {
+		<-ctx.Done()
+		return -1, ctx.Err()
+	}
+	return e.code, e.err
+}
+
+var allHooks = config.Hooks{Enabled: true, OnAdd: "on-add", OnComplete: "on-complete", OnDelete: "on-delete"}
+
+func TestRunEvents(t *testing.T) {
+	item := domain.NewItem("pay the rent")
+	for event, command := range map[Event]string{EventAdd: "on-add", EventComplete: "on-complete", EventDelete: "on-delete"} {
+		t.Run(string(event), func(t *testing.T) {
+			executor := &fakeExecutor{}
+			if err := New(allHooks, executor).Run(event, item); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			if len(executor.commands) != 1 || executor.commands[0] != command {
+				t.Fatalf("ran %q, want %q", executor.commands, command)
+			}
+			var got domain.Item
+			if err := json.Unmarshal(executor.stdin[0], &got); err != nil {
+				t.Fatalf("stdin isn't an item: %v", err)
+			}
+			if got.ID != item.ID || got.Title() != item.Title() {
+				t.Errorf("stdin has %s %q, want %s %q", got.ID, got.Title(), item.ID, item.Title())
+			}
+		})
+	}
+}
+
+func TestRunNothingConfigured(t *testing.T) {
+	tests := []struct {
+		name   string
+		runner *Runner
+	}{
+		{"nil runner", nil},
+		{"disabled", New(config.Hooks{OnAdd: "on-add"}, &fakeExecutor{})},
+		{"no command for the event", New(config.Hooks{Enabled: true, OnComplete: "on-complete"}, &fakeExecutor{})},
+	}
+	for _, tt := range tests {
+		if tt.runner.Enabled(EventAdd) {
+			t.Errorf("%s: Enabled(add) = true", tt.name)
+		}
+		if err := tt.runner.Run(EventAdd, domain.NewItem("pay the rent")); err != nil {
+			t.Errorf("%s: Run() error = %v", tt.name, err)
+		}
+		if tt.runner != nil && len(tt.runner.executor.(*fakeExecutor).commands) != 0 {
+			t.Errorf("%s: a hook ran", tt.name)
+		}
+	}
+}
+
+func TestRunFailures(t *testing.T) {
+	item := domain.NewItem("pay the rent")
+
+	var hookErr *Error
+	err := New(allHooks, &fakeExecutor{code: 3}).Run(EventDelete, item)
+	if !errors.As(err, &hookErr) || hookErr.Event != EventDelete || hookErr.ExitCode != 3 {
+		t.Errorf("exit code 3 gave %v, want an *Error with the event and code", err)
+	}
+	if err != nil && err.Error() != "on_delete hook failed with exit code 3" {
+		t.Errorf("Error() = %q", err)
+	}
+
+	err = New(allHooks, &fakeExecutor{err: errors.New("sh not found")}).Run(EventAdd, item)
+	if err == nil || err.Error() != "on_add hook failed: sh not found" {
+		t.Errorf("a hook that couldn't start gave %v", err)
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	cfg := allHooks
+	cfg.Timeout = 10 * time.Millisecond
+	executor := &fakeExecutor{block: true}
+
+	start := time.Now()
+	err := New(cfg, executor).Run(EventComplete, domain.NewItem("pay the rent"))
+	if err == nil || !strings.Contains(err.Error(), "timed out after 10ms") {
+		t.Errorf("Run() error = %v, want it to time out", err)
+	}
+	if waited := time.Since(start); waited > time.Second {
+		t.Errorf("Run() waited %s for a hook that times out after 10ms", waited)
+	}
+}
+
+func TestRunExpandsHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	executor := &fakeExecutor{}
+	cfg := config.Hooks{Enabled: true, OnAdd: "~/bin/on-add"}
+	if err := New(cfg, executor).Run(EventAdd, domain.NewItem("pay the rent")); err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(home, "bin", "on-add"); executor.commands[0] != want {
+		t.Errorf("ran %q, want %q", executor.commands[0], want)
+	}
+}
@@ -0,0 +1,116 @@
+package importer
+
+import (
+	"clitodo/pkg/domain"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrSkip is returned by Next for records that were read but can't be turned
+// into an item, such as blank lines or deleted tasks.
+var ErrSkip = errors.New("record skipped")
+
+// Importer reads items from an external source one record at a time, so large
+// files can be imported incrementally. Next returns io.EOF once the source is
+// exhausted.
+type Importer interface {
+	Next() (domain.Item, error)
+}
+
+// Progress counts what happened to the records read so far.
+type Progress struct {
+	Parsed  int
+	Created int
+	Skipped int
+}
+
+// Source is an opened import file. Read reports how many bytes of the file have
+// been consumed so callers can show a progress bar without knowing the number
+// of records up front.
+type Source struct {
+	Importer
+	file *os.File
+	size int64
+	read *countingReader
+}
+
+// Open opens the file at path and picks an importer based on its extension:
+// .json is read as a Taskwarrior export, anything else as todo.txt.
+func Open(path string) (*Source, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	src := &Source{file: file, size: info.Size(), read: &countingReader{r: file}}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		src.Importer = NewTaskwarriorImporter(src.read)
+	} else {
+		src.Importer = NewTodoTxtImporter(src.read)
+	}
+	return src, nil
+}
+
+// Fraction returns the share of the file consumed so far, between 0 and 1.
+func (s *Source) Fraction() float64 {
+	if s.size <= 0 {
+		return 1
+	}
+	return min(1, float64(s.read.n)/float64(s.size))
+}
+
+// Close releases the underlying file.
+func (s *Source) Close() error {
+	return s.file.Close()
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// Batch reads up to n records from imp and returns the items to create,
+// updating p as it goes. Titles already present in seen are skipped and
+// returned as duplicates; created titles are added to seen. done is true once
+// the source is exhausted.
+func Batch(imp Importer, n int, seen map[string]bool, p *Progress) (items, duplicates []domain.Item, done bool, err error) {
+	for i := 0; i < n; i++ {
+		item, err := imp.Next()
+		if errors.Is(err, io.EOF) {
+			return items, duplicates, true, nil
+		}
+		if errors.Is(err, ErrSkip) {
+			p.Parsed++
+			p.Skipped++
+			continue
+		}
+		if err != nil {
+			return items, duplicates, false, err
+		}
+
+		p.Parsed++
+		if seen[item.Title()] {
+			p.Skipped++
+			duplicates = append(duplicates, item)
+			continue
+		}
+		seen[item.Title()] = true
+		p.Created++
+		items = append(items, item)
+	}
+	return items, duplicates, false, nil
+}
@@ -0,0 +1,56 @@
+package importer
+
+import (
+	"clitodo/pkg/domain"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type taskwarriorTask struct {
+	Description string `json:"description"`
+	Status      string `json:"status"`
+}
+
+type taskwarriorImporter struct {
+	decoder *json.Decoder
+	started bool
+}
+
+// NewTaskwarriorImporter streams the JSON array produced by `task export`,
+// decoding one task at a time. Deleted tasks are skipped.
+func NewTaskwarriorImporter(r io.Reader) Importer {
+	return &taskwarriorImporter{decoder: json.NewDecoder(r)}
+}
+
+func (t *taskwarriorImporter) Next() (domain.Item, error) {
+	if !t.started {
+		tok, err := t.decoder.Token()
+		if err != nil {
+			return domain.Item{}, err
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return domain.Item{}, fmt.Errorf("taskwarrior export must be a JSON array")
+		}
+		t.started = true
+	}
+
+	if !t.decoder.More() {
+		return domain.Item{}, io.EOF
+	}
+
+	var task taskwarriorTask
+	if err := t.decoder.Decode(&task); err != nil {
+		return domain.Item{}, err
+	}
+
+	title := strings.TrimSpace(task.Description)
+	if title == "" || task.Status == "deleted" {
+		return domain.Item{}, ErrSkip
+	}
+
+	item := domain.NewItem(title)
+	item.ItemCompleted = task.Status == "completed"
+	return item, nil
+}
@@ -0,0 +1,57 @@
+package importer
+
+import (
+	"bufio"
+	"clitodo/pkg/domain"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var (
+	todoTxtPriority = regexp.MustCompile(`^\([A-Z]\) `)
+	todoTxtDate     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2} `)
+)
+
+type todoTxtImporter struct {
+	scanner *bufio.Scanner
+}
+
+// NewTodoTxtImporter reads one task per line in the todo.txt format. Completion
+// markers, priorities and dates are stripped from the title.
+func NewTodoTxtImporter(r io.Reader) Importer {
+	return &todoTxtImporter{scanner: bufio.NewScanner(r)}
+}
+
+func (t *todoTxtImporter) Next() (domain.Item, error) {
+	if !t.scanner.Scan() {
+		if err := t.scanner.Err(); err != nil {
+			return domain.Item{}, err
+		}
+		return domain.Item{}, io.EOF
+	}
+
+	line := strings.TrimSpace(t.scanner.Text())
+	if line == "" {
+		return domain.Item{}, ErrSkip
+	}
+
+	completed := false
+	if strings.HasPrefix(line, "x ") {
+		completed = true
+		line = line[2:]
+	}
+	line = todoTxtPriority.ReplaceAllString(line, "")
+	// Completion and creation dates, in that order.
+	line = todoTxtDate.ReplaceAllString(line, "")
+	line = todoTxtDate.ReplaceAllString(line, "")
+
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return domain.Item{}, ErrSkip
+	}
+
+	item := domain.NewItem(line)
+	item.ItemCompleted = completed
+	return item, nil
+}
@@ -0,0 +1,142 @@
+package match
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/sahilm/fuzzy"
+
+	"clitodo/pkg/fold"
+)
+
+// Filters are the ways the filter can match items, by the name the config
+// picks one with. Each is set up with how to fold the term and the items;
+// regular expressions fold case on their own.
+var Filters = map[string]func(fold.Folder) FilterFunc{
+	"fuzzy":     NewFilter,
+	"substring": NewSubstringFilter,
+	"regex":     func(fold.Folder) FilterFunc { return RegexFilter },
+	"tags":      NewTagFilter,
+}
+
+// FilterNames returns the names of Filters in order.
+func FilterNames() []string {
+	return slices.Sorted(maps.Keys(Filters))
+}
+
+// FilterByName returns the filter of the given name from Filters, folding
+// with folder. An empty name is the fuzzy one.
+func FilterByName(name string, folder fold.Folder) (FilterFunc, error) {
+	if name == "" {
+		name = "fuzzy"
+	}
+	newFilter, ok := Filters[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown filter %q; use one of %s", name, strings.Join(FilterNames(), ", "))
+	}
+	return newFilter(folder), nil
+}
+
+// NewSubstringFilter returns a filter that keeps the targets containing the
+// term as it's typed, in their original order.
+func NewSubstringFilter(folder fold.Folder) FilterFunc {
+	return func(term string, targets []string) []Rank {
+		return foldedFind(folder, term, targets, func(term string, targets []string) fuzzy.Matches {
+			var matches fuzzy.Matches
+			for i, target := range targets {
+				if offsets := substringOffsets(term, target); offsets != nil {
+					matches = append(matches, fuzzy.Match{Str: target, Index: i, MatchedIndexes: offsets})
+				}
+			}
+			return matches
+		})
+	}
+}
+
+// substringOffsets returns the byte offsets of every occurrence of term in
+// target, or nil if there is none.
+func substringOffsets(term, target string) []int {
+	if term == "" {
+		return nil
+	}
+	var offsets []int
+	for start := 0; ; {
+		i := strings.Index(target[start:], term)
+		if i < 0 {
+			return offsets
+		}
+		for o := range len(term) {
+			offsets = append(offsets, start+i+o)
+		}
+		start += i + len(term)
+	}
+}
+
+// NewTagFilter returns a filter that matches each word of the term on its
+// own, and keeps the targets all of them match: "#work" only a whole tag,
+// like the "#work" in "call Bob #work", and other words fuzzily. The best
+// matches come first.
+func NewTagFilter(folder fold.Folder) FilterFunc {
+	return func(term string, targets []string) []Rank {
+		return foldedFind(folder, term, targets, func(term string, targets []string) fuzzy.Matches {
+			words := strings.Fields(term)
+			var matches fuzzy.Matches
+			for i, target := range targets {
+				if m, ok := matchWords(words, target); ok {
+					m.Index = i
+					matches = append(matches, m)
+				}
+			}
+			slices.SortStableFunc(matches, func(a, b fuzzy.Match) int { return b.Score - a.Score })
+			return matches
+		})
+	}
+}
+
+// matchWords matches every word against target as NewTagFilter does, and
+// adds up where and how well they matched.
+func matchWords(words []string, target string) (fuzzy.Match, bool) {
+	m := fuzzy.Match{Str: target}
+	for _, word := range words {
+		if strings.HasPrefix(word, "#") && len(word) > 1 {
+			offsets := tagOffsets(word, target)
+			if offsets == nil {
+				return fuzzy.Match{}, false
+			}
+			m.MatchedIndexes = append(m.MatchedIndexes, offsets...)
+			continue
+		}
+		found := fuzzy.Find(word, []string{target})
+		if len(found) == 0 {
+			return fuzzy.Match{}, false
+		}
+		m.MatchedIndexes = append(m.MatchedIndexes, found[0].MatchedIndexes...)
+		m.Score += found[0].Score
+	}
+	slices.Sort(m.MatchedIndexes)
+	m.MatchedIndexes = slices.Compact(m.MatchedIndexes)
+	return m, true
+}
+
+// tagOffsets returns the byte offsets of the first occurrence of tag, like
+// "#work", in target that's a tag of its own and not the start of a longer
+// one, or nil if there is none.
+func tagOffsets(tag, target string) []int {
+	for start := 0; ; {
+		i := strings.Index(target[start:], tag)
+		if i < 0 {
+			return nil
+		}
+		begin, end := start+i, start+i+len(tag)
+		if (begin == 0 || target[begin-1] == ' ') && (end == len(target) || target[end] == ' ') {
+			offsets := make([]int, 0, len(tag))
+			for o := begin; o < end; o++ {
+				offsets = append(offsets, o)
+			}
+			return offsets
+		}
+		start = begin + 1
+	}
+}
@@ -0,0 +1,85 @@
+// Package match finds the items a filter term matches, the way the TUI's
+// filter and the CLI's title lookups both do. Terms and items are folded
+// first, and what matched is reported as rune indices into the original
+// items so it can be highlighted.
+package match
+
+import (
+	"sort"
+
+	"github.com/sahilm/fuzzy"
+
+	"clitodo/pkg/fold"
+)
+
+// FilterFunc takes a term and a list of strings to search through
+// (defined by domain.Item#FilterValue).
+// It should return a sorted list of ranks. An empty term matches every
+// target, in order, with nothing highlighted.
+type FilterFunc func(string, []string) []Rank
+
+// Rank defines a rank for a given item.
+type Rank struct {
+	// The index of the item in the original input.
+	Index int
+	// Indices of the runes in the item's filter value that were matched
+	// against the filter term.
+	MatchedIndexes []int
+}
+
+// DefaultFolder is how DefaultFilter and UnsortedFilter normalize the term and
+// the items before matching: case is folded and accents are stripped.
+var DefaultFolder = fold.Folder{StripDiacritics: true}
+
+// DefaultFilter uses the sahilm/fuzzy to filter through the list.
+func DefaultFilter(term string, targets []string) []Rank {
+	return NewFilter(DefaultFolder)(term, targets)
+}
+
+// UnsortedFilter uses the sahilm/fuzzy to filter through the list. It does not
+// sort the results.
+func UnsortedFilter(term string, targets []string) []Rank {
+	return foldedFind(DefaultFolder, term, targets, fuzzy.FindNoSort)
+}
+
+// NewFilter returns a filter like DefaultFilter that normalizes the term and
+// the targets with folder before matching.
+func NewFilter(folder fold.Folder) FilterFunc {
+	return func(term string, targets []string) []Rank {
+		return foldedFind(folder, term, targets, func(term string, targets []string) fuzzy.Matches {
+			ranks := fuzzy.Find(term, targets)
+			sort.Stable(ranks)
+			return ranks
+		})
+	}
+}
+
+// foldedFind runs find on the folded term and targets and maps the matched
+// indexes back to rune positions in the original targets, so highlighting
+// lands on the right characters even when folding changed the length.
+func foldedFind(folder fold.Folder, term string, targets []string, find func(string, []string) fuzzy.Matches) []Rank {
+	if term == "" {
+		ranks := make([]Rank, len(targets))
+		for i := range targets {
+			ranks[i] = Rank{Index: i}
+		}
+		return ranks
+	}
+
+	folded := make([]fold.Folded, len(targets))
+	texts := make([]string, len(targets))
+	for i, target := range targets {
+		folded[i] = folder.Fold(target)
+		texts[i] = folded[i].Text
+	}
+
+	ranks := find(folder.String(term), texts)
+	result := make([]Rank, len(ranks))
+	for i, r := range ranks {
+		result[i] = Rank{
+			Index:          r.Index,
+			MatchedIndexes: folded[r.Index].Runes(r.MatchedIndexes),
+		}
+	}
+	return result
+}
@@ -0,0 +1,107 @@
+package match
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"clitodo/pkg/fold"
+)
+
+// contractTargets are items the way FilterValue makes them: with accents
+// written both ways, letters that fold to several, and tags.
+var contractTargets = []string{
+	"pay the rent",
+	"Café with Ana #social",
+	"café notes",
+	"Straße sweeping #home",
+	"東京 trip #work #travel",
+	"ΣΊΣΥΦΟΣ rock",
+	"",
+	"call Bob #work",
+}
+
+var contractTerms = []string{"rent", "cafe", "café", "ss", "strasse", "#work", "work trip", "東京", "σισυφ", "e", "zzz"}
+
+// TestFilterContract holds every filter to what FilterFunc promises, however
+// it matches.
+func TestFilterContract(t *testing.T) {
+	filters := map[string]FilterFunc{
+		"RegexFilter":    RegexFilter,
+		"DefaultFilter":  DefaultFilter,
+		"UnsortedFilter": UnsortedFilter,
+	}
+	for _, name := range FilterNames() {
+		for _, strip := range []bool{false, true} {
+			f, err := FilterByName(name, fold.Folder{StripDiacritics: strip})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strip {
+				name += " stripping diacritics"
+			}
+			filters[name] = f
+		}
+	}
+
+	for name, filter := range filters {
+		t.Run(name, func(t *testing.T) {
+			ranks := filter("", contractTargets)
+			if len(ranks) != len(contractTargets) {
+				t.Fatalf("an empty term matched %d of %d targets", len(ranks), len(contractTargets))
+			}
+			for i, r := range ranks {
+				if r.Index != i || len(r.MatchedIndexes) != 0 {
+					t.Errorf("an empty term ranked %d as %d, matching %v", i, r.Index, r.MatchedIndexes)
+				}
+			}
+
+			for _, term := range contractTerms {
+				checkRanks(t, term, filter(term, contractTargets))
+			}
+		})
+	}
+}
+
+// checkRanks checks that ranks are of distinct targets, and that what they
+// matched are runes of the target, in order, that fold to something of the
+// term, or to nothing at all for accents stripped off the rune before them.
+func checkRanks(t *testing.T, term string, ranks []Rank) {
+	t.Helper()
+	folder := fold.Folder{StripDiacritics: true}
+	foldedTerm := folder.String(term)
+	seen := make(map[int]bool)
+	for _, r := range ranks {
+		if r.Index < 0 || r.Index >= len(contractTargets) {
+			t.Errorf("%q ranked index %d of %d targets", term, r.Index, len(contractTargets))
+			continue
+		}
+		if seen[r.Index] {
+			t.Errorf("%q ranked %d twice", term, r.Index)
+		}
+		seen[r.Index] = true
+
+		runes := []rune(contractTargets[r.Index])
+		if !slices.IsSorted(r.MatchedIndexes) || len(slices.Compact(slices.Clone(r.MatchedIndexes))) != len(r.MatchedIndexes) {
+			t.Errorf("%q matched %v in %q, out of order", term, r.MatchedIndexes, contractTargets[r.Index])
+		}
+		for _, m := range r.MatchedIndexes {
+			if m < 0 || m >= len(runes) {
+				t.Errorf("%q matched rune %d of %q, which has %d", term, m, contractTargets[r.Index], len(runes))
+				continue
+			}
+			if folded := folder.String(string(runes[m])); folded != "" && !strings.ContainsAny(foldedTerm, folded) {
+				t.Errorf("%q matched %q at %d in %q", term, runes[m], m, contractTargets[r.Index])
+			}
+		}
+	}
+}
+
+func TestFilterByName(t *testing.T) {
+	if _, err := FilterByName("", fold.Folder{}); err != nil {
+		t.Errorf("FilterByName(\"\") error = %v, want the fuzzy filter", err)
+	}
+	if _, err := FilterByName("exact", fold.Folder{}); err == nil || !strings.Contains(err.Error(), "fuzzy, regex, substring, tags") {
+		t.Errorf("FilterByName(\"exact\") error = %v, want the filters listed", err)
+	}
+}
@@ -0,0 +1,38 @@
+package match
+
+import (
+	"regexp"
+	"unicode/utf8"
+)
+
+// RegexFilter keeps the targets the term, a regular expression, matches, in
+// their original order. Case is ignored unless the term turns that off with
+// (?-i). A term that doesn't compile matches nothing.
+func RegexFilter(term string, targets []string) []Rank {
+	re, err := CompilePattern(term)
+	if err != nil {
+		return nil
+	}
+	var ranks []Rank
+	for i, target := range targets {
+		locs := re.FindAllStringIndex(target, -1)
+		if locs == nil {
+			continue
+		}
+		// The matches are byte ranges; highlighting wants runes.
+		var matched []int
+		for _, loc := range locs {
+			first := utf8.RuneCountInString(target[:loc[0]])
+			for r := range utf8.RuneCountInString(target[loc[0]:loc[1]]) {
+				matched = append(matched, first+r)
+			}
+		}
+		ranks = append(ranks, Rank{Index: i, MatchedIndexes: matched})
+	}
+	return ranks
+}
+
+// CompilePattern compiles term the way RegexFilter does, ignoring case.
+func CompilePattern(term string) (*regexp.Regexp, error) {
+	return regexp.Compile("(?i)" + term)
+}
@@ -0,0 +1,128 @@
+package notify
+
+import (
+	"clitodo/pkg/clock"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Notification is a message for the user outside of the list itself.
+type Notification struct {
+	Title string
+	Body  string
+
+	// Bell additionally rings the terminal bell.
+	Bell bool
+}
+
+// Sender delivers notifications.
+type Sender interface {
+	Send(n Notification) error
+}
+
+// DesktopSender shows notifications with the platform's notifier
+// (notify-send on Linux, osascript on macOS) and rings the bell on Bell.
+type DesktopSender struct {
+	// Where the bell character is written, usually the terminal.
+	Terminal io.Writer
+}
+
+func (d DesktopSender) Send(n Notification) error {
+	if n.Bell && d.Terminal != nil {
+		fmt.Fprint(d.Terminal, "\a")
+	}
+
+	var c *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", n.Body, n.Title)
+		c = exec.Command("osascript", "-e", script)
+	case "linux", "freebsd", "openbsd":
+		c = exec.Command("notify-send", n.Title, n.Body)
+	default:
+		return nil
+	}
+	return c.Run()
+}
+
+// Center routes notifications through the quiet hours schedule: while quiet
+// hours are active notifications are queued, and Flush delivers them as one
+// digest once they're over. A nil Center drops everything.
+type Center struct {
+	sender Sender
+	clock  clock.Clock
+	quiet  QuietHours
+
+	mu     sync.Mutex
+	queued []Notification
+}
+
+// NewCenter returns a Center sending through sender.
+func NewCenter(sender Sender, c clock.Clock, quiet QuietHours) *Center {
+	return &Center{sender: sender, clock: c, quiet: quiet}
+}
+
+// Quiet returns whether quiet hours are active right now.
+func (c *Center) Quiet() bool {
+	return c != nil && c.quiet.Active(c.clock.Now())
+}
+
+// Queued returns the number of notifications held back by quiet hours.
+func (c *Center) Queued() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.queued)
+}
+
+// Notify sends n, or queues it during quiet hours.
+func (c *Center) Notify(n Notification) error {
+	if c == nil {
+		return nil
+	}
+	if c.Quiet() {
+		c.mu.Lock()
+		c.queued = append(c.queued, n)
+		c.mu.Unlock()
+		return nil
+	}
+	return c.sender.Send(n)
+}
+
+// Flush delivers the queued notifications as a single digest if quiet hours
+// are over. It's a no-op while they're still active or nothing is queued.
+func (c *Center) Flush() error {
+	if c == nil || c.Quiet() {
+		return nil
+	}
+
+	c.mu.Lock()
+	queued := c.queued
+	c.queued = nil
+	c.mu.Unlock()
+
+	switch len(queued) {
+	case 0:
+		return nil
+	case 1:
+		return c.sender.Send(queued[0])
+	}
+
+	lines := make([]string, len(queued))
+	for i, n := range queued {
+		lines[i] = n.Title
+		if n.Body != "" {
+			lines[i] += ": " + n.Body
+		}
+	}
+	return c.sender.Send(Notification{
+		Title: fmt.Sprintf("%d notifications during quiet hours", len(queued)),
+		Body:  strings.Join(lines, "\n"),
+	})
+}
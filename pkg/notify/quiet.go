@@ -0,0 +1,76 @@
+package notify
+
+import (
+	"fmt"
+	"time"
+)
+
+// QuietHours is a daily local-time window during which notifications are held
+// back. The window may cross midnight, e.g. 22:00–07:00. The zero value is
+// never active.
+type QuietHours struct {
+	start, end time.Duration // offsets from local midnight
+	set        bool
+}
+
+// ParseQuietHours parses a window given as two "HH:MM" times.
+func ParseQuietHours(start, end string) (QuietHours, error) {
+	if start == "" && end == "" {
+		return QuietHours{}, nil
+	}
+	s, err := parseClockTime(start)
+	if err != nil {
+		return QuietHours{}, fmt.Errorf("quiet hours start: %w", err)
+	}
+	e, err := parseClockTime(end)
+	if err != nil {
+		return QuietHours{}, fmt.Errorf("quiet hours end: %w", err)
+	}
+	return QuietHours{start: s, end: e, set: s != e}, nil
+}
+
+func parseClockTime(v string) (time.Duration, error) {
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return 0, fmt.Errorf("expected HH:MM, got %q", v)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Active returns whether t falls inside the window, using t's location.
+func (q QuietHours) Active(t time.Time) bool {
+	if !q.set {
+		return false
+	}
+	offset := time.Duration(t.Hour())*time.Hour +
+		time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second
+	if q.start < q.end {
+		return offset >= q.start && offset < q.end
+	}
+	// The window crosses midnight.
+	return offset >= q.start || offset < q.end
+}
+
+// End returns when the window t falls in is over, or t if it isn't active.
+func (q QuietHours) End(t time.Time) time.Time {
+	if !q.Active(t) {
+		return t
+	}
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	end := midnight.Add(q.end)
+	if !end.After(t) {
+		end = midnight.AddDate(0, 0, 1).Add(q.end)
+	}
+	return end
+}
+
+func (q QuietHours) String() string {
+	if !q.set {
+		return "off"
+	}
+	format := func(d time.Duration) string {
+		return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return format(q.start) + "–" + format(q.end)
+}
@@ -0,0 +1,38 @@
+package notify
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuietHoursEnd(t *testing.T) {
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 3, day, hour, minute, 0, 0, time.UTC)
+	}
+	overnight, err := ParseQuietHours("22:00", "07:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lunch, err := ParseQuietHours("12:00", "13:30")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		quiet QuietHours
+		t     time.Time
+		want  time.Time
+	}{
+		{"before midnight", overnight, at(10, 23, 15), at(11, 7, 0)},
+		{"after midnight", overnight, at(11, 3, 0), at(11, 7, 0)},
+		{"not active", overnight, at(11, 9, 0), at(11, 9, 0)},
+		{"within a day", lunch, at(10, 12, 45), at(10, 13, 30)},
+		{"off", QuietHours{}, at(10, 23, 15), at(10, 23, 15)},
+	}
+	for _, tt := range tests {
+		if got := tt.quiet.End(tt.t); !got.Equal(tt.want) {
+			t.Errorf("%s: End(%s) = %s, want %s", tt.name, tt.t, got, tt.want)
+		}
+	}
+}
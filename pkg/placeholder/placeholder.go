@@ -0,0 +1,92 @@
+// Package placeholder fills in the {{name}} placeholders of task templates
+// with the values given for them.
+package placeholder
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MissingError is returned by Expand for placeholders no value was given
+// for.
+type MissingError struct {
+	// Names of the placeholders without a value, in the order they first
+	// appear.
+	Names []string
+}
+
+func (e *MissingError) Error() string {
+	return fmt.Sprintf("missing variables: %s", strings.Join(e.Names, ", "))
+}
+
+// Expand replaces each {{name}} in text with the value of name in vars.
+// Spaces around the name are ignored, so {{ city }} works as well. A
+// backslash in front keeps the braces as they are: \{{city}} stays {{city}}
+// without the backslash. Braces around nothing or around more than one line
+// aren't placeholders and are left alone. If any placeholder has no value,
+// Expand returns a *MissingError naming all of them.
+func Expand(text string, vars map[string]string) (string, error) {
+	var (
+		b       strings.Builder
+		missing []string
+	)
+	scan(text, func(literal, name string) {
+		if name == "" {
+			b.WriteString(literal)
+			return
+		}
+		value, ok := vars[name]
+		if !ok && !slices.Contains(missing, name) {
+			missing = append(missing, name)
+		}
+		b.WriteString(value)
+	})
+	if len(missing) > 0 {
+		return "", &MissingError{Names: missing}
+	}
+	return b.String(), nil
+}
+
+// Names returns the names of the placeholders in text, each once, in the
+// order they first appear.
+func Names(text string) []string {
+	var names []string
+	scan(text, func(_, name string) {
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	})
+	return names
+}
+
+// scan splits text into literal text and placeholders, calling yield with
+// either the literal text, escapes resolved, or a placeholder's name.
+func scan(text string, yield func(literal, name string)) {
+	for text != "" {
+		i := strings.Index(text, "{{")
+		if i < 0 {
+			yield(text, "")
+			return
+		}
+		if i > 0 && text[i-1] == '\\' {
+			yield(text[:i-1]+"{{", "")
+			text = text[i+2:]
+			continue
+		}
+		end := strings.Index(text[i+2:], "}}")
+		inner := ""
+		if end >= 0 {
+			inner = text[i+2 : i+2+end]
+		}
+		name := strings.TrimSpace(inner)
+		if name == "" || strings.ContainsAny(inner, "{}\n") {
+			yield(text[:i+2], "")
+			text = text[i+2:]
+			continue
+		}
+		yield(text[:i], "")
+		yield("", name)
+		text = text[i+2+end+2:]
+	}
+}
@@ -0,0 +1,74 @@
+package placeholder
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	vars := map[string]string{"city": "Lisbon", "date": "May 3", "b": "bags"}
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"book a hotel in {{city}}", "book a hotel in Lisbon"},
+		{"{{city}} on {{date}}, back from {{city}}", "Lisbon on May 3, back from Lisbon"},
+		{"book a hotel in {{ city }}", "book a hotel in Lisbon"},
+		{"book a hotel in {{\tcity  }}", "book a hotel in Lisbon"},
+		{`book a hotel in \{{city}}`, "book a hotel in {{city}}"},
+		{`\{{city}} or {{city}}`, "{{city}} or Lisbon"},
+		{`\\{{city}}`, `\{{city}}`},
+		{"book a hotel in {{city", "book a hotel in {{city"},
+		{"book a hotel in {{city}", "book a hotel in {{city}"},
+		{"{{}} and {{   }}", "{{}} and {{   }}"},
+		{"{{city\n}}", "{{city\n}}"},
+		{"pack {{a {{b}}", "pack {{a bags"},
+		{"pack {{b}}}}", "pack bags}}"},
+		{"no placeholders }} at all", "no placeholders }} at all"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got, err := Expand(tt.text, vars)
+		if err != nil || got != tt.want {
+			t.Errorf("Expand(%q) = %q, %v, want %q", tt.text, got, err, tt.want)
+		}
+	}
+}
+
+func TestExpandMissing(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"book a hotel in {{city}}", []string{"city"}},
+		{"{{ date }}: {{city}}, {{date}}, {{hotel}}, {{city}}", []string{"date", "city", "hotel"}},
+		{`\{{date}} {{hotel}} {{a {{city}}`, []string{"hotel", "city"}},
+	}
+	for _, tt := range tests {
+		got, err := Expand(tt.text, map[string]string{"b": "bags"})
+		var missing *MissingError
+		if !errors.As(err, &missing) {
+			t.Errorf("Expand(%q) = %q, %v, want a *MissingError", tt.text, got, err)
+			continue
+		}
+		if !slices.Equal(missing.Names, tt.want) {
+			t.Errorf("Expand(%q) is missing %q, want %q", tt.text, missing.Names, tt.want)
+		}
+		if got != "" {
+			t.Errorf("Expand(%q) = %q along with the error", tt.text, got)
+		}
+	}
+
+	err := &MissingError{Names: []string{"date", "city"}}
+	if want := "missing variables: date, city"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestNames(t *testing.T) {
+	text := `{{ date }} in {{city}}: \{{hotel}}, {{city}}, {{a {{b}}, {{unclosed`
+	if got, want := Names(text), []string{"date", "city", "b"}; !slices.Equal(got, want) {
+		t.Errorf("Names() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,232 @@
+// Package position makes keys that put items in order by comparing as
+// strings. An item goes between two others by getting a key between theirs,
+// so nothing else is renumbered, and two copies of a list changed apart, on
+// two machines or two git branches, come out in the same order once merged and
+// sorted by key.
+//
+// Keys are fractions written in base 62, most significant digit first and
+// without the leading "0.": "V" is about one half and "V8" a bit more. A key
+// never ends in 0, so there is always room for one before it.
+package position
+
+import (
+	"slices"
+	"strings"
+)
+
+// digits are the digits of a key, in the order they compare as bytes.
+const digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// MaxLength is how long a key may get before Assign spreads all of them out
+// again. Adding items one after another, at the end or anywhere else, adds a
+// digit about every thirty; always going halfway between two items, every
+// six.
+const MaxLength = 8
+
+// Valid reports whether key is one this package could have made.
+func Valid(key string) bool {
+	if key == "" || key[len(key)-1] == '0' {
+		return false
+	}
+	for i := range len(key) {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Between returns a key that sorts after a and before b. An empty a stands
+// for the start and an empty b for the end, so Between("", "") is the first
+// key of a list. a and b must be valid, and a less than b, or it returns "".
+func Between(a, b string) string {
+	if a != "" && !Valid(a) || b != "" && !Valid(b) || a != "" && b != "" && a >= b {
+		return ""
+	}
+	return between(a, b)
+}
+
+func between(a, b string) string {
+	switch {
+	case b == "":
+		// After a. One up from its first digit keeps the keys of items
+		// appended one by one short.
+		if a == "" {
+			return digits[len(digits)/2 : len(digits)/2+1]
+		}
+		if d := strings.IndexByte(digits, a[0]); d < len(digits)-1 {
+			return digits[d+1 : d+2]
+		}
+		return a[:1] + between(a[1:], "")
+	case a == "":
+		// Before b, likewise one down from its first digit.
+		d := strings.IndexByte(digits, b[0])
+		switch {
+		case d > 1:
+			return digits[d-1 : d]
+		case d == 1 && len(b) > 1:
+			return b[:1]
+		}
+		return "0" + between("", b[1:])
+	}
+
+	// The digits both share stay; a is as if padded with 0s.
+	n := 0
+	for n < len(b) && digitAt(a, n) == b[n] {
+		n++
+	}
+	if n > 0 {
+		rest := ""
+		if n < len(a) {
+			rest = a[n:]
+		}
+		return b[:n] + between(rest, b[n:])
+	}
+
+	da := strings.IndexByte(digits, a[0])
+	db := strings.IndexByte(digits, b[0])
+	if db-da > 1 {
+		return digits[(da+db)/2 : (da+db)/2+1]
+	}
+	// The first digits are neighbours: b's first digit alone is in between if
+	// b goes on, and otherwise a's first digit followed by anything after the
+	// rest of a.
+	if len(b) > 1 {
+		return b[:1]
+	}
+	return a[:1] + between(a[1:], "")
+}
+
+// digitAt returns the digit of key at i, or 0 past its end.
+func digitAt(key string, i int) byte {
+	if i < len(key) {
+		return key[i]
+	}
+	return digits[0]
+}
+
+// BetweenN returns n keys in order, after a and before b, as Between does.
+func BetweenN(a, b string, n int) []string {
+	if n <= 0 || Between(a, b) == "" {
+		return nil
+	}
+	return betweenN(a, b, n)
+}
+
+func betweenN(a, b string, n int) []string {
+	keys := make([]string, n)
+	switch {
+	case n == 0:
+		return nil
+	case b == "":
+		for i := range keys {
+			a = between(a, "")
+			keys[i] = a
+		}
+		return keys
+	case a == "":
+		for i := n - 1; i >= 0; i-- {
+			b = between("", b)
+			keys[i] = b
+		}
+		return keys
+	}
+	// Halving keeps the keys about as short as n allows.
+	mid := between(a, b)
+	left := betweenN(a, mid, n/2)
+	right := betweenN(mid, b, n-1-n/2)
+	return append(append(left, mid), right...)
+}
+
+// Spread returns n keys in order, spread evenly from start to end and as
+// short as that allows.
+func Spread(n int) []string {
+	if n <= 0 {
+		return nil
+	}
+	length, capacity := 1, len(digits)
+	for capacity <= n {
+		length++
+		capacity *= len(digits)
+	}
+
+	keys := make([]string, n)
+	buf := make([]byte, length)
+	for i := range keys {
+		v := (i + 1) * capacity / (n + 1)
+		for j := length - 1; j >= 0; j-- {
+			buf[j] = digits[v%len(digits)]
+			v /= len(digits)
+		}
+		keys[i] = strings.TrimRight(string(buf), digits[:1])
+	}
+	return keys
+}
+
+// Assign returns keys for items in the order given, whose keys are current
+// so far; "" is an item without one yet. As many of them as can be are kept:
+// the longest run of valid keys already in order. The others get new keys
+// between their neighbours. If that makes any key longer than MaxLength, all
+// of them are spread out again instead.
+func Assign(current []string) []string {
+	keys := make([]string, len(current))
+	kept := ordered(current)
+	if len(kept) == 0 {
+		return Spread(len(current))
+	}
+
+	prev, start := "", 0
+	for _, k := range append(kept, len(current)) {
+		next := ""
+		if k < len(current) {
+			next = current[k]
+		}
+		copy(keys[start:k], betweenN(prev, next, k-start))
+		if k < len(current) {
+			keys[k] = current[k]
+			prev, start = current[k], k+1
+		}
+	}
+
+	if slices.ContainsFunc(keys, func(key string) bool { return len(key) > MaxLength }) {
+		return Spread(len(current))
+	}
+	return keys
+}
+
+// ordered returns the indexes of the longest run of valid keys that are
+// strictly increasing, the same one every time for the same keys.
+func ordered(keys []string) []int {
+	// tails[l] is the index of the smallest key ending a run of length
+	// l+1, and prev links each index to the one before it in its run.
+	var tails []int
+	prev := make([]int, len(keys))
+	for i, key := range keys {
+		if !Valid(key) {
+			continue
+		}
+		l, _ := slices.BinarySearchFunc(tails, key, func(t int, key string) int {
+			return strings.Compare(keys[t], key)
+		})
+		prev[i] = -1
+		if l > 0 {
+			prev[i] = tails[l-1]
+		}
+		if l == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[l] = i
+		}
+	}
+
+	run := make([]int, len(tails))
+	for i, k := len(tails)-1, -1; i >= 0; i-- {
+		if k < 0 {
+			k = tails[i]
+		} else {
+			k = prev[k]
+		}
+		run[i] = k
+	}
+	return run
+}
@@ -0,0 +1,307 @@
+package position
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// checkOrdered fails t unless keys are valid and strictly increasing, all of
+// them after a and before b, "" standing for the start and end.
+func checkOrdered(t *testing.T, keys []string, a, b string) {
+	t.Helper()
+	prev := a
+	for i, key := range keys {
+		if !Valid(key) {
+			t.Errorf("key %d %q isn't valid", i, key)
+		}
+		if key <= prev {
+			t.Errorf("key %d %q doesn't sort after %q", i, key, prev)
+		}
+		prev = key
+	}
+	if b != "" && prev >= b {
+		t.Errorf("last key %q doesn't sort before %q", prev, b)
+	}
+}
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		a, b, want string
+	}{
+		{"", "", "V"},
+		{"V", "", "W"},
+		{"", "V", "U"},
+		{"A", "a", "N"},
+		{"V", "W", "VV"},
+		{"V", "W1", "W"},
+		{"z", "", "zV"},
+		{"", "1", "0V"},
+		{"", "01", "00V"},
+		{"V1", "V2", "V1V"},
+		{"V", "V1", "V0V"},
+		{"Vz", "W", "VzV"},
+
+		// Not valid, or not in order.
+		{"W", "V", ""},
+		{"V", "V", ""},
+		{"V0", "", ""},
+		{"", "V-", ""},
+	}
+	for _, tt := range tests {
+		got := Between(tt.a, tt.b)
+		if got != tt.want {
+			t.Errorf("Between(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+		if got != "" {
+			checkOrdered(t, []string{got}, tt.a, tt.b)
+		}
+	}
+}
+
+func TestBetweenRepeated(t *testing.T) {
+	// Going halfway between the same two keys again and again, or always
+	// before the first, never runs out of room.
+	a, b := "V", "W"
+	for i := 0; i < 200; i++ {
+		mid := Between(a, b)
+		checkOrdered(t, []string{mid}, a, b)
+		if i%2 == 0 {
+			a = mid
+		} else {
+			b = mid
+		}
+	}
+	first := "V"
+	for i := 0; i < 200; i++ {
+		key := Between("", first)
+		checkOrdered(t, []string{key}, "", first)
+		first = key
+	}
+}
+
+func TestBetweenN(t *testing.T) {
+	tests := []struct {
+		a, b string
+		n    int
+	}{
+		{"", "", 1},
+		{"", "", 100},
+		{"V", "", 70},
+		{"", "V", 70},
+		{"V", "W", 1},
+		{"V", "W", 10},
+		{"A", "a", 300},
+		{"V1", "V2", 5},
+	}
+	for _, tt := range tests {
+		keys := BetweenN(tt.a, tt.b, tt.n)
+		if len(keys) != tt.n {
+			t.Errorf("BetweenN(%q, %q, %d) gave %d keys", tt.a, tt.b, tt.n, len(keys))
+			continue
+		}
+		checkOrdered(t, keys, tt.a, tt.b)
+	}
+
+	// Halving keeps ten keys between neighbours short.
+	for _, key := range BetweenN("V", "W", 10) {
+		if len(key) > 4 {
+			t.Errorf("BetweenN(V, W, 10) made %q", key)
+		}
+	}
+
+	if keys := BetweenN("", "", 0); keys != nil {
+		t.Errorf("BetweenN() of 0 keys = %q", keys)
+	}
+	if keys := BetweenN("W", "V", 3); keys != nil {
+		t.Errorf("BetweenN() out of order = %q", keys)
+	}
+}
+
+func TestSpread(t *testing.T) {
+	if got := Spread(1); !slices.Equal(got, []string{"V"}) {
+		t.Errorf("Spread(1) = %q, want V", got)
+	}
+	if got := Spread(3); !slices.Equal(got, []string{"F", "V", "k"}) {
+		t.Errorf("Spread(3) = %q, want F, V and k", got)
+	}
+	if got := Spread(0); got != nil {
+		t.Errorf("Spread(0) = %q", got)
+	}
+
+	tests := []struct {
+		n, length int
+	}{
+		{10, 1},
+		{61, 1},
+		{62, 2},
+		{1000, 2},
+		{3843, 2},
+		{3844, 3},
+	}
+	for _, tt := range tests {
+		keys := Spread(tt.n)
+		if len(keys) != tt.n {
+			t.Errorf("Spread(%d) gave %d keys", tt.n, len(keys))
+			continue
+		}
+		checkOrdered(t, keys, "", "")
+		for _, key := range keys {
+			if len(key) > tt.length {
+				t.Errorf("Spread(%d) made %q, longer than %d", tt.n, key, tt.length)
+				break
+			}
+		}
+	}
+}
+
+func TestAssign(t *testing.T) {
+	tests := []struct {
+		name    string
+		current []string
+		kept    []int
+	}{
+		{"all new", []string{"", "", ""}, nil},
+		{"all in order", []string{"F", "V", "k"}, []int{0, 1, 2}},
+		{"added in between and at the ends", []string{"", "F", "", "V", ""}, []int{1, 3}},
+		{"moved up", []string{"k", "F", "V"}, []int{1, 2}},
+		{"moved down", []string{"V", "k", "F"}, []int{0, 1}},
+		{"not valid", []string{"F", "V0", "k", "x-"}, []int{0, 2}},
+		{"the same twice", []string{"F", "F", "V"}, []int{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := Assign(tt.current)
+			if len(keys) != len(tt.current) {
+				t.Fatalf("Assign(%q) gave %d keys", tt.current, len(keys))
+			}
+			checkOrdered(t, keys, "", "")
+			for _, i := range tt.kept {
+				if keys[i] != tt.current[i] {
+					t.Errorf("Assign(%q) = %q, changed key %d", tt.current, keys, i)
+				}
+			}
+		})
+	}
+
+	if keys := Assign(nil); len(keys) != 0 {
+		t.Errorf("Assign(nil) = %q", keys)
+	}
+}
+
+func TestAssignRebalances(t *testing.T) {
+	// The one key between these two is longer than MaxLength, so all of
+	// them are spread out again.
+	current := []string{"V", "", "V0000001"}
+	if got := Assign(current); !slices.Equal(got, Spread(3)) {
+		t.Errorf("Assign(%q) = %q, want them spread out as %q", current, got, Spread(3))
+	}
+
+	// Inserting again and again at the same place keeps the keys in order
+	// and no longer than MaxLength, spreading them out when they'd get too
+	// long.
+	keys := Spread(2)
+	rebalanced := 0
+	for i := 0; i < 500; i++ {
+		current := slices.Insert(slices.Clone(keys), 1, "")
+		keys = Assign(current)
+		checkOrdered(t, keys, "", "")
+		if keys[0] != current[0] {
+			rebalanced++
+		}
+		if slices.ContainsFunc(keys, func(key string) bool { return len(key) > MaxLength }) {
+			t.Fatalf("after %d inserts a key is longer than %d: %q", i+1, MaxLength, keys)
+		}
+	}
+	if rebalanced == 0 {
+		t.Error("500 inserts at the same place never spread the keys out")
+	}
+}
+
+// entry is an item of a list kept in two copies.
+type entry struct {
+	name, key string
+}
+
+// merge returns the entries of both copies, each once, in the order of their
+// keys, ties broken by name, and gives them keys again.
+func merge(a, b []entry) []entry {
+	merged := slices.Clone(a)
+	for _, e := range b {
+		if !slices.ContainsFunc(merged, func(m entry) bool { return m.name == e.name }) {
+			merged = append(merged, e)
+		}
+	}
+	slices.SortStableFunc(merged, func(x, y entry) int {
+		if c := strings.Compare(x.key, y.key); c != 0 {
+			return c
+		}
+		return strings.Compare(x.name, y.name)
+	})
+	keys := make([]string, len(merged))
+	for i, e := range merged {
+		keys[i] = e.key
+	}
+	for i, key := range Assign(keys) {
+		merged[i].key = key
+	}
+	return merged
+}
+
+// insert puts an entry called name at i and gives it a key.
+func insert(list []entry, i int, name string) []entry {
+	list = slices.Insert(slices.Clone(list), i, entry{name: name})
+	keys := make([]string, len(list))
+	for i, e := range list {
+		keys[i] = e.key
+	}
+	for i, key := range Assign(keys) {
+		list[i].key = key
+	}
+	return list
+}
+
+func names(list []entry) string {
+	s := make([]string, len(list))
+	for i, e := range list {
+		s[i] = e.name
+	}
+	return strings.Join(s, " ")
+}
+
+func TestMergeTwoCopies(t *testing.T) {
+	base := make([]entry, 4)
+	for i, key := range Spread(len(base)) {
+		base[i] = entry{string(rune('a' + i)), key}
+	}
+
+	// One copy adds x after a, the other y after c and z at the end.
+	one := insert(base, 1, "x")
+	other := insert(insert(base, 3, "y"), 5, "z")
+
+	want := "a x b c y d z"
+	if got := names(merge(one, other)); got != want {
+		t.Errorf("merged %s, want %s", got, want)
+	}
+	if got := names(merge(other, one)); got != want {
+		t.Errorf("merged the other way round %s, want %s", got, want)
+	}
+
+	// Both copies adding after a give their items the same key. They both
+	// stay after a and before b, in the order of their names, and get keys
+	// of their own.
+	one = insert(base, 1, "q")
+	other = insert(base, 1, "p")
+	merged := merge(one, other)
+	if got := names(merged); got != "a p q b c d" {
+		t.Errorf("merged %s, want a p q b c d", got)
+	}
+	keys := make([]string, len(merged))
+	for i, e := range merged {
+		keys[i] = e.key
+	}
+	checkOrdered(t, keys, "", "")
+	if names(merge(other, one)) != names(merged) {
+		t.Errorf("merged the other way round %s", names(merge(other, one)))
+	}
+}
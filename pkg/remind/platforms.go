@@ -0,0 +1,165 @@
+package remind
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// At schedules jobs with at(1).
+type At struct {
+	Runner Runner
+}
+
+var atJobNumber = regexp.MustCompile(`(?m)^job (\d+) at`)
+
+func (a At) Install(job Job, command []string) (string, error) {
+	out, err := a.Runner.Run("at", []string{"-t", job.When.Local().Format("200601021504.05")}, shellQuote(command)+"\n")
+	if err != nil {
+		return "", err
+	}
+	m := atJobNumber.FindStringSubmatch(out)
+	if m == nil {
+		return "", fmt.Errorf("at: unexpected output %q", strings.TrimSpace(out))
+	}
+	return m[1], nil
+}
+
+func (a At) Remove(handle string) error {
+	_, err := a.Runner.Run("atrm", []string{handle}, "")
+	if err != nil && strings.Contains(err.Error(), "Cannot find jobid") {
+		return nil
+	}
+	return err
+}
+
+// Schtasks schedules jobs with the Windows task scheduler.
+type Schtasks struct {
+	Runner Runner
+}
+
+func (s Schtasks) Install(job Job, command []string) (string, error) {
+	name := `clitodo\remind-` + job.ItemID
+	when := job.When.Local()
+	args := []string{
+		"/Create", "/F",
+		"/TN", name,
+		"/SC", "ONCE",
+		"/SD", when.Format("01/02/2006"),
+		"/ST", when.Format("15:04"),
+		"/TR", windowsCommandLine(command),
+	}
+	if _, err := s.Runner.Run("schtasks", args, ""); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+func (s Schtasks) Remove(handle string) error {
+	_, err := s.Runner.Run("schtasks", []string{"/Delete", "/F", "/TN", handle}, "")
+	if err != nil && strings.Contains(err.Error(), "cannot find") {
+		return nil
+	}
+	return err
+}
+
+func windowsCommandLine(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		if strings.ContainsAny(a, ` "`) {
+			a = `"` + strings.ReplaceAll(a, `"`, `\"`) + `"`
+		}
+		quoted[i] = a
+	}
+	return strings.Join(quoted, " ")
+}
+
+// Launchd schedules jobs as launchd agents. Each job is a plist in Dir that
+// fires once on the given calendar date; launchd has no one-shot jobs, so
+// Sync removes it again once the reminder is past.
+type Launchd struct {
+	Runner Runner
+
+	// Where agent plists are written, normally ~/Library/LaunchAgents.
+	Dir string
+}
+
+func launchAgentsDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "Library", "LaunchAgents"), nil
+}
+
+var plist = template.Must(template.New("plist").Parse(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>{{.Label}}</string>
+	<key>ProgramArguments</key>
+	<array>
+{{- range .Command}}
+		<string>{{.}}</string>
+{{- end}}
+	</array>
+	<key>StartCalendarInterval</key>
+	<dict>
+		<key>Month</key>
+		<integer>{{.When.Month | printf "%d"}}</integer>
+		<key>Day</key>
+		<integer>{{.When.Day}}</integer>
+		<key>Hour</key>
+		<integer>{{.When.Hour}}</integer>
+		<key>Minute</key>
+		<integer>{{.When.Minute}}</integer>
+	</dict>
+</dict>
+</plist>
+`))
+
+func (l Launchd) Install(job Job, command []string) (string, error) {
+	label := "com.clitodo.remind." + job.ItemID
+	path := filepath.Join(l.Dir, label+".plist")
+
+	if err := os.MkdirAll(l.Dir, 0o755); err != nil {
+		return "", err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	err = plist.Execute(f, struct {
+		Label   string
+		Command []string
+		When    time.Time
+	}{label, command, job.When.Local()})
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := l.Runner.Run("launchctl", []string{"load", path}, ""); err != nil {
+		return "", err
+	}
+	return label, nil
+}
+
+func (l Launchd) Remove(handle string) error {
+	path := filepath.Join(l.Dir, handle+".plist")
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if _, err := l.Runner.Run("launchctl", []string{"unload", path}, ""); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
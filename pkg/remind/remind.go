@@ -0,0 +1,182 @@
+// Package remind schedules reminders with the operating system's scheduler
+// (at on unix, schtasks on Windows, launchd on macOS), so they fire even when
+// the TUI isn't running. Every job runs `clitodo notify --item <id>`, with
+// --list for tasks in another list than the default one.
+package remind
+
+import (
+	"clitodo/pkg/domain"
+	"clitodo/pkg/state"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Job is a reminder that should fire for an item at When.
+type Job struct {
+	ItemID string
+	When   time.Time
+
+	// Workspace or list the item is in. Empty is the default list.
+	List string
+}
+
+// Command returns the command line a job runs, given the path of the clitodo
+// executable.
+func (j Job) Command(executable string) []string {
+	command := []string{executable}
+	if j.List != "" {
+		command = append(command, "--list", j.List)
+	}
+	return append(command, "notify", "--item", j.ItemID)
+}
+
+// Jobs returns a job for the next reminder of each open item in list that has
+// one, subtasks included.
+func Jobs(items []domain.Item, list string) []Job {
+	var jobs []Job
+	for _, item := range items {
+		if item.Remind != nil && !item.Completed() {
+			if when, ok := item.Remind.Next(); ok {
+				jobs = append(jobs, Job{ItemID: item.ID, When: when, List: list})
+			}
+		}
+		jobs = append(jobs, Jobs(item.Children, list)...)
+	}
+	return jobs
+}
+
+// Scheduler installs and removes jobs with the OS scheduler.
+type Scheduler interface {
+	// Install schedules command to run at job.When and returns the handle
+	// the scheduler knows the job by.
+	Install(job Job, command []string) (handle string, err error)
+
+	// Remove unschedules the job with the given handle. Removing a job
+	// that already ran or no longer exists is not an error.
+	Remove(handle string) error
+}
+
+// Runner runs the scheduler's command line tools. Tests replace ExecRunner
+// with a fake so no real jobs are scheduled.
+type Runner interface {
+	Run(name string, args []string, stdin string) (output string, err error)
+}
+
+// ExecRunner runs commands on the system.
+type ExecRunner struct{}
+
+func (ExecRunner) Run(name string, args []string, stdin string) (string, error) {
+	c := exec.Command(name, args...)
+	c.Stdin = strings.NewReader(stdin)
+	out, err := c.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("%s: %w: %s", name, err, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+// ErrUnsupported is returned by ForOS on platforms without a known scheduler.
+var ErrUnsupported = errors.New("no supported scheduler on this platform")
+
+// ForOS returns the scheduler for the running platform.
+func ForOS(runner Runner) (Scheduler, error) {
+	switch runtime.GOOS {
+	case "windows":
+		return Schtasks{Runner: runner}, nil
+	case "darwin":
+		dir, err := launchAgentsDir()
+		if err != nil {
+			return nil, err
+		}
+		return Launchd{Runner: runner, Dir: dir}, nil
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return At{Runner: runner}, nil
+	}
+	return nil, ErrUnsupported
+}
+
+// Sync reconciles the installed jobs with the wanted ones: jobs that are no
+// longer wanted or whose time changed are removed, and missing ones are
+// installed. Jobs in the past are never installed. installed holds the jobs of
+// the list wanted is for, as other lists' jobs would be removed. It returns the jobs that
+// are installed afterwards, which is what should be saved in the state file,
+// even when some of the changes failed.
+func Sync(s Scheduler, executable string, wanted []Job, installed map[string]state.Reminder, now time.Time) (map[string]state.Reminder, error) {
+	result := make(map[string]state.Reminder, len(installed))
+	for id, r := range installed {
+		result[id] = r
+	}
+
+	want := make(map[string]Job, len(wanted))
+	for _, job := range wanted {
+		if job.When.After(now) {
+			want[job.ItemID] = job
+		}
+	}
+
+	var errs []error
+	for id, r := range installed {
+		if job, ok := want[id]; ok && job.When.Equal(r.When) && job.List == r.List {
+			continue
+		}
+		if err := s.Remove(r.Handle); err != nil {
+			errs = append(errs, fmt.Errorf("removing reminder for %s: %w", id, err))
+			continue
+		}
+		delete(result, id)
+	}
+
+	for id, job := range want {
+		if _, ok := result[id]; ok {
+			continue
+		}
+		handle, err := s.Install(job, job.Command(executable))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("installing reminder for %s: %w", id, err))
+			continue
+		}
+		result[id] = state.Reminder{Handle: handle, When: job.When, List: job.List}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// shellQuote quotes args for a POSIX shell.
+func shellQuote(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
+
+// Firing is a reminder that fired for an item.
+type Firing struct {
+	Item domain.Item
+
+	// Whether it was the last of a repeating series.
+	Final bool
+}
+
+// Fire fires the reminders of the open items that are due at now, recording
+// it in the items, and returns what fired. What's due follows from each
+// item's reminder alone, so after a restart the series picks up where it was.
+func Fire(items []domain.Item, now time.Time) []Firing {
+	var fired []Firing
+	for i := range items {
+		r := items[i].Remind
+		if r == nil || items[i].Completed() || !r.Due(now) {
+			continue
+		}
+		final := r.Final()
+		next := *r
+		next.Fire(now)
+		items[i].Remind = &next
+		fired = append(fired, Firing{Item: items[i], Final: final})
+	}
+	return fired
+}
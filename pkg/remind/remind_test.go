@@ -0,0 +1,189 @@
+package remind
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"clitodo/pkg/domain"
+	"clitodo/pkg/state"
+)
+
+// fakeScheduler records what's installed instead of scheduling anything.
+type fakeScheduler struct {
+	jobs    map[string]Job
+	removed []string
+	fail    map[string]bool
+}
+
+func newFakeScheduler() *fakeScheduler {
+	return &fakeScheduler{jobs: map[string]Job{}, fail: map[string]bool{}}
+}
+
+func (s *fakeScheduler) Install(job Job, command []string) (string, error) {
+	if s.fail[job.ItemID] {
+		return "", errors.New("scheduler refused")
+	}
+	handle := "job-" + job.ItemID + "-" + job.When.Format("1504")
+	s.jobs[handle] = job
+	return handle, nil
+}
+
+func (s *fakeScheduler) Remove(handle string) error {
+	delete(s.jobs, handle)
+	s.removed = append(s.removed, handle)
+	return nil
+}
+
+// fakeRunner answers every command with output and remembers the calls.
+type fakeRunner struct {
+	output string
+	calls  [][]string
+}
+
+func (r *fakeRunner) Run(name string, args []string, stdin string) (string, error) {
+	r.calls = append(r.calls, append([]string{name}, args...))
+	return r.output, nil
+}
+
+var now = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func TestSync(t *testing.T) {
+	s := newFakeScheduler()
+	wanted := []Job{
+		{ItemID: "a", When: now.Add(time.Hour)},
+		{ItemID: "b", When: now.Add(2 * time.Hour)},
+		{ItemID: "past", When: now.Add(-time.Hour)},
+	}
+	installed, err := Sync(s, "clitodo", wanted, nil, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(installed) != 2 || len(s.jobs) != 2 {
+		t.Fatalf("installed %v, scheduled %v; want a and b only", installed, s.jobs)
+	}
+
+	// a moves, b is completed, c is new.
+	wanted = []Job{
+		{ItemID: "a", When: now.Add(3 * time.Hour)},
+		{ItemID: "c", When: now.Add(4 * time.Hour)},
+	}
+	installed, err = Sync(s, "clitodo", wanted, installed, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]state.Reminder{
+		"a": {Handle: "job-a-1500", When: now.Add(3 * time.Hour)},
+		"c": {Handle: "job-c-1600", When: now.Add(4 * time.Hour)},
+	}
+	if !reflect.DeepEqual(installed, want) {
+		t.Errorf("installed = %v, want %v", installed, want)
+	}
+	slices.Sort(s.removed)
+	if !reflect.DeepEqual(s.removed, []string{"job-a-1300", "job-b-1400"}) {
+		t.Errorf("removed %v", s.removed)
+	}
+
+	// Uninstalling wants nothing.
+	installed, err = Sync(s, "clitodo", nil, installed, now)
+	if err != nil || len(installed) != 0 || len(s.jobs) != 0 {
+		t.Errorf("after uninstall: installed %v, scheduled %v, err %v", installed, s.jobs, err)
+	}
+}
+
+func TestSyncKeepsWhatWorked(t *testing.T) {
+	s := newFakeScheduler()
+	s.fail["b"] = true
+	wanted := []Job{
+		{ItemID: "a", When: now.Add(time.Hour)},
+		{ItemID: "b", When: now.Add(time.Hour)},
+	}
+	installed, err := Sync(s, "clitodo", wanted, nil, now)
+	if err == nil {
+		t.Error("Sync() didn't report the failed install")
+	}
+	if _, ok := installed["a"]; !ok || len(installed) != 1 {
+		t.Errorf("installed = %v, want a only", installed)
+	}
+}
+
+func TestJobs(t *testing.T) {
+	reminder := func(at time.Time) *domain.Reminder { return &domain.Reminder{At: at} }
+	open := domain.Item{ID: "open", Remind: reminder(now.Add(time.Hour))}
+	done := domain.Item{ID: "done", Remind: reminder(now.Add(time.Hour))}
+	done.ItemCompleted = true
+	spent := domain.Item{ID: "spent", Remind: &domain.Reminder{At: now, Fired: 1}}
+	parent := domain.Item{ID: "parent", Children: []domain.Item{
+		{ID: "child", Remind: reminder(now.Add(2 * time.Hour))},
+	}}
+
+	got := Jobs([]domain.Item{open, done, spent, parent, {ID: "plain"}}, "work")
+	want := []Job{
+		{ItemID: "open", When: now.Add(time.Hour), List: "work"},
+		{ItemID: "child", When: now.Add(2 * time.Hour), List: "work"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Jobs() = %v, want %v", got, want)
+	}
+}
+
+func TestJobCommand(t *testing.T) {
+	tests := []struct {
+		job  Job
+		want []string
+	}{
+		{Job{ItemID: "x1"}, []string{"/bin/clitodo", "notify", "--item", "x1"}},
+		{Job{ItemID: "x1", List: "work"}, []string{"/bin/clitodo", "--list", "work", "notify", "--item", "x1"}},
+	}
+	for _, tt := range tests {
+		if got := tt.job.Command("/bin/clitodo"); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Command() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestAtInstall(t *testing.T) {
+	runner := &fakeRunner{output: "warning: commands will be executed using /bin/sh\njob 42 at Tue Mar 10 13:00:00 2026\n"}
+	job := Job{ItemID: "x1", When: now.Add(time.Hour)}
+	handle, err := At{Runner: runner}.Install(job, []string{"/bin/clitodo", "notify", "--item", "it's"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if handle != "42" {
+		t.Errorf("handle = %q, want 42", handle)
+	}
+	if len(runner.calls) != 1 || runner.calls[0][0] != "at" {
+		t.Errorf("calls = %q", runner.calls)
+	}
+
+	if _, err := (At{Runner: &fakeRunner{output: "garbage"}}).Install(job, nil); err == nil {
+		t.Error("Install() accepted output without a job number")
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	got := shellQuote([]string{"/opt/my apps/clitodo", "it's"})
+	want := `'/opt/my apps/clitodo' 'it'\''s'`
+	if got != want {
+		t.Errorf("shellQuote() = %s, want %s", got, want)
+	}
+}
+
+func TestFire(t *testing.T) {
+	items := []domain.Item{
+		{ID: "due", Remind: &domain.Reminder{At: now.Add(-time.Minute)}},
+		{ID: "later", Remind: &domain.Reminder{At: now.Add(time.Minute)}},
+	}
+	fired := Fire(items, now)
+	if len(fired) != 1 || fired[0].Item.ID != "due" {
+		t.Fatalf("Fire() = %v, want the due one", fired)
+	}
+	if items[0].Remind.Fired != 1 || items[1].Remind.Fired != 0 {
+		t.Errorf("fired counts %d, %d; want 1, 0", items[0].Remind.Fired, items[1].Remind.Fired)
+	}
+	if again := Fire(items, now); len(again) != 0 {
+		t.Errorf("Fire() fired %v again", again)
+	}
+}
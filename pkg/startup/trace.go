@@ -0,0 +1,60 @@
+// Package startup times the phases of starting clitodo, for --trace-startup.
+package startup
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Trace records when each phase of startup was done. A nil Trace records
+// nothing.
+type Trace struct {
+	mu     sync.Mutex
+	start  time.Time
+	phases []Phase
+}
+
+// Phase is one step of startup and when it was done, counted from the start
+// of the trace.
+type Phase struct {
+	Name string
+	At   time.Duration
+}
+
+// New starts a trace.
+func New() *Trace {
+	return &Trace{start: time.Now()}
+}
+
+// Mark records that the phase called name is done. Only the first mark of a
+// phase counts, so one like the first frame can be marked wherever it may
+// happen.
+func (t *Trace) Mark(name string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if slices.ContainsFunc(t.phases, func(p Phase) bool { return p.Name == name }) {
+		return
+	}
+	t.phases = append(t.phases, Phase{Name: name, At: time.Since(t.start)})
+}
+
+// Print writes one line per phase, in the order they were done: when it was
+// done and how long after the previous one.
+func (t *Trace) Print(w io.Writer) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var last time.Duration
+	for _, p := range t.phases {
+		fmt.Fprintf(w, "%10s %10s  %s\n", p.At.Round(time.Microsecond), "+"+(p.At-last).Round(time.Microsecond).String(), p.Name)
+		last = p.At
+	}
+}
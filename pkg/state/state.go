@@ -0,0 +1,195 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// State is what clitodo remembers between runs that isn't user data or
+// configuration, such as when it last asked about stale tasks.
+type State struct {
+	// Day of the last stale-task prompt, as YYYY-MM-DD in local time.
+	LastNag string `json:"last_nag,omitempty"`
+
+	// Whether the list's "Done today" section was left expanded.
+	DoneExpanded bool `json:"done_expanded,omitempty"`
+
+	// Header IDs of the list's other sections that were left collapsed,
+	// such as "someday" or "agenda-later".
+	CollapsedSections []string `json:"collapsed_sections,omitempty"`
+
+	// Name of the theme picked in the list, used instead of the configured
+	// ones.
+	Theme string `json:"theme,omitempty"`
+
+	// Whether completed tasks were hidden from the list.
+	HideCompleted bool `json:"hide_completed,omitempty"`
+
+	// Whether the list was left in zen mode, showing nothing but the tasks.
+	Zen bool `json:"zen,omitempty"`
+
+	// Whether the list's full help was left open.
+	FullHelp bool `json:"full_help,omitempty"`
+
+	// OS-level reminder jobs installed by `clitodo remind`, keyed by item ID.
+	Reminders map[string]Reminder `json:"reminders,omitempty"`
+
+	// When a change went over the work-in-progress limit, for the stats
+	// screen. Only the last few weeks are kept.
+	WIPExceeded []time.Time `json:"wip_exceeded,omitempty"`
+
+	// Pairs of item IDs that were shown as possible duplicates and
+	// dismissed, each pair in sorted order, so they aren't suggested again.
+	DismissedDuplicates [][2]string `json:"dismissed_duplicates,omitempty"`
+
+	// Lists created in the list, kept in NAME.json next to the storage. The
+	// workspace picker offers them next to the configured workspaces.
+	Lists []string `json:"lists,omitempty"`
+
+	// The version of clitodo that ran last, to show what's new after an
+	// upgrade. Builds that aren't a release leave it alone.
+	LastVersion string `json:"last_version,omitempty"`
+
+	// How often the list was opened, counted up to the last launch that
+	// shows tips.
+	Launches int `json:"launches,omitempty"`
+
+	// Tips that were followed or dismissed, by name, so they aren't shown
+	// again.
+	TipsDone []string `json:"tips_done,omitempty"`
+
+	// Where each workspace was left, keyed by workspace name. "" is the
+	// list used without a workspace.
+	Workspaces map[string]Workspace `json:"workspaces,omitempty"`
+
+	// What was decided about a storage.json left in a working directory by
+	// older versions, keyed by the directory, so it's only asked about once.
+	LegacyStorage map[string]LegacyDecision `json:"legacy_storage,omitempty"`
+}
+
+// LegacyDecision is what to do with a storage.json in the working directory
+// while another storage is configured.
+type LegacyDecision string
+
+const (
+	// Its items were moved to the configured storage.
+	LegacyMigrated LegacyDecision = "migrated"
+	// It's used instead of the configured storage in that directory.
+	LegacyKept LegacyDecision = "kept"
+	// It's left alone.
+	LegacyIgnored LegacyDecision = "ignored"
+)
+
+// Workspace is the part of the list's state that belongs to one workspace.
+type Workspace struct {
+	// ID of the selected item.
+	Selected string `json:"selected,omitempty"`
+	Filter   string `json:"filter,omitempty"`
+}
+
+// Reminder is an installed OS scheduler job, remembered so it can be removed
+// or rescheduled later.
+type Reminder struct {
+	// What the scheduler knows the job by: an at job number, a schtasks
+	// task name or a launchd label.
+	Handle string    `json:"handle"`
+	When   time.Time `json:"when"`
+
+	// Workspace or list the item is in. Empty is the default list.
+	List string `json:"list,omitempty"`
+}
+
+// DuplicateDismissed reports whether the items with IDs a and b were dismissed
+// as duplicates of each other.
+func (s State) DuplicateDismissed(a, b string) bool {
+	return slices.Contains(s.DismissedDuplicates, duplicatePair(a, b))
+}
+
+// DismissDuplicates records every pair of the given item IDs as not being
+// duplicates.
+func (s *State) DismissDuplicates(ids []string) {
+	for i, a := range ids {
+		for _, b := range ids[i+1:] {
+			if !s.DuplicateDismissed(a, b) {
+				s.DismissedDuplicates = append(s.DismissedDuplicates, duplicatePair(a, b))
+			}
+		}
+	}
+}
+
+func duplicatePair(a, b string) [2]string {
+	if b < a {
+		a, b = b, a
+	}
+	return [2]string{a, b}
+}
+
+// Path returns the location of the state file, honoring XDG_STATE_HOME.
+func Path() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "clitodo", "state.json"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "state", "clitodo", "state.json"), nil
+}
+
+// Load reads the state file. A missing file yields the zero State.
+func Load() (State, error) {
+	var s State
+
+	path, err := Path()
+	if err != nil {
+		return s, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	return s, json.Unmarshal(data, &s)
+}
+
+// Save writes the state file, creating its directory if needed.
+func (s State) Save() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Several instances, one per list, may save at about the same time.
+	// Renaming a finished file over the old one means none of them reads
+	// half a file.
+	f, err := os.CreateTemp(filepath.Dir(path), ".state-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(f.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,109 @@
+// Package stats aggregates items for the stats screen.
+package stats
+
+import (
+	"fmt"
+	"time"
+
+	"clitodo/pkg/domain"
+)
+
+// Day is a calendar day. Unlike a time.Time at midnight it doesn't depend on
+// the time of day or on DST: a day that is 23 or 25 hours long is still one
+// Day.
+type Day struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DayOf returns the calendar day t falls on in loc.
+func DayOf(t time.Time, loc *time.Location) Day {
+	y, m, d := t.In(loc).Date()
+	return Day{y, m, d}
+}
+
+// AddDays returns the day n days later, or earlier for negative n. The
+// arithmetic happens in UTC, where every day has 24 hours.
+func (d Day) AddDays(n int) Day {
+	return DayOf(time.Date(d.Year, d.Month, d.Day+n, 0, 0, 0, 0, time.UTC), time.UTC)
+}
+
+// Weekday returns the day of the week of d.
+func (d Day) Weekday() time.Weekday {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC).Weekday()
+}
+
+// Before reports whether d is earlier than other.
+func (d Day) Before(other Day) bool {
+	if d.Year != other.Year {
+		return d.Year < other.Year
+	}
+	if d.Month != other.Month {
+		return d.Month < other.Month
+	}
+	return d.Day < other.Day
+}
+
+func (d Day) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// CompletionsByDay buckets the completed items by the local day they were
+// checked off in loc. Items without a completion time are left out.
+func CompletionsByDay(items []domain.Item, loc *time.Location) map[Day][]domain.Item {
+	days := make(map[Day][]domain.Item)
+	for _, item := range items {
+		if !item.Completed() || item.CompletedAt == nil {
+			continue
+		}
+		day := DayOf(*item.CompletedAt, loc)
+		days[day] = append(days[day], item)
+	}
+	return days
+}
+
+// Weeks returns n weeks of days, Monday first, ending with the week that
+// contains last. Days after last are included to fill that week.
+func Weeks(last Day, n int) [][7]Day {
+	// Days since Monday; time.Weekday starts on Sunday.
+	offset := (int(last.Weekday()) + 6) % 7
+	monday := last.AddDays(-offset - 7*(n-1))
+
+	weeks := make([][7]Day, n)
+	for w := range weeks {
+		for d := range weeks[w] {
+			weeks[w][d] = monday.AddDays(7*w + d)
+		}
+	}
+	return weeks
+}
+
+// Levels is the number of shades a heatmap cell can have, including the one
+// for days without completions.
+const Levels = 5
+
+// Level maps count to a shade between 0 and Levels-1, relative to the busiest
+// day max. Any completion at all gets at least shade 1, the busiest day the
+// darkest one.
+func Level(count, max int) int {
+	if count <= 0 || max <= 0 {
+		return 0
+	}
+	level := (count*(Levels-1) + max - 1) / max
+	return min(level, Levels-1)
+}
+
+// ThisWeek counts how many of times fall in the week of now, Monday to
+// Sunday in now's location.
+func ThisWeek(times []time.Time, now time.Time) int {
+	today := DayOf(now, now.Location())
+	monday := today.AddDays(-((int(today.Weekday()) + 6) % 7)) //nolint:mnd
+	n := 0
+	for _, t := range times {
+		if day := DayOf(t, now.Location()); !day.Before(monday) && !today.Before(day) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,168 @@
+package stats
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"clitodo/pkg/domain"
+)
+
+// berlin returns the location of Berlin, where 2026 has a 23-hour day on
+// March 29 and a 25-hour one on October 25.
+func berlin(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("no timezone data for Europe/Berlin: %v", err)
+	}
+	return loc
+}
+
+func TestDayOf(t *testing.T) {
+	loc := berlin(t)
+	tests := []struct {
+		t    time.Time
+		want Day
+	}{
+		{time.Date(2026, time.March, 28, 22, 59, 0, 0, time.UTC), Day{2026, time.March, 28}},
+		{time.Date(2026, time.March, 28, 23, 0, 0, 0, time.UTC), Day{2026, time.March, 29}},
+		// Midnight is an hour earlier in UTC once summer time started.
+		{time.Date(2026, time.March, 29, 21, 59, 0, 0, time.UTC), Day{2026, time.March, 29}},
+		{time.Date(2026, time.March, 29, 22, 0, 0, 0, time.UTC), Day{2026, time.March, 30}},
+		{time.Date(2026, time.October, 24, 22, 0, 0, 0, time.UTC), Day{2026, time.October, 25}},
+		{time.Date(2026, time.October, 25, 22, 59, 0, 0, time.UTC), Day{2026, time.October, 25}},
+		{time.Date(2026, time.October, 25, 23, 0, 0, 0, time.UTC), Day{2026, time.October, 26}},
+		{time.Date(2026, time.December, 31, 23, 30, 0, 0, time.UTC), Day{2027, time.January, 1}},
+	}
+	for _, tt := range tests {
+		if got := DayOf(tt.t, loc); got != tt.want {
+			t.Errorf("DayOf(%s) = %s, want %s", tt.t.Format(time.RFC3339), got, tt.want)
+		}
+	}
+}
+
+func TestAddDays(t *testing.T) {
+	tests := []struct {
+		day  Day
+		n    int
+		want Day
+	}{
+		{Day{2026, time.March, 28}, 1, Day{2026, time.March, 29}},
+		{Day{2026, time.March, 28}, 2, Day{2026, time.March, 30}},
+		{Day{2026, time.October, 26}, -1, Day{2026, time.October, 25}},
+		{Day{2026, time.October, 26}, -2, Day{2026, time.October, 24}},
+		{Day{2026, time.December, 31}, 1, Day{2027, time.January, 1}},
+		{Day{2028, time.March, 1}, -1, Day{2028, time.February, 29}},
+		{Day{2026, time.March, 10}, 0, Day{2026, time.March, 10}},
+	}
+	for _, tt := range tests {
+		if got := tt.day.AddDays(tt.n); got != tt.want {
+			t.Errorf("%s.AddDays(%d) = %s, want %s", tt.day, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestCompletionsByDay(t *testing.T) {
+	loc := berlin(t)
+	completed := func(title string, at time.Time) domain.Item {
+		item := domain.NewItem(title)
+		item.SetCompleted(true, at)
+		return item
+	}
+	open := domain.NewItem("call mum")
+	unstamped := domain.NewItem("pay the rent")
+	unstamped.ItemCompleted = true
+	items := []domain.Item{
+		completed("water the plants", time.Date(2026, time.March, 28, 23, 30, 0, 0, loc)),
+		completed("book the flights", time.Date(2026, time.March, 29, 0, 30, 0, 0, loc)),
+		completed("fix the bike", time.Date(2026, time.March, 29, 23, 30, 0, 0, loc)),
+		completed("clean the windows", time.Date(2026, time.October, 25, 23, 30, 0, 0, loc)),
+		completed("order new glasses", time.Date(2026, time.October, 26, 0, 30, 0, 0, loc)),
+		open,
+		unstamped,
+	}
+
+	got := make(map[Day][]string)
+	for day, items := range CompletionsByDay(items, loc) {
+		for _, item := range items {
+			got[day] = append(got[day], item.Title())
+		}
+	}
+	want := map[Day][]string{
+		{2026, time.March, 28}:   {"water the plants"},
+		{2026, time.March, 29}:   {"book the flights", "fix the bike"},
+		{2026, time.October, 25}: {"clean the windows"},
+		{2026, time.October, 26}: {"order new glasses"},
+	}
+	if len(got) != len(want) {
+		t.Errorf("CompletionsByDay() = %v, want %v", got, want)
+	}
+	for day, titles := range want {
+		if !slices.Equal(got[day], titles) {
+			t.Errorf("on %s %q were completed, want %q", day, got[day], titles)
+		}
+	}
+
+	// The same moments fall on other days in UTC.
+	if utc := CompletionsByDay(items, time.UTC); len(utc[Day{2026, time.March, 28}]) != 2 {
+		t.Errorf("in UTC %d were completed on 2026-03-28, want 2", len(utc[Day{2026, time.March, 28}]))
+	}
+}
+
+func TestWeeks(t *testing.T) {
+	tests := []struct {
+		last  Day
+		n     int
+		first Day
+		end   Day
+	}{
+		// A Tuesday, the week the clocks went forward before it.
+		{Day{2026, time.March, 31}, 2, Day{2026, time.March, 23}, Day{2026, time.April, 5}},
+		// A Sunday, the day the clocks went back.
+		{Day{2026, time.October, 25}, 1, Day{2026, time.October, 19}, Day{2026, time.October, 25}},
+		// A Monday.
+		{Day{2026, time.March, 30}, 1, Day{2026, time.March, 30}, Day{2026, time.April, 5}},
+		{Day{2026, time.December, 31}, 53, Day{2025, time.December, 29}, Day{2027, time.January, 3}},
+	}
+	for _, tt := range tests {
+		weeks := Weeks(tt.last, tt.n)
+		if len(weeks) != tt.n {
+			t.Fatalf("Weeks(%s, %d) gave %d weeks", tt.last, tt.n, len(weeks))
+		}
+		if first, end := weeks[0][0], weeks[tt.n-1][6]; first != tt.first || end != tt.end {
+			t.Errorf("Weeks(%s, %d) runs from %s to %s, want %s to %s", tt.last, tt.n, first, end, tt.first, tt.end)
+		}
+		// Every day once, in order, each week from Monday.
+		prev := tt.first.AddDays(-1)
+		for _, week := range weeks {
+			if week[0].Weekday() != time.Monday {
+				t.Errorf("Weeks(%s, %d) has a week starting on %s", tt.last, tt.n, week[0].Weekday())
+			}
+			for _, day := range week {
+				if day != prev.AddDays(1) {
+					t.Errorf("Weeks(%s, %d) has %s after %s", tt.last, tt.n, day, prev)
+				}
+				prev = day
+			}
+		}
+	}
+}
+
+func TestThisWeek(t *testing.T) {
+	loc := berlin(t)
+	// The Sunday the clocks went forward.
+	now := time.Date(2026, time.March, 29, 12, 0, 0, 0, loc)
+	times := []time.Time{
+		time.Date(2026, time.March, 22, 23, 59, 0, 0, loc),
+		time.Date(2026, time.March, 23, 0, 0, 0, 0, loc),
+		time.Date(2026, time.March, 26, 8, 0, 0, 0, loc),
+		time.Date(2026, time.March, 29, 23, 59, 0, 0, loc),
+		time.Date(2026, time.March, 30, 0, 0, 0, 0, loc),
+		// Sunday evening in UTC, Monday in Berlin.
+		time.Date(2026, time.March, 29, 22, 30, 0, 0, time.UTC),
+	}
+	if got := ThisWeek(times, now); got != 3 {
+		t.Errorf("ThisWeek() = %d, want 3", got)
+	}
+}
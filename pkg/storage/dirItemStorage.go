@@ -0,0 +1,259 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"clitodo/pkg/domain"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Backend is how a storage lays out its items on disk.
+type Backend string
+
+const (
+	// BackendFile keeps all items in one JSON file.
+	BackendFile Backend = "file"
+
+	// BackendDir keeps each item, with its subtasks, in a JSON file of its
+	// own named by its ID, in a directory with an index file listing the
+	// IDs in order, one per line. A change to one item only changes its
+	// file, which keeps diffs small and merges clean when the directory is
+	// synced with git.
+	BackendDir Backend = "dir"
+)
+
+// ParseBackend returns the backend named by s, "file" or "dir".
+func ParseBackend(s string) (Backend, error) {
+	switch b := Backend(s); b {
+	case BackendFile, BackendDir:
+		return b, nil
+	}
+	return "", fmt.Errorf("backend must be file or dir, got %q", s)
+}
+
+// newBackend is the backend of storages that don't exist yet.
+var newBackend = BackendFile
+
+// SetBackend sets the backend storages that don't exist yet are created with.
+// Existing ones keep theirs until they're converted, see Convert. Call it once
+// at startup.
+func SetBackend(b Backend) {
+	newBackend = b
+}
+
+// Backend returns the backend of the storage: BackendDir if its path is a
+// directory, BackendFile if it's a file, and the one set with SetBackend if
+// there's nothing there yet.
+func (r FileItemStorage) Backend() Backend {
+	if r.backend != "" {
+		return r.backend
+	}
+	info, err := os.Stat(r.filePath)
+	switch {
+	case err == nil && info.IsDir():
+		return BackendDir
+	case err == nil:
+		return BackendFile
+	}
+	return newBackend
+}
+
+// indexName is the file in a BackendDir directory that lists the IDs of the
+// items in order.
+const indexName = "index"
+
+// getDirItems reads the items of a BackendDir directory in the order of its
+// index. Items the index doesn't list, such as ones added on both sides of a
+// merge, follow in the order they were created, and index entries without a
+// file are skipped.
+func (r FileItemStorage) getDirItems() ([]domain.Item, error) {
+	entries, err := os.ReadDir(r.filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	byID := map[string]domain.Item{}
+	var unlisted []domain.Item
+	for _, entry := range entries {
+		id, ok := itemFileID(entry.Name())
+		if !ok || entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(r.filePath, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var item domain.Item
+		if err := json.Unmarshal(data, &item); err != nil {
+			return nil, newCorruptError(path, data, err)
+		}
+		// The file name is what the index refers to.
+		item.ID = id
+		backfillIDs(item.Children)
+		byID[id] = item
+		unlisted = append(unlisted, item)
+	}
+
+	order, err := r.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	items := make([]domain.Item, 0, len(byID))
+	for _, id := range order {
+		if item, ok := byID[id]; ok {
+			items = append(items, item)
+			delete(byID, id)
+		}
+	}
+	unlisted = slices.DeleteFunc(unlisted, func(item domain.Item) bool {
+		_, ok := byID[item.ID]
+		return !ok
+	})
+	slices.SortStableFunc(unlisted, func(a, b domain.Item) int {
+		switch {
+		case a.CreatedAt == nil && b.CreatedAt == nil:
+		case a.CreatedAt == nil:
+			return -1
+		case b.CreatedAt == nil:
+			return 1
+		default:
+			if c := a.CreatedAt.Compare(*b.CreatedAt); c != 0 {
+				return c
+			}
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	items = append(items, unlisted...)
+	domain.SortByPosition(items)
+	return items, nil
+}
+
+// readIndex returns the IDs listed in the index, or none if there's no index.
+func (r FileItemStorage) readIndex() ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(r.filePath, indexName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var ids []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if id := strings.TrimSpace(scanner.Text()); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids, scanner.Err()
+}
+
+// storeDirItems writes the files of the items that changed, removes those of
+// the items that are gone and then updates the index. Every file is replaced
+// in one go, so a crash leaves each one either old or new: items written but
+// not listed yet are read at the end, and index entries whose file is gone
+// are skipped.
+func (r FileItemStorage) storeDirItems(items []domain.Item) error {
+	if err := os.MkdirAll(r.filePath, 0o755); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(r.filePath)
+	if err != nil {
+		return err
+	}
+
+	var index strings.Builder
+	kept := map[string]bool{}
+	for _, item := range items {
+		if item.ID == "" || item.ID != filepath.Base(item.ID) || strings.HasPrefix(item.ID, ".") || item.ID == indexName {
+			return fmt.Errorf("item %q can't be stored in a file named by its ID %q", item.Title(), item.ID)
+		}
+		if kept[item.ID] {
+			return fmt.Errorf("two items have the ID %q", item.ID)
+		}
+		kept[item.ID] = true
+
+		data, err := json.MarshalIndent(item, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := updateFile(filepath.Join(r.filePath, item.ID+".json"), append(data, '\n')); err != nil {
+			return err
+		}
+		index.WriteString(item.ID + "\n")
+	}
+
+	for _, entry := range entries {
+		if id, ok := itemFileID(entry.Name()); ok && !entry.IsDir() && !kept[id] {
+			if err := os.Remove(filepath.Join(r.filePath, entry.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+	return updateFile(filepath.Join(r.filePath, indexName), []byte(index.String()))
+}
+
+// itemFileID returns the ID of the item stored in the file of the given name
+// in a BackendDir directory, if it's an item file. Hidden files are left out,
+// which also skips the temporary ones of an unfinished write.
+func itemFileID(name string) (string, bool) {
+	id, ok := strings.CutSuffix(name, ".json")
+	return id, ok && id != "" && !strings.HasPrefix(name, ".")
+}
+
+// updateFile replaces the file at path with data unless it already holds
+// exactly that.
+func updateFile(path string, data []byte) error {
+	if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, data) {
+		return nil
+	}
+	return replaceFile(path, data, 0o644)
+}
+
+// Convert moves the items stored at path to the given backend, under the same
+// path. What was there before is kept at path+".bak". It returns how many
+// items were converted. Take the storage's lock first.
+func Convert(path string, to Backend) (int, error) {
+	from := NewFileItemRepository(path)
+	if _, err := os.Stat(path); err != nil {
+		return 0, err
+	}
+	if from.Backend() == to {
+		return 0, fmt.Errorf("%s already uses the %s backend", path, to)
+	}
+	backup := path + ".bak"
+	if _, err := os.Lstat(backup); err == nil {
+		return 0, fmt.Errorf("%s is in the way, move it elsewhere first", backup)
+	}
+
+	items, err := from.GetItems()
+	if err != nil {
+		return 0, err
+	}
+	converting := path + ".converting"
+	if err := os.RemoveAll(converting); err != nil {
+		return 0, err
+	}
+	target := FileItemStorage{filePath: converting, backend: to}
+	if err := target.StoreItemsState(items); err != nil {
+		os.RemoveAll(converting)
+		return 0, err
+	}
+	if err := os.Rename(path, backup); err != nil {
+		os.RemoveAll(converting)
+		return 0, err
+	}
+	if err := os.Rename(converting, path); err != nil {
+		return 0, errors.Join(err, os.Rename(backup, path))
+	}
+	return len(items), nil
+}
@@ -0,0 +1,240 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"clitodo/pkg/domain"
+)
+
+// writeItemFile writes item to its file in the BackendDir directory dir, the
+// way the storage would but without a position, so only the index orders it.
+func writeItemFile(t *testing.T, dir string, item domain.Item) {
+	t.Helper()
+	data, err := json.MarshalIndent(item, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, item.ID+".json"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// createdItem returns an item with the given ID and title, created at t.
+func createdItem(id, title string, t time.Time) domain.Item {
+	return domain.Item{ID: id, ItemTitle: title, CreatedAt: &t}
+}
+
+func titles(items []domain.Item) []string {
+	titles := make([]string, len(items))
+	for i, item := range items {
+		titles[i] = item.Title()
+	}
+	return titles
+}
+
+func TestGetDirItems(t *testing.T) {
+	day := time.Date(2026, time.March, 10, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		files []domain.Item
+		extra map[string]string // other files in the directory
+		index string
+		want  []string
+	}{
+		{
+			name:  "in the order of the index",
+			files: []domain.Item{createdItem("a", "pay the rent", day), createdItem("b", "water the plants", day), createdItem("c", "call mum", day)},
+			index: "c\na\nb\n",
+			want:  []string{"call mum", "pay the rent", "water the plants"},
+		},
+		{
+			// Added on both sides of a merge, say: after the listed ones,
+			// oldest first, ties broken by ID.
+			name: "files missing from the index",
+			files: []domain.Item{
+				createdItem("a", "pay the rent", day),
+				createdItem("d", "book the flights", day.Add(2*time.Hour)),
+				createdItem("c", "call mum", day.Add(time.Hour)),
+				createdItem("b", "water the plants", day.Add(time.Hour)),
+			},
+			index: "a\n",
+			want:  []string{"pay the rent", "water the plants", "call mum", "book the flights"},
+		},
+		{
+			name:  "no index",
+			files: []domain.Item{createdItem("b", "water the plants", day.Add(time.Hour)), createdItem("a", "pay the rent", day)},
+			want:  []string{"pay the rent", "water the plants"},
+		},
+		{
+			name:  "index entries without a file",
+			files: []domain.Item{createdItem("a", "pay the rent", day), createdItem("c", "call mum", day)},
+			index: "gone\na\n\n  b  \nc\n",
+			want:  []string{"pay the rent", "call mum"},
+		},
+		{
+			name:  "hidden and other files",
+			files: []domain.Item{createdItem("a", "pay the rent", day)},
+			extra: map[string]string{
+				".a.json.tmp123": `{"id": "a", "title": "half written`,
+				".b.json":        `{"id": "b", "title": "hidden"}`,
+				"notes.txt":      "not an item",
+				".json":          `{"title": "no ID"}`,
+			},
+			index: "a\n",
+			want:  []string{"pay the rent"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "tasks")
+			if err := os.Mkdir(dir, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			for _, item := range tt.files {
+				writeItemFile(t, dir, item)
+			}
+			for name, content := range tt.extra {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.index != "" {
+				if err := os.WriteFile(filepath.Join(dir, indexName), []byte(tt.index), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			items, err := NewFileItemRepository(dir).GetItems()
+			if err != nil {
+				t.Fatalf("GetItems() error = %v", err)
+			}
+			if got := titles(items); !slices.Equal(got, tt.want) {
+				t.Errorf("GetItems() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStoreDirItemsInvalidIDs(t *testing.T) {
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"", "can't be stored in a file named by its ID"},
+		{"a/b", "can't be stored in a file named by its ID"},
+		{"..", "can't be stored in a file named by its ID"},
+		{".hidden", "can't be stored in a file named by its ID"},
+		{indexName, "can't be stored in a file named by its ID"},
+		{"a", "two items have the ID"},
+	}
+	for _, tt := range tests {
+		dir := filepath.Join(t.TempDir(), "tasks")
+		r := FileItemStorage{filePath: dir, backend: BackendDir}
+		items := []domain.Item{{ID: "a", ItemTitle: "pay the rent"}, {ID: tt.id, ItemTitle: "water the plants"}}
+		err := r.StoreItemsState(items)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("storing an item with the ID %q: error = %v, want %q", tt.id, err, tt.want)
+		}
+		if _, err := os.Stat(filepath.Join(dir, indexName)); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("storing an item with the ID %q wrote the index", tt.id)
+		}
+	}
+}
+
+func TestStoreDirItemsRemovesGoneItems(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "tasks")
+	r := FileItemStorage{filePath: dir, backend: BackendDir}
+	rent, plants := domain.NewItem("pay the rent"), domain.NewItem("water the plants")
+	if err := r.StoreItemsState([]domain.Item{rent, plants}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("mine"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.StoreItemsState([]domain.Item{plants}); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	want := []string{indexName, "notes.txt", plants.ID + ".json"}
+	slices.Sort(want)
+	if !slices.Equal(names, want) {
+		t.Errorf("the directory holds %q, want %q", names, want)
+	}
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.json")
+	parent := domain.NewItem("move flat")
+	parent.Children = []domain.Item{domain.NewItem("pack the books")}
+	items := []domain.Item{domain.NewItem("pay the rent"), parent, domain.NewItem("water the plants")}
+	if err := NewFileItemRepository(path).StoreItemsState(items); err != nil {
+		t.Fatal(err)
+	}
+	original, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// File to directory, the file kept as the backup.
+	n, err := Convert(path, BackendDir)
+	if err != nil || n != 3 {
+		t.Fatalf("Convert() to dir = %d, %v", n, err)
+	}
+	if r := NewFileItemRepository(path); r.Backend() != BackendDir {
+		t.Fatalf("converted to %s, want dir", r.Backend())
+	}
+	if backup, err := os.ReadFile(path + ".bak"); err != nil || string(backup) != string(original) {
+		t.Errorf("the backup holds %q, %v, want the file as it was", backup, err)
+	}
+	if _, err := os.Stat(path + ".converting"); !errors.Is(err, os.ErrNotExist) {
+		t.Error("the converted copy was left behind")
+	}
+
+	// The backup is in the way of converting back, and nothing changes.
+	if _, err := Convert(path, BackendFile); err == nil || !strings.Contains(err.Error(), "is in the way") {
+		t.Errorf("converting over a backup error = %v, want it refused", err)
+	}
+	if NewFileItemRepository(path).Backend() != BackendDir {
+		t.Error("the refused conversion changed the storage")
+	}
+	if _, err := Convert(path, BackendDir); err == nil || !strings.Contains(err.Error(), "already uses the dir backend") {
+		t.Errorf("converting to the same backend error = %v", err)
+	}
+
+	if err := os.Remove(path + ".bak"); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := Convert(path, BackendFile); err != nil || n != 3 {
+		t.Fatalf("Convert() back to a file = %d, %v", n, err)
+	}
+	if info, err := os.Stat(path + ".bak"); err != nil || !info.IsDir() {
+		t.Errorf("the directory wasn't kept as the backup: %v", err)
+	}
+
+	got, err := NewFileItemRepository(path).GetItems()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(itemIDs(got), itemIDs(items)) || len(got[1].Children) != 1 || got[1].Children[0].ID != parent.Children[0].ID {
+		t.Errorf("after the round trip the file holds %q, want %q with the subtask", titles(got), titles(items))
+	}
+
+	if _, err := Convert(filepath.Join(t.TempDir(), "missing.json"), BackendDir); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("converting a missing storage error = %v", err)
+	}
+}
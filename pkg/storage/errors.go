@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrNotFound is returned by GetItems when the storage file doesn't exist
+// yet. It also matches os.ErrNotExist.
+var ErrNotFound = errors.New("storage file not found")
+
+// CorruptError is returned by GetItems when the storage file can't be read as
+// a list of items. Line and Column point at the problem, or are 0 when it
+// can't be located.
+type CorruptError struct {
+	Path         string
+	Line, Column int
+	Err          error
+}
+
+func (e *CorruptError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s is not a valid item list: %v", e.Path, e.Err)
+	}
+	return fmt.Sprintf("%s is not a valid item list at line %d, column %d: %v", e.Path, e.Line, e.Column, e.Err)
+}
+
+func (e *CorruptError) Unwrap() error {
+	return e.Err
+}
+
+// newCorruptError locates the JSON error err in data.
+func newCorruptError(path string, data []byte, err error) *CorruptError {
+	var offset int64
+	var syntax *json.SyntaxError
+	var typ *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntax):
+		offset = syntax.Offset
+	case errors.As(err, &typ):
+		offset = typ.Offset
+	}
+
+	e := &CorruptError{Path: path, Err: err}
+	if offset > 0 && offset <= int64(len(data)) {
+		before := data[:offset]
+		e.Line = bytes.Count(before, []byte("\n")) + 1
+		e.Column = int(offset) - bytes.LastIndexByte(before, '\n') - 1
+	}
+	return e
+}
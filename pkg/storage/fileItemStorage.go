@@ -1,22 +1,83 @@
 package storage
 
 import (
+	"bytes"
 	"clitodo/pkg/domain"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 )
 
+// ErrReadOnly is returned when storing items in a read-only storage.
+var ErrReadOnly = errors.New("storage is read-only")
+
+// DefaultFilePath is where items are stored when no storage path is
+// configured.
+const DefaultFilePath = "storage.json"
+
+// FileItemStorage keeps items in the file at its path, or, if the path is a
+// directory, one file per item in there; see Backend.
 type FileItemStorage struct {
 	filePath string
+	readOnly bool
+
+	// Backend the storage always uses. Empty uses the one found at
+	// filePath.
+	backend Backend
+}
+
+func NewFileItemRepository(filePath string) FileItemStorage {
+	return FileItemStorage{filePath: filePath}
+}
+
+// NewReadOnlyFileItemRepository returns a storage that reads items from
+// filePath but never writes to it; StoreItemsState returns ErrReadOnly.
+func NewReadOnlyFileItemRepository(filePath string) FileItemStorage {
+	return FileItemStorage{filePath: filePath, readOnly: true}
+}
+
+// ReadOnly reports whether the storage refuses to store items.
+func (r FileItemStorage) ReadOnly() bool {
+	return r.readOnly
+}
+
+// Path returns the file the items are stored in.
+func (r FileItemStorage) Path() string {
+	return r.filePath
+}
+
+// Archive returns the storage completed items are moved to, next to this one:
+// tasks.json is archived to tasks.archive.json.
+func (r FileItemStorage) Archive() ItemRepository {
+	path := strings.TrimSuffix(r.filePath, filepath.Ext(r.filePath)) + ".archive.json"
+	return FileItemStorage{filePath: path, readOnly: r.readOnly, backend: BackendFile}
 }
 
-func NewFileItemRepository() FileItemStorage {
-	return FileItemStorage{filePath: "storage.json"}
+// Someday returns the storage items put aside for some day are kept in, next
+// to this one: tasks.json keeps them in tasks.someday.json.
+func (r FileItemStorage) Someday() ItemRepository {
+	path := strings.TrimSuffix(r.filePath, filepath.Ext(r.filePath)) + ".someday.json"
+	return FileItemStorage{filePath: path, readOnly: r.readOnly, backend: BackendFile}
 }
 
-func (r *FileItemStorage) GetItems() ([]domain.Item, error) {
+// GetItems reads the stored items. A missing file gives ErrNotFound, and one
+// that isn't a list of items a *CorruptError.
+func (r FileItemStorage) GetItems() ([]domain.Item, error) {
+	if r.Backend() == BackendDir {
+		return r.getDirItems()
+	}
 	jsonFile, err := os.Open(r.filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -25,22 +86,118 @@ func (r *FileItemStorage) GetItems() ([]domain.Item, error) {
 	if err != nil {
 		return nil, err
 	}
-	var items []domain.Item
-	err = json.Unmarshal(byteValue, &items)
+	items, err := DecodeItems(byteValue)
 	if err != nil {
+		return nil, newCorruptError(r.filePath, byteValue, err)
+	}
+	return items, nil
+}
+
+// DecodeItems reads items from the contents of a storage file, bringing those
+// written by older versions up to date the way GetItems does.
+func DecodeItems(data []byte) ([]domain.Item, error) {
+	var items []domain.Item
+	if err := json.Unmarshal(data, &items); err != nil {
 		return nil, err
 	}
+	backfillIDs(items)
+	domain.SortByPosition(items)
 	return items, nil
 }
 
-func (r *FileItemStorage) StoreItemsState(items []domain.Item) error {
-	file, err := os.Create(r.filePath)
-	if err != nil {
+// backfillIDs gives items stored before IDs existed, and their subtasks, an
+// ID. It's written with the next save. The ID is made from the item's title,
+// when it was created and its parent's ID, so every copy of a list saved
+// before then, such as an old backup, gives an item the same one and its
+// copies can still be matched by ID.
+func backfillIDs(items []domain.Item) {
+	backfillChildIDs("", items)
+}
+
+func backfillChildIDs(parentID string, items []domain.Item) {
+	seen := map[string]int{}
+	for i := range items {
+		if items[i].ID == "" {
+			// Items alike in all of that are numbered in order.
+			key := parentID + "\x00" + items[i].Title()
+			if created := items[i].CreatedAt; created != nil {
+				key += "\x00" + created.UTC().Format(time.RFC3339Nano)
+			}
+			seen[key]++
+			key += "\x00" + strconv.Itoa(seen[key])
+			items[i].ID = uuid.NewSHA1(legacyIDSpace, []byte(key)).String()
+		}
+		backfillChildIDs(items[i].ID, items[i].Children)
+	}
+}
+
+// legacyIDSpace is the UUID namespace of the IDs backfillIDs makes.
+var legacyIDSpace = uuid.NewSHA1(uuid.NameSpaceOID, []byte("clitodo legacy item"))
+
+// StoreItemsState stores items, replacing what was stored before. Items
+// without a position, or out of order, get one first, in place; see
+// domain.AssignPositions.
+func (r FileItemStorage) StoreItemsState(items []domain.Item) error {
+	if r.readOnly {
+		return ErrReadOnly
+	}
+	domain.AssignPositions(items)
+	if r.Backend() == BackendDir {
+		return r.storeDirItems(items)
+	}
+
+	// Write next to the file and rename over it, so a crash leaves either the
+	// old or the new items but never half of them. A symlinked storage file
+	// stays a symlink.
+	path, err := filepath.EvalSymlinks(r.filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		path = r.filePath
+	} else if err != nil {
 		return err
 	}
-	defer file.Close()
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
 
-	encoder := json.NewEncoder(file)
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
 	encoder.SetIndent("", "  ")
-	return encoder.Encode(items)
+	if err := encoder.Encode(items); err != nil {
+		return err
+	}
+	return replaceFile(path, buf.Bytes(), mode)
+}
+
+// replaceFile writes data to a file next to path and renames it over path,
+// making the directory path is in first if need be.
+func replaceFile(path string, data []byte, mode os.FileMode) error {
+	if err := ensureDir(path); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Chmod(mode); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// ensureDir makes the directory the file at path goes in, and its parents,
+// unless they exist. Storage paths are resolved without creating anything,
+// so this happens when something is first written there.
+func ensureDir(path string) error {
+	return os.MkdirAll(filepath.Dir(path), 0o755)
 }
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"clitodo/pkg/domain"
+)
+
+func TestStoreItemsStateMakesTheDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "not", "yet", "tasks.json")
+	r := NewFileItemRepository(path)
+
+	if _, err := r.GetItems(); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetItems() error = %v, want ErrNotFound", err)
+	}
+	if _, err := os.Stat(filepath.Dir(path)); !os.IsNotExist(err) {
+		t.Fatalf("reading made %s", filepath.Dir(path))
+	}
+
+	if err := r.StoreItemsState([]domain.Item{domain.NewItem("first")}); err != nil {
+		t.Fatalf("StoreItemsState() error = %v", err)
+	}
+	items, err := r.GetItems()
+	if err != nil || len(items) != 1 || items[0].Title() != "first" {
+		t.Fatalf("GetItems() = %v, %v", items, err)
+	}
+}
+
+func TestLockMakesTheDirectory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "new", "tasks.json")
+	r := NewFileItemRepository(path)
+	unlock, err := r.Lock()
+	if err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	if err := unlock(); err != nil {
+		t.Fatalf("unlock() error = %v", err)
+	}
+}
+
+func TestDecodeItemsBackfillsTheSameIDs(t *testing.T) {
+	data := []byte(`[
+		{"title": "fix the bike", "children": [{"title": "buy a tube"}]},
+		{"title": "fix the bike"},
+		{"title": "fix the bike", "created_at": "2024-05-01T10:00:00Z"},
+		{"id": "kept", "title": "pay the rent"}
+	]`)
+	first, err := DecodeItems(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := DecodeItems(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ids := map[string]bool{}
+	for i, item := range first {
+		if item.ID == "" || ids[item.ID] {
+			t.Errorf("item %d got ID %q, empty or given twice", i, item.ID)
+		}
+		ids[item.ID] = true
+		if second[i].ID != item.ID {
+			t.Errorf("item %d got ID %s the first time and %s the second", i, item.ID, second[i].ID)
+		}
+	}
+	if first[3].ID != "kept" {
+		t.Errorf("an item with an ID got %s", first[3].ID)
+	}
+	sub := first[0].Children[0].ID
+	if sub == "" || sub != second[0].Children[0].ID || ids[sub] {
+		t.Errorf("subtask got ID %q the first time and %q the second", sub, second[0].Children[0].ID)
+	}
+}
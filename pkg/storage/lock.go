@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// LockError is returned by Lock when another process holds the lock. PID and
+// Since are zero when the lock file couldn't be read.
+type LockError struct {
+	Path  string
+	PID   int
+	Since time.Time
+}
+
+func (e *LockError) Error() string {
+	if e.PID == 0 {
+		return fmt.Sprintf("storage is locked by another clitodo process (remove %s if none is running)", e.Path)
+	}
+	return fmt.Sprintf("storage is locked by clitodo process %d since %s (remove %s if it isn't running)", e.PID, e.Since.Format("15:04"), e.Path)
+}
+
+// lockHolder reads who holds the lock at path and since when.
+func lockHolder(path string) (pid int, since time.Time) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, time.Time{}
+	}
+	pid, _ = strconv.Atoi(strings.TrimSpace(string(data)))
+	if info, err := os.Stat(path); err == nil {
+		since = info.ModTime()
+	}
+	return pid, since
+}
+
+// lockWait is how long Lock waits for another process to release the lock.
+const lockWait = 2 * time.Second
+
+// Lock takes an exclusive lock on the storage file by creating a lock file
+// next to it, so two commands can't change it at the same time. Read the
+// items after locking, and call unlock once the changes are stored.
+func (r FileItemStorage) Lock() (unlock func() error, err error) {
+	path := r.filePath + ".lock"
+	if err := ensureDir(path); err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(lockWait)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			_, err = f.WriteString(strconv.Itoa(os.Getpid()) + "\n")
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				os.Remove(path)
+				return nil, err
+			}
+			return func() error { return os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if time.Now().After(deadline) {
+			pid, since := lockHolder(path)
+			return nil, &LockError{Path: path, PID: pid, Since: since}
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// InUseError is returned by Claim when a running clitodo already has the list
+// open. PID is zero when the claim couldn't be read.
+type InUseError struct {
+	Path string
+	PID  int
+}
+
+func (e *InUseError) Error() string {
+	if e.PID == 0 {
+		return fmt.Sprintf("%s is open in another clitodo process", e.Path)
+	}
+	return fmt.Sprintf("%s is open in clitodo process %d", e.Path, e.PID)
+}
+
+// Claim marks the list as open in this process for as long as it's shown, in
+// a file next to it: tasks.json is claimed by tasks.json.open. Every list has
+// its own, so instances showing different lists don't get in each other's way.
+// Unlike Lock it doesn't wait: if another running process has the list open it
+// returns an *InUseError. A claim left behind by a process that has exited is
+// taken over.
+func (r FileItemStorage) Claim() (release func() error, err error) {
+	path := r.filePath + ".open"
+	if err := ensureDir(path); err != nil {
+		return nil, err
+	}
+	for attempt := 0; ; attempt++ {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			_, err = f.WriteString(strconv.Itoa(os.Getpid()) + "\n")
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				os.Remove(path)
+				return nil, err
+			}
+			return func() error { return os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+
+		// A claim without a PID may be one that's still being written.
+		pid, since := lockHolder(path)
+		stale := pid != 0 && !processRunning(pid) || pid == 0 && time.Since(since) > lockWait
+		if attempt > 0 || !stale {
+			return nil, &InUseError{Path: r.filePath, PID: pid}
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+}
+
+// processRunning reports whether a process with the given ID exists.
+func processRunning(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		// Finding a process on Windows already opens it, which fails for
+		// one that has exited.
+		return true
+	}
+	err = p.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
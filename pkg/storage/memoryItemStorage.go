@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"bytes"
+	"clitodo/pkg/domain"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// BackendMemory keeps the items in memory only, for driving the TUI without
+// touching the disk, as in tests. Only a MemoryItemStorage has it; the config
+// can't pick it.
+const BackendMemory Backend = "memory"
+
+// MemoryItemStorage keeps items in memory, encoded as they would be in a
+// file, so they read back the way they do from disk. Copies of it share them.
+type MemoryItemStorage struct {
+	file *memoryFile
+}
+
+// memoryFile holds what a MemoryItemStorage stores. The storages next to it,
+// archive and someday, are made when first asked for.
+type memoryFile struct {
+	mu      sync.Mutex
+	data    []byte
+	archive *memoryFile
+	someday *memoryFile
+}
+
+// NewMemoryItemRepository returns a storage that keeps items in memory,
+// starting with items. Nil items is a storage that doesn't exist yet, so
+// GetItems returns ErrNotFound until something is stored.
+func NewMemoryItemRepository(items []domain.Item) MemoryItemStorage {
+	r := MemoryItemStorage{file: &memoryFile{}}
+	if items != nil {
+		r.file.store(items)
+	}
+	return r
+}
+
+// GetItems reads the stored items, ErrNotFound if none were stored yet.
+func (r MemoryItemStorage) GetItems() ([]domain.Item, error) {
+	return r.file.get()
+}
+
+// StoreItemsState stores items, replacing what was stored before, after
+// giving them positions as FileItemStorage does.
+func (r MemoryItemStorage) StoreItemsState(items []domain.Item) error {
+	domain.AssignPositions(items)
+	return r.file.store(items)
+}
+
+// Lock does nothing, only this process seeing the items.
+func (r MemoryItemStorage) Lock() (unlock func() error, err error) {
+	return func() error { return nil }, nil
+}
+
+// Claim does nothing, only this process seeing the items.
+func (r MemoryItemStorage) Claim() (release func() error, err error) {
+	return func() error { return nil }, nil
+}
+
+// Archive returns the storage in memory completed items are moved to.
+func (r MemoryItemStorage) Archive() ItemRepository {
+	return MemoryItemStorage{file: r.file.next(&r.file.archive)}
+}
+
+// Someday returns the storage in memory items put aside for some day are
+// kept in.
+func (r MemoryItemStorage) Someday() ItemRepository {
+	return MemoryItemStorage{file: r.file.next(&r.file.someday)}
+}
+
+// Path returns "", the items not being on disk.
+func (r MemoryItemStorage) Path() string {
+	return ""
+}
+
+// ReadOnly returns false; see ReadOnly for a storage that is.
+func (r MemoryItemStorage) ReadOnly() bool {
+	return false
+}
+
+// Backend returns BackendMemory.
+func (r MemoryItemStorage) Backend() Backend {
+	return BackendMemory
+}
+
+func (f *memoryFile) get() ([]domain.Item, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.data == nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotFound, os.ErrNotExist)
+	}
+	return DecodeItems(f.data)
+}
+
+func (f *memoryFile) store(items []domain.Item) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(items); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.data = buf.Bytes()
+	return nil
+}
+
+// next returns the storage kept next to f in *slot, making it if need be.
+func (f *memoryFile) next(slot **memoryFile) *memoryFile {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if *slot == nil {
+		*slot = &memoryFile{}
+	}
+	return *slot
+}
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"bytes"
+	"clitodo/pkg/domain"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// MigratedSuffix is added to a storage file once its items were moved
+// elsewhere with Migrate.
+const MigratedSuffix = ".migrated"
+
+// Migrate moves the items stored at from to the storage at to: they're
+// written there the usual way, read back and compared, and only then is from
+// renamed to from+MigratedSuffix. Whatever was at to before is kept at
+// to+".bak", like Convert does. It returns how many items were moved. Take
+// the lock of to first.
+func Migrate(from, to string) (int, error) {
+	source := NewFileItemRepository(from)
+	items, err := source.GetItems()
+	if err != nil {
+		return 0, err
+	}
+	migrated := from + MigratedSuffix
+	if _, err := os.Lstat(migrated); err == nil {
+		return 0, fmt.Errorf("%s is in the way, move it elsewhere first", migrated)
+	}
+
+	backup := to + ".bak"
+	if _, err := os.Lstat(to); err == nil {
+		if _, err := os.Lstat(backup); err == nil {
+			return 0, fmt.Errorf("%s is in the way, move it elsewhere first", backup)
+		}
+		if err := os.Rename(to, backup); err != nil {
+			return 0, err
+		}
+	}
+	restore := func(err error) error {
+		if _, statErr := os.Lstat(backup); statErr != nil {
+			return errors.Join(err, os.RemoveAll(to))
+		}
+		return errors.Join(err, os.RemoveAll(to), os.Rename(backup, to))
+	}
+
+	target := NewFileItemRepository(to)
+	if err := target.StoreItemsState(items); err != nil {
+		return 0, restore(err)
+	}
+	if err := sameItems(items, target); err != nil {
+		return 0, restore(err)
+	}
+	if err := os.Rename(from, migrated); err != nil {
+		return 0, restore(err)
+	}
+	return len(items), nil
+}
+
+// sameItems checks that storage reads back as items.
+func sameItems(items []domain.Item, storage FileItemStorage) error {
+	stored, err := storage.GetItems()
+	if err != nil {
+		return fmt.Errorf("reading back %s: %w", storage.Path(), err)
+	}
+	want, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	got, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(want, got) {
+		return fmt.Errorf("%s doesn't read back as what was written", storage.Path())
+	}
+	return nil
+}
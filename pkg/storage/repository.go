@@ -0,0 +1,76 @@
+package storage
+
+import "clitodo/pkg/domain"
+
+// ItemRepository is where a list's items are kept: a file or directory on
+// disk, see FileItemStorage, or memory, see MemoryItemStorage.
+type ItemRepository interface {
+	// GetItems reads the stored items. A storage nothing was stored in yet
+	// gives ErrNotFound.
+	GetItems() ([]domain.Item, error)
+
+	// StoreItemsState stores items, replacing what was stored before.
+	StoreItemsState(items []domain.Item) error
+
+	// Lock keeps other processes from changing the items until unlock is
+	// called.
+	Lock() (unlock func() error, err error)
+
+	// Claim marks the list as shown by this process until release is
+	// called, failing with an *InUseError if another one shows it.
+	Claim() (release func() error, err error)
+
+	// Archive and Someday return the storages kept next to this one for
+	// archived items and those put aside for some day.
+	Archive() ItemRepository
+	Someday() ItemRepository
+
+	// Path returns where the items are stored, empty if not on disk.
+	Path() string
+
+	// ReadOnly reports whether the storage refuses to store items.
+	ReadOnly() bool
+
+	// Backend returns how the storage keeps its items.
+	Backend() Backend
+}
+
+// readOnlyRepository reads the items of the storage it wraps but refuses to
+// store any; see ReadOnly.
+type readOnlyRepository struct {
+	ItemRepository
+}
+
+// ReadOnly returns a storage that reads r's items but never stores any;
+// StoreItemsState returns ErrReadOnly. Writable gives r back.
+func ReadOnly(r ItemRepository) ItemRepository {
+	if r.ReadOnly() {
+		return r
+	}
+	return readOnlyRepository{r}
+}
+
+// Writable returns the storage r was made from by ReadOnly, or r itself if it
+// wasn't.
+func Writable(r ItemRepository) ItemRepository {
+	if ro, ok := r.(readOnlyRepository); ok {
+		return ro.ItemRepository
+	}
+	return r
+}
+
+func (readOnlyRepository) ReadOnly() bool {
+	return true
+}
+
+func (readOnlyRepository) StoreItemsState([]domain.Item) error {
+	return ErrReadOnly
+}
+
+func (r readOnlyRepository) Archive() ItemRepository {
+	return ReadOnly(r.ItemRepository.Archive())
+}
+
+func (r readOnlyRepository) Someday() ItemRepository {
+	return ReadOnly(r.ItemRepository.Someday())
+}
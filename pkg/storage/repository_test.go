@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"clitodo/pkg/domain"
+)
+
+// backends are the storages every ItemRepository is held to. new makes an
+// empty one; corrupt, if the storage can hold anything but items, puts
+// something there that isn't a list of them.
+var backends = []struct {
+	name    string
+	new     func(t *testing.T) ItemRepository
+	corrupt func(t *testing.T, r ItemRepository)
+	claims  bool
+}{
+	{
+		name: "file",
+		new: func(t *testing.T) ItemRepository {
+			return FileItemStorage{filePath: filepath.Join(t.TempDir(), "tasks.json"), backend: BackendFile}
+		},
+		corrupt: func(t *testing.T, r ItemRepository) {
+			if err := os.WriteFile(r.Path(), []byte(`[{"title": 1}]`), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		},
+		claims: true,
+	},
+	{
+		name: "dir",
+		new: func(t *testing.T) ItemRepository {
+			return FileItemStorage{filePath: filepath.Join(t.TempDir(), "tasks"), backend: BackendDir}
+		},
+		corrupt: func(t *testing.T, r ItemRepository) {
+			if err := os.MkdirAll(r.Path(), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(r.Path(), "a.json"), []byte(`{"title": `), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		},
+		claims: true,
+	},
+	{
+		name: "memory",
+		new:  func(*testing.T) ItemRepository { return NewMemoryItemRepository(nil) },
+	},
+}
+
+func itemIDs(items []domain.Item) []string {
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+func TestRepositoryErrors(t *testing.T) {
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			r := b.new(t)
+			if _, err := r.GetItems(); !errors.Is(err, ErrNotFound) || !errors.Is(err, os.ErrNotExist) {
+				t.Errorf("GetItems() of an empty storage error = %v, want ErrNotFound", err)
+			}
+
+			items := []domain.Item{domain.NewItem("pay the rent"), domain.NewItem("water the plants")}
+			if err := r.StoreItemsState(items); err != nil {
+				t.Fatalf("StoreItemsState() error = %v", err)
+			}
+			got, err := r.GetItems()
+			if err != nil {
+				t.Fatalf("GetItems() error = %v", err)
+			}
+			if !slices.Equal(itemIDs(got), itemIDs(items)) {
+				t.Errorf("GetItems() = %v, want %v back", itemIDs(got), itemIDs(items))
+			}
+
+			ro := ReadOnly(r)
+			if !ro.ReadOnly() || !ro.Someday().ReadOnly() {
+				t.Error("ReadOnly() storage or the one next to it says it's writable")
+			}
+			if err := ro.StoreItemsState(nil); !errors.Is(err, ErrReadOnly) {
+				t.Errorf("StoreItemsState() of a read-only storage error = %v, want ErrReadOnly", err)
+			}
+			if got, err := ro.GetItems(); err != nil || len(got) != len(items) {
+				t.Errorf("GetItems() of a read-only storage = %d items, %v", len(got), err)
+			}
+			if Writable(ro) != r {
+				t.Error("Writable() didn't give the storage back")
+			}
+
+			release, err := r.Claim()
+			if err != nil {
+				t.Fatalf("Claim() error = %v", err)
+			}
+			var inUse *InUseError
+			if _, err := r.Claim(); b.claims && !errors.As(err, &inUse) {
+				t.Errorf("claiming twice error = %v, want an *InUseError", err)
+			} else if b.claims && inUse.PID != os.Getpid() {
+				t.Errorf("claimed by PID %d, want %d", inUse.PID, os.Getpid())
+			}
+			if err := release(); err != nil {
+				t.Errorf("release() error = %v", err)
+			}
+
+			if b.corrupt == nil {
+				return
+			}
+			r = b.new(t)
+			b.corrupt(t, r)
+			var corrupt *CorruptError
+			if _, err := r.GetItems(); !errors.As(err, &corrupt) {
+				t.Errorf("GetItems() of a corrupt storage error = %v, want a *CorruptError", err)
+			} else if corrupt.Line != 1 || corrupt.Column == 0 {
+				t.Errorf("corrupt at line %d, column %d, want it located on line 1", corrupt.Line, corrupt.Column)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package version
+
+// Release is what changed in one version, in a few lines for the what's new
+// screen.
+type Release struct {
+	Version string
+	Changes []string
+}
+
+// Changelog lists the releases, newest first. A release adds its entry here
+// when it sets the version, so the binary carries its own notes.
+var Changelog = []Release{
+	{
+		Version: "0.1.0",
+		Changes: []string{
+			"clitodo --version, and what's new in the command palette under Version",
+			"Rows can be numbered with show_numbers; type a number and enter to go there",
+			"[defaults.NAME] rules give new tasks a due date or priority by tag and list",
+			"F toggles zen mode, which shows nothing but the tasks",
+			"m moves the selected task to another list",
+		},
+	},
+}
+
+// Since returns the releases after last up to this one, newest first. It
+// returns none for a build that isn't a release, and none without a last
+// version, since a first run isn't an upgrade.
+func Since(last string) []Release {
+	current := Current()
+	if last == "" || current == Dev {
+		return nil
+	}
+	var releases []Release
+	for _, r := range Changelog {
+		if Compare(r.Version, last) > 0 && Compare(r.Version, current) <= 0 {
+			releases = append(releases, r)
+		}
+	}
+	return releases
+}
@@ -0,0 +1,123 @@
+// Package version says which clitodo this is and what changed in it.
+package version
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// Version and Commit are set when building a release, as in
+//
+//	go build -ldflags "-X clitodo/pkg/version.Version=0.1.0 -X clitodo/pkg/version.Commit=$(git rev-parse --short HEAD)"
+//
+// Left empty, they're taken from what the Go toolchain recorded in the
+// binary: the module version of `go install clitodo@v0.1.0` and the commit of
+// a build from a git checkout.
+var (
+	Version string
+	Commit  string
+)
+
+// Dev is the version of builds that aren't a release.
+const Dev = "dev"
+
+// shortCommit is how many characters of a commit hash are shown.
+const shortCommit = 7
+
+// Current returns the version of this clitodo, without a leading "v", or Dev.
+func Current() string {
+	if Version != "" {
+		return strings.TrimPrefix(Version, "v")
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return strings.TrimPrefix(info.Main.Version, "v")
+	}
+	return Dev
+}
+
+// Revision returns the commit this clitodo was built from, "-dirty" if it had
+// uncommitted changes, or "" if that isn't known.
+func Revision() string {
+	if Commit != "" {
+		return Commit
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	var revision, modified string
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			revision = s.Value
+		case "vcs.modified":
+			if s.Value == "true" {
+				modified = "-dirty"
+			}
+		}
+	}
+	if revision == "" {
+		return ""
+	}
+	return revision[:min(len(revision), shortCommit)] + modified
+}
+
+// String returns the version and commit as `clitodo --version` prints them:
+// "clitodo 0.1.0 (1a2b3c4)".
+func String() string {
+	s := "clitodo " + Current()
+	if r := Revision(); r != "" {
+		s += fmt.Sprintf(" (%s)", r)
+	}
+	return s
+}
+
+// Compare compares two versions of the form 1.2.3 by their numbers, returning
+// -1, 0 or +1. Dev and anything else that isn't such a version comes after
+// all of them.
+func Compare(a, b string) int {
+	pa, okA := parse(a)
+	pb, okB := parse(b)
+	switch {
+	case !okA && !okB:
+		return 0
+	case !okA:
+		return 1
+	case !okB:
+		return -1
+	}
+	for i := range max(len(pa), len(pb)) {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func parse(v string) ([]int, bool) {
+	v = strings.TrimPrefix(v, "v")
+	// Pre-release and build suffixes count as the release they lead up to.
+	v, _, _ = strings.Cut(v, "-")
+	v, _, _ = strings.Cut(v, "+")
+	var parts []int
+	for _, s := range strings.Split(v, ".") {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		parts = append(parts, n)
+	}
+	return parts, true
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"clitodo/cmd"
+	"clitodo/cmd/views"
+	"clitodo/pkg/chime"
+	"clitodo/pkg/clock"
+	"clitodo/pkg/config"
+	"clitodo/pkg/domain"
+	"clitodo/pkg/fold"
+	"clitodo/pkg/hooks"
+	"clitodo/pkg/match"
+	"clitodo/pkg/notify"
+	"clitodo/pkg/state"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// A subsystem is an optional part of the TUI that is set up from the config.
+// --safe-mode skips all of them, so a bad setting or a broken integration
+// can't keep clitodo from starting.
+type subsystem struct {
+	name  string
+	setup func(cfg config.Config, options *views.Options) error
+}
+
+// subsystems are set up in this order before the TUI starts.
+var subsystems = []subsystem{
+	{name: "keys", setup: setupKeys},
+	{name: "theme", setup: setupTheme},
+	{name: "hooks", setup: setupHooks},
+	{name: "notifications", setup: setupNotifications},
+	{name: "done chime", setup: setupChime},
+	{name: "nag", setup: setupNag},
+	{name: "titles", setup: setupTitles},
+	{name: "page summary", setup: setupPageSummary},
+	{name: "status hints", setup: setupStatusHints},
+	{name: "tips", setup: setupTips},
+	{name: "notes", setup: setupNotes},
+	{name: "subtasks", setup: setupSubtasks},
+	{name: "after delete", setup: setupAfterDelete},
+	{name: "quit warning", setup: setupQuitWarning},
+	{name: "done section", setup: setupDoneSection},
+	{name: "sink completed", setup: setupSinkCompleted},
+	{name: "split layout", setup: setupSplit},
+	{name: "filter", setup: setupFilter},
+	{name: "workspaces", setup: setupWorkspaces},
+	{name: "defaults", setup: setupDefaults},
+	{name: "undo", setup: setupUndo},
+	{name: "wip limit", setup: setupWIP},
+}
+
+// setupSubsystems sets up every subsystem, stopping at the first error.
+func setupSubsystems(cfg config.Config, options *views.Options) error {
+	for _, s := range subsystems {
+		if err := s.setup(cfg, options); err != nil {
+			return fmt.Errorf("%s: %w", s.name, err)
+		}
+	}
+	return nil
+}
+
+func setupKeys(cfg config.Config, options *views.Options) error {
+	if err := cmd.SetKeyPreset(cfg.Keymap); err != nil {
+		return err
+	}
+	keys, err := config.LoadKeys()
+	if err != nil {
+		return err
+	}
+	if err := cmd.SetKeyOverrides(keys); err != nil {
+		path, _ := config.KeysPath()
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	for _, action := range cfg.PinnedKeys {
+		if !slices.Contains(cmd.ActionNames(), action) {
+			return fmt.Errorf("pinned_keys: unknown action %q", action)
+		}
+	}
+	options.PinnedKeys = cfg.PinnedKeys
+	return nil
+}
+
+func setupTheme(cfg config.Config, options *views.Options) error {
+	dark, err := cmd.DetectDarkBackground(cfg.Background)
+	if err != nil {
+		return err
+	}
+	lipgloss.SetHasDarkBackground(dark)
+
+	options.Theme, err = configuredTheme(cfg)
+	if err == nil && cfg.Cursor != "" {
+		options.Cursor = options.Theme.Cursor
+	}
+	return err
+}
+
+// configuredTheme returns the theme of the active workspace with the cursor
+// from the config, if one is set.
+func configuredTheme(cfg config.Config) (cmd.Theme, error) {
+	theme, err := cmd.ThemeByName(cfg.ThemeName())
+	if err != nil || cfg.Cursor == "" {
+		return theme, err
+	}
+	theme.Cursor, err = cmd.ParseCursor(cfg.Cursor)
+	return theme, err
+}
+
+func setupHooks(cfg config.Config, options *views.Options) error {
+	options.Hooks = hooks.New(cfg.Hooks, hooks.ShellExecutor{})
+	return nil
+}
+
+func setupNotifications(cfg config.Config, options *views.Options) error {
+	if !cfg.Notifications.Enabled {
+		return nil
+	}
+	quiet, err := notify.ParseQuietHours(cfg.Notifications.QuietStart, cfg.Notifications.QuietEnd)
+	if err != nil {
+		return err
+	}
+	options.Notifications = notify.NewCenter(notify.DesktopSender{Terminal: os.Stdout}, clock.Real{}, quiet)
+	return nil
+}
+
+func setupChime(cfg config.Config, options *views.Options) error {
+	options.Chime = chime.New(cfg.Done, chime.ShellRunner{}, clock.Real{}, os.Stdout)
+	return nil
+}
+
+func setupNag(cfg config.Config, options *views.Options) error {
+	options.Nag = cfg.Nag
+	return nil
+}
+
+func setupTitles(cfg config.Config, options *views.Options) error {
+	options.TitleLimit = cfg.Titles.SoftLimit
+	return nil
+}
+
+func setupPageSummary(cfg config.Config, options *views.Options) error {
+	options.PageSummary = cfg.PageSummary
+	return nil
+}
+
+func setupStatusHints(cfg config.Config, options *views.Options) error {
+	options.StatusHints = cfg.StatusHints
+	return nil
+}
+
+func setupTips(cfg config.Config, options *views.Options) error {
+	options.Tips = cfg.Tips
+	return nil
+}
+
+func setupNotes(cfg config.Config, options *views.Options) error {
+	options.ShowNotes = cfg.ShowNotes
+	options.ShowNumbers = cfg.ShowNumbers
+	options.WrapTitles = cfg.WrapTitles
+	options.PlainCompleted = cfg.PlainCompleted
+	switch cfg.Layout {
+	case "", views.LayoutDefault, views.LayoutCompact, views.LayoutTable:
+		options.Layout = cfg.Layout
+	default:
+		return fmt.Errorf("layout must be default, compact or table, got %q", cfg.Layout)
+	}
+	return nil
+}
+
+func setupSubtasks(cfg config.Config, options *views.Options) error {
+	options.CompleteParents = cfg.CompleteParents
+	return nil
+}
+
+func setupAfterDelete(cfg config.Config, options *views.Options) error {
+	switch cfg.AfterDelete {
+	case "", "previous":
+	case "next":
+		options.SelectNextOnDelete = true
+	default:
+		return fmt.Errorf("after_delete must be previous or next, got %q", cfg.AfterDelete)
+	}
+	return nil
+}
+
+func setupQuitWarning(cfg config.Config, options *views.Options) error {
+	if cfg.QuitWarning < 0 {
+		return fmt.Errorf("quit_warning must not be negative, got %s", cfg.QuitWarning)
+	}
+	options.QuitWarning = cfg.QuitWarning
+	return nil
+}
+
+func setupDoneSection(cfg config.Config, options *views.Options) error {
+	options.DoneSection = cfg.DoneSection
+	return nil
+}
+
+func setupSinkCompleted(cfg config.Config, options *views.Options) error {
+	if cfg.SinkCompletedAfter < 0 {
+		return fmt.Errorf("sink_completed_after must not be negative, got %s", cfg.SinkCompletedAfter)
+	}
+	options.SinkCompletedAfter = cfg.SinkCompletedAfter
+	return nil
+}
+
+func setupSplit(cfg config.Config, options *views.Options) error {
+	options.SplitWidth = cfg.SplitWidth
+	return nil
+}
+
+func setupFilter(cfg config.Config, options *views.Options) error {
+	if _, err := match.FilterByName(cfg.Filter.Match, fold.Folder{}); err != nil {
+		return fmt.Errorf("filter.match: %w", err)
+	}
+	options.KeepAccents = !cfg.Filter.IgnoreAccents
+	options.FilterMatch = cfg.Filter.Match
+	options.FilterTitlesOnly = cfg.Filter.TitlesOnly
+	options.TagChips = cfg.Filter.TagChips
+	return nil
+}
+
+func setupWorkspaces(cfg config.Config, options *views.Options) error {
+	options.Workspace = cfg.Workspace
+	options.DefaultTags = cfg.DefaultTags()
+	options.NewList = func(name string) (views.Workspace, error) {
+		if err := cfg.UseList(name); err != nil {
+			return views.Workspace{}, err
+		}
+		return workspaceOption(cfg, name)
+	}
+
+	// Lists created in the TUI are offered like configured workspaces.
+	if st, err := state.Load(); err == nil {
+		active := cfg.Workspace
+		for _, name := range st.Lists {
+			if _, ok := cfg.Workspaces[name]; !ok {
+				if err := cfg.UseList(name); err != nil {
+					return fmt.Errorf("list %s: %w", name, err)
+				}
+			}
+		}
+		cfg.Workspace = active
+	}
+	if len(cfg.Workspaces) == 0 {
+		return nil
+	}
+
+	// The list used without a workspace comes first in the picker.
+	for _, name := range append([]string{""}, cfg.WorkspaceNames()...) {
+		w, err := workspaceOption(cfg, name)
+		if err != nil && name != "" {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if err != nil {
+			return err
+		}
+		options.Workspaces = append(options.Workspaces, w)
+	}
+	return nil
+}
+
+func workspaceOption(cfg config.Config, name string) (views.Workspace, error) {
+	cfg.Workspace = name
+	path, err := cfg.StoragePath()
+	if err != nil {
+		return views.Workspace{}, err
+	}
+	theme, err := configuredTheme(cfg)
+	if err != nil {
+		return views.Workspace{}, err
+	}
+	return views.Workspace{
+		Name:        name,
+		StoragePath: path,
+		Theme:       theme,
+		DefaultTags: cfg.DefaultTags(),
+	}, nil
+}
+
+func setupDefaults(cfg config.Config, options *views.Options) error {
+	rules, err := cfg.DefaultRules()
+	if err != nil {
+		return err
+	}
+	options.DefaultRules = rules
+	return nil
+}
+
+func setupUndo(cfg config.Config, options *views.Options) error {
+	if cfg.UndoDepth < 0 {
+		return fmt.Errorf("undo_depth must not be negative, got %d", cfg.UndoDepth)
+	}
+	options.UndoDepth = cfg.UndoDepth
+	return nil
+}
+
+func setupWIP(cfg config.Config, options *views.Options) error {
+	if cfg.WIP.Limit < 0 {
+		return fmt.Errorf("wip.limit must not be negative, got %d", cfg.WIP.Limit)
+	}
+	if cfg.WIP.Limit > 0 && cfg.WIP.Tag == "" {
+		return errors.New("wip.tag must be set to use wip.limit")
+	}
+	options.WIP = domain.WIPLimit{Tag: strings.TrimPrefix(cfg.WIP.Tag, "#"), Limit: cfg.WIP.Limit}
+	return nil
+}